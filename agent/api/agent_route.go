@@ -96,6 +96,7 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 		pools.Use(common.Verify())
 		pool.Use(common.Verify())
 		recyclebin.Use(common.Verify())
+		zone.Use(common.Verify())
 	}
 
 	v1.GET(constant.URI_TIME, TimeHandler)
@@ -123,12 +124,20 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	ob.GET(constant.URI_INFO, obInfoHandler)
 	ob.POST(constant.URI_SCALE_OUT, obClusterScaleOutHandler)
 	ob.POST(constant.URI_SCALE_IN, obClusterScaleInHandler)
+	ob.POST(constant.URI_REPLACE, obClusterReplaceServerHandler)
 	ob.POST(constant.URI_UPGRADE, obUpgradeHandler)
 	ob.POST(constant.URI_UPGRADE+constant.URI_CHECK, obUpgradeCheckHandler)
+	ob.GET(constant.URI_UPGRADE+constant.URI_GATES, obUpgradeGatesHandler)
+	ob.POST(constant.URI_UPGRADE+constant.URI_APPROVE, obUpgradeApproveHandler)
+	ob.POST(constant.URI_UPGRADE+constant.URI_ABORT, obUpgradeAbortHandler)
+	ob.GET(constant.URI_UPGRADE+constant.URI_ROLLBACK, obUpgradeRollbackPointHandler)
+	ob.POST(constant.URI_UPGRADE+constant.URI_ROLLBACK, obUpgradeRollbackHandler)
 	ob.GET(constant.URI_AGENTS, obAgentsHandler)
+	ob.POST(constant.URI_HOST_CHECK, obHostCheckHandler)
 
 	// agent routes
 	agent.GET(constant.URI_HOST_INFO, GetHostInfo)
+	agent.POST(constant.URI_HOST_CHECK, agentHostCheckHandler)
 	agent.POST(constant.URI_JOIN, agentJoinHandler)
 	agent.POST("", agentJoinHandler)
 	agent.DELETE("", agentRemoveHandler)
@@ -146,17 +155,33 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	obcluster.GET(constant.URI_INFO, obclusterInfoHandler)
 	obcluster.GET(constant.URI_PARAMETERS, obclusterParametersHandler)
 	obcluster.PATCH(constant.URI_PARAMETERS, obclusterSetParametersHandler)
+	obcluster.GET(constant.URI_PARAMETERS+constant.URI_HISTORY, checkClusterAgentWrapper(obclusterParameterHistoryHandler))
+	obcluster.POST(constant.URI_PARAMETERS+constant.URI_HISTORY+constant.URI_PATH_PARAM_ID+constant.URI_ROLLBACK, checkClusterAgentWrapper(obclusterRollbackParameterChangeHandler))
+	obcluster.GET(constant.URI_PARAMETERS+constant.URI_SNAPSHOTS, checkClusterAgentWrapper(obclusterListParameterSnapshotsHandler))
+	obcluster.POST(constant.URI_PARAMETERS+constant.URI_SNAPSHOTS, checkClusterAgentWrapper(obclusterCreateParameterSnapshotHandler))
+	obcluster.DELETE(constant.URI_PARAMETERS+constant.URI_SNAPSHOTS+constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(obclusterDeleteParameterSnapshotHandler))
+	obcluster.GET(constant.URI_PARAMETERS+constant.URI_SNAPSHOTS+constant.URI_PATH_PARAM_NAME+constant.URI_DIFF, checkClusterAgentWrapper(obclusterDiffParameterSnapshotHandler))
+	obcluster.POST(constant.URI_PARAMETERS+constant.URI_SNAPSHOTS+constant.URI_PATH_PARAM_NAME+constant.URI_ROLLBACK, checkClusterAgentWrapper(obclusterRollbackParameterSnapshotHandler))
+	obcluster.GET(constant.URI_PARAMETERS+constant.URI_DIFF, checkClusterAgentWrapper(obclusterDiffTenantParametersHandler))
 	obcluster.GET(constant.URI_CHARSETS, getObclusterCharsets)
 	obcluster.GET(constant.URI_STATISTICS, GetStatistics)
 	obcluster.GET(constant.URI_UNIT_CONFIG_LIMIT, checkClusterAgentWrapper(getUnitConfigLimitHandler))
+	obcluster.GET(constant.URI_CLOCK_SKEW, checkClusterAgentWrapper(obclusterClockSkewHandler))
+	obcluster.GET(constant.URI_CLOCK_SKEW+constant.URI_HISTORY, checkClusterAgentWrapper(obclusterClockSkewHistoryHandler))
+	obcluster.GET(constant.URI_CLOCK_SKEW+constant.URI_WATCHER, checkClusterAgentWrapper(getClockSkewWatcherConfigHandler))
+	obcluster.PATCH(constant.URI_CLOCK_SKEW+constant.URI_WATCHER, checkClusterAgentWrapper(patchClockSkewWatcherConfigHandler))
 
 	// observer routes
 	observer.PUT(constant.URI_CONFIG, obServerConfigHandler(true))
 	observer.POST(constant.URI_CONFIG, obServerConfigHandler(true))
 	observer.DELETE("", obClusterScaleInHandler)
+	observer.GET(constant.URI_CONFIG+constant.URI_DRIFT, checkClusterAgentWrapper(obServerConfigDriftHandler))
+	observer.POST(constant.URI_CONFIG+constant.URI_DRIFT+constant.URI_RECONCILE, checkClusterAgentWrapper(obServerReconcileConfigDriftHandler))
 
 	// zone routes
 	zone.DELETE(constant.URI_PATH_PARAM_NAME, zoneDeleteHandler)
+	zone.POST(constant.URI_PATH_PARAM_NAME+constant.URI_MAINTENANCE, checkClusterAgentWrapper(zoneEnterMaintenanceHandler))
+	zone.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_MAINTENANCE, checkClusterAgentWrapper(zoneExitMaintenanceHandler))
 
 	// upgrade routes
 	upgrade.POST(constant.URI_PACKAGE, pkgUploadHandler)
@@ -170,6 +195,8 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	unit.POST("", unitConfigCreateHandler)
 	unit.DELETE(constant.URI_PATH_PARAM_NAME, unitConfigDropHandler)
 	unit.GET(constant.URI_PATH_PARAM_NAME, unitConfigGetHandler)
+	unit.PATCH(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(unitConfigModifyHandler))
+	unit.POST(constant.URI_PATH_PARAM_NAME+constant.URI_CHECK, checkClusterAgentWrapper(unitConfigModifyCheckHandler))
 	units.GET("", unitConfigListHandler)
 
 	// pool routes
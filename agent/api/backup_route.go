@@ -51,14 +51,29 @@ func InitBackupRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP, tenantStartBackupHandler)
 	tenantGroup.PATCH(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP, patchTenantBackupHandler)
 	tenantGroup.PATCH(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_ARCHIVE, patchTenantArchiveLogHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_LAG, tenantArchiveLagHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_REMEDIATE, tenantRemediateArchiveLogHandler)
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_OVERVIEW, tenantBackupOverviewHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_CATALOG, tenantBackupCatalogHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_USAGE, tenantBackupUsageHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_VALIDATE, tenantValidateBackupHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_CLEAN, tenantCleanBackupHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_CLEAN+constant.URI_PREVIEW, tenantCleanBackupPreviewHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_KEYS, tenantBackupKeysHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_KEYS, tenantRotateBackupKeyHandler)
 
 	obclusterGroup.POST(constant.URI_BACKUP+constant.URI_CONFIG, obclusterBackupConfigHandler)
 	obclusterGroup.PATCH(constant.URI_BACKUP+constant.URI_CONFIG, patchObclusterBackupConfigHandler)
 	obclusterGroup.POST(constant.URI_BACKUP, obclusterStartBackupHandler)
 	obclusterGroup.PATCH(constant.URI_BACKUP, patchObclusterBackupHandler)
 	obclusterGroup.PATCH(constant.URI_BACKUP+constant.URI_ARCHIVE, patchObclusterArchiveLogHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_LAG, obclusterArchiveLagHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_WATCHER, getArchiveWatcherConfigHandler)
+	obclusterGroup.PATCH(constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_WATCHER, patchArchiveWatcherConfigHandler)
 	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_OVERVIEW, obclusterBackupOverviewHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_CATALOG, obclusterBackupCatalogHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_USAGE, obclusterBackupUsageHandler)
+	obclusterGroup.POST(constant.URI_BACKUP+constant.URI_VALIDATE, obclusterValidateBackupHandler)
 }
 
 // @ID				obclusterBackupConfig
@@ -381,6 +396,126 @@ func patchTenantArchiveLogHandler(c *gin.Context) {
 	common.SendResponse(c, nil, err)
 }
 
+// @ID				obclusterArchiveLag
+// @Summary		Get archive log status and lag for all tenants
+// @Description	Get archive log status and lag for all tenants
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Success		200				object	http.OcsAgentResponse{data=param.ArchiveLagOverview}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/log/lag [get]
+func obclusterArchiveLagHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	overview, err := ob.GetArchiveLagOverview("")
+	common.SendResponse(c, overview, err)
+}
+
+// @ID				tenantArchiveLag
+// @Summary		Get archive log status and lag for tenant
+// @Description	Get archive log status and lag for tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=param.ArchiveLagOverview}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/log/lag [get]
+func tenantArchiveLagHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	overview, err := ob.GetArchiveLagOverview(tenant.TenantName)
+	common.SendResponse(c, overview, err)
+}
+
+// @ID				tenantRemediateArchiveLog
+// @Summary		Remediate archive log for tenant
+// @Description	Close the interrupted archive log, re-enable the archive dest and open the archive log again
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/log/remediate [post]
+func tenantRemediateArchiveLogHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantRemediateArchiveLog(tenant)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				getArchiveWatcherConfig
+// @Summary		Get archive lag watcher config
+// @Description	Get archive lag watcher config
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Success		200				object	http.OcsAgentResponse{data=param.ArchiveWatcherConfig}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/log/watcher [get]
+func getArchiveWatcherConfigHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	cfg, err := ob.GetArchiveWatcherConfig()
+	common.SendResponse(c, cfg, err)
+}
+
+// @ID				patchArchiveWatcherConfig
+// @Summary		Patch archive lag watcher config
+// @Description	Patch archive lag watcher config
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string							true	"Authorization"
+// @Param			body			body	param.ArchiveWatcherConfigParam	true	"Archive lag watcher config"
+// @Success		200				object	http.OcsAgentResponse{data=param.ArchiveWatcherConfig}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/log/watcher [patch]
+func patchArchiveWatcherConfigHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	var p param.ArchiveWatcherConfigParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	cfg, err := ob.SetArchiveWatcherConfig(&p)
+	common.SendResponse(c, cfg, err)
+}
+
 // @ID				obclusterBackupOverview
 // @Summary		Get backup overview for all tenants
 // @Description	Get backup overview for all tenants
@@ -426,3 +561,273 @@ func tenantBackupOverviewHandler(c *gin.Context) {
 	overview, err := ob.GetTenantBackupOverview(tenant.TenantName)
 	common.SendResponse(c, overview, err)
 }
+
+// @ID				obclusterBackupCatalog
+// @Summary		List backup sets and archive pieces for all tenants
+// @Description	List backup sets and archive pieces for all tenants
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Success		200				object	http.OcsAgentResponse{data=param.BackupCatalog}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/catalog [get]
+func obclusterBackupCatalogHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	catalog, err := ob.GetObclusterBackupCatalog()
+	common.SendResponse(c, catalog, err)
+}
+
+// @ID				tenantBackupCatalog
+// @Summary		List backup sets and archive pieces for tenant
+// @Description	List backup sets and archive pieces for tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=param.TenantBackupCatalog}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/catalog [get]
+func tenantBackupCatalogHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	catalog, err := ob.GetTenantBackupCatalog(tenant)
+	common.SendResponse(c, catalog, err)
+}
+
+// @ID				obclusterBackupUsage
+// @Summary		Get backup storage usage and throughput for all tenants
+// @Description	Get backup storage usage and throughput for all tenants. The usage of object storage is summed from the backup catalog since its credentials are hidden in the dest views, and the destinations on the same file system or bucket share the capacity.
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			capacity		query	string	false	"Capacity of the backup destination, such as 10T, used to estimate days until full"
+// @Success		200				object	http.OcsAgentResponse{data=param.BackupUsage}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/usage [get]
+func obclusterBackupUsageHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	usage, err := ob.GetObclusterBackupUsage(c.Query("capacity"))
+	common.SendResponse(c, usage, err)
+}
+
+// @ID				tenantBackupUsage
+// @Summary		Get backup storage usage and throughput for tenant
+// @Description	Get backup storage usage and throughput for tenant. The usage of object storage is summed from the backup catalog since its credentials are hidden in the dest views, and the destinations on the same file system or bucket share the capacity.
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Param			capacity		query	string	false	"Capacity of the backup destination, such as 10T, used to estimate days until full"
+// @Success		200				object	http.OcsAgentResponse{data=param.TenantBackupUsage}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/usage [get]
+func tenantBackupUsageHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	usage, err := ob.GetTenantBackupUsage(tenant, c.Query("capacity"))
+	common.SendResponse(c, usage, err)
+}
+
+// @ID				obclusterValidateBackup
+// @Summary		Validate backup sets for all tenants
+// @Description	Validate backup sets for all tenants
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string						true	"Authorization"
+// @Param			body			body	param.BackupValidateParam	true	"Validate param"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/validate [post]
+func obclusterValidateBackupHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	var p param.BackupValidateParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.ObclusterValidateBackup(&p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				tenantValidateBackup
+// @Summary		Validate backup sets for tenant
+// @Description	Validate backup sets for tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string						true	"Authorization"
+// @Param			name			path	string						true	"Tenant name"
+// @Param			body			body	param.BackupValidateParam	true	"Validate param"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/validate [post]
+func tenantValidateBackupHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupValidateParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantValidateBackup(tenant, &p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				tenantCleanBackup
+// @Summary		Delete backup sets and obsolete archive pieces for tenant
+// @Description	Delete backup sets and obsolete archive pieces for tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string					true	"Authorization"
+// @Param			name			path	string					true	"Tenant name"
+// @Param			body			body	param.BackupCleanParam	true	"Clean param"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/clean [post]
+func tenantCleanBackupHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupCleanParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantCleanBackup(tenant, &p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				tenantCleanBackupPreview
+// @Summary		Preview backup sets and archive pieces to be deleted for tenant
+// @Description	Preview backup sets and archive pieces to be deleted for tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string					true	"Authorization"
+// @Param			name			path	string					true	"Tenant name"
+// @Param			body			body	param.BackupCleanParam	true	"Clean param"
+// @Success		200				object	http.OcsAgentResponse{data=param.BackupCleanPreview}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/clean/preview [post]
+func tenantCleanBackupPreviewHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupCleanParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	preview, err := ob.PreviewTenantBackupClean(tenant, &p)
+	common.SendResponse(c, preview, err)
+}
+
+// @ID				tenantBackupKeys
+// @Summary		List backup encryption keys for tenant
+// @Description	List the key versions and the backup sets encrypted with each version, without the passwords
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=[]bo.BackupEncryptionKey}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/keys [get]
+func tenantBackupKeysHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	keys, err := ob.GetTenantBackupKeys(tenant)
+	common.SendResponse(c, keys, err)
+}
+
+// @ID				tenantRotateBackupKey
+// @Summary		Rotate backup encryption key for tenant
+// @Description	Activate a new version of the backup encryption password, a random one is generated if not specified
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string					true	"Authorization"
+// @Param			name			path	string					true	"Tenant name"
+// @Param			body			body	param.BackupKeyParam	true	"Backup key"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupEncryptionKey}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/keys [post]
+func tenantRotateBackupKeyHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupKeyParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	key, err := ob.RotateTenantBackupKey(tenant, &p)
+	common.SendResponse(c, key, err)
+}
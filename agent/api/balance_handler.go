@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/executor/tenant"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID getTenantBalance
+// @Summary get tenant balance
+// @Description get leader and log stream distribution, unit load and in-progress balance jobs of a tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=bo.TenantBalance}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/balance [GET]
+func getTenantBalance(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	balance, err := tenant.GetTenantBalance(name)
+	common.SendResponse(c, balance, err)
+}
+
+// @ID triggerTenantBalance
+// @Summary trigger tenant balance
+// @Description trigger partition balance of a tenant and wait for the balance job to finish
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/balance [POST]
+func triggerTenantBalance(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	dag, err := tenant.TriggerTenantBalance(name)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID transferPartition
+// @Summary transfer partition
+// @Description transfer a partition of a table to the target log stream
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.TransferPartitionParam true "transfer partition param"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/balance/transfer [POST]
+func transferPartition(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TransferPartitionParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	dag, err := tenant.TransferPartition(name, &param)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID switchLeaderToPrimaryZone
+// @Summary switch leaders to primary zone
+// @Description switch the leaders of log streams back to the first priority of the primary zone
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.SwitchLeaderParam true "switch leader param"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/balance/leader [POST]
+func switchLeaderToPrimaryZone(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.SwitchLeaderParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	dag, err := tenant.SwitchLeaderToPrimaryZone(name, &param)
+	common.SendResponse(c, dag, err)
+}
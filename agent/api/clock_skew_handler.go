@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID			obclusterClockSkew
+// @Summary	get the clock offset and round-trip time of all the agents measured by the maintainer
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		refresh			query	bool	false	"measure the clock skew right now rather than return the latest result"
+// @Success	200				object	http.OcsAgentResponse{data=param.ClockSkewOverview}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/clock-skew [get]
+func obclusterClockSkewHandler(c *gin.Context) {
+	var refresh bool
+	if value := c.Query("refresh"); value != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "refresh", err.Error()))
+			return
+		}
+	}
+	overview, err := ob.GetClockSkewOverview(refresh)
+	common.SendResponse(c, overview, err)
+}
+
+// @ID			obclusterClockSkewHistory
+// @Summary	get the history of the clock skew samples
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		agent			query	string	false	"the agent, such as 127.0.0.1:2886, all the agents if not specified"
+// @Success	200				object	http.OcsAgentResponse{data=[]param.ClockSkewHistory}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/clock-skew/history [get]
+func obclusterClockSkewHistoryHandler(c *gin.Context) {
+	history, err := ob.GetClockSkewHistory(c.Query("agent"))
+	common.SendResponse(c, history, err)
+}
+
+// @ID			getClockSkewWatcherConfig
+// @Summary	get clock skew watcher config
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Success	200				object	http.OcsAgentResponse{data=param.ClockSkewWatcherConfig}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/clock-skew/watcher [get]
+func getClockSkewWatcherConfigHandler(c *gin.Context) {
+	cfg, err := ob.GetClockSkewWatcherConfig()
+	common.SendResponse(c, cfg, err)
+}
+
+// @ID			patchClockSkewWatcherConfig
+// @Summary	patch clock skew watcher config
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string								true	"Authorization"
+// @Param		body			body	param.ClockSkewWatcherConfigParam	true	"clock skew watcher config"
+// @Success	200				object	http.OcsAgentResponse{data=param.ClockSkewWatcherConfig}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/clock-skew/watcher [patch]
+func patchClockSkewWatcherConfigHandler(c *gin.Context) {
+	var p param.ClockSkewWatcherConfigParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	cfg, err := ob.SetClockSkewWatcherConfig(&p)
+	common.SendResponse(c, cfg, err)
+}
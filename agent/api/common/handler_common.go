@@ -72,3 +72,11 @@ func IsApiRoute(c *gin.Context) bool {
 	_, isApiRoute := c.Get(apiRouteKey)
 	return isApiRoute
 }
+
+// GetOperator returns who sends the request, "local" for the request from the local unix socket.
+func GetOperator(c *gin.Context) string {
+	if IsLocalRoute(c) {
+		return "local"
+	}
+	return c.ClientIP()
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID			obServerConfigDrift
+// @Summary	get the drift between the persisted observer configs and the live parameters
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		refresh			query	bool	false	"check the drift right now rather than return the latest report"
+// @Success	200				object	http.OcsAgentResponse{data=bo.ConfigDriftReport}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/observer/config/drift [get]
+func obServerConfigDriftHandler(c *gin.Context) {
+	var refresh bool
+	if value := c.Query("refresh"); value != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "refresh", err.Error()))
+			return
+		}
+	}
+	report, err := ob.GetConfigDriftReport(refresh)
+	common.SendResponse(c, report, err)
+}
+
+// @ID			obServerReconcileConfigDrift
+// @Summary	reconcile the drift between the persisted observer configs and the live parameters
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string							true	"Authorization"
+// @Param		body			body	param.ReconcileConfigDriftParam	true	"reconcile direction and filters"
+// @Success	200				object	http.OcsAgentResponse{data=ob.ConfigDriftReconciliation}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/observer/config/drift/reconcile [post]
+func obServerReconcileConfigDriftHandler(c *gin.Context) {
+	var param param.ReconcileConfigDriftParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	reconciliation, err := ob.ReconcileConfigDrift(&param, common.GetOperator(c))
+	common.SendResponse(c, reconciliation, err)
+}
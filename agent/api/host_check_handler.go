@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/executor/host"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID			agentHostCheck
+// @Summary	check the local host against the requirement profile
+// @Tags		agent
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		body			body	param.HostCheckParam	true	"host check param"
+// @Success	200				object	http.OcsAgentResponse{data=bo.HostCheckReport}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/agent/host-check [post]
+func agentHostCheckHandler(c *gin.Context) {
+	var param param.HostCheckParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	report, err := host.Check(&param)
+	common.SendResponse(c, report, err)
+}
+
+// @ID			obHostCheck
+// @Summary	check the hosts of the specified servers or all the agents in the cluster against the requirement profile
+// @Tags		ob
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		body			body	param.HostCheckParam	true	"host check param"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.HostCheckReport}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/ob/host-check [post]
+func obHostCheckHandler(c *gin.Context) {
+	var param param.HostCheckParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	reports, err := ob.CheckHosts(&param)
+	common.SendResponse(c, reports, err)
+}
@@ -17,11 +17,14 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/oceanbase/obshell/agent/api/common"
 	"github.com/oceanbase/obshell/agent/errors"
 	metricexecutor "github.com/oceanbase/obshell/agent/executor/metric"
 	metricconstant "github.com/oceanbase/obshell/agent/executor/metric/constant"
+	"github.com/oceanbase/obshell/agent/executor/ob"
 	"github.com/oceanbase/obshell/model/metric"
 	"github.com/sirupsen/logrus"
 )
@@ -86,3 +89,41 @@ func QueryMetrics(c *gin.Context) {
 	logrus.Debugf("Query metric data: %+v", metricDatas)
 	common.SendResponse(c, metricDatas, nil)
 }
+
+// @ID ArchiveLagMetrics
+// @Summary archive lag metrics
+// @Description export archive log status and lag of all tenants in prometheus text format
+// @Tags Metric
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/metrics/archive-lag [GET]
+// @Security ApiKeyAuth
+func ArchiveLagMetrics(c *gin.Context) {
+	overview, err := ob.GetArchiveLagOverview("")
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	c.String(http.StatusOK, ob.FormatArchiveLagMetrics(overview))
+}
+
+// @ID ClockSkewMetrics
+// @Summary clock skew metrics
+// @Description export clock offset and round-trip time of all agents in prometheus text format
+// @Tags Metric
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/metrics/clock-skew [GET]
+// @Security ApiKeyAuth
+func ClockSkewMetrics(c *gin.Context) {
+	overview, err := ob.GetClockSkewOverview(false)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	c.String(http.StatusOK, ob.FormatClockSkewMetrics(overview))
+}
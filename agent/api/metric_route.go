@@ -29,4 +29,6 @@ func InitMetricRoutes(r *gin.RouterGroup, isLocalRoute bool) {
 	}
 	group.GET("", ListMetricMetas)
 	group.POST("/query", QueryMetrics)
+	group.GET(constant.URI_ARCHIVE_LAG, ArchiveLagMetrics)
+	group.GET(constant.URI_CLOCK_SKEW, ClockSkewMetrics)
 }
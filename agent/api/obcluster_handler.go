@@ -311,7 +311,7 @@ func obStartHandler(c *gin.Context) {
 
 // @ID ScaleOut
 // @Summary cluster scale-out
-// @Description cluster scale-out
+// @Description cluster scale-out, multiple agents could be scaled out in one dag
 // @Tags ob
 // @Accept application/json
 // @Param X-OCS-Header header string true "Authorization"
@@ -370,6 +370,28 @@ func obClusterScaleInHandler(c *gin.Context) {
 	}
 }
 
+// @ID ReplaceServer
+// @Summary cluster replace server
+// @Description replace the failed server with a new server in the same zone
+// @Tags ob
+// @Accept application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.ClusterReplaceServerParam true "replace server param"
+// @Produce application/json
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/replace_server [post]
+func obClusterReplaceServerHandler(c *gin.Context) {
+	var param param.ClusterReplaceServerParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := ob.HandleClusterReplaceServer(param)
+	common.SendResponse(c, data, err)
+}
+
 // @ID GetObInfo
 // @Summary get ob and agent info
 // @Description get ob and agent info
@@ -456,7 +478,7 @@ func obclusterSetParametersHandler(c *gin.Context) {
 		common.SendResponse(c, nil, err)
 		return
 	}
-	common.SendResponse(c, nil, ob.SetObclusterParameters(param.Params))
+	common.SendResponse(c, nil, ob.SetObclusterParameters(param.Params, common.GetOperator(c)))
 }
 
 func isEmergencyMode(c *gin.Context, scope *param.Scope) (bool, error) {
@@ -676,6 +698,121 @@ func obUpgradeHandler(c *gin.Context) {
 	common.SendResponse(c, dag, err)
 }
 
+// @ID obUpgradeGates
+// @Summary list upgrade zone gates
+// @Description list the approval gates of a rolling ob upgrade
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeGateParam true "upgrade gate params"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.UpgradeZoneGate}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/upgrade/gates [get]
+func obUpgradeGatesHandler(c *gin.Context) {
+	var param param.UpgradeGateParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	gates, err := ob.ListUpgradeZoneGates(param.DagID)
+	common.SendResponse(c, gates, err)
+}
+
+// @ID obUpgradeApprove
+// @Summary approve upgrade zone
+// @Description approve the zone waiting for approval and continue the rolling ob upgrade
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeGateParam true "upgrade gate params"
+// @Success 200 object http.OcsAgentResponse{data=bo.UpgradeZoneGate}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/upgrade/approve [post]
+func obUpgradeApproveHandler(c *gin.Context) {
+	var param param.UpgradeGateParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	gate, err := ob.ApproveUpgradeZone(&param)
+	common.SendResponse(c, gate, err)
+}
+
+// @ID obUpgradeAbort
+// @Summary abort upgrade zone
+// @Description abort the rolling ob upgrade at the zone waiting for approval
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeGateParam true "upgrade gate params"
+// @Success 200 object http.OcsAgentResponse{data=bo.UpgradeZoneGate}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/upgrade/abort [post]
+func obUpgradeAbortHandler(c *gin.Context) {
+	var param param.UpgradeGateParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	gate, err := ob.AbortUpgradeZone(&param)
+	common.SendResponse(c, gate, err)
+}
+
+// @ID obUpgradeRollbackPoint
+// @Summary get upgrade rollback point
+// @Description get the point which the failed rolling ob upgrade will be rolled back to
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeRollbackParam true "upgrade rollback params"
+// @Success 200 object http.OcsAgentResponse{data=param.UpgradeRollbackPoint}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/upgrade/rollback [get]
+func obUpgradeRollbackPointHandler(c *gin.Context) {
+	var param param.UpgradeRollbackParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	point, err := ob.GetUpgradeRollbackPoint(&param)
+	common.SendResponse(c, point, err)
+}
+
+// @ID obUpgradeRollback
+// @Summary rollback ob upgrade
+// @Description roll back the zones switched by the failed rolling ob upgrade
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeRollbackParam true "upgrade rollback params"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/ob/upgrade/rollback [post]
+func obUpgradeRollbackHandler(c *gin.Context) {
+	var param param.UpgradeRollbackParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	dag, err := ob.RollbackObUpgrade(&param)
+	common.SendResponse(c, dag, err)
+}
+
 func obAgentsHandler(c *gin.Context) {
 	if meta.OCS_AGENT.IsFollowerAgent() {
 		master := agentService.GetMasterAgentInfo()
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID			obclusterParameterHistory
+// @Summary	list the parameter and variable changes
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			query	string	false	"parameter or variable name"
+// @Param		tenant			query	string	false	"tenant name"
+// @Param		limit			query	int		false	"max number of changes"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.ParameterChange}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/history [get]
+func obclusterParameterHistoryHandler(c *gin.Context) {
+	var limit int
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "limit", err.Error()))
+			return
+		}
+	}
+	changes, err := ob.GetParameterChanges(c.Query("name"), c.Query("tenant"), limit)
+	common.SendResponse(c, changes, err)
+}
+
+// @ID			obclusterRollbackParameterChange
+// @Summary	roll back a parameter or variable change
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		id				path	int		true	"change id"
+// @Success	200				object	http.OcsAgentResponse{data=bo.ParameterChange}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/history/{id}/rollback [post]
+func obclusterRollbackParameterChangeHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param(constant.URI_PARAM_ID), 10, 64)
+	if err != nil {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "id", err.Error()))
+		return
+	}
+	change, err := ob.RollbackParameterChange(id, common.GetOperator(c))
+	common.SendResponse(c, change, err)
+}
+
+// @ID			obclusterCreateParameterSnapshot
+// @Summary	save the current parameters and variables as a snapshot
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string								true	"Authorization"
+// @Param		body			body	param.CreateParameterSnapshotParam	true	"snapshot"
+// @Success	204				object	http.OcsAgentResponse
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/snapshots [post]
+func obclusterCreateParameterSnapshotHandler(c *gin.Context) {
+	var param param.CreateParameterSnapshotParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	common.SendResponse(c, nil, ob.CreateParameterSnapshot(param, common.GetOperator(c)))
+}
+
+// @ID			obclusterListParameterSnapshots
+// @Summary	list the parameter snapshots
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.ParameterSnapshot}
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/snapshots [get]
+func obclusterListParameterSnapshotsHandler(c *gin.Context) {
+	snapshots, err := ob.GetParameterSnapshots()
+	common.SendResponse(c, snapshots, err)
+}
+
+// @ID			obclusterDeleteParameterSnapshot
+// @Summary	delete the parameter snapshot
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"snapshot name"
+// @Success	204				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/snapshots/{name} [delete]
+func obclusterDeleteParameterSnapshotHandler(c *gin.Context) {
+	common.SendResponse(c, nil, ob.DeleteParameterSnapshot(c.Param(constant.URI_PARAM_NAME)))
+}
+
+// @ID			obclusterDiffParameterSnapshot
+// @Summary	diff the parameter snapshot with the current parameters and variables
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"snapshot name"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.ParameterDiff}
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/snapshots/{name}/diff [get]
+func obclusterDiffParameterSnapshotHandler(c *gin.Context) {
+	diffs, err := ob.DiffParametersWithSnapshot(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, diffs, err)
+}
+
+// @ID			obclusterRollbackParameterSnapshot
+// @Summary	roll back the parameters and variables to the snapshot
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"snapshot name"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.ParameterChange}
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/snapshots/{name}/rollback [post]
+func obclusterRollbackParameterSnapshotHandler(c *gin.Context) {
+	changes, err := ob.RollbackParameterSnapshot(c.Param(constant.URI_PARAM_NAME), common.GetOperator(c))
+	common.SendResponse(c, changes, err)
+}
+
+// @ID			obclusterDiffTenantParameters
+// @Summary	diff the tenant parameters and variables between two tenants
+// @Tags		obcluster
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		tenant			query	string	true	"base tenant name"
+// @Param		target_tenant	query	string	true	"target tenant name"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.ParameterDiff}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/obcluster/parameters/diff [get]
+func obclusterDiffTenantParametersHandler(c *gin.Context) {
+	tenantName := c.Query("tenant")
+	targetTenantName := c.Query("target_tenant")
+	if tenantName == "" || targetTenantName == "" {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "tenant or target_tenant", "both tenant and target_tenant are required"))
+		return
+	}
+	diffs, err := ob.DiffTenantParameters(tenantName, targetTenantName)
+	common.SendResponse(c, diffs, err)
+}
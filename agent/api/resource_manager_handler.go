@@ -0,0 +1,358 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/executor/tenant"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID listResourcePlans
+// @Summary list resource plans
+// @Description list resource plans of a tenant with their directives
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ResourcePlan}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans [GET]
+func listResourcePlansHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	plans, err := tenant.ListResourcePlans(name, param.RootPassword)
+	common.SendResponse(c, plans, err)
+}
+
+// @ID createResourcePlan
+// @Summary create resource plan
+// @Description create resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.CreateResourcePlanParam true "create resource plan param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans [POST]
+func createResourcePlanHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.CreateResourcePlanParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateResourcePlan(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deleteResourcePlan
+// @Summary delete resource plan
+// @Description delete resource plan and its directives
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan} [DELETE]
+func deleteResourcePlanHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeleteResourcePlan(name, plan, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID createPlanDirective
+// @Summary create plan directive
+// @Description create the cpu and io directive of a consumer group in a resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param body body param.CreatePlanDirectiveParam true "create plan directive param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives [POST]
+func createPlanDirectiveHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	var param param.CreatePlanDirectiveParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreatePlanDirective(name, plan, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyPlanDirective
+// @Summary modify plan directive
+// @Description modify the cpu and io directive of a consumer group in a resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param group path string true "consumer group name"
+// @Param body body param.ModifyPlanDirectiveParam true "modify plan directive param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives/{group} [PATCH]
+func modifyPlanDirectiveHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	group := c.Param(constant.URI_PARAM_GROUP)
+	var param param.ModifyPlanDirectiveParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyPlanDirective(name, plan, group, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deletePlanDirective
+// @Summary delete plan directive
+// @Description delete the directive of a consumer group in a resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param group path string true "consumer group name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives/{group} [DELETE]
+func deletePlanDirectiveHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	group := c.Param(constant.URI_PARAM_GROUP)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeletePlanDirective(name, plan, group, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listConsumerGroups
+// @Summary list consumer groups
+// @Description list consumer groups of a tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ConsumerGroup}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups [GET]
+func listConsumerGroupsHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	groups, err := tenant.ListConsumerGroups(name, param.RootPassword)
+	common.SendResponse(c, groups, err)
+}
+
+// @ID createConsumerGroup
+// @Summary create consumer group
+// @Description create consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.CreateConsumerGroupParam true "create consumer group param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups [POST]
+func createConsumerGroupHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.CreateConsumerGroupParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateConsumerGroup(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deleteConsumerGroup
+// @Summary delete consumer group
+// @Description delete consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param group path string true "consumer group name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups/{group} [DELETE]
+func deleteConsumerGroupHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	group := c.Param(constant.URI_PARAM_GROUP)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeleteConsumerGroup(name, group, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listConsumerGroupMappings
+// @Summary list consumer group mappings
+// @Description list user and column mappings of consumer groups
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ConsumerGroupMapping}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/mappings [GET]
+func listConsumerGroupMappingsHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	mappings, err := tenant.ListConsumerGroupMappings(name, param.RootPassword)
+	common.SendResponse(c, mappings, err)
+}
+
+// @ID setConsumerGroupMapping
+// @Summary set consumer group mapping
+// @Description map a user or column to a consumer group, remove the mapping if consumer group is empty
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.ConsumerGroupMappingParam true "consumer group mapping param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/mappings [PUT]
+func setConsumerGroupMappingHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.ConsumerGroupMappingParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.SetConsumerGroupMapping(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID activateResourcePlan
+// @Summary activate resource plan
+// @Description activate resource plan, disable the resource manager if plan name is empty
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.ActivateResourcePlanParam true "activate resource plan param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/active-plan [PUT]
+func activateResourcePlanHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.ActivateResourcePlanParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ActivateResourcePlan(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID getResourceManagerUsage
+// @Summary get resource manager usage
+// @Description get the effective resource plan of a tenant and the io usage of each consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=bo.ResourceManagerUsage}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/usage [GET]
+func getResourceManagerUsageHandler(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	usage, err := tenant.GetResourceManagerUsage(name, param.RootPassword)
+	common.SendResponse(c, usage, err)
+}
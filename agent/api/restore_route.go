@@ -17,6 +17,8 @@
 package api
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
@@ -40,10 +42,18 @@ func InitRestoreRoutes(r *gin.RouterGroup, isLocalRoute bool) {
 	}
 
 	restoreGroup.GET(constant.URI_WINDOWS, getRestoreWindowsHandler)
+	restoreGroup.POST(constant.URI_SOURCES, listRestoreSourcesHandler)
+	restoreGroup.POST(constant.URI_POINT, getRestorePointHandler)
+	restoreGroup.POST(constant.URI_DRILL, restoreDrillHandler)
+	restoreGroup.GET(constant.URI_DRILL+constant.URI_REPORTS, listRestoreDrillReportsHandler)
+	restoreGroup.GET(constant.URI_DRILL+constant.URI_REPORTS+constant.URI_PATH_PARAM_ID, getRestoreDrillReportHandler)
+	restoreGroup.GET(constant.URI_DRILL+constant.URI_SCHEDULE, getRestoreDrillScheduleHandler)
+	restoreGroup.PATCH(constant.URI_DRILL+constant.URI_SCHEDULE, patchRestoreDrillScheduleHandler)
 
 	tenantGroup.POST(constant.URI_RESTORE, tenantRestoreHandler)
 	tenantGroup.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE, cancelRestoreTaskHandler)
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE+constant.URI_OVERVIEW, getRestoreOverviewHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE+constant.URI_TABLES, tenantRecoverTableHandler)
 
 }
 
@@ -179,3 +189,218 @@ func getRestoreWindowsHandler(c *gin.Context) {
 	windows, err := ob.GetRestoreWindows(&p)
 	common.SendResponse(c, windows, err)
 }
+
+// @ID			listRestoreSources
+// @Summary	List the tenant backups and their restore windows under the base uri
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string						true	"Authorization"
+// @Param		body			body	param.RestoreSourceParam	true	"Backup base uri"
+// @Success	200				object	http.OcsAgentResponse{data=[]param.RestoreSource}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/sources [post]
+func listRestoreSourcesHandler(c *gin.Context) {
+	var p param.RestoreSourceParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	sources, err := ob.ListRestoreSources(&p)
+	common.SendResponse(c, sources, err)
+}
+
+// @ID			getRestorePoint
+// @Summary	Get the closest restorable point to a wall-clock time or scn
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		body			body	param.RestorePointParam	true	"Requested restore point"
+// @Success	200				object	http.OcsAgentResponse{data=param.RestorePoint}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/point [post]
+func getRestorePointHandler(c *gin.Context) {
+	var p param.RestorePointParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	point, err := ob.GetRestorePoint(&p)
+	common.SendResponse(c, point, err)
+}
+
+// @ID			restoreDrill
+// @Summary	Restore the latest restorable point into a scratch tenant and verify it
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		body			body	param.RestoreDrillParam	true	"Restore drill"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/drill [post]
+func restoreDrillHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	var p param.RestoreDrillParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	if p.ScratchTenantName != nil && *p.ScratchTenantName == constant.TENANT_SYS {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObTenantSysOperationNotAllowed))
+		return
+	}
+	if len(p.ZoneList) == 0 {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObTenantZoneListEmpty))
+		return
+	}
+
+	dag, err := ob.TenantRestoreDrill(&p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID			listRestoreDrillReports
+// @Summary	List restore drill reports
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		limit			query	int		false	"Max number of reports, the latest first"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.RestoreDrillReport}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/drill/reports [get]
+func listRestoreDrillReportsHandler(c *gin.Context) {
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "limit", err.Error()))
+			return
+		}
+	}
+
+	reports, err := ob.GetRestoreDrillReports(limit)
+	common.SendResponse(c, reports, err)
+}
+
+// @ID			getRestoreDrillReport
+// @Summary	Get restore drill report
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		id				path	int		true	"Report id"
+// @Success	200				object	http.OcsAgentResponse{data=bo.RestoreDrillReport}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/drill/reports/{id} [get]
+func getRestoreDrillReportHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param(constant.URI_PARAM_ID), 10, 64)
+	if err != nil {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "id", err.Error()))
+		return
+	}
+
+	report, err := ob.GetRestoreDrillReport(id)
+	common.SendResponse(c, report, err)
+}
+
+// @ID			getRestoreDrillSchedule
+// @Summary	Get restore drill schedule
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Success	200				object	http.OcsAgentResponse{data=param.RestoreDrillSchedule}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/drill/schedule [get]
+func getRestoreDrillScheduleHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	schedule, err := ob.GetRestoreDrillSchedule()
+	common.SendResponse(c, schedule, err)
+}
+
+// @ID			patchRestoreDrillSchedule
+// @Summary	Patch restore drill schedule
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string							true	"Authorization"
+// @Param		body			body	param.RestoreDrillScheduleParam	true	"Restore drill schedule"
+// @Success	200				object	http.OcsAgentResponse{data=param.RestoreDrillSchedule}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/restore/drill/schedule [patch]
+func patchRestoreDrillScheduleHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	var p param.RestoreDrillScheduleParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	schedule, err := ob.SetRestoreDrillSchedule(&p)
+	common.SendResponse(c, schedule, err)
+}
+
+// @ID			tenantRecoverTable
+// @Summary	Restore databases and tables into an existing tenant
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		tenantName		path	string					true	"Target tenant name"
+// @Param		body			body	param.RecoverTableParam	true	"Table restore"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/:tenantName/restore/tables [post]
+func tenantRecoverTableHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.RecoverTableParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	if len(p.ZoneList) == 0 {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObTenantZoneListEmpty))
+		return
+	}
+	p.TargetTenantName = tenant.TenantName
+
+	dag, err := ob.TenantRecoverTable(&p)
+	common.SendResponse(c, dag, err)
+}
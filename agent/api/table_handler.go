@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/agent/api/common"
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/tenant"
+	"github.com/oceanbase/obshell/param"
+)
+
+// @ID listTables
+// @Summary list tables
+// @Description list tables of a database with row count, data size and partition count
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param database path string true "database name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ObTable}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/databases/{database}/tables [GET]
+func listTables(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	databaseName := c.Param(constant.URI_PARAM_DATABASE)
+	tables, err := tenant.ListTables(name, databaseName)
+	common.SendResponse(c, tables, err)
+}
+
+// @ID getTable
+// @Summary get table
+// @Description get table with its partitions and indexes
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param database path string true "database name"
+// @Param table path string true "table name"
+// @Success 200 object http.OcsAgentResponse{data=bo.ObTableDetail}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/databases/{database}/tables/{table} [GET]
+func getTable(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	databaseName := c.Param(constant.URI_PARAM_DATABASE)
+	tableName := c.Param(constant.URI_PARAM_TABLE)
+	table, err := tenant.GetTable(name, databaseName, tableName)
+	common.SendResponse(c, table, err)
+}
+
+// @ID getTableDdl
+// @Summary get table ddl
+// @Description get the create statement of a table
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param database path string true "database name"
+// @Param table path string true "table name"
+// @Param body body param.TenantRootPasswordParam true "tenant root password"
+// @Success 200 object http.OcsAgentResponse{data=bo.TableDdl}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/databases/{database}/tables/{table}/ddl [GET]
+func getTableDdl(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	databaseName := c.Param(constant.URI_PARAM_DATABASE)
+	tableName := c.Param(constant.URI_PARAM_TABLE)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	ddl, err := tenant.GetTableDdl(name, databaseName, tableName, param.RootPassword)
+	common.SendResponse(c, ddl, err)
+}
+
+// @ID listTableIndexes
+// @Summary list table indexes
+// @Description list indexes of a table with the location of each partition
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param database path string true "database name"
+// @Param table path string true "table name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.TableIndex}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/databases/{database}/tables/{table}/indexes [GET]
+func listTableIndexes(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	databaseName := c.Param(constant.URI_PARAM_DATABASE)
+	tableName := c.Param(constant.URI_PARAM_TABLE)
+	indexes, err := tenant.ListTableIndexes(name, databaseName, tableName)
+	common.SendResponse(c, indexes, err)
+}
+
+// @ID listTablePartitions
+// @Summary list table partitions
+// @Description list partitions of a table with the location and leader of each replica
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param database path string true "database name"
+// @Param table path string true "table name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.TablePartition}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/databases/{database}/tables/{table}/partitions [GET]
+func listTablePartitions(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	databaseName := c.Param(constant.URI_PARAM_DATABASE)
+	tableName := c.Param(constant.URI_PARAM_TABLE)
+	partitions, err := tenant.ListTablePartitions(name, databaseName, tableName)
+	common.SendResponse(c, partitions, err)
+}
+
+// @ID listTablets
+// @Summary list tablets
+// @Description list tablets of a tenant grouped by server
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param server query string false "observer address, in the format of 'ip' or 'ip:port'"
+// @Param database query string false "database name"
+// @Param limit query int false "max number of tablets, all if not positive"
+// @Param offset query int false "number of tablets to skip"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ServerTablets}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/tablets [GET]
+func listTablets(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	server := c.Query("server")
+	databaseName := c.Query("database")
+	var limit, offset int
+	var err error
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "limit", err.Error()))
+			return
+		}
+	}
+	if value := c.Query("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "offset", err.Error()))
+			return
+		}
+	}
+	tablets, err := tenant.ListTablets(name, server, databaseName, limit, offset)
+	common.SendResponse(c, tablets, err)
+}
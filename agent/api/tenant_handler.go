@@ -68,11 +68,30 @@ func InitTenantRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_USER+constant.URI_PATH_PARAM_USER+constant.URI_LOCK, tenantHandlerWrapper(lockUser))
 	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_USER+constant.URI_PATH_PARAM_USER+constant.URI_STATS, tenantHandlerWrapper(getUserStats))
 	tenant.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_USER+constant.URI_PATH_PARAM_USER+constant.URI_LOCK, tenantHandlerWrapper(unlockUser))
+	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_USER+constant.URI_PATH_PARAM_USER+constant.URI_ROLES, tenantHandlerWrapper(modifyUserRoles))
+	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_USER+constant.URI_PATH_PARAM_USER+constant.URI_OBJECT_PRIVILEGE, tenantHandlerWrapper(modifyUserObjectPrivilege))
+	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES, tenantHandlerWrapper(createRole))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES, tenantHandlerWrapper(listRoles))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES+constant.URI_PATH_PARAM_ROLE, tenantHandlerWrapper(getRole))
+	tenant.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES+constant.URI_PATH_PARAM_ROLE, tenantHandlerWrapper(dropRole))
+	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES+constant.URI_PATH_PARAM_ROLE+constant.URI_GLOBAL_PRIVILEGE, tenantHandlerWrapper(modifyRoleGlobalPrivilege))
+	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES+constant.URI_PATH_PARAM_ROLE+constant.URI_ROLES, tenantHandlerWrapper(modifyRoleRoles))
+	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_ROLES+constant.URI_PATH_PARAM_ROLE+constant.URI_OBJECT_PRIVILEGE, tenantHandlerWrapper(modifyRoleObjectPrivilege))
 	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES, tenantHandlerWrapper(createDatabase))
 	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES, tenantHandlerWrapper(listDatabases))
 	tenant.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE, tenantHandlerWrapper(updateDatabase))
 	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE, tenantHandlerWrapper(getDatabase))
 	tenant.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE, tenantHandlerWrapper(deleteDatabase))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE+constant.URI_TABLES, tenantHandlerWrapper(listTables))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE+constant.URI_TABLES+constant.URI_PATH_PARAM_TABLE, tenantHandlerWrapper(getTable))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE+constant.URI_TABLES+constant.URI_PATH_PARAM_TABLE+constant.URI_DDL, tenantHandlerWrapper(getTableDdl))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE+constant.URI_TABLES+constant.URI_PATH_PARAM_TABLE+constant.URI_INDEXES, tenantHandlerWrapper(listTableIndexes))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_DATABASES+constant.URI_PATH_PARAM_DATABASE+constant.URI_TABLES+constant.URI_PATH_PARAM_TABLE+constant.URI_PARTITIONS, tenantHandlerWrapper(listTablePartitions))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_TABLETS, tenantHandlerWrapper(listTablets))
+	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BALANCE, tenantHandlerWrapper(getTenantBalance))
+	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BALANCE, tenantHandlerWrapper(triggerTenantBalance))
+	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BALANCE+constant.URI_TRANSFER, tenantHandlerWrapper(transferPartition))
+	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BALANCE+constant.URI_LEADER, tenantHandlerWrapper(switchLeaderToPrimaryZone))
 
 	// for compaction
 	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_COMPACTION, getTenantCompactionHandler)
@@ -83,6 +102,22 @@ func InitTenantRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	// for slow sql
 	tenant.GET(constant.URI_TOP_SLOW_SQLS, getTenantTopSlowSqlRankHandler)
 
+	// for resource manager
+	resourceManager := tenant.Group(constant.URI_PATH_PARAM_NAME + constant.URI_RESOURCE_MANAGER)
+	resourceManager.GET(constant.URI_PLANS, tenantHandlerWrapper(listResourcePlansHandler))
+	resourceManager.POST(constant.URI_PLANS, tenantHandlerWrapper(createResourcePlanHandler))
+	resourceManager.DELETE(constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN, tenantHandlerWrapper(deleteResourcePlanHandler))
+	resourceManager.POST(constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES, tenantHandlerWrapper(createPlanDirectiveHandler))
+	resourceManager.PATCH(constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES+constant.URI_PATH_PARAM_GROUP, tenantHandlerWrapper(modifyPlanDirectiveHandler))
+	resourceManager.DELETE(constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES+constant.URI_PATH_PARAM_GROUP, tenantHandlerWrapper(deletePlanDirectiveHandler))
+	resourceManager.GET(constant.URI_CONSUMER_GROUPS, tenantHandlerWrapper(listConsumerGroupsHandler))
+	resourceManager.POST(constant.URI_CONSUMER_GROUPS, tenantHandlerWrapper(createConsumerGroupHandler))
+	resourceManager.DELETE(constant.URI_CONSUMER_GROUPS+constant.URI_PATH_PARAM_GROUP, tenantHandlerWrapper(deleteConsumerGroupHandler))
+	resourceManager.GET(constant.URI_MAPPINGS, tenantHandlerWrapper(listConsumerGroupMappingsHandler))
+	resourceManager.PUT(constant.URI_MAPPINGS, tenantHandlerWrapper(setConsumerGroupMappingHandler))
+	resourceManager.PUT(constant.URI_ACTIVE_PLAN, tenantHandlerWrapper(activateResourcePlanHandler))
+	resourceManager.GET(constant.URI_USAGE, tenantHandlerWrapper(getResourceManagerUsageHandler))
+
 	tenants.GET(constant.URI_OVERVIEW, getTenantOverView)
 }
 
@@ -463,7 +498,7 @@ func tenantSetParametersHandler(c *gin.Context) {
 		common.SendResponse(c, nil, err)
 		return
 	}
-	common.SendResponse(c, nil, tenant.SetTenantParameters(name, param.Parameters))
+	common.SendResponse(c, nil, tenant.SetTenantParameters(name, param.Parameters, common.GetOperator(c)))
 }
 
 // @ID tenantSetVariable
@@ -1236,3 +1271,239 @@ func getTenantTopSlowSqlRankHandler(c *gin.Context) {
 	res, err := tenantService.GetSlowSqlRank(param.Top, param.StartTime.UnixMicro(), param.EndTime.UnixMicro())
 	common.SendResponse(c, res, err)
 }
+
+// @ID modifyUserRoles
+// @Summary modify granted roles of a user
+// @Description modify granted roles of a user, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param user path string true "user name"
+// @Param body body param.ModifyGrantedRolesParam true "modify granted roles param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/user/{user}/roles [PUT]
+func modifyUserRoles(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	user := c.Param(constant.URI_PARAM_USER)
+	var param param.ModifyGrantedRolesParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyGrantedRoles(name, user, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyUserObjectPrivilege
+// @Summary modify object privilege of a user
+// @Description modify object privilege of a user, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param user path string true "user name"
+// @Param body body param.ModifyObjectPrivilegeParam true "modify object privilege param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/user/{user}/object-privilege [PUT]
+func modifyUserObjectPrivilege(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	user := c.Param(constant.URI_PARAM_USER)
+	var param param.ModifyObjectPrivilegeParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyObjectPrivilege(name, user, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID createRole
+// @Summary create role
+// @Description create role, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.CreateRoleParam true "create role param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles [POST]
+func createRole(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.CreateRoleParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateRole(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listRoles
+// @Summary list roles
+// @Description list roles of a tenant, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ObRole}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles [GET]
+func listRoles(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	roles, err := tenant.ListRoles(name, param.RootPassword)
+	common.SendResponse(c, roles, err)
+}
+
+// @ID getRole
+// @Summary get role
+// @Description get role of a tenant, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param role path string true "role name"
+// @Success 200 object http.OcsAgentResponse{data=bo.ObRole}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles/{role} [GET]
+func getRole(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	role := c.Param(constant.URI_PARAM_ROLE)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	obrole, err := tenant.GetRole(name, role, param.RootPassword)
+	common.SendResponse(c, obrole, err)
+}
+
+// @ID dropRole
+// @Summary drop role
+// @Description drop role, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param role path string true "role name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles/{role} [DELETE]
+func dropRole(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	role := c.Param(constant.URI_PARAM_ROLE)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DropRole(name, role, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyRoleGlobalPrivilege
+// @Summary modify global privilege of a role
+// @Description modify system privilege of a role, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param role path string true "role name"
+// @Param body body param.ModifyUserGlobalPrivilegeParam true "modify global privilege param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles/{role}/global-privilege [PUT]
+func modifyRoleGlobalPrivilege(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	role := c.Param(constant.URI_PARAM_ROLE)
+	var param param.ModifyUserGlobalPrivilegeParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyRoleGlobalPrivilege(name, role, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyRoleRoles
+// @Summary modify granted roles of a role
+// @Description modify granted roles of a role, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param role path string true "role name"
+// @Param body body param.ModifyGrantedRolesParam true "modify granted roles param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles/{role}/roles [PUT]
+func modifyRoleRoles(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	role := c.Param(constant.URI_PARAM_ROLE)
+	var param param.ModifyGrantedRolesParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyGrantedRoles(name, role, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyRoleObjectPrivilege
+// @Summary modify object privilege of a role
+// @Description modify object privilege of a role, only for oracle mode tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param role path string true "role name"
+// @Param body body param.ModifyObjectPrivilegeParam true "modify object privilege param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/roles/{role}/object-privilege [PUT]
+func modifyRoleObjectPrivilege(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	role := c.Param(constant.URI_PARAM_ROLE)
+	var param param.ModifyObjectPrivilegeParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyObjectPrivilege(name, role, &param)
+	common.SendResponse(c, nil, err)
+}
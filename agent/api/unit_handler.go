@@ -128,3 +128,59 @@ func unitConfigGetHandler(c *gin.Context) {
 	unit, err := unit.GetUnitConfig(name)
 	common.SendResponse(c, unit, err)
 }
+
+// @ID unitConfigModify
+// @Summary modify resource unit config
+// @Description modify resource unit config in place
+// @Tags unit
+// @Accept application/json
+// @Produce application/json
+// @Param name path string true "resource unit name"
+// @Param body body param.ModifyResourceUnitConfigParams true "Resource unit config"
+// @Success 200 object http.OcsAgentResponse{data=bo.UnitConfigModifyImpact}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/unit/config/{name} [patch]
+func unitConfigModifyHandler(c *gin.Context) {
+	var param param.ModifyResourceUnitConfigParams
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	name := c.Param(constant.URI_PARAM_NAME)
+	if name == "" {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObResourceUnitConfigNameEmpty))
+		return
+	}
+	impact, err := unit.ModifyUnitConfig(name, &param)
+	common.SendResponse(c, impact, err)
+}
+
+// @ID unitConfigModifyCheck
+// @Summary check resource unit config modification
+// @Description check free resources for modifying resource unit config and report the affected tenants and pools
+// @Tags unit
+// @Accept application/json
+// @Produce application/json
+// @Param name path string true "resource unit name"
+// @Param body body param.ModifyResourceUnitConfigParams true "Resource unit config"
+// @Success 200 object http.OcsAgentResponse{data=bo.UnitConfigModifyImpact}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/unit/config/{name}/check [post]
+func unitConfigModifyCheckHandler(c *gin.Context) {
+	var param param.ModifyResourceUnitConfigParams
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	name := c.Param(constant.URI_PARAM_NAME)
+	if name == "" {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObResourceUnitConfigNameEmpty))
+		return
+	}
+	impact, err := unit.CheckModifyUnitConfig(name, &param)
+	common.SendResponse(c, impact, err)
+}
@@ -56,3 +56,49 @@ func zoneDeleteHandler(c *gin.Context) {
 		common.SendResponse(c, dag, err)
 	}
 }
+
+// @ID EnterZoneMaintenance
+//
+// @Summary enter zone maintenance
+// @Description stop the zone to drain its leaders and stop all observers in the zone
+// @Tags ob
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param zoneName path string true "zone name"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/zone/{zoneName}/maintenance [post]
+func zoneEnterMaintenanceHandler(c *gin.Context) {
+	zoneName := c.Param(constant.URI_PARAM_NAME)
+	if zoneName == "" {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObZoneNameEmpty))
+		return
+	}
+	dag, err := ob.EnterZoneMaintenance(zoneName)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID ExitZoneMaintenance
+//
+// @Summary exit zone maintenance
+// @Description start all observers in the zone and restore leaders to the primary zone
+// @Tags ob
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param zoneName path string true "zone name"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/zone/{zoneName}/maintenance [delete]
+func zoneExitMaintenanceHandler(c *gin.Context) {
+	zoneName := c.Param(constant.URI_PARAM_NAME)
+	if zoneName == "" {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObZoneNameEmpty))
+		return
+	}
+	dag, err := ob.ExitZoneMaintenance(zoneName)
+	common.SendResponse(c, dag, err)
+}
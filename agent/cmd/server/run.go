@@ -47,6 +47,7 @@ func (a *Agent) run() (err error) {
 	}
 
 	a.handleOBMeta()
+	ob.StartWatchers()
 	return nil
 }
 
@@ -34,6 +34,12 @@ const (
 	ARCHIVE_LAG_TARGET_HIGH       = time.Hour * 2
 	ARCHIVE_LAG_TARGET_LOW_FOR_S3 = time.Minute
 
+	ARCHIVE_WATCHER_CONFIG_KEY            = "archive_watcher_config"
+	ARCHIVE_WATCHER_CHECK_INTERVAL        = time.Minute
+	ARCHIVE_WATCHER_LAG_THRESHOLD_DEFAULT = "30m"
+	ARCHIVE_ALARM_RULE_INTERRUPTED        = "archive_log_interrupted"
+	ARCHIVE_ALARM_RULE_LAG                = "archive_log_lag"
+
 	DELETE_POLICY_DEFAULT = "default"
 
 	LOG_MODE_NOARCHIVELOG = "NOARCHIVELOG"
@@ -56,15 +62,20 @@ const (
 	TIME_UNIT_HOUR        = "h"
 	TIME_UNIT_DAY         = "d"
 
-	PREFIX_OSS  = "oss://"
-	PREFIX_COS  = "cos://"
-	PREFIX_S3   = "s3://"
-	PREFIX_FILE = "file://"
+	PREFIX_OSS    = "oss://"
+	PREFIX_COS    = "cos://"
+	PREFIX_S3     = "s3://"
+	PREFIX_AZBLOB = "azblob://"
+	PREFIX_FILE   = "file://"
+
+	PROTOCOL_OSS    = "oss"
+	PROTOCOL_COS    = "cos"
+	PROTOCOL_S3     = "s3"
+	PROTOCOL_AZBLOB = "azblob"
+	PROTOCOL_FILE   = "file"
 
-	PROTOCOL_OSS  = "oss"
-	PROTOCOL_COS  = "cos"
-	PROTOCOL_S3   = "s3"
-	PROTOCOL_FILE = "file"
+	ADDRESSING_MODEL_VIRTUAL = "virtual"
+	ADDRESSING_MODEL_PATH    = "path"
 
 	BACKUP_DIR_CLOG = "clog"
 	BACKUP_DIR_DATA = "data"
@@ -73,10 +84,54 @@ const (
 	BACKUP_MODE_INCREMENTAL = "incremental"
 
 	BACKUP_CANCELED = "canceled"
+
+	BACKUP_SET_TYPE_FULL = "FULL"
+	BACKUP_SET_TYPE_INC  = "INC"
+
+	BACKUP_SET_STATUS_SUCCESS    = "SUCCESS"
+	BACKUP_FILE_STATUS_AVAILABLE = "AVAILABLE"
+	BACKUP_PLUS_ARCHIVELOG_ON    = "ON"
+	BACKUP_PIECE_STATUS_ACTIVE   = "ACTIVE"
+
+	// The directories of the backup set and the archive piece under their destination.
+	BACKUP_SET_DIR_FORMAT   = "backup_set_%d_%s"
+	BACKUP_PIECE_DIR_FORMAT = "piece_d%dr%dp%d"
 )
 
 const (
+	BACKUP_USAGE_TREND_DAYS     = 7
+	BACKUP_USAGE_SOURCE_STORAGE = "storage"
+	BACKUP_USAGE_SOURCE_CATALOG = "catalog"
+
 	RESTORE_UNIT_NUM_DEFAULT = 1
 
+	RESTORE_DRILL_STATUS_RUNNING = "RUNNING"
+	RESTORE_DRILL_STATUS_SUCCESS = "SUCCESS"
+	RESTORE_DRILL_STATUS_FAILED  = "FAILED"
+
+	RESTORE_DRILL_TENANT_PREFIX   = "obshell_drill_"
+	RESTORE_DRILL_REPORTS_DEFAULT = 20
+
+	RESTORE_DRILL_SCHEDULE_CONFIG_KEY       = "restore_drill_schedule"
+	RESTORE_DRILL_SCHEDULE_CHECK_INTERVAL   = time.Minute
+	RESTORE_DRILL_SCHEDULE_INTERVAL_DEFAULT = "7d"
+	RESTORE_DRILL_SCHEDULE_INTERVAL_MIN     = time.Hour
+
+	// The dir in the backup dest of a tenant which lists the backup sets.
+	RESTORE_SOURCE_DIR_BACKUP_SETS = "backup_sets"
+
+	RESTORE_STATUS_SUCCESS = "SUCCESS"
+
+	// Used to estimate the duration of a restore.
+	RESTORE_POINT_HISTORY_SAMPLES    = 5
+	RESTORE_POINT_THROUGHPUT_DEFAULT = 100 << 20 // Bytes per second.
+	RESTORE_POINT_SOURCE_HISTORY     = "history"
+	RESTORE_POINT_SOURCE_DEFAULT     = "default"
+
+	BACKUP_KEY_RANDOM_BYTES = 24
+
+	RECOVER_TABLE_RESULT_SUCCESS = "SUCCESS"
+	RECOVER_TABLE_AUX_POOL_NAME  = "obshell_recover"
+
 	HA_HIGH_THREAD_SCORE_DEFAULT = 10
 )
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	CLOCK_SKEW_WATCHER_CONFIG_KEY      = "clock_skew_watcher_config"
+	CLOCK_SKEW_CHECK_INTERVAL          = 30 * time.Second
+	CLOCK_SKEW_HISTORY_SIZE            = 120 // Keep the samples of the last hour.
+	CLOCK_SKEW_PROBE_TIMES             = 3
+	CLOCK_SKEW_WARN_THRESHOLD_DEFAULT  = "50ms"
+	CLOCK_SKEW_BLOCK_THRESHOLD_DEFAULT = "100ms"
+	CLOCK_SKEW_ALARM_RULE_WARN         = "clock_skew_warning"
+	CLOCK_SKEW_ALARM_RULE_CRITICAL     = "clock_skew_critical"
+)
+
+// level of clock skew
+const (
+	CLOCK_SKEW_LEVEL_OK       = "OK"
+	CLOCK_SKEW_LEVEL_WARN     = "WARN"
+	CLOCK_SKEW_LEVEL_CRITICAL = "CRITICAL"
+	CLOCK_SKEW_LEVEL_UNKNOWN  = "UNKNOWN"
+)
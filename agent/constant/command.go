@@ -30,4 +30,9 @@ const (
 	COMMAND_ULIMIT_NOFILE             string = "ulimit -n"
 	COMMAND_ULIMIT_MAX_USER_PROCESSES string = "ulimit -u"
 	COMMAND_DF                        string = "df -h | grep -v Filesystem"
+
+	COMMAND_ULIMIT_NOFILE_SOFT             string = "ulimit -Sn"
+	COMMAND_ULIMIT_NOFILE_HARD             string = "ulimit -Hn"
+	COMMAND_ULIMIT_MAX_USER_PROCESSES_SOFT string = "ulimit -Su"
+	COMMAND_CLOCK_SYNCHRONIZED             string = "timedatectl show -p NTPSynchronized --value"
 )
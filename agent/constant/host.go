@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+// result of host check item
+const (
+	HOST_CHECK_RESULT_PASS = "PASS"
+	HOST_CHECK_RESULT_WARN = "WARN"
+	HOST_CHECK_RESULT_FAIL = "FAIL"
+)
+
+// name of host check item
+const (
+	HOST_CHECK_ITEM_CPU_CORES         = "cpu_cores"
+	HOST_CHECK_ITEM_MEMORY            = "memory"
+	HOST_CHECK_ITEM_NOFILE            = "ulimit_nofile"
+	HOST_CHECK_ITEM_NPROC             = "ulimit_nproc"
+	HOST_CHECK_ITEM_MAX_MAP_COUNT     = "vm.max_map_count"
+	HOST_CHECK_ITEM_OVERCOMMIT        = "vm.overcommit_memory"
+	HOST_CHECK_ITEM_AIO_MAX_NR        = "fs.aio-max-nr"
+	HOST_CHECK_ITEM_CLOCK_SYNC        = "clock_sync"
+	HOST_CHECK_ITEM_DATA_DIR          = "data_dir"
+	HOST_CHECK_ITEM_REDO_DIR          = "redo_dir"
+	HOST_CHECK_ITEM_DATA_AND_REDO_DIR = "data_dir & redo_dir"
+	HOST_CHECK_ITEM_REACHABLE         = "reachable"
+)
+
+const (
+	PROC_SYS_VM_MAX_MAP_COUNT     = "/proc/sys/vm/max_map_count"
+	PROC_SYS_VM_OVERCOMMIT_MEMORY = "/proc/sys/vm/overcommit_memory"
+	PROC_SYS_FS_AIO_MAX_NR        = "/proc/sys/fs/aio-max-nr"
+)
@@ -16,6 +16,8 @@
 
 package constant
 
+import "time"
+
 const (
 	PARAMETER_ENABLE_REBALANCE               = "enable_rebalance"
 	PARAMETER_GLOBAL_INDEX_AUTO_SPLIT_POLICY = "global_index_auto_split_policy"
@@ -24,6 +26,24 @@ const (
 	VARIABLE_TIME_ZONE            = "time_zone"
 	VARIABLE_OB_TCP_INVITED_NODES = "ob_tcp_invited_nodes"
 	VARIABLE_READ_ONLY            = "read_only"
+
+	PARAMETER_SCOPE_CLUSTER = "CLUSTER"
+	PARAMETER_SCOPE_TENANT  = "TENANT"
+
+	// The categories of the parameter changes recorded by obshell.
+	PARAMETER_CHANGE_CATEGORY_PARAMETER = "PARAMETER"
+	PARAMETER_CHANGE_CATEGORY_VARIABLE  = "VARIABLE"
+
+	// The levels of the observer configs persisted in sqlite.
+	CONFIG_LEVEL_GLOBAL = "GLOBAL"
+	CONFIG_LEVEL_ZONE   = "ZONE"
+	CONFIG_LEVEL_SERVER = "SERVER"
+
+	// The directions to reconcile the drift between the persisted configs and the live parameters.
+	CONFIG_DRIFT_PERSISTED_TO_LIVE = "PERSISTED_TO_LIVE" // Set the live parameters to the persisted values.
+	CONFIG_DRIFT_LIVE_TO_PERSISTED = "LIVE_TO_PERSISTED" // Overwrite the persisted configs with the live values.
+
+	CONFIG_DRIFT_CHECK_INTERVAL = 10 * time.Minute
 )
 
 var (
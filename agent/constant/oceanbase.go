@@ -46,6 +46,41 @@ const (
 	OB_MYSQL_PRIVILEGE_GRANT_OPTION   = "GRANT_OPTION"
 )
 
+const (
+	OB_ORACLE_OBJECT_PRIVILEGE_ALTER      = "ALTER"
+	OB_ORACLE_OBJECT_PRIVILEGE_DELETE     = "DELETE"
+	OB_ORACLE_OBJECT_PRIVILEGE_EXECUTE    = "EXECUTE"
+	OB_ORACLE_OBJECT_PRIVILEGE_INDEX      = "INDEX"
+	OB_ORACLE_OBJECT_PRIVILEGE_INSERT     = "INSERT"
+	OB_ORACLE_OBJECT_PRIVILEGE_REFERENCES = "REFERENCES"
+	OB_ORACLE_OBJECT_PRIVILEGE_SELECT     = "SELECT"
+	OB_ORACLE_OBJECT_PRIVILEGE_UPDATE     = "UPDATE"
+)
+
+var OB_ORACLE_OBJECT_PRIVILEGES = []string{OB_ORACLE_OBJECT_PRIVILEGE_ALTER, OB_ORACLE_OBJECT_PRIVILEGE_DELETE, OB_ORACLE_OBJECT_PRIVILEGE_EXECUTE, OB_ORACLE_OBJECT_PRIVILEGE_INDEX, OB_ORACLE_OBJECT_PRIVILEGE_INSERT, OB_ORACLE_OBJECT_PRIVILEGE_REFERENCES, OB_ORACLE_OBJECT_PRIVILEGE_SELECT, OB_ORACLE_OBJECT_PRIVILEGE_UPDATE}
+
+// Spaces in the system privileges are replaced by '_' like the mysql privileges.
+var OB_ORACLE_SYSTEM_PRIVILEGES = []string{
+	"CREATE_SESSION", "ALTER_SESSION", "ALTER_SYSTEM",
+	"CREATE_TABLE", "CREATE_ANY_TABLE", "ALTER_ANY_TABLE", "DROP_ANY_TABLE", "COMMENT_ANY_TABLE", "LOCK_ANY_TABLE",
+	"SELECT_ANY_TABLE", "INSERT_ANY_TABLE", "UPDATE_ANY_TABLE", "DELETE_ANY_TABLE", "FLASHBACK_ANY_TABLE",
+	"CREATE_ANY_INDEX", "ALTER_ANY_INDEX", "DROP_ANY_INDEX",
+	"CREATE_VIEW", "CREATE_ANY_VIEW", "DROP_ANY_VIEW",
+	"CREATE_PROCEDURE", "CREATE_ANY_PROCEDURE", "ALTER_ANY_PROCEDURE", "DROP_ANY_PROCEDURE", "EXECUTE_ANY_PROCEDURE",
+	"CREATE_SYNONYM", "CREATE_ANY_SYNONYM", "DROP_ANY_SYNONYM", "CREATE_PUBLIC_SYNONYM", "DROP_PUBLIC_SYNONYM",
+	"CREATE_SEQUENCE", "CREATE_ANY_SEQUENCE", "ALTER_ANY_SEQUENCE", "DROP_ANY_SEQUENCE", "SELECT_ANY_SEQUENCE",
+	"CREATE_TRIGGER", "CREATE_ANY_TRIGGER", "ALTER_ANY_TRIGGER", "DROP_ANY_TRIGGER",
+	"CREATE_TYPE", "CREATE_ANY_TYPE", "ALTER_ANY_TYPE", "DROP_ANY_TYPE", "EXECUTE_ANY_TYPE",
+	"CREATE_USER", "ALTER_USER", "DROP_USER",
+	"CREATE_ROLE", "ALTER_ANY_ROLE", "DROP_ANY_ROLE", "GRANT_ANY_ROLE",
+	"GRANT_ANY_PRIVILEGE", "GRANT_ANY_OBJECT_PRIVILEGE", "SELECT_ANY_DICTIONARY",
+	"CREATE_DATABASE_LINK", "CREATE_PUBLIC_DATABASE_LINK", "DROP_PUBLIC_DATABASE_LINK",
+	"PURGE_DBA_RECYCLEBIN", "SYSDBA", "SYSOPER",
+}
+
+// Roles predefined by oracle mode tenant.
+var OB_ORACLE_INNER_ROLES = []string{"CONNECT", "RESOURCE", "DBA", "PUBLIC", "STANDBY_REPLICATION"}
+
 const (
 	OB_CONNECTION_TYPE_DIRECT = "DIRECT"
 	OB_CONNECTION_TYPE_PROXY  = "PROXY"
@@ -117,6 +152,7 @@ const (
 	OB_ROOT_PASSWORD = "OB_ROOT_PASSWORD"
 
 	OB_VERSION_4_3_5_2 = "4.3.5.2"
+	OB_VERSION_4_2_1_0 = "4.2.1.0" // the minimum version supports table level restore
 )
 
 const (
@@ -129,3 +165,16 @@ const (
 
 	OBSERVER_STATUS_DELETING = "DELETING"
 )
+
+const (
+	UPGRADE_GATE_STATUS_WAITING  = "WAITING"
+	UPGRADE_GATE_STATUS_APPROVED = "APPROVED"
+	UPGRADE_GATE_STATUS_ABORTED  = "ABORTED"
+
+	UPGRADE_GATE_TIMEOUT_ACTION_APPROVE = "APPROVE"
+	UPGRADE_GATE_TIMEOUT_ACTION_ABORT   = "ABORT"
+
+	// The gate without approval timeout waits until the task times out.
+	UPGRADE_GATE_TASK_TIMEOUT   = 30 * 24 * time.Hour
+	UPGRADE_GATE_CHECK_INTERVAL = 5 * time.Second
+)
@@ -32,6 +32,10 @@ const (
 	TENANT_SYS    = "sys"
 	TENANT_SYS_ID = 1
 
+	// root user of the tenant in each mode
+	MYSQL_TENANT_ROOT_USER  = "root"
+	ORACLE_TENANT_ROOT_USER = "SYS"
+
 	REPLICA_TYPE_FULL     = "FULL"
 	REPLICA_TYPE_READONLY = "READONLY"
 
@@ -51,4 +55,5 @@ const (
 	ALTER_RESOURCE_TENANT_UNIT_NUM = "ALTER_RESOURCE_TENANT_UNIT_NUM"
 	ALTER_TENANT_LOCALITY          = "ALTER_TENANT_LOCALITY"
 	ALTER_TENANT_PRIMARY_ZONE      = "ALTER_TENANT_PRIMARY_ZONE"
+	MIGRATE_UNIT                   = "MIGRATE_UNIT"
 )
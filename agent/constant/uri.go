@@ -41,12 +41,13 @@ const (
 	URI_PROMETHEUS       = "/prometheus"
 	URI_ALERTMANAGER     = "/alertmanager"
 
-	URI_INFO      = "/info"
-	URI_TIME      = "/time"
-	URI_GIT_INFO  = "/git-info"
-	URI_HOST_INFO = "/host-info"
-	URI_STATUS    = "/status"
-	URI_SECRET    = "secret"
+	URI_INFO       = "/info"
+	URI_TIME       = "/time"
+	URI_GIT_INFO   = "/git-info"
+	URI_HOST_INFO  = "/host-info"
+	URI_HOST_CHECK = "/host-check"
+	URI_STATUS     = "/status"
+	URI_SECRET     = "secret"
 
 	URI_JOIN     = "/join"
 	URI_REMOVE   = "/remove"
@@ -77,19 +78,30 @@ const (
 	URI_DESTROY     = "/destroy"
 	URI_SCALE_OUT   = "/scale_out"
 	URI_SCALE_IN    = "/scale_in"
+	URI_REPLACE     = "/replace_server"
+	URI_MAINTENANCE = "/maintenance"
 	URI_AGENTS      = "/agents"
 	URI_CHARSETS    = "/charsets"
 	URI_STATISTICS  = "/statistics"
 
 	// Used for upgrade
-	URI_UPGRADE = "/upgrade"
-	URI_CHECK   = "/check"
-	URI_ROUTE   = "/route"
-	URI_PACKAGE = "/package"
-	URI_PARAMS  = "/params"
-	URI_BACKUP  = "/backup"
-	URI_RESTORE = "/restore"
-	URI_WINDOWS = "/windows"
+	URI_UPGRADE  = "/upgrade"
+	URI_CHECK    = "/check"
+	URI_ROUTE    = "/route"
+	URI_PACKAGE  = "/package"
+	URI_PARAMS   = "/params"
+	URI_BACKUP   = "/backup"
+	URI_RESTORE  = "/restore"
+	URI_WINDOWS  = "/windows"
+	URI_DRILL    = "/drill"
+	URI_REPORTS  = "/reports"
+	URI_SCHEDULE = "/schedule"
+	URI_SOURCES  = "/sources"
+	URI_POINT    = "/point"
+	URI_GATES    = "/gates"
+	URI_APPROVE  = "/approve"
+	URI_ABORT    = "/abort"
+	URI_ROLLBACK = "/rollback"
 
 	// Used for tenant
 	URI_TENANTS          = "/tenants"
@@ -103,6 +115,11 @@ const (
 	URI_VARIABLES        = "/variables"
 	URI_VARIABLE         = "/variable"
 	URI_PARAMETER        = "/parameter"
+	URI_HISTORY          = "/history"
+	URI_SNAPSHOTS        = "/snapshots"
+	URI_DIFF             = "/diff"
+	URI_DRIFT            = "/drift"
+	URI_RECONCILE        = "/reconcile"
 	URI_OVERVIEW         = "/overview"
 	URI_TENANT           = "/tenant"
 	URI_USER             = "/user"
@@ -117,6 +134,25 @@ const (
 	URI_PERSIST          = "/persist"
 	URI_STATS            = "/stats"
 	URI_PRECHECK         = "/precheck"
+	URI_ROLES            = "/roles"
+	URI_OBJECT_PRIVILEGE = "/object-privilege"
+	URI_TABLES           = "/tables"
+	URI_DDL              = "/ddl"
+	URI_INDEXES          = "/indexes"
+	URI_PARTITIONS       = "/partitions"
+	URI_TABLETS          = "/tablets"
+	URI_BALANCE          = "/balance"
+	URI_TRANSFER         = "/transfer"
+	URI_LEADER           = "/leader"
+
+	// Used for tenant resource manager
+	URI_RESOURCE_MANAGER = "/resource-manager"
+	URI_PLANS            = "/plans"
+	URI_CONSUMER_GROUPS  = "/consumer-groups"
+	URI_DIRECTIVES       = "/directives"
+	URI_MAPPINGS         = "/mappings"
+	URI_ACTIVE_PLAN      = "/active-plan"
+	URI_USAGE            = "/usage"
 
 	URI_UNIT_CONFIG_LIMIT = "/unit-config-limit"
 
@@ -130,9 +166,28 @@ const (
 	URI_PATH_PARAM_USER     = "/:" + URI_PARAM_USER
 	URI_PARAM_DATABASE      = "database"
 	URI_PATH_PARAM_DATABASE = "/:" + URI_PARAM_DATABASE
+	URI_PARAM_ROLE          = "role"
+	URI_PATH_PARAM_ROLE     = "/:" + URI_PARAM_ROLE
+	URI_PARAM_TABLE         = "table"
+	URI_PATH_PARAM_TABLE    = "/:" + URI_PARAM_TABLE
+	URI_PARAM_PLAN          = "plan"
+	URI_PATH_PARAM_PLAN     = "/:" + URI_PARAM_PLAN
+	URI_PARAM_GROUP         = "group"
+	URI_PATH_PARAM_GROUP    = "/:" + URI_PARAM_GROUP
 
 	// Used for backup
-	URI_ARCHIVE = "/log"
+	URI_ARCHIVE   = "/log"
+	URI_CATALOG   = "/catalog"
+	URI_VALIDATE  = "/validate"
+	URI_CLEAN     = "/clean"
+	URI_PREVIEW   = "/preview"
+	URI_LAG       = "/lag"
+	URI_WATCHER   = "/watcher"
+	URI_REMEDIATE = "/remediate"
+	URI_KEYS      = "/keys"
+
+	URI_ARCHIVE_LAG = "/archive-lag"
+	URI_CLOCK_SKEW  = "/clock-skew"
 
 	URI_POOL_API_PREFIX   = URI_API_V1 + URI_POOL_GROUP
 	URI_UNIT_GROUP_PREFIX = URI_API_V1 + URI_UNIT_GROUP
@@ -26,6 +26,8 @@ import (
 const (
 	EXECUTE_AGENTS           = "execute_agents"
 	FAILURE_EXIT_MAINTENANCE = "failure_exit_maintenance"
+	// TAKEN_OVER_BY records the generic id of the dag which takes over a failed dag.
+	TAKEN_OVER_BY = "taken_over_by"
 )
 
 type TaskContext struct {
@@ -73,6 +73,8 @@ var (
 	ErrCommonUnexpected                 = NewErrorCode("Common.Unexpected", unexpected, "err.common.unexpected")                              // "unexpected error: %s"
 	ErrCommonUnauthorized               = NewErrorCode("Common.Unauthorized", unauthorized, "err.common.unauthorized", 10008)                 // "unauthorized"
 	ErrCommonInvalidTimeDuration        = NewErrorCode("Common.InvalidTimeDuration", illegalArgument, "err.common.invalid.time.duration")     // "time duration '%s' is invalid: %s"
+	ErrCommonInvalidTime                = NewErrorCode("Common.InvalidTime", illegalArgument, "err.common.invalid.time")                      // "time '%s' is invalid: %s"
+	ErrCommonInvalidTimezone            = NewErrorCode("Common.InvalidTimezone", illegalArgument, "err.common.invalid.timezone")              // "timezone '%s' is invalid: %s"
 	ErrJsonMarshal                      = NewErrorCode("Common.JsonMarshal", unexpected, "err.common.json.marshal")                           // "json marshal failed: %s"
 	ErrJsonUnmarshal                    = NewErrorCode("Common.JsonUnmarshal", unexpected, "err.common.json.unmarshal")                       // "json unmarshal failed: %s"
 	// Log
@@ -136,9 +138,10 @@ var (
 	ErrObRecyclebinTenantNotExist = NewErrorCode("OB.Recyclebin.Tenant.NotExist", badRequest, "err.ob.recyclebin.tenant.not.exist")
 
 	// OB.Resource.UnitConfig
-	ErrObResourceUnitConfigNameEmpty = NewErrorCode("OB.Resource.UnitConfig.Name.Empty", illegalArgument, "err.ob.resource.unit.config.name.empty")
-	ErrObResourceUnitConfigNotExist  = NewErrorCode("OB.Resource.UnitConfig.NotExist", illegalArgument, "err.ob.resource.unit.config.not.exist")
-	ErrObResourceUnitConfigExisted   = NewErrorCode("OB.Resource.UnitConfig.Existed", illegalArgument, "err.ob.resource.unit.config.existed")
+	ErrObResourceUnitConfigNameEmpty         = NewErrorCode("OB.Resource.UnitConfig.Name.Empty", illegalArgument, "err.ob.resource.unit.config.name.empty")
+	ErrObResourceUnitConfigNotExist          = NewErrorCode("OB.Resource.UnitConfig.NotExist", illegalArgument, "err.ob.resource.unit.config.not.exist")
+	ErrObResourceUnitConfigExisted           = NewErrorCode("OB.Resource.UnitConfig.Existed", illegalArgument, "err.ob.resource.unit.config.existed")
+	ErrObResourceUnitConfigResourceNotEnough = NewErrorCode("OB.Resource.UnitConfig.ResourceNotEnough", badRequest, "err.ob.resource.unit.config.resource.not.enough") // "server '%s' has no enough %s to modify unit config '%s'"
 
 	// OB.Resource.Pool
 	ErrObResourcePoolNameEmpty = NewErrorCode("OB.Resource.Pool.Name.Empty", illegalArgument, "err.ob.resource.pool.name.empty")
@@ -166,13 +169,33 @@ var (
 	ErrObBackupArchiveLogStatusInvalid      = NewErrorCode("OB.Backup.ArchiveLogStatus.Invalid", illegalArgument, "err.ob.backup.archive.log.status.invalid")
 	ErrObBackupArchiveDestEmpty             = NewErrorCode("OB.Backup.ArchiveDestEmpty", illegalArgument, "err.ob.backup.archive.dest.empty")
 	ErrObBackupDataDestEmpty                = NewErrorCode("OB.Backup.DataDestEmpty", illegalArgument, "err.ob.backup.data.dest.empty")
+	ErrObBackupSetNotExist                  = NewErrorCode("OB.Backup.BackupSet.NotExist", notFound, "err.ob.backup.backup.set.not.exist")
+	ErrObBackupValidateFailed               = NewErrorCode("OB.Backup.Validate.Failed", unexpected, "err.ob.backup.validate.failed")
+	ErrObBackupCleanTargetEmpty             = NewErrorCode("OB.Backup.Clean.TargetEmpty", illegalArgument, "err.ob.backup.clean.target.empty")
+	ErrObBackupSetDepended                  = NewErrorCode("OB.Backup.BackupSet.Depended", illegalArgument, "err.ob.backup.backup.set.depended")
+	ErrObBackupCleanNoRestorableChain       = NewErrorCode("OB.Backup.Clean.NoRestorableChain", illegalArgument, "err.ob.backup.clean.no.restorable.chain")
+	ErrObBackupDeleteJobRunning             = NewErrorCode("OB.Backup.DeleteJob.Running", badRequest, "err.ob.backup.delete.job.running")
+	ErrObBackupUsageCapacityInvalid         = NewErrorCode("OB.Backup.Usage.CapacityInvalid", illegalArgument, "err.ob.backup.usage.capacity.invalid")
+	ErrObBackupKeyUnavailable               = NewErrorCode("OB.Backup.Key.Unavailable", unexpected, "err.ob.backup.key.unavailable")
+	ErrObBackupKeyPasswordInvalid           = NewErrorCode("OB.Backup.Key.PasswordInvalid", illegalArgument, "err.ob.backup.key.password.invalid")
+	ErrObArchiveWatcherThresholdInvalid     = NewErrorCode("OB.Backup.ArchiveWatcher.LagThresholdInvalid", illegalArgument, "err.ob.backup.archive.watcher.lag.threshold.invalid")
 
 	// Ob.Restore
-	ErrObStorageURIInvalid         = NewErrorCode("OB.Storage.URI.Invalid", illegalArgument, "err.ob.storage.uri.invalid")
-	ErrObRestoreNotRecovering      = NewErrorCode("OB.Restore.NotRecovering", illegalArgument, "err.ob.restore.not.recovering")
-	ErrObRestoreTimeNotValid       = NewErrorCode("OB.Restore.TimeNotValid", illegalArgument, "err.ob.restore.time.not.valid")
-	ErrObRestoreTaskNotExist       = NewErrorCode("OB.Restore.Task.NotExist", illegalArgument, "err.ob.restore.task.not.exist")
-	ErrObRestoreTaskAlreadySucceed = NewErrorCode("OB.Restore.Task.AlreadySucceed", illegalArgument, "err.ob.restore.task.already.succeed")
+	ErrObStorageURIInvalid               = NewErrorCode("OB.Storage.URI.Invalid", illegalArgument, "err.ob.storage.uri.invalid")
+	ErrObRestoreNotRecovering            = NewErrorCode("OB.Restore.NotRecovering", illegalArgument, "err.ob.restore.not.recovering")
+	ErrObRestoreTimeNotValid             = NewErrorCode("OB.Restore.TimeNotValid", illegalArgument, "err.ob.restore.time.not.valid")
+	ErrObRestoreTaskNotExist             = NewErrorCode("OB.Restore.Task.NotExist", illegalArgument, "err.ob.restore.task.not.exist")
+	ErrObRestoreTaskAlreadySucceed       = NewErrorCode("OB.Restore.Task.AlreadySucceed", illegalArgument, "err.ob.restore.task.already.succeed")
+	ErrObRestoreDrillVerifyFailed        = NewErrorCode("OB.Restore.Drill.VerifyFailed", unexpected, "err.ob.restore.drill.verify.failed")
+	ErrObRestoreDrillReportNotExist      = NewErrorCode("OB.Restore.Drill.ReportNotExist", notFound, "err.ob.restore.drill.report.not.exist")
+	ErrObRestoreTableTargetEmpty         = NewErrorCode("OB.Restore.Table.TargetEmpty", illegalArgument, "err.ob.restore.table.target.empty")
+	ErrObRestoreTableVersionNotSupported = NewErrorCode("OB.Restore.Table.VersionNotSupported", badRequest, "err.ob.restore.table.version.not.supported")
+	ErrObRestoreTableJobRunning          = NewErrorCode("OB.Restore.Table.JobRunning", badRequest, "err.ob.restore.table.job.running")
+	ErrObRestoreTableExisted             = NewErrorCode("OB.Restore.Table.Existed", illegalArgument, "err.ob.restore.table.existed")
+	ErrObRestoreTableFailed              = NewErrorCode("OB.Restore.Table.Failed", unexpected, "err.ob.restore.table.failed")
+	ErrObRestoreSourceListFailed         = NewErrorCode("OB.Restore.Source.ListFailed", unexpected, "err.ob.restore.source.list.failed")
+	ErrObRestoreSourceNotFound           = NewErrorCode("OB.Restore.Source.NotFound", notFound, "err.ob.restore.source.not.found")
+	ErrObRestoreWindowsEmpty             = NewErrorCode("OB.Restore.WindowsEmpty", notFound, "err.ob.restore.windows.empty")
 
 	// OB.Cluster
 	ErrObClusterUnderMaintenance                 = NewErrorCode("OB.Cluster.UnderMaintenance", known, "err.ob.cluster.under.maintenance")
@@ -194,6 +217,8 @@ var (
 	ErrObClusterForceStopRequired                = NewErrorCode("OB.Cluster.ForceStopRequired", illegalArgument, "err.ob.cluster.force.stop.required")
 	ErrObClusterForceStopOrTerminateRequired     = NewErrorCode("OB.Cluster.ForceStopOrTerminateRequired", illegalArgument, "err.ob.cluster.force.stop.or.terminate.required")
 	ErrObClusterPasswordIncorrect                = NewErrorCode("OB.Cluster.Password.Incorrect", illegalArgument, "err.ob.cluster.password.incorrect") // "password incorrect"
+	ErrObClusterClockSkewTooHigh                 = NewErrorCode("OB.Cluster.ClockSkew.TooHigh", known, "err.ob.cluster.clock.skew.too.high")
+	ErrObClusterClockSkewThresholdInvalid        = NewErrorCode("OB.Cluster.ClockSkew.ThresholdInvalid", illegalArgument, "err.ob.cluster.clock.skew.threshold.invalid")
 	// OB.Server
 	ErrObServerDeleteSelf         = NewErrorCode("OB.Server.DeleteSelf", illegalArgument, "err.ob.server.delete.self")
 	ErrObServerProcessCheckFailed = NewErrorCode("OB.Server.Process.CheckFailed", unexpected, "err.ob.server.process.check.failed")      // "check observer process exist: %s."
@@ -205,8 +230,13 @@ var (
 	ErrObServerStoppedInMultiZone = NewErrorCode("OB.Server.StoppedInMultiZone", illegalArgument, "err.ob.server.stopped.in.multi.zone") // "cannot stop server or stop zone in multiple zones"
 
 	// OB.Parameter
-	ErrObParameterScopeInvalid  = NewErrorCode("OB.Parameter.Scope.Invalid", illegalArgument, "err.ob.parameter.scope.invalid")
-	ErrObParameterRsListInvalid = NewErrorCode("OB.Parameter.RsList.Invalid", illegalArgument, "err.ob.parameter.rs.list.invalid")
+	ErrObParameterScopeInvalid          = NewErrorCode("OB.Parameter.Scope.Invalid", illegalArgument, "err.ob.parameter.scope.invalid")
+	ErrObParameterRsListInvalid         = NewErrorCode("OB.Parameter.RsList.Invalid", illegalArgument, "err.ob.parameter.rs.list.invalid")
+	ErrObParameterChangeNotExist        = NewErrorCode("OB.Parameter.Change.NotExist", notFound, "err.ob.parameter.change.not.exist")                       // "parameter change %d is not exist"
+	ErrObParameterChangeOldValueUnknown = NewErrorCode("OB.Parameter.Change.OldValueUnknown", illegalArgument, "err.ob.parameter.change.old.value.unknown") // "the old value of parameter change %d is unknown, it could not be rolled back"
+	ErrObParameterChangeOverridden      = NewErrorCode("OB.Parameter.Change.Overridden", illegalArgument, "err.ob.parameter.change.overridden")             // "'%s' has been changed to '%s' after parameter change %d, it could not be rolled back"
+	ErrObParameterSnapshotNotExist      = NewErrorCode("OB.Parameter.Snapshot.NotExist", notFound, "err.ob.parameter.snapshot.not.exist")                   // "parameter snapshot '%s' is not exist"
+	ErrObParameterSnapshotAlreadyExists = NewErrorCode("OB.Parameter.Snapshot.AlreadyExists", illegalArgument, "err.ob.parameter.snapshot.already.exists")  // "parameter snapshot '%s' already exists"
 
 	// OB.Zone
 	ErrObZoneNotExist   = NewErrorCode("OB.Zone.NotExist", badRequest, "err.ob.zone.not.exist")          // "zone '%s' is not exist"
@@ -214,6 +244,10 @@ var (
 	ErrObZoneDeleteSelf = NewErrorCode("OB.Zone.DeleteSelf", illegalArgument, "err.ob.zone.delete.self") // "The current agent is in '%s', please initiate the request through another agent."
 	ErrObZoneNameEmpty  = NewErrorCode("OB.Zone.Name.Empty", illegalArgument, "err.ob.zone.name.empty")
 
+	ErrObZoneMaintenanceSelf         = NewErrorCode("OB.Zone.Maintenance.Self", illegalArgument, "err.ob.zone.maintenance.self")                  // "The current agent is in '%s', please initiate the zone maintenance through another agent"
+	ErrObZoneMaintenanceMajorityLost = NewErrorCode("OB.Zone.Maintenance.MajorityLost", illegalArgument, "err.ob.zone.maintenance.majority.lost") // "tenant '%s' will lose the majority of paxos replicas without zone '%s'"
+	ErrObZoneNotActive               = NewErrorCode("OB.Zone.NotActive", illegalArgument, "err.ob.zone.not.active")                               // "zone '%s' is not active"
+
 	// OB.Package
 	ErrObPackageNameNotSupport = NewErrorCode("OB.Package.Name.NotSupport", illegalArgument, "err.ob.package.name.not.support")
 	ErrObPackageMissingFile    = NewErrorCode("OB.Package.MissingFile", unexpected, "err.ob.package.missing.file")
@@ -230,6 +264,14 @@ var (
 	ErrObUpgradeUnableToRollingUpgrade = NewErrorCode("OB.Upgrade.UnableToRollingUpgrade", illegalArgument, "err.ob.upgrade.unable.to.rolling.upgrade")
 	ErrObUpgradeToDeprecatedVersion    = NewErrorCode("OB.Upgrade.ToDeprecatedVersion", illegalArgument, "err.ob.upgrade.to.deprecated.version")
 	ErrObUpgradePathNotExist           = NewErrorCode("OB.Upgrade.Path.NotExist", illegalArgument, "err.ob.upgrade.path.not.exist")
+	ErrObUpgradeApprovalNotSupported   = NewErrorCode("OB.Upgrade.Approval.NotSupported", illegalArgument, "err.ob.upgrade.approval.not.supported")
+	ErrObUpgradeGateNotWaiting         = NewErrorCode("OB.Upgrade.Gate.NotWaiting", illegalArgument, "err.ob.upgrade.gate.not.waiting")
+	ErrObUpgradeAborted                = NewErrorCode("OB.Upgrade.Aborted", unexpected, "err.ob.upgrade.aborted")
+	ErrObUpgradeRollbackNotRolling     = NewErrorCode("OB.Upgrade.Rollback.NotRolling", illegalArgument, "err.ob.upgrade.rollback.not.rolling")
+	ErrObUpgradeRollbackFinalized      = NewErrorCode("OB.Upgrade.Rollback.Finalized", illegalArgument, "err.ob.upgrade.rollback.finalized")
+	ErrObUpgradeRollbackNothing        = NewErrorCode("OB.Upgrade.Rollback.Nothing", illegalArgument, "err.ob.upgrade.rollback.nothing")
+	ErrObUpgradeRollbackBackupNotFound = NewErrorCode("OB.Upgrade.Rollback.BackupNotFound", unexpected, "err.ob.upgrade.rollback.backup.not.found")
+	ErrObUpgradeRollbackVersionWrong   = NewErrorCode("OB.Upgrade.Rollback.VersionWrong", unexpected, "err.ob.upgrade.rollback.version.wrong")
 
 	// Agent
 	ErrAgentCoordinatorIsFaulty         = NewErrorCode("Agent.Coordinator.IsFaulty", unexpected, "err.agent.coordinator.is.faulty")
@@ -256,6 +298,7 @@ var (
 	ErrAgentUnavailable                 = NewErrorCode("Agent.Unavailable", unexpected, "err.agent.unavailable")
 	ErrAgentOBVersionNotSupported       = NewErrorCode("Agent.OBVersionNotSupported", badRequest, "err.agent.ob.version.not.supported")
 	ErrAgentNoActiveServer              = NewErrorCode("Agent.NoActiveServer", unexpected, "err.agent.no.active.server")
+	ErrAgentHostCheckProfileNotExist    = NewErrorCode("Agent.HostCheck.ProfileNotExist", illegalArgument, "err.agent.host.check.profile.not.exist")
 
 	// Agent.Upgrade
 	ErrAgentUpgradeToLowerVersion = NewErrorCode("Agent.Upgrade.ToLowerVersion", illegalArgument, "err.agent.upgrade.to.lower.version")
@@ -277,9 +320,27 @@ var (
 	// Ob.Database
 	ErrObDatabaseNotExist = NewErrorCode("OB.Database.NotExist", notFound, "err.ob.database.not.exist")
 
+	// Ob.Table
+	ErrObTableNotExist = NewErrorCode("OB.Table.NotExist", notFound, "err.ob.table.not.exist")
+
+	// Ob.Balance
+	ErrObBalanceLsNotExist           = NewErrorCode("OB.Balance.LsNotExist", notFound, "err.ob.balance.ls.not.exist")
+	ErrObBalancePartitionNotExist    = NewErrorCode("OB.Balance.PartitionNotExist", notFound, "err.ob.balance.partition.not.exist")
+	ErrObBalanceLeaderSwitchTimeout  = NewErrorCode("OB.Balance.LeaderSwitchTimeout", unexpected, "err.ob.balance.leader.switch.timeout")
+	ErrObBalanceZoneNotInPrimaryZone = NewErrorCode("OB.Balance.ZoneNotInPrimaryZone", illegalArgument, "err.ob.balance.zone.not.in.primary.zone")
+
+	// Ob.ResourceManager
+	ErrObResourcePlanNotExist  = NewErrorCode("OB.ResourcePlan.NotExist", notFound, "err.ob.resource.plan.not.exist")
+	ErrObConsumerGroupNotExist = NewErrorCode("OB.ConsumerGroup.NotExist", notFound, "err.ob.consumer.group.not.exist")
+	ErrObPlanDirectiveNotExist = NewErrorCode("OB.PlanDirective.NotExist", notFound, "err.ob.plan.directive.not.exist")
+
 	// Ob.User
 	ErrObUserPrivilegeNotSupported = NewErrorCode("OB.User.Privilege.NotSupported", illegalArgument, "err.ob.user.privilege.not.supported")
 	ErrObUserNameEmpty             = NewErrorCode("OB.User.Name.Empty", illegalArgument, "err.ob.user.name.empty")
+	ErrObUserNotExist              = NewErrorCode("OB.User.NotExist", notFound, "err.ob.user.not.exist")
+
+	// Ob.Role
+	ErrObRoleNotExist = NewErrorCode("OB.Role.NotExist", notFound, "err.ob.role.not.exist")
 
 	// Request
 	ErrRequestFileMissing                        = NewErrorCode("Request.File.Missing", unexpected, "err.request.file.missing")
@@ -367,6 +428,7 @@ var (
 	ErrTaskDagOperatorPassNotAllowed       = NewErrorCode("Task.Dag.Operator.PassNotAllowed", illegalArgument, "err.task.dag.operator.pass.not.allowed")
 	ErrTaskDagOperatorRetryNotFailedDag    = NewErrorCode("Task.Dag.Operator.RetryNotFailedDag", illegalArgument, "err.task.dag.operator.retry.not.failed.dag")
 	ErrTaskDagOperatorRetryNotAllowed      = NewErrorCode("Task.Dag.Operator.RetryNotAllowed", illegalArgument, "err.task.dag.operator.retry.not.allowed")
+	ErrTaskDagTakenOver                    = NewErrorCode("Task.Dag.TakenOver", illegalArgument, "err.task.dag.taken.over")
 	ErrTaskNodeOperatorPassNotFailedDag    = NewErrorCode("Task.Node.Operator.PassNotFailedDag", illegalArgument, "err.task.node.operator.pass.not.failed.dag")
 	ErrTaskNodeOperatorPassNotFailedNode   = NewErrorCode("Task.Node.Operator.PassNotFailedNode", illegalArgument, "err.task.node.operator.pass.not.failed.node")
 	ErrTaskNodeOperatorPassNotAllowed      = NewErrorCode("Task.Node.Operator.PassNotAllowed", illegalArgument, "err.task.node.operator.pass.not.allowed")
@@ -391,6 +453,8 @@ var (
 	ErrCliUpgradeNoValidTargetBuildVersionFound = NewErrorCode("Cli.Upgrade.NoValidTargetBuildVersionFound", unexpected, "err.cli.upgrade.no.valid.target.build.version.found") // "no valid target build version found by '%s'"
 	ErrCliStartRemoteAgentFailed                = NewErrorCode("Cli.StartRemoteAgentFailed", unexpected, "err.cli.start.remote.agent.failed")                                   // "failed to start remote agent"
 	ErrCliUnixSocketRequestFailed               = NewErrorCode("Cli.UnixSocket.RequestFailed", unexpected, "err.cli.unix.socket.request.failed")                                // "request unix-socket [%s]%s failed: %v"
+	ErrCliApplyConflict                         = NewErrorCode("Cli.Apply.Conflict", illegalArgument, "err.cli.apply.conflict")                                                 // "the desired state conflicts with the cluster: %s"
+	ErrCliHostCheckFailed                       = NewErrorCode("Cli.HostCheck.Failed", known, "err.cli.host.check.failed")                                                      // "host check failed on %s"
 	ErrEmpty                                    = NewErrorCode("Empty", unexpected, "err.empty")                                                                                // this error code won't be display
 
 	// 启动相关
@@ -85,3 +85,18 @@ func filterAlert(alert *alert.Alert, filter *alert.AlertFilter) bool {
 	}
 	return matched
 }
+
+func PostAlerts(ctx context.Context, alerts ammodels.PostableAlerts) error {
+	client, err := getAlertmanagerClientFromConfig()
+	if err != nil {
+		return errors.WrapRetain(errors.ErrAlarmClientFailed, err)
+	}
+
+	resp, err := client.R().SetContext(ctx).SetHeader("content-type", "application/json").SetBody(alerts).Post(alarmconstant.AlertUrl)
+	if err != nil {
+		return errors.WrapRetain(errors.ErrAlarmQueryFailed, err)
+	} else if resp.StatusCode() != http.StatusOK {
+		return errors.Occur(errors.ErrAlarmUnexpectedStatus, resp.StatusCode())
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/global"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/param"
+)
+
+const unknownFact = "unknown"
+
+var resultSeverity = map[string]int{
+	constant.HOST_CHECK_RESULT_PASS: 0,
+	constant.HOST_CHECK_RESULT_WARN: 1,
+	constant.HOST_CHECK_RESULT_FAIL: 2,
+}
+
+// Check evaluates the local host against the requirement profile,
+// the result of the report is the worst result of all the items.
+func Check(p *param.HostCheckParam) (*bo.HostCheckReport, error) {
+	profile, err := getRequirementProfile(p.Profile)
+	if err != nil {
+		return nil, err
+	}
+	dataDir := p.DataDir
+	if dataDir == "" {
+		dataDir = filepath.Join(global.HomePath, constant.OB_DIR_STORE)
+	}
+	redoDir := p.RedoDir
+	if redoDir == "" {
+		redoDir = filepath.Join(dataDir, constant.OB_DIR_CLOG)
+	}
+
+	items := []bo.HostCheckItem{
+		checkCpuCores(profile),
+		checkMemory(profile),
+		checkNofile(profile),
+		checkNproc(profile),
+		checkSysctl(constant.HOST_CHECK_ITEM_MAX_MAP_COUNT, constant.PROC_SYS_VM_MAX_MAP_COUNT, profile.maxMapCount),
+		checkOvercommitMemory(),
+		checkSysctl(constant.HOST_CHECK_ITEM_AIO_MAX_NR, constant.PROC_SYS_FS_AIO_MAX_NR, profile.aioMaxNr),
+		checkClockSync(),
+	}
+	items = append(items, checkDirs(profile, dataDir, redoDir)...)
+
+	report := &bo.HostCheckReport{
+		Agent:   meta.OCS_AGENT.String(),
+		Profile: profile.name,
+		Result:  constant.HOST_CHECK_RESULT_PASS,
+		Items:   items,
+	}
+	for _, item := range items {
+		if resultSeverity[item.Result] > resultSeverity[report.Result] {
+			report.Result = item.Result
+		}
+	}
+	return report, nil
+}
+
+func execCommand(command string) (string, error) {
+	output, err := exec.Command("bash", "-c", command).CombinedOutput()
+	if err != nil {
+		log.Errorf("Got error when executing command '%s': %v", command, err)
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parseLimit parses the output of ulimit, 'unlimited' is regarded as the max value.
+func parseLimit(output string) (int64, error) {
+	if output == "unlimited" {
+		return 1<<63 - 1, nil
+	}
+	return strconv.ParseInt(output, 10, 64)
+}
+
+func formatCount(n int64) string {
+	if n == 1<<63-1 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func formatBytes(n int64) string {
+	return parse.FormatCapacity(n)
+}
+
+func newThresholdItem(name string, actual int64, t threshold, format func(int64) string, fix string) bo.HostCheckItem {
+	item := bo.HostCheckItem{
+		Name:     name,
+		Expected: t.expected(format),
+		Actual:   format(actual),
+		Result:   t.evaluate(actual),
+	}
+	if item.Result != constant.HOST_CHECK_RESULT_PASS {
+		item.Fix = fix
+	}
+	return item
+}
+
+// newUnknownItem is used when the fact could not be collected, which needs a manual check.
+func newUnknownItem(name string, expected string, fix string) bo.HostCheckItem {
+	return bo.HostCheckItem{
+		Name:     name,
+		Expected: expected,
+		Actual:   unknownFact,
+		Result:   constant.HOST_CHECK_RESULT_WARN,
+		Fix:      fix,
+	}
+}
+
+func checkCpuCores(profile *requirementProfile) bo.HostCheckItem {
+	const fix = "Use a host with more CPU cores."
+	output, err := execCommand(constant.COMMAND_CPU_LOGIC_CORES)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_CPU_CORES, profile.cpuCores.expected(formatCount), fix)
+	}
+	cores, err := strconv.ParseInt(output, 10, 64)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_CPU_CORES, profile.cpuCores.expected(formatCount), fix)
+	}
+	return newThresholdItem(constant.HOST_CHECK_ITEM_CPU_CORES, cores, profile.cpuCores, formatCount, fix)
+}
+
+func checkMemory(profile *requirementProfile) bo.HostCheckItem {
+	const fix = "Use a host with more memory."
+	// The output is like '16318584 kB'.
+	output, err := execCommand(constant.COMMAND_MEMORY_TOTAL)
+	if err != nil || len(strings.Fields(output)) == 0 {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_MEMORY, profile.memory.expected(formatBytes), fix)
+	}
+	kb, err := strconv.ParseInt(strings.Fields(output)[0], 10, 64)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_MEMORY, profile.memory.expected(formatBytes), fix)
+	}
+	return newThresholdItem(constant.HOST_CHECK_ITEM_MEMORY, kb*parse.KB, profile.memory, formatBytes, fix)
+}
+
+func checkNofile(profile *requirementProfile) bo.HostCheckItem {
+	fix := "Add '* soft nofile " + formatCount(profile.nofile.warn) + "' and '* hard nofile " + formatCount(profile.nofile.warn) +
+		"' to /etc/security/limits.conf, then log in again and restart obshell."
+	output, err := execCommand(constant.COMMAND_ULIMIT_NOFILE_SOFT)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_NOFILE, profile.nofile.expected(formatCount), fix)
+	}
+	soft, err := parseLimit(output)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_NOFILE, profile.nofile.expected(formatCount), fix)
+	}
+	item := newThresholdItem(constant.HOST_CHECK_ITEM_NOFILE, soft, profile.nofile, formatCount, fix)
+	if output, err := execCommand(constant.COMMAND_ULIMIT_NOFILE_HARD); err == nil {
+		item.Actual += " (hard " + output + ")"
+	}
+	return item
+}
+
+func checkNproc(profile *requirementProfile) bo.HostCheckItem {
+	fix := "Add '* soft nproc " + formatCount(profile.nproc.warn) + "' and '* hard nproc " + formatCount(profile.nproc.warn) +
+		"' to /etc/security/limits.conf, then log in again and restart obshell."
+	output, err := execCommand(constant.COMMAND_ULIMIT_MAX_USER_PROCESSES_SOFT)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_NPROC, profile.nproc.expected(formatCount), fix)
+	}
+	soft, err := parseLimit(output)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_NPROC, profile.nproc.expected(formatCount), fix)
+	}
+	return newThresholdItem(constant.HOST_CHECK_ITEM_NPROC, soft, profile.nproc, formatCount, fix)
+}
+
+func readSysctl(path string) (int64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+func checkSysctl(name string, path string, t threshold) bo.HostCheckItem {
+	fix := "Add '" + name + " = " + formatCount(t.warn) + "' to /etc/sysctl.conf and run 'sysctl -p'."
+	value, err := readSysctl(path)
+	if err != nil {
+		log.WithError(err).Errorf("read %s failed", path)
+		return newUnknownItem(name, t.expected(formatCount), fix)
+	}
+	return newThresholdItem(name, value, t, formatCount, fix)
+}
+
+func checkOvercommitMemory() bo.HostCheckItem {
+	const expected = "0"
+	fix := "Add '" + constant.HOST_CHECK_ITEM_OVERCOMMIT + " = 0' to /etc/sysctl.conf and run 'sysctl -p'."
+	value, err := readSysctl(constant.PROC_SYS_VM_OVERCOMMIT_MEMORY)
+	if err != nil {
+		log.WithError(err).Errorf("read %s failed", constant.PROC_SYS_VM_OVERCOMMIT_MEMORY)
+		return newUnknownItem(constant.HOST_CHECK_ITEM_OVERCOMMIT, expected, fix)
+	}
+	item := bo.HostCheckItem{
+		Name:     constant.HOST_CHECK_ITEM_OVERCOMMIT,
+		Expected: expected,
+		Actual:   formatCount(value),
+		Result:   constant.HOST_CHECK_RESULT_PASS,
+	}
+	if value != 0 {
+		item.Result = constant.HOST_CHECK_RESULT_WARN
+		item.Fix = fix
+	}
+	return item
+}
+
+func checkClockSync() bo.HostCheckItem {
+	const expected = "synchronized"
+	const fix = "Install and enable chronyd or ntpd to synchronize the clock with the other hosts."
+	output, err := execCommand(constant.COMMAND_CLOCK_SYNCHRONIZED)
+	if err != nil {
+		return newUnknownItem(constant.HOST_CHECK_ITEM_CLOCK_SYNC, expected, fix)
+	}
+	if output == "yes" {
+		return bo.HostCheckItem{
+			Name:     constant.HOST_CHECK_ITEM_CLOCK_SYNC,
+			Expected: expected,
+			Actual:   expected,
+			Result:   constant.HOST_CHECK_RESULT_PASS,
+		}
+	}
+	return bo.HostCheckItem{
+		Name:     constant.HOST_CHECK_ITEM_CLOCK_SYNC,
+		Expected: expected,
+		Actual:   "not synchronized",
+		Result:   constant.HOST_CHECK_RESULT_WARN,
+		Fix:      fix,
+	}
+}
+
+// checkDirs checks the available size of the filesystems where the data dir and the redo dir will be located.
+// The dirs may not exist before deploying, so the nearest existing ancestor is checked.
+// If both the dirs are in the same filesystem, the requirements are added up.
+func checkDirs(profile *requirementProfile, dataDir string, redoDir string) []bo.HostCheckItem {
+	dataPath, dataFsid, dataErr := system.GetFsId(dataDir)
+	_, redoFsid, redoErr := system.GetFsId(redoDir)
+	if dataErr == nil && redoErr == nil && dataFsid == redoFsid {
+		name := constant.HOST_CHECK_ITEM_DATA_AND_REDO_DIR
+		return []bo.HostCheckItem{checkDirAvailable(name, dataPath, profile.dataDirFree.add(profile.redoDirFree))}
+	}
+	return []bo.HostCheckItem{
+		checkDir(constant.HOST_CHECK_ITEM_DATA_DIR, dataDir, profile.dataDirFree),
+		checkDir(constant.HOST_CHECK_ITEM_REDO_DIR, redoDir, profile.redoDirFree),
+	}
+}
+
+func checkDir(name string, dir string, t threshold) bo.HostCheckItem {
+	path, _, err := system.GetFsId(dir)
+	if err != nil {
+		log.WithError(err).Errorf("get filesystem of %s failed", dir)
+		return newUnknownItem(name, t.expected(formatBytes), "Make sure "+dir+" is accessible.")
+	}
+	return checkDirAvailable(name, path, t)
+}
+
+func checkDirAvailable(name string, path string, t threshold) bo.HostCheckItem {
+	fix := "Free up or mount a larger disk on " + path + "."
+	diskInfo, err := system.GetDiskInfo(path)
+	if err != nil {
+		log.WithError(err).Errorf("get disk info of %s failed", path)
+		return newUnknownItem(name, t.expected(formatBytes), fix)
+	}
+	item := newThresholdItem(name, int64(diskInfo.AvailableSizeBytes), t, formatBytes, fix)
+	item.Actual += " available on " + path
+	return item
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"strings"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/binary"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/lib/pkg"
+)
+
+// threshold is the requirement of a numeric host fact,
+// the fact less than fail is FAIL and the fact less than warn is WARN.
+// The zero value means no requirement.
+type threshold struct {
+	fail int64
+	warn int64
+}
+
+// requirementProfile is the host requirement of the observers since minVersion.
+type requirementProfile struct {
+	name       string
+	minVersion string
+
+	cpuCores    threshold
+	memory      threshold
+	nofile      threshold
+	nproc       threshold
+	maxMapCount threshold
+	aioMaxNr    threshold
+	dataDirFree threshold
+	redoDirFree threshold
+}
+
+// requirementProfiles is sorted by minVersion.
+var requirementProfiles = []requirementProfile{
+	{
+		name:        "4.2",
+		minVersion:  "4.2.0.0",
+		cpuCores:    threshold{fail: 2, warn: 4},
+		memory:      threshold{fail: 6 * parse.GB, warn: 16 * parse.GB},
+		nofile:      threshold{fail: 20000, warn: 655350},
+		nproc:       threshold{warn: 655360},
+		maxMapCount: threshold{fail: 327680, warn: 655360},
+		aioMaxNr:    threshold{warn: 1048576},
+		dataDirFree: threshold{fail: 20 * parse.GB, warn: 100 * parse.GB},
+		redoDirFree: threshold{fail: 20 * parse.GB, warn: 60 * parse.GB},
+	},
+	{
+		name:        "4.3",
+		minVersion:  "4.3.0.0",
+		cpuCores:    threshold{fail: 2, warn: 4},
+		memory:      threshold{fail: 8 * parse.GB, warn: 16 * parse.GB},
+		nofile:      threshold{fail: 65535, warn: 655350},
+		nproc:       threshold{warn: 655360},
+		maxMapCount: threshold{fail: 327680, warn: 655360},
+		aioMaxNr:    threshold{warn: 1048576},
+		dataDirFree: threshold{fail: 20 * parse.GB, warn: 100 * parse.GB},
+		redoDirFree: threshold{fail: 20 * parse.GB, warn: 60 * parse.GB},
+	},
+}
+
+// GetProfileNames returns the names of all the requirement profiles.
+func GetProfileNames() []string {
+	names := make([]string, 0, len(requirementProfiles))
+	for _, profile := range requirementProfiles {
+		names = append(names, profile.name)
+	}
+	return names
+}
+
+// getRequirementProfile returns the profile named name if specified.
+// Otherwise, returns the latest profile which supports the version of the local observer binary,
+// or the latest profile if the version is unknown or no profile matches.
+func getRequirementProfile(name string) (*requirementProfile, error) {
+	if name != "" {
+		for i := range requirementProfiles {
+			if requirementProfiles[i].name == name {
+				return &requirementProfiles[i], nil
+			}
+		}
+		return nil, errors.Occur(errors.ErrAgentHostCheckProfileNotExist, name, strings.Join(GetProfileNames(), ", "))
+	}
+
+	version, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		return &requirementProfiles[len(requirementProfiles)-1], nil
+	}
+	return getRequirementProfileByVersion(version), nil
+}
+
+// getRequirementProfileByVersion returns the latest profile which supports the version,
+// or the latest profile if no profile matches.
+func getRequirementProfileByVersion(version string) *requirementProfile {
+	for i := len(requirementProfiles) - 1; i >= 0; i-- {
+		if pkg.CompareVersion(version, requirementProfiles[i].minVersion) >= 0 {
+			return &requirementProfiles[i]
+		}
+	}
+	return &requirementProfiles[len(requirementProfiles)-1]
+}
+
+// ResolveProfileName returns the name of the profile to check the hosts against.
+// The profile of the version is used if name is empty and version is known,
+// so that the agent coordinating the check judges all the hosts by the same profile.
+func ResolveProfileName(name string, version string) (string, error) {
+	if name == "" && version != "" {
+		return getRequirementProfileByVersion(version).name, nil
+	}
+	profile, err := getRequirementProfile(name)
+	if err != nil {
+		return "", err
+	}
+	return profile.name, nil
+}
+
+func (t threshold) add(other threshold) threshold {
+	return threshold{fail: t.fail + other.fail, warn: t.warn + other.warn}
+}
+
+func (t threshold) evaluate(actual int64) string {
+	switch {
+	case t.fail > 0 && actual < t.fail:
+		return constant.HOST_CHECK_RESULT_FAIL
+	case t.warn > 0 && actual < t.warn:
+		return constant.HOST_CHECK_RESULT_WARN
+	default:
+		return constant.HOST_CHECK_RESULT_PASS
+	}
+}
+
+func (t threshold) expected(format func(int64) string) string {
+	if t.fail > 0 && t.fail != t.warn {
+		return ">= " + format(t.warn) + " (at least " + format(t.fail) + ")"
+	}
+	return ">= " + format(t.warn)
+}
@@ -0,0 +1,390 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ammodels "github.com/prometheus/alertmanager/api/v2/models"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	alarmconstant "github.com/oceanbase/obshell/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	configservice "github.com/oceanbase/obshell/agent/service/config"
+	modelalarm "github.com/oceanbase/obshell/model/alarm"
+	modelob "github.com/oceanbase/obshell/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+// archiveRemediationCooldown avoids restarting the archive log of a tenant
+// over and over when the remediation does not help.
+const archiveRemediationCooldown = 30 * time.Minute
+
+var archiveWatcher = &ArchiveLagWatcher{
+	alerts:       newAlertTracker("archive log", constant.ARCHIVE_WATCHER_CHECK_INTERVAL),
+	remediations: make(map[string]archiveRemediation),
+}
+
+// ArchiveLagWatcher runs on the maintainer agent, it checks the archive log of
+// all user tenants periodically, raises alarms through alertmanager and
+// optionally starts a dag to remediate the archive log.
+type ArchiveLagWatcher struct {
+	lock         sync.Mutex
+	alerts       *alertTracker
+	remediations map[string]archiveRemediation
+}
+
+type archiveRemediation struct {
+	dagID     int64
+	genericID string
+	startTime time.Time
+}
+
+func GetArchiveWatcherConfig() (*param.ArchiveWatcherConfig, error) {
+	cfg := &param.ArchiveWatcherConfig{
+		Enabled:      true,
+		LagThreshold: constant.ARCHIVE_WATCHER_LAG_THRESHOLD_DEFAULT,
+	}
+	ocsConfig, err := configservice.GetOcsConfig(constant.ARCHIVE_WATCHER_CONFIG_KEY)
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrConfigGetFailed, err, constant.ARCHIVE_WATCHER_CONFIG_KEY, err.Error())
+	}
+	if ocsConfig == nil {
+		return cfg, nil
+	}
+	if err = json.Unmarshal([]byte(ocsConfig.Value), cfg); err != nil {
+		return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	return cfg, nil
+}
+
+func SetArchiveWatcherConfig(p *param.ArchiveWatcherConfigParam) (*param.ArchiveWatcherConfig, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	cfg, err := GetArchiveWatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+	if p.Enabled != nil {
+		cfg.Enabled = *p.Enabled
+	}
+	if p.LagThreshold != nil {
+		cfg.LagThreshold = *p.LagThreshold
+	}
+	if p.AutoRemediate != nil {
+		cfg.AutoRemediate = *p.AutoRemediate
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	if err = configservice.SaveOcsConfig(constant.ARCHIVE_WATCHER_CONFIG_KEY, string(data), "Archive log lag watcher configuration"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// GetArchiveLagOverview checks the archive log of all user tenants, or only
+// the given tenant when tenantName is not empty.
+func GetArchiveLagOverview(tenantName string) (*param.ArchiveLagOverview, error) {
+	cfg, err := GetArchiveWatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := checkArchiveLag(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &param.ArchiveLagOverview{
+		Config:    *cfg,
+		CheckTime: time.Now(),
+		Tenants:   make([]param.ArchiveLagStatus, 0),
+	}
+	for _, status := range statuses {
+		if tenantName == "" || status.TenantName == tenantName {
+			status.RemediationDagID = archiveWatcher.getRemediation(status.TenantName).genericID
+			overview.Tenants = append(overview.Tenants, status)
+		}
+	}
+	return overview, nil
+}
+
+// FormatArchiveLagMetrics renders the archive lag overview in prometheus text format.
+func FormatArchiveLagMetrics(overview *param.ArchiveLagOverview) string {
+	var b strings.Builder
+	b.WriteString("# HELP obshell_archive_log_lag_seconds Seconds between now and the archive log checkpoint.\n")
+	b.WriteString("# TYPE obshell_archive_log_lag_seconds gauge\n")
+	for _, status := range overview.Tenants {
+		fmt.Fprintf(&b, "obshell_archive_log_lag_seconds{%s=%q} %d\n", alarmconstant.LabelOBTenant, status.TenantName, status.LagSeconds)
+	}
+	b.WriteString("# HELP obshell_archive_log_status Archive log status of the tenant, the value is always 1.\n")
+	b.WriteString("# TYPE obshell_archive_log_status gauge\n")
+	for _, status := range overview.Tenants {
+		fmt.Fprintf(&b, "obshell_archive_log_status{%s=%q,status=%q} 1\n", alarmconstant.LabelOBTenant, status.TenantName, status.Status)
+	}
+	b.WriteString("# HELP obshell_archive_log_alarming Whether the archive log of the tenant is interrupted or lagging.\n")
+	b.WriteString("# TYPE obshell_archive_log_alarming gauge\n")
+	for _, status := range overview.Tenants {
+		alarming := 0
+		if status.Alarming {
+			alarming = 1
+		}
+		fmt.Fprintf(&b, "obshell_archive_log_alarming{%s=%q} %d\n", alarmconstant.LabelOBTenant, status.TenantName, alarming)
+	}
+	return b.String()
+}
+
+func checkArchiveLag(cfg *param.ArchiveWatcherConfig) ([]param.ArchiveLagStatus, error) {
+	threshold, err := system.ParseTime(cfg.LagThreshold)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrObArchiveWatcherThresholdInvalid, cfg.LagThreshold)
+	}
+	tenants, err := tenantService.GetAllUserTenants()
+	if err != nil {
+		return nil, errors.Wrap(err, "get all user tenants")
+	}
+	progress, err := tenantService.ListArchiveLogProgress()
+	if err != nil {
+		return nil, errors.Wrap(err, "list archive log progress")
+	}
+
+	tenantNames := make(map[int]string, len(tenants))
+	for _, tenant := range tenants {
+		tenantNames[tenant.TenantID] = tenant.TenantName
+	}
+	statuses := make([]param.ArchiveLagStatus, 0, len(progress))
+	for _, p := range progress {
+		tenantName, ok := tenantNames[p.TenantID]
+		if !ok {
+			continue
+		}
+		status := param.ArchiveLagStatus{
+			TenantID:       p.TenantID,
+			TenantName:     tenantName,
+			Status:         p.Status,
+			RoundID:        p.RoundID,
+			CheckpointTime: p.CheckpointScnDisplay,
+			LagSeconds:     p.LagSeconds,
+			Comment:        p.Comment,
+		}
+		lag := time.Duration(p.LagSeconds) * time.Second
+		switch p.Status {
+		case constant.ARCHIVELOG_STATUS_INTERRUPTED:
+			status.Alarming = true
+			status.Reason = "archive log is interrupted"
+		case constant.ARCHIVELOG_STATUS_DOING:
+			if lag > threshold {
+				status.Alarming = true
+				status.Reason = fmt.Sprintf("archive lag %s exceeds the threshold %s", lag, threshold)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (w *ArchiveLagWatcher) check() error {
+	cfg, err := GetArchiveWatcherConfig()
+	if err != nil {
+		return err
+	}
+	var statuses []param.ArchiveLagStatus
+	if cfg.Enabled {
+		if statuses, err = checkArchiveLag(cfg); err != nil {
+			return err
+		}
+	}
+	clusterName, err := getClusterName()
+	if err != nil {
+		log.WithError(err).Warn("get cluster name failed")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	firing := make(map[string]*ammodels.PostableAlert)
+	for _, status := range statuses {
+		if !status.Alarming {
+			continue
+		}
+		log.Warnf("tenant %s(%d) %s", status.TenantName, status.TenantID, status.Reason)
+		firing[status.TenantName] = newArchiveAlert(clusterName, &status)
+
+		if cfg.AutoRemediate {
+			w.remediate(status.TenantName)
+		}
+	}
+	w.alerts.update(firing)
+	return nil
+}
+
+func newArchiveAlert(clusterName string, status *param.ArchiveLagStatus) *ammodels.PostableAlert {
+	rule := constant.ARCHIVE_ALARM_RULE_LAG
+	severity := modelalarm.SeverityMajor
+	if status.Status == constant.ARCHIVELOG_STATUS_INTERRUPTED {
+		rule = constant.ARCHIVE_ALARM_RULE_INTERRUPTED
+		severity = modelalarm.SeverityCritical
+	}
+	return &ammodels.PostableAlert{
+		Annotations: ammodels.LabelSet{
+			alarmconstant.AnnoSummary:     fmt.Sprintf("Archive log of tenant %s is unhealthy", status.TenantName),
+			alarmconstant.AnnoDescription: fmt.Sprintf("Archive log of tenant %s is '%s', %s, checkpoint at %s", status.TenantName, status.Status, status.Reason, status.CheckpointTime.Format(time.DateTime)),
+		},
+		Alert: ammodels.Alert{
+			Labels: ammodels.LabelSet{
+				alarmconstant.LabelRuleName:     rule,
+				alarmconstant.LabelSeverity:     string(severity),
+				alarmconstant.LabelInstanceType: string(modelob.TypeOBTenant),
+				alarmconstant.LabelOBCluster:    clusterName,
+				alarmconstant.LabelOBTenant:     status.TenantName,
+			},
+		},
+	}
+}
+
+// remediate starts a remediation dag for the tenant, unless the last one is
+// still running or was started within archiveRemediationCooldown.
+func (w *ArchiveLagWatcher) remediate(tenantName string) {
+	if last, ok := w.remediations[tenantName]; ok {
+		if time.Since(last.startTime) < archiveRemediationCooldown {
+			return
+		}
+		dag, err := taskService.GetDagInstance(last.dagID)
+		if err == nil && !dag.IsFinished() {
+			return
+		}
+	}
+
+	tenant, err := tenantService.GetTenantByName(tenantName)
+	if err != nil {
+		log.WithError(err).Warnf("get tenant %s failed", tenantName)
+		return
+	}
+	dag, err := createRemediateArchiveLogDag(tenant)
+	if err != nil {
+		log.WithError(err).Warnf("remediate archive log of %s failed", tenantName)
+		return
+	}
+	log.Infof("start dag %d to remediate archive log of %s", dag.GetID(), tenantName)
+	w.remediations[tenantName] = archiveRemediation{
+		dagID:     dag.GetID(),
+		genericID: task.NewDagDetailDTO(dag).GenericID,
+		startTime: time.Now(),
+	}
+}
+
+func (w *ArchiveLagWatcher) getRemediation(tenantName string) archiveRemediation {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.remediations[tenantName]
+}
+
+func TenantRemediateArchiveLog(tenant *oceanbase.DbaObTenant) (*task.DagDetailDTO, error) {
+	dag, err := createRemediateArchiveLogDag(tenant)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func createRemediateArchiveLogDag(tenant *oceanbase.DbaObTenant) (*task.Dag, error) {
+	dest, err := tenantService.GetArchiveDestByID(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get archive dest of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	if dest == "" {
+		return nil, errors.Occur(errors.ErrObBackupArchiveDestEmpty, tenant.TenantName, tenant.TenantID)
+	}
+
+	ctx := task.NewTaskContext().SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName)
+	template := task.NewTemplateBuilder(fmt.Sprintf("%s for %s", DAG_REMEDIATE_ARCHIVE_LOG, tenant.TenantName)).
+		AddTask(newResetArchiveLogTask(), false).
+		AddTask(newOpenArchiveLogTask(), false).
+		Build()
+	return taskService.CreateDagInstanceByTemplate(template, ctx)
+}
+
+// ResetArchiveLogTask closes the interrupted archive log and re-enables the
+// archive dest, the archive log is opened again by OpenArchiveLogTask.
+type ResetArchiveLogTask struct {
+	task.Task
+	tenants []oceanbase.DbaObTenant
+}
+
+func newResetArchiveLogTask() *ResetArchiveLogTask {
+	t := &ResetArchiveLogTask{
+		Task: *task.NewSubTask(TASK_RESET_ARCHIVE_LOG),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *ResetArchiveLogTask) Execute() (err error) {
+	if t.tenants, err = getTenantFromCtx(t.GetContext()); err != nil {
+		return errors.Wrap(err, "get tenant from context")
+	}
+
+	for _, tenant := range t.tenants {
+		status, err := tenantService.GetArchiveLogStatus(tenant.TenantID)
+		if err != nil {
+			return errors.Wrap(err, "get archive log status")
+		}
+		t.ExecuteLogf("Archive log status of %s(%d) is '%s'", tenant.TenantName, tenant.TenantID, status)
+
+		if status == constant.ARCHIVELOG_STATUS_INTERRUPTED {
+			t.ExecuteLogf("Close archive log of %s(%d)", tenant.TenantName, tenant.TenantID)
+			if err = tenantService.CloseArchiveLog(tenant.TenantName); err != nil {
+				return errors.Wrap(err, "close archive log")
+			}
+			if err = t.waitArchiveLogStop(&tenant); err != nil {
+				return err
+			}
+		}
+
+		t.ExecuteLogf("Enable archive dest of %s(%d)", tenant.TenantName, tenant.TenantID)
+		if err = tenantService.EnableArchiveLogDest(tenant.TenantName); err != nil {
+			return errors.Wrap(err, "enable archive log dest")
+		}
+	}
+	return nil
+}
+
+func (t *ResetArchiveLogTask) waitArchiveLogStop(tenant *oceanbase.DbaObTenant) error {
+	t.ExecuteLogf("Wait for %s(%d) archive log to be '%s'", tenant.TenantName, tenant.TenantID, constant.ARCHIVELOG_STATUS_STOP)
+	for i := 0; i < waitForArchiveLogStop; i++ {
+		status, err := tenantService.GetArchiveLogStatus(tenant.TenantID)
+		if err != nil {
+			return err
+		}
+		if status == constant.ARCHIVELOG_STATUS_NULL || status == constant.ARCHIVELOG_STATUS_STOP {
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "stop archive log")
+}
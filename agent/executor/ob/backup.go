@@ -384,21 +384,31 @@ func (t *StartBackupTask) Execute() error {
 		return errors.Wrap(err, "get params")
 	}
 
-	if t.mode == constant.BACKUP_MODE_FULL {
-		for _, tenant := range t.tenants {
+	keyVersions := make(map[int]int)
+	for i := range t.tenants {
+		tenant := &t.tenants[i]
+		encryption, version, err := resolveBackupEncryption(tenant, t.encryption)
+		if err != nil {
+			return err
+		}
+		if version != 0 {
+			t.ExecuteLogf("Encrypt backup of %s(%d) with backup key version %d", tenant.TenantName, tenant.TenantID, version)
+			keyVersions[tenant.TenantID] = version
+		}
+
+		if t.mode == constant.BACKUP_MODE_FULL {
 			t.ExecuteLogf("Start full backup of %s(%d)", tenant.TenantName, tenant.TenantID)
-			if err := tenantService.StartFullBackup(tenant.TenantName, t.encryption, t.plusArchive); err != nil {
+			if err := tenantService.StartFullBackup(tenant.TenantName, encryption, t.plusArchive); err != nil {
 				return errors.Wrap(err, "start full backup")
 			}
-		}
-	} else {
-		for _, tenant := range t.tenants {
+		} else {
 			t.ExecuteLogf("Start incremental backup of %s(%d)", tenant.TenantName, tenant.TenantID)
-			if err := tenantService.StartIncrementalBackup(tenant.TenantName, t.encryption, t.plusArchive); err != nil {
+			if err := tenantService.StartIncrementalBackup(tenant.TenantName, encryption, t.plusArchive); err != nil {
 				return errors.Wrap(err, "start incremental backup")
 			}
 		}
 	}
+	t.GetContext().SetData(ADDL_KEY_BACKUP_KEYS, keyVersions)
 	return nil
 }
 
@@ -451,10 +461,22 @@ func (t *WaitBackupTaskFinish) Execute() (err error) {
 		return errors.Wrap(err, "get tenant from context")
 	}
 
+	keyVersions := make(map[int]int)
+	if t.GetContext().GetData(ADDL_KEY_BACKUP_KEYS) != nil {
+		if err = t.GetContext().GetDataWithValue(ADDL_KEY_BACKUP_KEYS, &keyVersions); err != nil {
+			return err
+		}
+	}
 	for _, tenant := range t.tenants {
 		if err := waitBackupFinish(t, &tenant); err != nil {
 			return err
 		}
+		if version, ok := keyVersions[tenant.TenantID]; ok {
+			t.ExecuteLogf("Record backup key version %d of %s(%d)", version, tenant.TenantName, tenant.TenantID)
+			if err := recordBackupSetKey(&tenant, version); err != nil {
+				return errors.Wrap(err, "record backup set key")
+			}
+		}
 	}
 	return nil
 }
@@ -0,0 +1,366 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+func GetObclusterBackupCatalog() (*param.BackupCatalog, error) {
+	tenants, err := tenantService.GetAllUserTenants()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &param.BackupCatalog{
+		Tenants: make([]param.TenantBackupCatalog, 0),
+	}
+	for i := range tenants {
+		tenantCatalog, err := GetTenantBackupCatalog(&tenants[i])
+		if err != nil {
+			return nil, err
+		}
+		catalog.Tenants = append(catalog.Tenants, *tenantCatalog)
+	}
+	return catalog, nil
+}
+
+func GetTenantBackupCatalog(tenant *oceanbase.DbaObTenant) (*param.TenantBackupCatalog, error) {
+	backupSets, err := tenantService.ListBackupSetFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list backup sets of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	pieces, err := tenantService.ListArchivelogPieceFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list archive pieces of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+
+	catalog := &param.TenantBackupCatalog{
+		TenantID:      tenant.TenantID,
+		TenantName:    tenant.TenantName,
+		BackupSets:    make([]oceanbase.CdbObBackupSetFile, 0),
+		ArchivePieces: make([]oceanbase.CdbObArchivelogPieceFile, 0),
+	}
+	catalog.BackupSets = append(catalog.BackupSets, backupSets...)
+	catalog.ArchivePieces = append(catalog.ArchivePieces, pieces...)
+	return catalog, nil
+}
+
+func ObclusterValidateBackup(p *param.BackupValidateParam) (*task.DagDetailDTO, error) {
+	allTenants, err := tenantService.GetAllUserTenants()
+	if err != nil {
+		return nil, err
+	}
+	if len(allTenants) == 0 {
+		return nil, errors.Occur(errors.ErrObBackupNoUserTenants)
+	}
+
+	ctx := task.NewTaskContext().SetParam(PARAM_ALL_TENANTS, true)
+	if p.BackupSetID != nil {
+		ctx.SetParam(PARAM_BACKUP_SET_ID, *p.BackupSetID)
+	}
+
+	template := buildValidateBackupTemplate(DAG_OBCLUSTER_VALIDATE_BACKUP)
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func TenantValidateBackup(tenant *oceanbase.DbaObTenant, p *param.BackupValidateParam) (*task.DagDetailDTO, error) {
+	ctx := task.NewTaskContext().SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName)
+	if p.BackupSetID != nil {
+		backupSet, err := tenantService.GetBackupSetFile(tenant.TenantID, *p.BackupSetID)
+		if err != nil {
+			return nil, err
+		}
+		if backupSet == nil {
+			return nil, errors.Occur(errors.ErrObBackupSetNotExist, *p.BackupSetID, tenant.TenantName)
+		}
+		ctx.SetParam(PARAM_BACKUP_SET_ID, *p.BackupSetID)
+	}
+
+	template := buildValidateBackupTemplate(fmt.Sprintf("%s for %s", DAG_OBCLUSTER_VALIDATE_BACKUP, tenant.TenantName))
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func buildValidateBackupTemplate(name string) *task.Template {
+	return task.NewTemplateBuilder(name).
+		AddTask(newValidateBackupTask(), false).
+		Build()
+}
+
+// ValidateBackupTask checks that the backup sets of each tenant are restorable:
+// the set itself is available and present on its destination, its full/incremental
+// chain is intact and the archive pieces present on their destination continuously
+// cover the logs needed to replay it.
+type ValidateBackupTask struct {
+	task.Task
+	backupSetID int64
+	tenants     []oceanbase.DbaObTenant
+	dest        *backupDestChecker
+}
+
+func newValidateBackupTask() *ValidateBackupTask {
+	t := &ValidateBackupTask{
+		Task: *task.NewSubTask(TASK_VALIDATE_BACKUP),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *ValidateBackupTask) Execute() (err error) {
+	if t.GetContext().GetParam(PARAM_BACKUP_SET_ID) != nil {
+		if err = t.GetContext().GetParamWithValue(PARAM_BACKUP_SET_ID, &t.backupSetID); err != nil {
+			return err
+		}
+	}
+	if t.tenants, err = getTenantFromCtx(t.GetContext()); err != nil {
+		return errors.Wrap(err, "get tenant from context")
+	}
+
+	t.dest = newBackupDestChecker()
+	for _, tenant := range t.tenants {
+		if err = t.validateTenant(&tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ValidateBackupTask) validateTenant(tenant *oceanbase.DbaObTenant) error {
+	backupSets, err := tenantService.ListBackupSetFiles(tenant.TenantID)
+	if err != nil {
+		return errors.Wrapf(err, "list backup sets of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	pieces, err := tenantService.ListArchivelogPieceFiles(tenant.TenantID)
+	if err != nil {
+		return errors.Wrapf(err, "list archive pieces of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+
+	setMap := make(map[int64]*oceanbase.CdbObBackupSetFile)
+	for i := range backupSets {
+		setMap[backupSets[i].BackupSetID] = &backupSets[i]
+	}
+
+	// Only the pieces present on the destination are able to replay the logs.
+	presentPieces := make([]oceanbase.CdbObArchivelogPieceFile, 0, len(pieces))
+	for i := range pieces {
+		piece := &pieces[i]
+		if piece.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+			continue
+		}
+		if problem := t.dest.checkPiece(piece); problem != "" {
+			t.ExecuteWarnLogf("Archive piece %d of %s: %s", piece.PieceID, tenant.TenantName, problem)
+			continue
+		}
+		presentPieces = append(presentPieces, *piece)
+	}
+
+	problems := 0
+	validated := 0
+	for i := range backupSets {
+		set := &backupSets[i]
+		if t.backupSetID != 0 && set.BackupSetID != t.backupSetID {
+			continue
+		}
+		if t.backupSetID == 0 && set.Status != constant.BACKUP_SET_STATUS_SUCCESS {
+			continue
+		}
+		validated++
+		t.ExecuteLogf("Validate %s backup set %d of %s", set.BackupType, set.BackupSetID, tenant.TenantName)
+		for _, problem := range validateBackupSet(set, setMap, presentPieces) {
+			t.ExecuteWarnLogf("Backup set %d of %s: %s", set.BackupSetID, tenant.TenantName, problem)
+			problems++
+		}
+		for _, problem := range t.dest.checkBackupSetChain(set, setMap) {
+			t.ExecuteWarnLogf("Backup set %d of %s: %s", set.BackupSetID, tenant.TenantName, problem)
+			problems++
+		}
+	}
+
+	if t.backupSetID != 0 && validated == 0 {
+		return errors.Occur(errors.ErrObBackupSetNotExist, t.backupSetID, tenant.TenantName)
+	}
+	if problems > 0 {
+		return errors.Occur(errors.ErrObBackupValidateFailed, tenant.TenantName, problems)
+	}
+	t.ExecuteLogf("%d backup set(s) of %s validated", validated, tenant.TenantName)
+	return nil
+}
+
+func validateBackupSet(set *oceanbase.CdbObBackupSetFile, setMap map[int64]*oceanbase.CdbObBackupSetFile, pieces []oceanbase.CdbObArchivelogPieceFile) (problems []string) {
+	problems = append(problems, checkBackupSetFile(set)...)
+
+	if set.BackupType == constant.BACKUP_SET_TYPE_INC {
+		for _, prevID := range []int64{set.PrevFullBackupSetID, set.PrevIncBackupSetID} {
+			if prevID == 0 {
+				continue
+			}
+			prev, ok := setMap[prevID]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("depended backup set %d is missing", prevID))
+				continue
+			}
+			for _, problem := range checkBackupSetFile(prev) {
+				problems = append(problems, fmt.Sprintf("depended backup set %d: %s", prevID, problem))
+			}
+		}
+	}
+
+	if set.PlusArchivelog != constant.BACKUP_PLUS_ARCHIVELOG_ON {
+		if scn := archiveCoveredUntil(pieces, set.StartReplayScn, set.MinRestoreScn); scn < set.MinRestoreScn {
+			problems = append(problems, fmt.Sprintf("archive log is not continuous from scn %d to %d, covered until %d", set.StartReplayScn, set.MinRestoreScn, scn))
+		}
+	}
+	return
+}
+
+func checkBackupSetFile(set *oceanbase.CdbObBackupSetFile) (problems []string) {
+	if set.Status != constant.BACKUP_SET_STATUS_SUCCESS {
+		problems = append(problems, fmt.Sprintf("status is %s", set.Status))
+	}
+	if set.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+		problems = append(problems, fmt.Sprintf("file status is %s", set.FileStatus))
+	}
+	return
+}
+
+// backupDestChecker checks the backup sets and the archive pieces recorded in the
+// catalog against the directories on their destination, each destination is listed once.
+type backupDestChecker struct {
+	dirs map[string][]string
+	errs map[string]error
+}
+
+func newBackupDestChecker() *backupDestChecker {
+	return &backupDestChecker{
+		dirs: make(map[string][]string),
+		errs: make(map[string]error),
+	}
+}
+
+// checkBackupSetChain checks that the set and the sets it depends on are present on the destination.
+func (c *backupDestChecker) checkBackupSetChain(set *oceanbase.CdbObBackupSetFile, setMap map[int64]*oceanbase.CdbObBackupSetFile) (problems []string) {
+	if problem := c.checkBackupSet(set); problem != "" {
+		problems = append(problems, problem)
+	}
+	if set.BackupType != constant.BACKUP_SET_TYPE_INC {
+		return
+	}
+	for _, prevID := range []int64{set.PrevFullBackupSetID, set.PrevIncBackupSetID} {
+		prev, ok := setMap[prevID]
+		if prevID == 0 || !ok {
+			continue
+		}
+		if problem := c.checkBackupSet(prev); problem != "" {
+			problems = append(problems, fmt.Sprintf("depended backup set %d: %s", prevID, problem))
+		}
+	}
+	return
+}
+
+func (c *backupDestChecker) checkBackupSet(set *oceanbase.CdbObBackupSetFile) string {
+	return c.checkDir(set.Path, fmt.Sprintf(constant.BACKUP_SET_DIR_FORMAT, set.BackupSetID, strings.ToLower(set.BackupType)))
+}
+
+func (c *backupDestChecker) checkPiece(piece *oceanbase.CdbObArchivelogPieceFile) string {
+	return c.checkDir(piece.Path, fmt.Sprintf(constant.BACKUP_PIECE_DIR_FORMAT, piece.DestID, piece.RoundID, piece.PieceID))
+}
+
+// checkDir returns the problem if the dir is missing or empty on the destination.
+// OceanBase hides the credentials of object storage in the catalog, such a
+// destination can not be listed and is reported as a problem as well.
+func (c *backupDestChecker) checkDir(dest string, dir string) string {
+	destPath := strings.SplitN(dest, "?", 2)[0]
+	dirs, err := c.listDirs(dest, "")
+	if err != nil {
+		return fmt.Sprintf("can not list the destination '%s': %v", destPath, err)
+	}
+	if !utils.ContainsString(dirs, dir) {
+		return fmt.Sprintf("'%s' is missing on the destination '%s'", dir, destPath)
+	}
+	subDirs, err := c.listDirs(dest, dir)
+	if err != nil {
+		return fmt.Sprintf("can not list '%s' on the destination '%s': %v", dir, destPath, err)
+	}
+	if len(subDirs) == 0 {
+		return fmt.Sprintf("'%s' is empty on the destination '%s'", dir, destPath)
+	}
+	return ""
+}
+
+func (c *backupDestChecker) listDirs(dest string, subpath string) ([]string, error) {
+	key := dest + "\x00" + subpath
+	if dirs, ok := c.dirs[key]; ok {
+		return dirs, nil
+	}
+	if err, ok := c.errs[key]; ok {
+		return nil, err
+	}
+	dirs, err := listBackupDestDirs(dest, subpath)
+	if err != nil {
+		c.errs[key] = err
+		return nil, err
+	}
+	c.dirs[key] = dirs
+	return dirs, nil
+}
+
+func listBackupDestDirs(dest string, subpath string) ([]string, error) {
+	storage, err := system.GetStorageInterfaceByURI(dest)
+	if err != nil {
+		return nil, err
+	}
+	return withSubpath(storage, subpath).ListDirs()
+}
+
+// archiveCoveredUntil returns the largest scn up to which the available
+// archive pieces continuously cover the logs starting from startScn.
+func archiveCoveredUntil(pieces []oceanbase.CdbObArchivelogPieceFile, startScn, endScn int64) int64 {
+	covered := startScn
+	for covered < endScn {
+		next := covered
+		for _, piece := range pieces {
+			if piece.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+				continue
+			}
+			if piece.StartScn <= covered && piece.CheckpointScn > next {
+				next = piece.CheckpointScn
+			}
+		}
+		if next == covered {
+			break
+		}
+		covered = next
+	}
+	return covered
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+func PreviewTenantBackupClean(tenant *oceanbase.DbaObTenant, p *param.BackupCleanParam) (*param.BackupCleanPreview, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	return buildBackupCleanPlan(tenant, p)
+}
+
+func TenantCleanBackup(tenant *oceanbase.DbaObTenant, p *param.BackupCleanParam) (*task.DagDetailDTO, error) {
+	plan, err := PreviewTenantBackupClean(tenant, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.BackupSets) == 0 && len(plan.ArchivePieces) == 0 {
+		return nil, errors.Occur(errors.ErrObBackupCleanTargetEmpty)
+	}
+
+	jobs, err := tenantService.ListBackupDeleteJobs(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list backup delete jobs of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	if len(jobs) != 0 {
+		return nil, errors.Occur(errors.ErrObBackupDeleteJobRunning, tenant.TenantName)
+	}
+
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName).
+		SetParam(PARAM_BACKUP_CLEAN, *p)
+	template := task.NewTemplateBuilder(fmt.Sprintf("%s for %s", DAG_CLEAN_BACKUP, tenant.TenantName)).
+		AddTask(newDeleteBackupTask(), false).
+		Build()
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// buildBackupCleanPlan resolves the backup sets and archive pieces to be deleted.
+// Archive pieces still needed by a retained backup set are never selected, and
+// the plan is refused if it would break the last restorable backup chain inside
+// the recovery window of the delete policy.
+func buildBackupCleanPlan(tenant *oceanbase.DbaObTenant, p *param.BackupCleanParam) (*param.BackupCleanPreview, error) {
+	catalog, err := GetTenantBackupCatalog(tenant)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := tenantService.GetDeletePolicy(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get delete policy of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+
+	plan := &param.BackupCleanPreview{
+		TenantName:    tenant.TenantName,
+		BackupSets:    make([]oceanbase.CdbObBackupSetFile, 0),
+		ArchivePieces: make([]oceanbase.CdbObArchivelogPieceFile, 0),
+	}
+	if policy != nil {
+		plan.RecoveryWindow = policy.RecoveryWindow
+	}
+
+	setMap := make(map[int64]*oceanbase.CdbObBackupSetFile)
+	for i := range catalog.BackupSets {
+		setMap[catalog.BackupSets[i].BackupSetID] = &catalog.BackupSets[i]
+	}
+	deleted := make(map[int64]bool)
+	for _, id := range p.BackupSetIDs {
+		set, ok := setMap[id]
+		if !ok {
+			return nil, errors.Occur(errors.ErrObBackupSetNotExist, id, tenant.TenantName)
+		}
+		if !deleted[id] {
+			deleted[id] = true
+			plan.BackupSets = append(plan.BackupSets, *set)
+		}
+	}
+
+	keptSets := make(map[int64]*oceanbase.CdbObBackupSetFile)
+	minReplayScn := int64(math.MaxInt64)
+	for id, set := range setMap {
+		if deleted[id] {
+			continue
+		}
+		for _, prevID := range []int64{set.PrevFullBackupSetID, set.PrevIncBackupSetID} {
+			if set.BackupType == constant.BACKUP_SET_TYPE_INC && deleted[prevID] {
+				return nil, errors.Occur(errors.ErrObBackupSetDepended, prevID, tenant.TenantName, id)
+			}
+		}
+		keptSets[id] = set
+		if len(checkBackupSetFile(set)) == 0 && set.StartReplayScn < minReplayScn {
+			minReplayScn = set.StartReplayScn
+		}
+	}
+
+	keptPieces := make([]oceanbase.CdbObArchivelogPieceFile, 0)
+	for _, piece := range catalog.ArchivePieces {
+		if p.ArchiveBefore != nil && *p.ArchiveBefore != constant.ZERO_TIME &&
+			piece.Status != constant.BACKUP_PIECE_STATUS_ACTIVE &&
+			piece.FileStatus == constant.BACKUP_FILE_STATUS_AVAILABLE &&
+			piece.CheckpointScnDisplay.Before(*p.ArchiveBefore) &&
+			piece.CheckpointScn < minReplayScn {
+			plan.ArchivePieces = append(plan.ArchivePieces, piece)
+		} else {
+			keptPieces = append(keptPieces, piece)
+		}
+	}
+
+	windowStart, err := recoveryWindowStart(plan.RecoveryWindow)
+	if err != nil {
+		return nil, err
+	}
+	if hasRestorableChain(setMap, catalog.ArchivePieces, windowStart) && !hasRestorableChain(keptSets, keptPieces, windowStart) {
+		return nil, errors.Occur(errors.ErrObBackupCleanNoRestorableChain, tenant.TenantName, plan.RecoveryWindow)
+	}
+	return plan, nil
+}
+
+func recoveryWindowStart(recoveryWindow string) (time.Time, error) {
+	if recoveryWindow == "" {
+		return time.Time{}, nil
+	}
+	window, err := system.ParseTime(recoveryWindow)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "parse recovery window '%s'", recoveryWindow)
+	}
+	return time.Now().Add(-window), nil
+}
+
+func hasRestorableChain(setMap map[int64]*oceanbase.CdbObBackupSetFile, pieces []oceanbase.CdbObArchivelogPieceFile, windowStart time.Time) bool {
+	for _, set := range setMap {
+		if set.EndTimestamp.Before(windowStart) {
+			continue
+		}
+		if len(validateBackupSet(set, setMap, pieces)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type DeleteBackupTask struct {
+	task.Task
+	param   param.BackupCleanParam
+	tenants []oceanbase.DbaObTenant
+}
+
+func newDeleteBackupTask() *DeleteBackupTask {
+	t := &DeleteBackupTask{
+		Task: *task.NewSubTask(TASK_DELETE_BACKUP),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *DeleteBackupTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_BACKUP_CLEAN, &t.param); err != nil {
+		return errors.Wrap(err, "get backup clean param")
+	}
+	if t.tenants, err = getTenantFromCtx(t.GetContext()); err != nil {
+		return errors.Wrap(err, "get tenant from context")
+	}
+
+	for _, tenant := range t.tenants {
+		// Rebuild the plan so that the safety checks see the latest catalog.
+		plan, err := buildBackupCleanPlan(&tenant, &t.param)
+		if err != nil {
+			return err
+		}
+		for _, set := range plan.BackupSets {
+			if set.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+				t.ExecuteLogf("Backup set %d of %s is %s, skip it", set.BackupSetID, tenant.TenantName, set.FileStatus)
+				continue
+			}
+			t.ExecuteLogf("Delete backup set %d of %s", set.BackupSetID, tenant.TenantName)
+			if err = tenantService.DeleteBackupSet(tenant.TenantName, set.BackupSetID); err != nil {
+				return errors.Wrapf(err, "delete backup set %d", set.BackupSetID)
+			}
+			if err = waitBackupDeleteFinish(t, &tenant); err != nil {
+				return err
+			}
+		}
+		for _, piece := range plan.ArchivePieces {
+			t.ExecuteLogf("Delete archive piece %d of %s", piece.PieceID, tenant.TenantName)
+			if err = tenantService.DeleteBackupPiece(tenant.TenantName, piece.PieceID); err != nil {
+				return errors.Wrapf(err, "delete archive piece %d", piece.PieceID)
+			}
+			if err = waitBackupDeleteFinish(t, &tenant); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func waitBackupDeleteFinish(t task.ExecutableTask, tenant *oceanbase.DbaObTenant) error {
+	for i := 0; i < waitForBackupStopped; i++ {
+		jobs, err := tenantService.ListBackupDeleteJobs(tenant.TenantID)
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occurf(errors.ErrObClusterAsyncOperationTimeout, "backup delete for %s(%d)", tenant.TenantName, tenant.TenantID)
+}
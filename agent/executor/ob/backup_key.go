@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/agent/secure"
+	"github.com/oceanbase/obshell/param"
+)
+
+// RotateTenantBackupKey activates a new version of the backup encryption password of the tenant,
+// the password is generated if not specified. Later backups of the tenant are encrypted with it.
+func RotateTenantBackupKey(tenant *oceanbase.DbaObTenant, p *param.BackupKeyParam) (*bo.BackupEncryptionKey, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	password := ""
+	if p.Password != nil {
+		password = *p.Password
+	} else if password, err = generateBackupPassword(); err != nil {
+		return nil, err
+	}
+	key, err := saveBackupKey(tenant, password)
+	if err != nil {
+		return nil, err
+	}
+	return toBackupKeyBO(key, nil), nil
+}
+
+// GetTenantBackupKeys returns the key history of the tenant without the passwords.
+func GetTenantBackupKeys(tenant *oceanbase.DbaObTenant) ([]bo.BackupEncryptionKey, error) {
+	keys, err := tenantService.ListBackupKeys(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup keys")
+	}
+	records, err := tenantService.ListBackupSetKeys(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup set keys")
+	}
+
+	res := make([]bo.BackupEncryptionKey, 0, len(keys))
+	for i := range keys {
+		res = append(res, *toBackupKeyBO(&keys[i], records))
+	}
+	return res, nil
+}
+
+func toBackupKeyBO(key *oceanbase.BackupEncryptionKey, records []oceanbase.BackupSetEncryptionKey) *bo.BackupEncryptionKey {
+	res := &bo.BackupEncryptionKey{
+		TenantID:     key.TenantID,
+		TenantName:   key.TenantName,
+		Version:      key.Version,
+		Active:       key.Active,
+		CreateTime:   key.GmtCreate,
+		RetiredTime:  key.RetiredTime,
+		BackupSetIDs: make([]int64, 0),
+	}
+	for _, record := range records {
+		if record.KeyVersion == key.Version {
+			res.BackupSetIDs = append(res.BackupSetIDs, record.BackupSetID)
+		}
+	}
+	return res
+}
+
+func generateBackupPassword() (string, error) {
+	b := make([]byte, constant.BACKUP_KEY_RANDOM_BYTES)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generate backup password")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// saveBackupKey encrypts the password for every agent and activates it as the next version.
+func saveBackupKey(tenant *oceanbase.DbaObTenant, password string) (*oceanbase.BackupEncryptionKey, error) {
+	passwords, err := encryptBackupPassword(password, nil)
+	if err != nil {
+		return nil, err
+	}
+	key := &oceanbase.BackupEncryptionKey{
+		TenantID:   tenant.TenantID,
+		TenantName: tenant.TenantName,
+		Passwords:  passwords,
+	}
+	if err = tenantService.RotateBackupKey(key); err != nil {
+		return nil, errors.Wrap(err, "rotate backup key")
+	}
+	log.Infof("backup key of %s(%d) is rotated to version %d", tenant.TenantName, tenant.TenantID, key.Version)
+	return key, nil
+}
+
+// encryptBackupPassword encrypts the password with the public key of each agent which is not in the encrypted map yet,
+// so that the key is still available after the maintainer changes.
+func encryptBackupPassword(password string, encrypted map[string]string) (string, error) {
+	agents, err := agentService.GetAllAgentsInfo()
+	if err != nil {
+		return "", errors.Wrap(err, "get all agents")
+	}
+	if encrypted == nil {
+		encrypted = make(map[string]string)
+	}
+	for i := range agents {
+		if _, ok := encrypted[agents[i].String()]; ok {
+			continue
+		}
+		cipher, err := secure.EncryptForAgent(password, &agents[i])
+		if err != nil {
+			return "", errors.Wrapf(err, "encrypt backup password for %s", agents[i].String())
+		}
+		encrypted[agents[i].String()] = cipher
+	}
+	res, err := json.Marshal(encrypted)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// decryptBackupKey decrypts the password of the key with the private key of the current agent,
+// and shares the password with the agents which joined after the key was saved.
+func decryptBackupKey(key *oceanbase.BackupEncryptionKey) (string, error) {
+	encrypted := make(map[string]string)
+	if err := json.Unmarshal([]byte(key.Passwords), &encrypted); err != nil {
+		return "", errors.Wrap(err, "parse backup key")
+	}
+	self := meta.OCS_AGENT.GetAgentInfo().String()
+	cipher, ok := encrypted[self]
+	if !ok {
+		return "", errors.Occur(errors.ErrObBackupKeyUnavailable, key.Version, key.TenantName, self)
+	}
+	password, err := secure.Decrypt(cipher)
+	if err != nil {
+		return "", errors.Wrapf(err, "decrypt backup key version %d of %s", key.Version, key.TenantName)
+	}
+
+	count := len(encrypted)
+	if passwords, err := encryptBackupPassword(password, encrypted); err != nil {
+		log.WithError(err).Warnf("share backup key version %d of %s failed", key.Version, key.TenantName)
+	} else if len(encrypted) != count {
+		if err = tenantService.UpdateBackupKeyPasswords(key.Id, passwords); err != nil {
+			log.WithError(err).Warnf("share backup key version %d of %s failed", key.Version, key.TenantName)
+		}
+	}
+	return password, nil
+}
+
+// resolveBackupEncryption returns the password to encrypt the backup of the tenant and its key version.
+// The specified password is saved as a new version if it is not the active one,
+// otherwise the active key is used, and no encryption if the tenant has no key.
+func resolveBackupEncryption(tenant *oceanbase.DbaObTenant, encryption string) (string, int, error) {
+	key, err := tenantService.GetActiveBackupKey(tenant.TenantID)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "get active backup key")
+	}
+	if key != nil {
+		password, err := decryptBackupKey(key)
+		if err != nil {
+			return "", 0, err
+		}
+		if encryption == "" || encryption == password {
+			return password, key.Version, nil
+		}
+	}
+	if encryption == "" {
+		return "", 0, nil
+	}
+	if key, err = saveBackupKey(tenant, encryption); err != nil {
+		return "", 0, err
+	}
+	return encryption, key.Version, nil
+}
+
+// recordBackupSetKey records the key version of the last backup set of the tenant.
+func recordBackupSetKey(tenant *oceanbase.DbaObTenant, version int) error {
+	backupTask, err := tenantService.GetLastBackupTask(tenant.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "get last backup task")
+	}
+	if backupTask == nil {
+		return nil
+	}
+	dest, err := tenantService.GetDataBackupDestByID(tenant.TenantID)
+	if err != nil {
+		return errors.Wrapf(err, "get data dest of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	return tenantService.SaveBackupSetKey(&oceanbase.BackupSetEncryptionKey{
+		TenantID:    tenant.TenantID,
+		BackupSetID: backupTask.BackupSetID,
+		KeyVersion:  version,
+		BackupDest:  uriWithoutSecret(dest),
+	})
+}
+
+// resolveRestoreDecryption returns the stored passwords of the backup sets in the data backup uri
+// if no decryption is specified.
+func resolveRestoreDecryption(dataBackupUri string, decryption *[]string) (*[]string, error) {
+	if decryption != nil && len(*decryption) > 0 {
+		return decryption, nil
+	}
+	records, err := tenantService.ListBackupSetKeysByDest(uriWithoutSecret(dataBackupUri))
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup set keys")
+	}
+
+	passwords := make([]string, 0)
+	resolved := make(map[string]bool)
+	for _, record := range records {
+		name := fmt.Sprintf("%d-%d", record.TenantID, record.KeyVersion)
+		if resolved[name] {
+			continue
+		}
+		resolved[name] = true
+		key, err := tenantService.GetBackupKey(record.TenantID, record.KeyVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "get backup key")
+		}
+		if key == nil {
+			continue
+		}
+		password, err := decryptBackupKey(key)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, password)
+	}
+	if len(passwords) == 0 {
+		return decryption, nil
+	}
+	return &passwords, nil
+}
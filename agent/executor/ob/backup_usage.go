@@ -0,0 +1,304 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+func parseBackupDestCapacity(capacity string) (int64, error) {
+	if capacity == "" {
+		return 0, nil
+	}
+	size, ok := parse.CapacityParser(capacity)
+	if !ok {
+		return 0, errors.Occur(errors.ErrObBackupUsageCapacityInvalid, capacity)
+	}
+	return int64(size), nil
+}
+
+func GetObclusterBackupUsage(capacity string) (*param.BackupUsage, error) {
+	capacityBytes, err := parseBackupDestCapacity(capacity)
+	if err != nil {
+		return nil, err
+	}
+	return getObclusterBackupUsage(capacityBytes)
+}
+
+// GetTenantBackupUsage reports the usage of the tenant, the usage of all the
+// tenants is collected because the destinations may share the storage.
+func GetTenantBackupUsage(tenant *oceanbase.DbaObTenant, capacity string) (*param.TenantBackupUsage, error) {
+	capacityBytes, err := parseBackupDestCapacity(capacity)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := getObclusterBackupUsage(capacityBytes)
+	if err != nil {
+		return nil, err
+	}
+	for i := range usage.Tenants {
+		if usage.Tenants[i].TenantID == tenant.TenantID {
+			return &usage.Tenants[i], nil
+		}
+	}
+	tenantUsage, err := getTenantBackupUsage(tenant)
+	if err != nil {
+		return nil, err
+	}
+	estimateBackupStorageCapacity(backupDestsOf(*tenantUsage), capacityBytes)
+	return tenantUsage, nil
+}
+
+func getObclusterBackupUsage(capacityBytes int64) (*param.BackupUsage, error) {
+	tenants, err := tenantService.GetAllUserTenants()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &param.BackupUsage{
+		Tenants: make([]param.TenantBackupUsage, 0),
+	}
+	for i := range tenants {
+		tenantUsage, err := getTenantBackupUsage(&tenants[i])
+		if err != nil {
+			return nil, err
+		}
+		usage.Tenants = append(usage.Tenants, *tenantUsage)
+	}
+
+	estimateBackupStorageCapacity(backupDestsOf(usage.Tenants...), capacityBytes)
+	return usage, nil
+}
+
+func backupDestsOf(tenants ...param.TenantBackupUsage) []*param.BackupDestUsage {
+	dests := make([]*param.BackupDestUsage, 0)
+	for _, tenant := range tenants {
+		for _, dest := range []*param.BackupDestUsage{tenant.DataDest, tenant.ArchiveDest} {
+			if dest != nil {
+				dests = append(dests, dest)
+			}
+		}
+	}
+	return dests
+}
+
+// getTenantBackupUsage reports the usage of the destinations of the tenant,
+// the capacity is estimated later with the destinations on the same storage.
+func getTenantBackupUsage(tenant *oceanbase.DbaObTenant) (*param.TenantBackupUsage, error) {
+	since := time.Now().AddDate(0, 0, -constant.BACKUP_USAGE_TREND_DAYS)
+	tasks, err := tenantService.ListBackupTaskHistory(tenant.TenantID, since)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list backup tasks of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	backupSets, err := tenantService.ListBackupSetFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list backup sets of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	pieces, err := tenantService.ListArchivelogPieceFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list archive pieces of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+
+	usage := &param.TenantBackupUsage{
+		TenantID:      tenant.TenantID,
+		TenantName:    tenant.TenantName,
+		BackupTasks:   make([]param.BackupTaskThroughput, 0),
+		ArchivePieces: make([]param.ArchivePieceThroughput, 0),
+	}
+	for _, t := range tasks {
+		usage.BackupTasks = append(usage.BackupTasks, newBackupTaskThroughput(t))
+	}
+	for _, p := range pieces {
+		usage.ArchivePieces = append(usage.ArchivePieces, newArchivePieceThroughput(p))
+	}
+
+	dataDest, err := tenantService.GetDataBackupDestByID(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get data backup dest of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	if dataDest != "" {
+		var catalogBytes, growthBytes int64
+		for _, set := range backupSets {
+			catalogBytes += set.OutputBytes
+			if set.StartTimestamp.After(since) {
+				growthBytes += set.OutputBytes
+			}
+		}
+		usage.DataDest = newBackupDestUsage(dataDest, catalogBytes, growthBytes)
+	}
+
+	archiveDest, err := tenantService.GetArchiveDestByID(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get archive dest of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	if archiveDest != "" {
+		var catalogBytes, growthBytes int64
+		for _, piece := range usage.ArchivePieces {
+			catalogBytes += piece.OutputBytes
+			growthBytes += archivePieceGrowthSince(piece, since)
+		}
+		usage.ArchiveDest = newBackupDestUsage(archiveDest, catalogBytes, growthBytes)
+	}
+	return usage, nil
+}
+
+func newBackupTaskThroughput(t oceanbase.CdbObBackupTask) param.BackupTaskThroughput {
+	throughput := param.BackupTaskThroughput{
+		TaskID:          t.TaskID,
+		JobID:           t.JobID,
+		BackupSetID:     t.BackupSetID,
+		Status:          t.Status,
+		StartTimestamp:  t.StartTimestamp,
+		EndTimestamp:    t.EndTimestamp,
+		OutputBytes:     t.OutputBytes,
+		OutputRateBytes: int64(t.OutputRateBytes),
+	}
+	end := t.EndTimestamp
+	if end.IsZero() || end.Before(t.StartTimestamp) {
+		end = time.Now()
+	}
+	throughput.DurationSeconds = int64(end.Sub(t.StartTimestamp).Seconds())
+	if throughput.OutputRateBytes == 0 && throughput.DurationSeconds > 0 {
+		throughput.OutputRateBytes = t.OutputBytes / throughput.DurationSeconds
+	}
+	return throughput
+}
+
+func newArchivePieceThroughput(p oceanbase.CdbObArchivelogPieceFile) param.ArchivePieceThroughput {
+	throughput := param.ArchivePieceThroughput{
+		RoundID:        p.RoundID,
+		PieceID:        p.PieceID,
+		Status:         p.Status,
+		StartTimestamp: p.StartScnDisplay,
+		CheckpointTime: p.CheckpointScnDisplay,
+		OutputBytes:    p.OutputBytes,
+	}
+	if seconds := int64(p.CheckpointScnDisplay.Sub(p.StartScnDisplay).Seconds()); seconds > 0 {
+		throughput.OutputRateBytes = p.OutputBytes / seconds
+	}
+	return throughput
+}
+
+// archivePieceGrowthSince estimates how many bytes of the piece were written
+// after since, assuming the piece was written at a steady rate.
+func archivePieceGrowthSince(piece param.ArchivePieceThroughput, since time.Time) int64 {
+	if !piece.CheckpointTime.After(since) {
+		return 0
+	}
+	if !piece.StartTimestamp.Before(since) {
+		return piece.OutputBytes
+	}
+	return piece.OutputRateBytes * int64(piece.CheckpointTime.Sub(since).Seconds())
+}
+
+// newBackupDestUsage walks the destination on the file system. OceanBase hides
+// the credentials of object storage in the dest views, so the usage of object
+// storage is only summed from the backup catalog.
+func newBackupDestUsage(dest string, catalogBytes, growthBytes int64) *param.BackupDestUsage {
+	usage := &param.BackupDestUsage{
+		Path:             strings.SplitN(dest, "?", 2)[0],
+		Storage:          backupDestStorage(dest),
+		Source:           constant.BACKUP_USAGE_SOURCE_CATALOG,
+		CatalogBytes:     catalogBytes,
+		DailyGrowthBytes: growthBytes / constant.BACKUP_USAGE_TREND_DAYS,
+	}
+	usage.UsedBytes = catalogBytes
+	if idx := strings.Index(dest, "://"); idx > 0 {
+		usage.StorageType = dest[:idx]
+	}
+	if !strings.HasPrefix(dest, constant.PREFIX_FILE) {
+		return usage
+	}
+
+	storageUsage, err := getBackupDestStorageUsage(dest)
+	if err != nil {
+		log.Warnf("get storage usage of '%s' failed: %v", usage.Path, err)
+		usage.Error = err.Error()
+	} else {
+		usage.Source = constant.BACKUP_USAGE_SOURCE_STORAGE
+		usage.StorageUsage = *storageUsage
+	}
+	return usage
+}
+
+// backupDestStorage identifies the physical storage of the destination, that is
+// the file system of nfs and the bucket of object storage.
+func backupDestStorage(dest string) string {
+	path, query, _ := strings.Cut(dest, "?")
+	if strings.HasPrefix(path, constant.PREFIX_FILE) {
+		if _, fsid, err := system.GetFsId(strings.TrimPrefix(path, constant.PREFIX_FILE)); err == nil {
+			return fmt.Sprintf("%sfsid%v", constant.PREFIX_FILE, fsid)
+		}
+		return path
+	}
+	scheme, rest, _ := strings.Cut(path, "://")
+	bucket, _, _ := strings.Cut(rest, "/")
+	storage := fmt.Sprintf("%s://%s", scheme, bucket)
+	if values, err := url.ParseQuery(query); err == nil && values.Get("host") != "" {
+		storage += "?host=" + values.Get("host")
+	}
+	return storage
+}
+
+// estimateBackupStorageCapacity estimates the availability of the destinations,
+// the used bytes and the growth of the destinations on the same storage are
+// summed up since they share the capacity.
+func estimateBackupStorageCapacity(dests []*param.BackupDestUsage, capacityBytes int64) {
+	usedBytes := make(map[string]int64)
+	growthBytes := make(map[string]int64)
+	for _, dest := range dests {
+		usedBytes[dest.Storage] += dest.UsedBytes
+		growthBytes[dest.Storage] += dest.DailyGrowthBytes
+	}
+
+	for _, dest := range dests {
+		dest.StorageUsedBytes = usedBytes[dest.Storage]
+		dest.StorageDailyGrowthBytes = growthBytes[dest.Storage]
+		if capacityBytes > 0 {
+			dest.TotalBytes = capacityBytes
+			dest.AvailableBytes = capacityBytes - dest.StorageUsedBytes
+			if dest.AvailableBytes < 0 {
+				dest.AvailableBytes = 0
+			}
+		}
+		// The available bytes of the file system are already shared by the destinations on it.
+		if dest.TotalBytes > 0 && dest.StorageDailyGrowthBytes > 0 {
+			days := float64(dest.AvailableBytes) / float64(dest.StorageDailyGrowthBytes)
+			dest.DaysUntilFull = &days
+		}
+	}
+}
+
+func getBackupDestStorageUsage(dest string) (*system.StorageUsage, error) {
+	storage, err := system.GetStorageInterfaceByURI(dest)
+	if err != nil {
+		return nil, err
+	}
+	return storage.GetUsage()
+}
@@ -0,0 +1,451 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ammodels "github.com/prometheus/alertmanager/api/v2/models"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/coordinator"
+	"github.com/oceanbase/obshell/agent/errors"
+	alarmconstant "github.com/oceanbase/obshell/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/secure"
+	configservice "github.com/oceanbase/obshell/agent/service/config"
+	modelalarm "github.com/oceanbase/obshell/model/alarm"
+	modelob "github.com/oceanbase/obshell/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+var clockSkewWatcher = &ClockSkewWatcher{
+	history: make(map[string][]param.ClockSkewSample),
+	alerts:  newAlertTracker("clock skew", constant.CLOCK_SKEW_CHECK_INTERVAL),
+}
+
+// ClockSkewWatcher runs on the maintainer agent, it samples the clock offset and
+// the round-trip time of all the agents periodically through the time api,
+// keeps the history of the samples and raises alarms through alertmanager.
+type ClockSkewWatcher struct {
+	lock     sync.Mutex
+	overview *param.ClockSkewOverview
+	history  map[string][]param.ClockSkewSample
+	alerts   *alertTracker
+}
+
+func GetClockSkewWatcherConfig() (*param.ClockSkewWatcherConfig, error) {
+	cfg := &param.ClockSkewWatcherConfig{
+		Enabled:        true,
+		WarnThreshold:  constant.CLOCK_SKEW_WARN_THRESHOLD_DEFAULT,
+		BlockThreshold: constant.CLOCK_SKEW_BLOCK_THRESHOLD_DEFAULT,
+	}
+	ocsConfig, err := configservice.GetOcsConfig(constant.CLOCK_SKEW_WATCHER_CONFIG_KEY)
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrConfigGetFailed, err, constant.CLOCK_SKEW_WATCHER_CONFIG_KEY, err.Error())
+	}
+	if ocsConfig == nil {
+		return cfg, nil
+	}
+	if err = json.Unmarshal([]byte(ocsConfig.Value), cfg); err != nil {
+		return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	return cfg, nil
+}
+
+func SetClockSkewWatcherConfig(p *param.ClockSkewWatcherConfigParam) (*param.ClockSkewWatcherConfig, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	cfg, err := GetClockSkewWatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+	if p.Enabled != nil {
+		cfg.Enabled = *p.Enabled
+	}
+	if p.WarnThreshold != nil {
+		cfg.WarnThreshold = *p.WarnThreshold
+	}
+	if p.BlockThreshold != nil {
+		cfg.BlockThreshold = *p.BlockThreshold
+	}
+	warn, block, err := parseClockSkewThresholds(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if warn > block {
+		return nil, errors.Occur(errors.ErrObClusterClockSkewThresholdInvalid, cfg.WarnThreshold, "warn threshold should not be greater than block threshold "+cfg.BlockThreshold)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	if err = configservice.SaveOcsConfig(constant.CLOCK_SKEW_WATCHER_CONFIG_KEY, string(data), "Clock skew watcher configuration"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func parseClockSkewThresholds(cfg *param.ClockSkewWatcherConfig) (warn time.Duration, block time.Duration, err error) {
+	if warn, err = system.ParseTime(cfg.WarnThreshold); err != nil {
+		return 0, 0, errors.Occur(errors.ErrObClusterClockSkewThresholdInvalid, cfg.WarnThreshold, err.Error())
+	}
+	if block, err = system.ParseTime(cfg.BlockThreshold); err != nil {
+		return 0, 0, errors.Occur(errors.ErrObClusterClockSkewThresholdInvalid, cfg.BlockThreshold, err.Error())
+	}
+	return warn, block, nil
+}
+
+// GetClockSkewOverview returns the latest clock skew of all the agents measured by the maintainer,
+// the clock skew will be measured right now if refresh is true or it has never been measured.
+// The request is sent to the maintainer if the current agent is not the maintainer.
+func GetClockSkewOverview(refresh bool) (*param.ClockSkewOverview, error) {
+	maintainer, err := getClockSkewMaintainer()
+	if err != nil {
+		return nil, err
+	}
+	if maintainer != nil {
+		var overview param.ClockSkewOverview
+		uri := fmt.Sprintf("%s%s?refresh=%t", constant.URI_OBCLUSTER_API_PREFIX, constant.URI_CLOCK_SKEW, refresh)
+		if err := secure.SendGetRequest(maintainer, uri, nil, &overview); err != nil {
+			return nil, errors.Wrapf(err, "get clock skew from maintainer %s failed", maintainer.String())
+		}
+		return &overview, nil
+	}
+	if !refresh {
+		if overview := clockSkewWatcher.getOverview(); overview != nil {
+			return overview, nil
+		}
+	}
+	return clockSkewWatcher.check()
+}
+
+// GetClockSkewHistory returns the history of the clock skew samples of the agent,
+// or all the agents if agent is empty.
+func GetClockSkewHistory(agent string) ([]param.ClockSkewHistory, error) {
+	maintainer, err := getClockSkewMaintainer()
+	if err != nil {
+		return nil, err
+	}
+	if maintainer != nil {
+		var history []param.ClockSkewHistory
+		uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_CLOCK_SKEW + constant.URI_HISTORY
+		if agent != "" {
+			uri += "?agent=" + url.QueryEscape(agent)
+		}
+		if err := secure.SendGetRequest(maintainer, uri, nil, &history); err != nil {
+			return nil, errors.Wrapf(err, "get clock skew history from maintainer %s failed", maintainer.String())
+		}
+		return history, nil
+	}
+	return clockSkewWatcher.getHistory(agent), nil
+}
+
+// getClockSkewMaintainer returns the maintainer if it is another agent, or nil if the current agent is the maintainer.
+func getClockSkewMaintainer() (meta.AgentInfoInterface, error) {
+	if coordinator.OCS_COORDINATOR == nil || coordinator.OCS_COORDINATOR.IsFaulty() || !coordinator.OCS_COORDINATOR.HasMaintainer() {
+		return nil, errors.Occur(errors.ErrAgentMaintainerNotActive)
+	}
+	if coordinator.OCS_COORDINATOR.IsMaintainer() {
+		return nil, nil
+	}
+	return coordinator.OCS_COORDINATOR.Maintainer, nil
+}
+
+// CheckClockSkewForOperation blocks the risky operation if the clock offset of any agent in the cluster,
+// or any of the agents to be added by the operation, exceeds the block threshold.
+// The operation is not blocked if the clock skew could not be measured.
+func CheckClockSkewForOperation(operation string, newAgents ...meta.AgentInfoInterface) error {
+	cfg, err := GetClockSkewWatcherConfig()
+	if err != nil {
+		return err
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	_, block, err := parseClockSkewThresholds(cfg)
+	if err != nil {
+		return err
+	}
+	overview, err := GetClockSkewOverview(false)
+	if err != nil {
+		log.WithError(err).Warnf("get clock skew failed, skip the clock skew check for %s", operation)
+		return nil
+	}
+
+	var selfOffset float64
+	for _, status := range overview.Agents {
+		if status.Level == constant.CLOCK_SKEW_LEVEL_CRITICAL {
+			return errors.Occur(errors.ErrObClusterClockSkewTooHigh, status.Agent, formatClockOffset(status.OffsetMs), cfg.BlockThreshold, operation)
+		}
+		if status.Agent == meta.OCS_AGENT.String() {
+			selfOffset = status.OffsetMs
+		}
+	}
+	// The offset of the new agent to the maintainer is measured through the current agent.
+	for _, agent := range newAgents {
+		sample := sampleClockOffset(agent)
+		if sample.Error != "" {
+			log.Warnf("sample clock offset of %s failed: %s, skip the clock skew check for it", agent.String(), sample.Error)
+			continue
+		}
+		offset := selfOffset + sample.OffsetMs
+		if math.Abs(offset) >= durationToMs(block) {
+			return errors.Occur(errors.ErrObClusterClockSkewTooHigh, agent.String(), formatClockOffset(offset), cfg.BlockThreshold, operation)
+		}
+	}
+	return nil
+}
+
+// FormatClockSkewMetrics renders the clock skew overview in prometheus text format.
+func FormatClockSkewMetrics(overview *param.ClockSkewOverview) string {
+	var b strings.Builder
+	b.WriteString("# HELP obshell_clock_offset_seconds Clock offset of the agent to the maintainer agent.\n")
+	b.WriteString("# TYPE obshell_clock_offset_seconds gauge\n")
+	for _, status := range overview.Agents {
+		if status.Error == "" {
+			fmt.Fprintf(&b, "obshell_clock_offset_seconds{agent=%q,%s=%q} %g\n", status.Agent, alarmconstant.LabelOBZone, status.Zone, status.OffsetMs/1000)
+		}
+	}
+	b.WriteString("# HELP obshell_clock_rtt_seconds Round-trip time from the maintainer agent to the agent.\n")
+	b.WriteString("# TYPE obshell_clock_rtt_seconds gauge\n")
+	for _, status := range overview.Agents {
+		if status.Error == "" {
+			fmt.Fprintf(&b, "obshell_clock_rtt_seconds{agent=%q,%s=%q} %g\n", status.Agent, alarmconstant.LabelOBZone, status.Zone, status.RttMs/1000)
+		}
+	}
+	b.WriteString("# HELP obshell_clock_skew_alarming Whether the clock offset of the agent exceeds the warn threshold.\n")
+	b.WriteString("# TYPE obshell_clock_skew_alarming gauge\n")
+	for _, status := range overview.Agents {
+		alarming := 0
+		if status.Level == constant.CLOCK_SKEW_LEVEL_WARN || status.Level == constant.CLOCK_SKEW_LEVEL_CRITICAL {
+			alarming = 1
+		}
+		fmt.Fprintf(&b, "obshell_clock_skew_alarming{agent=%q,%s=%q} %d\n", status.Agent, alarmconstant.LabelOBZone, status.Zone, alarming)
+	}
+	b.WriteString("# HELP obshell_clock_max_skew_seconds Max clock difference between any two agents.\n")
+	b.WriteString("# TYPE obshell_clock_max_skew_seconds gauge\n")
+	fmt.Fprintf(&b, "obshell_clock_max_skew_seconds %g\n", overview.MaxSkewMs/1000)
+	return b.String()
+}
+
+// sampleClockOffset probes the time api of the agent several times, and keeps the probe
+// with the least round-trip time, whose offset is the most accurate.
+func sampleClockOffset(agent meta.AgentInfoInterface) param.ClockSkewSample {
+	sample := param.ClockSkewSample{SampleTime: time.Now()}
+	if meta.OCS_AGENT.Equal(agent) {
+		return sample
+	}
+	var lastErr error
+	rtt := time.Duration(math.MaxInt64)
+	for i := 0; i < constant.CLOCK_SKEW_PROBE_TIMES; i++ {
+		start := time.Now()
+		remote, err := getAgentTime(agent)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		end := time.Now()
+		if end.Sub(start) < rtt {
+			rtt = end.Sub(start)
+			sample.RttMs = durationToMs(rtt)
+			sample.OffsetMs = durationToMs(remote.Sub(start.Add(rtt / 2)))
+		}
+	}
+	if rtt == time.Duration(math.MaxInt64) {
+		sample.Error = lastErr.Error()
+	}
+	return sample
+}
+
+// getAgentTime gets the current time of the agent through the time api.
+// The data of the response is a time rather than an object, so the body is parsed here.
+func getAgentTime(agent meta.AgentInfoInterface) (time.Time, error) {
+	resp, err := http.SendGetRequestAndReturnResponse(agent, constant.URI_API_V1+constant.URI_TIME, nil, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if resp.IsError() {
+		return time.Time{}, errors.Occur(errors.ErrAgentRPCRequestError, http.GET, constant.URI_API_V1+constant.URI_TIME, agent.String(), resp.String())
+	}
+	var body struct {
+		Data time.Time `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body(), &body); err != nil {
+		return time.Time{}, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	return body.Data, nil
+}
+
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func formatClockOffset(offsetMs float64) string {
+	return fmt.Sprintf("%.3fms", offsetMs)
+}
+
+func (w *ClockSkewWatcher) check() (*param.ClockSkewOverview, error) {
+	cfg, err := GetClockSkewWatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+	warn, block, err := parseClockSkewThresholds(cfg)
+	if err != nil {
+		return nil, err
+	}
+	overview := &param.ClockSkewOverview{
+		Config:     *cfg,
+		Maintainer: meta.OCS_AGENT.String(),
+		CheckTime:  time.Now(),
+		Agents:     make([]param.ClockSkewStatus, 0),
+	}
+	if cfg.Enabled {
+		agents, err := agentService.GetAllAgentsDO()
+		if err != nil {
+			return nil, errors.Wrap(err, "get all agents failed")
+		}
+		minOffset, maxOffset := 0.0, 0.0
+		for _, agent := range agents {
+			agentInfo := meta.NewAgentInfo(agent.Ip, agent.Port)
+			status := param.ClockSkewStatus{
+				Agent:           agentInfo.String(),
+				Zone:            agent.Zone,
+				ClockSkewSample: sampleClockOffset(agentInfo),
+				Level:           constant.CLOCK_SKEW_LEVEL_OK,
+			}
+			offset := math.Abs(status.OffsetMs)
+			switch {
+			case status.Error != "":
+				status.Level = constant.CLOCK_SKEW_LEVEL_UNKNOWN
+				status.Reason = "failed to sample the clock offset"
+			case offset >= durationToMs(block):
+				status.Level = constant.CLOCK_SKEW_LEVEL_CRITICAL
+				status.Reason = fmt.Sprintf("clock offset %s exceeds the block threshold %s", formatClockOffset(status.OffsetMs), cfg.BlockThreshold)
+			case offset >= durationToMs(warn):
+				status.Level = constant.CLOCK_SKEW_LEVEL_WARN
+				status.Reason = fmt.Sprintf("clock offset %s exceeds the warn threshold %s", formatClockOffset(status.OffsetMs), cfg.WarnThreshold)
+			}
+			if status.Error == "" {
+				minOffset = math.Min(minOffset, status.OffsetMs)
+				maxOffset = math.Max(maxOffset, status.OffsetMs)
+			}
+			overview.Agents = append(overview.Agents, status)
+		}
+		overview.MaxSkewMs = maxOffset - minOffset
+		sort.Slice(overview.Agents, func(i, j int) bool {
+			return overview.Agents[i].Agent < overview.Agents[j].Agent
+		})
+	}
+	clusterName, err := getClusterName()
+	if err != nil {
+		log.WithError(err).Warn("get cluster name failed")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	history := make(map[string][]param.ClockSkewSample, len(overview.Agents))
+	for _, status := range overview.Agents {
+		samples := append(w.history[status.Agent], status.ClockSkewSample)
+		if len(samples) > constant.CLOCK_SKEW_HISTORY_SIZE {
+			samples = samples[len(samples)-constant.CLOCK_SKEW_HISTORY_SIZE:]
+		}
+		history[status.Agent] = samples
+	}
+	w.history = history
+	w.overview = overview
+	w.postAlerts(clusterName, overview)
+	return overview, nil
+}
+
+func (w *ClockSkewWatcher) postAlerts(clusterName string, overview *param.ClockSkewOverview) {
+	firing := make(map[string]*ammodels.PostableAlert)
+	for i := range overview.Agents {
+		status := &overview.Agents[i]
+		if status.Level != constant.CLOCK_SKEW_LEVEL_WARN && status.Level != constant.CLOCK_SKEW_LEVEL_CRITICAL {
+			continue
+		}
+		log.Warnf("agent %s %s", status.Agent, status.Reason)
+		firing[status.Agent] = newClockSkewAlert(clusterName, overview.Maintainer, status)
+	}
+	w.alerts.update(firing)
+}
+
+func newClockSkewAlert(clusterName string, maintainer string, status *param.ClockSkewStatus) *ammodels.PostableAlert {
+	rule := constant.CLOCK_SKEW_ALARM_RULE_WARN
+	severity := modelalarm.SeverityWarning
+	if status.Level == constant.CLOCK_SKEW_LEVEL_CRITICAL {
+		rule = constant.CLOCK_SKEW_ALARM_RULE_CRITICAL
+		severity = modelalarm.SeverityCritical
+	}
+	ip := status.Agent
+	if agentInfo, err := meta.ConvertAddressToAgentInfo(status.Agent); err == nil {
+		ip = agentInfo.Ip
+	}
+	return &ammodels.PostableAlert{
+		Annotations: ammodels.LabelSet{
+			alarmconstant.AnnoSummary:     fmt.Sprintf("Clock of agent %s is skewed", status.Agent),
+			alarmconstant.AnnoDescription: fmt.Sprintf("Clock of agent %s is skewed to the maintainer %s, %s, round-trip time %.3fms", status.Agent, maintainer, status.Reason, status.RttMs),
+		},
+		Alert: ammodels.Alert{
+			Labels: ammodels.LabelSet{
+				alarmconstant.LabelRuleName:     rule,
+				alarmconstant.LabelSeverity:     string(severity),
+				alarmconstant.LabelInstanceType: string(modelob.TypeOBServer),
+				alarmconstant.LabelOBCluster:    clusterName,
+				alarmconstant.LabelOBZone:       status.Zone,
+				alarmconstant.LabelOBServer:     ip,
+			},
+		},
+	}
+}
+
+func (w *ClockSkewWatcher) getOverview() *param.ClockSkewOverview {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.overview
+}
+
+func (w *ClockSkewWatcher) getHistory(agent string) []param.ClockSkewHistory {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	history := make([]param.ClockSkewHistory, 0, len(w.history))
+	for name, samples := range w.history {
+		if agent == "" || agent == name {
+			history = append(history, param.ClockSkewHistory{
+				Agent:   name,
+				Samples: append([]param.ClockSkewSample(nil), samples...),
+			})
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Agent < history[j].Agent
+	})
+	return history
+}
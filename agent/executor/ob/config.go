@@ -19,6 +19,7 @@ package ob
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -90,6 +91,38 @@ func CreateUpdateOBServerConfigDag(params param.ObServerConfigParams, deleteAll
 	return task.NewDagDetailDTO(dag), nil
 }
 
+// createUpdateOBServerConfigsDag updates the server configs of several agents in one dag,
+// the configs of each agent are given by the context of its own node.
+func createUpdateOBServerConfigsDag(configs map[string]map[string]string) (*task.DagDetailDTO, error) {
+	agents := make([]string, 0, len(configs))
+	for agent := range configs {
+		agents = append(agents, agent)
+	}
+	sort.Strings(agents)
+
+	builder := task.NewTemplateBuilder(TASK_NAME_UPDATE_OB_CONFIG).SetMaintenance(task.GlobalMaintenance())
+	for _, agent := range agents {
+		params := param.ObServerConfigParams{
+			ObServerConfig: configs[agent],
+			Scope: param.Scope{
+				Type:   SCOPE_SERVER,
+				Target: []string{agent},
+			},
+		}
+		if err := paramToConfig(params.ObServerConfig); err != nil {
+			return nil, err
+		}
+		builder.AddNode(task.NewNodeWithContext(newUpdateOBServerConfigTask(), false, task.NewTaskContext().SetParam(PARAM_CONFIG, params)))
+	}
+
+	ctx := task.NewTaskContext().SetParam(PARAM_DELETE_ALL, false)
+	dag, err := localTaskService.CreateDagInstanceByTemplate(builder.Build(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
 func paramToConfig(obServerConfig map[string]string) error {
 	// Check if both mysql_porth and mysqlPort are set.
 	for k, v := range constant.OB_CONFIG_COMPATIBLE_MAP {
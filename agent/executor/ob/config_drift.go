@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/sqlite"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+var configDriftWatcher = &ConfigDriftWatcher{}
+
+// ConfigDriftWatcher checks periodically whether the observer configs persisted in sqlite,
+// which are used to start or take over the observers, are still the same as the live parameters.
+// The configs may go stale once the parameters are changed by 'ALTER SYSTEM' directly.
+type ConfigDriftWatcher struct {
+	lock   sync.Mutex
+	report *bo.ConfigDriftReport
+}
+
+type persistedConfig struct {
+	level string
+	value string
+}
+
+func (w *ConfigDriftWatcher) check() (*bo.ConfigDriftReport, error) {
+	report, err := checkConfigDrift()
+	if err != nil {
+		return nil, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	known := make(map[bo.ConfigDrift]bool)
+	if w.report != nil {
+		for _, drift := range w.report.Drifts {
+			known[drift] = true
+		}
+	}
+	for _, drift := range report.Drifts {
+		if !known[drift] {
+			log.Warnf("config '%s' of %s drifts, persisted in %s level: '%s', live: '%s'", drift.Name, drift.Server, drift.Level, drift.PersistedValue, drift.LiveValue)
+		}
+	}
+	w.report = report
+	return report, nil
+}
+
+func (w *ConfigDriftWatcher) getReport() *bo.ConfigDriftReport {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.report
+}
+
+// GetConfigDriftReport returns the report of the latest check,
+// the drift will be checked right now if refresh is true or it has never been checked.
+func GetConfigDriftReport(refresh bool) (*bo.ConfigDriftReport, error) {
+	if !refresh {
+		if report := configDriftWatcher.getReport(); report != nil {
+			return report, nil
+		}
+	}
+	return configDriftWatcher.check()
+}
+
+// checkConfigDrift compares the configs of each observer persisted in sqlite with the live parameters.
+// The server config overrides the zone config, and the zone config overrides the global config.
+// The configs which are not parameters of observer, such as the cluster configs, are ignored.
+func checkConfigDrift() (*bo.ConfigDriftReport, error) {
+	report := &bo.ConfigDriftReport{
+		CheckTime: time.Now(),
+		Drifts:    make([]bo.ConfigDrift, 0),
+	}
+	agents, err := agentService.GetAllAgentsDO()
+	if err != nil {
+		return nil, errors.Wrap(err, "get all agents failed")
+	}
+	globalConfigs, err := observerService.GetObGlobalConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "get global config failed")
+	}
+
+	zoneConfigs := make(map[string][]sqlite.ObZoneConfig)
+	for _, agent := range agents {
+		if agent.RpcPort == 0 {
+			continue
+		}
+		agentInfo := meta.NewAgentInfo(agent.Ip, agent.Port)
+		configs := make(map[string]persistedConfig)
+		for _, config := range globalConfigs {
+			if !config.IsCluster {
+				configs[normalizeConfigName(config.Name)] = persistedConfig{level: constant.CONFIG_LEVEL_GLOBAL, value: config.Value}
+			}
+		}
+		if _, ok := zoneConfigs[agent.Zone]; !ok {
+			if zoneConfigs[agent.Zone], err = observerService.GetObZoneConfig(agent.Zone); err != nil {
+				return nil, errors.Wrapf(err, "get config of zone %s failed", agent.Zone)
+			}
+		}
+		for _, config := range zoneConfigs[agent.Zone] {
+			configs[normalizeConfigName(config.Name)] = persistedConfig{level: constant.CONFIG_LEVEL_ZONE, value: config.Value}
+		}
+		serverConfigs, err := observerService.GetObServerConfig(agentInfo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get config of %s failed", agentInfo.String())
+		}
+		for _, config := range serverConfigs {
+			configs[normalizeConfigName(config.Name)] = persistedConfig{level: constant.CONFIG_LEVEL_SERVER, value: config.Value}
+		}
+		if len(configs) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(configs))
+		for name := range configs {
+			names = append(names, name)
+		}
+		params, err := observerService.GetServerParameters(agent.Ip, agent.RpcPort, names)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get parameters of %s failed", meta.NewAgentInfo(agent.Ip, agent.RpcPort).String())
+		}
+		for _, p := range params {
+			config := configs[p.Name]
+			if parse.ConfigValueEqual(config.value, p.Value) {
+				continue
+			}
+			report.Drifts = append(report.Drifts, bo.ConfigDrift{
+				Level:          config.level,
+				Name:           p.Name,
+				Zone:           agent.Zone,
+				Agent:          agentInfo.String(),
+				Server:         meta.NewAgentInfo(p.SvrIp, p.SvrPort).String(),
+				PersistedValue: config.value,
+				LiveValue:      p.Value,
+			})
+		}
+	}
+
+	sort.Slice(report.Drifts, func(i, j int) bool {
+		if report.Drifts[i].Server != report.Drifts[j].Server {
+			return report.Drifts[i].Server < report.Drifts[j].Server
+		}
+		return report.Drifts[i].Name < report.Drifts[j].Name
+	})
+	return report, nil
+}
+
+// normalizeConfigName converts the compatible config name such as 'mysqlPort' to the parameter name.
+func normalizeConfigName(name string) string {
+	for k, v := range constant.OB_CONFIG_COMPATIBLE_MAP {
+		if name == v {
+			return k
+		}
+	}
+	return name
+}
+
+// ConfigDriftReconciliation is the result of a reconciliation. For LIVE_TO_PERSISTED,
+// the live values are persisted by the dag asynchronously.
+type ConfigDriftReconciliation struct {
+	Reconciled []bo.ConfigDrift   `json:"reconciled"`
+	Dag        *task.DagDetailDTO `json:"dag,omitempty"`
+}
+
+// ReconcileConfigDrift checks the drift right now and reconciles the drifts matching the param.
+// For PERSISTED_TO_LIVE, the parameter is set to the persisted value on the observer.
+// For LIVE_TO_PERSISTED, the live value is persisted as the server config of the observer,
+// so that the other observers sharing the zone or global config are not affected.
+func ReconcileConfigDrift(p *param.ReconcileConfigDriftParam, operator string) (*ConfigDriftReconciliation, error) {
+	direction := strings.ToUpper(p.Direction)
+	if direction != constant.CONFIG_DRIFT_PERSISTED_TO_LIVE && direction != constant.CONFIG_DRIFT_LIVE_TO_PERSISTED {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "direction",
+			"direction should be "+constant.CONFIG_DRIFT_PERSISTED_TO_LIVE+" or "+constant.CONFIG_DRIFT_LIVE_TO_PERSISTED)
+	}
+	report, err := configDriftWatcher.check()
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := make([]bo.ConfigDrift, 0)
+	for _, drift := range report.Drifts {
+		if len(p.Names) != 0 && !utils.ContainsString(p.Names, drift.Name) {
+			continue
+		}
+		if len(p.Servers) != 0 && !utils.ContainsString(p.Servers, drift.Server) {
+			continue
+		}
+		drifts = append(drifts, drift)
+	}
+	if direction == constant.CONFIG_DRIFT_LIVE_TO_PERSISTED {
+		return reconcileConfigDriftToPersisted(drifts)
+	}
+
+	res := &ConfigDriftReconciliation{Reconciled: make([]bo.ConfigDrift, 0)}
+	for _, drift := range drifts {
+		if err = reconcileConfigDriftToLive(drift, operator); err != nil {
+			return res, errors.Wrapf(err, "reconcile config '%s' of %s failed", drift.Name, drift.Server)
+		}
+		res.Reconciled = append(res.Reconciled, drift)
+	}
+	if len(res.Reconciled) != 0 {
+		if _, err := configDriftWatcher.check(); err != nil {
+			log.WithError(err).Warn("check config drift after reconciliation failed")
+		}
+	}
+	return res, nil
+}
+
+func reconcileConfigDriftToLive(drift bo.ConfigDrift, operator string) error {
+	setParameterParam := param.SetParameterParam{
+		Name:   drift.Name,
+		Value:  drift.PersistedValue,
+		Server: drift.Server,
+	}
+	changes, err := buildObclusterParameterChanges(constant.PARAMETER_SCOPE_CLUSTER, setParameterParam, operator)
+	if err != nil {
+		return err
+	}
+	if err := obclusterService.SetParameter(setParameterParam); err != nil {
+		return err
+	}
+	obclusterService.RecordParameterChanges(changes)
+	return nil
+}
+
+// reconcileConfigDriftToPersisted persists the live values through the dag updating the server configs.
+func reconcileConfigDriftToPersisted(drifts []bo.ConfigDrift) (*ConfigDriftReconciliation, error) {
+	res := &ConfigDriftReconciliation{Reconciled: drifts}
+	if len(drifts) == 0 {
+		return res, nil
+	}
+	configs := make(map[string]map[string]string)
+	for _, drift := range drifts {
+		if _, ok := configs[drift.Agent]; !ok {
+			configs[drift.Agent] = make(map[string]string)
+		}
+		configs[drift.Agent][drift.Name] = drift.LiveValue
+	}
+	dag, err := createUpdateOBServerConfigsDag(configs)
+	if err != nil {
+		return nil, err
+	}
+	res.Dag = dag
+	return res, nil
+}
@@ -31,8 +31,8 @@ const (
 	SYS_STAT_MAX_CPU_STAT_ID      = 140005
 	SYS_STAT_CPU_USAGE_STAT_ID    = 140006
 
-	PARAMETER_SCOPE_TENANT  = "TENANT"
-	PARAMETER_SCOPE_CLUSTER = "CLUSTER"
+	PARAMETER_SCOPE_TENANT  = constant.PARAMETER_SCOPE_TENANT
+	PARAMETER_SCOPE_CLUSTER = constant.PARAMETER_SCOPE_CLUSTER
 
 	// task context key
 	PARAM_CONFIG     = "config"
@@ -45,10 +45,14 @@ const (
 	PARAM_UNRS       = "unRs" // PARAM_UNRS is a map, key is zone, value is servers that not in rs
 	PARAM_DELETE_ALL = "deleteAll"
 	// for scale out
-	PARAM_IS_NEW_ZONE          = "isNewZone"
-	PARAM_AGENT_INFO           = "agentInfo"
 	PARAM_SCALE_OUT_UUID       = "scaleOutUUID"
 	PARAM_TARGET_AGENT_VERSION = "targetAgentVersion"
+	PARAM_SCALE_OUT_TARGETS    = "scaleOutTargets"
+	PARAM_NEW_ZONES            = "newZones"
+	// The scale out dags created before batch scale out was supported use the keys below instead.
+	PARAM_IS_NEW_ZONE           = "isNewZone"
+	PARAM_AGENT_INFO            = "agentInfo"
+	PARAM_TARGET_AGENT_PASSWORD = "targetAgentPassword"
 
 	PARAM_EXPECTED_STAGE         = "expectedStage"
 	PARAM_MAIN_DAG_ID            = "mainDagId"
@@ -79,6 +83,9 @@ const (
 	PARAM_OBSERVER_STATE = "observerState"
 	PARAM_FORCE_KILL     = "forceKill"
 
+	// for replace server
+	PARAM_REPLACE_NEW_SERVER = "replaceNewServer"
+
 	// for upgrade
 	PARAM_VERSION                = "version"
 	PARAM_BUILD_NUMBER           = "buildNumber"
@@ -93,6 +100,10 @@ const (
 	PARAM_OB_PARAMETERS          = "obParameters"
 	PARAM_UPGRADE_ROUTE          = "upgradeRoute"
 	PARAM_UPGRADE_ROUTE_INDEX    = "upgradeRouteIndex"
+	PARAM_APPROVAL_TIMEOUT       = "approvalTimeout"
+	PARAM_APPROVAL_ACTION        = "approvalTimeoutAction"
+	PARAM_UPGRADE_FROM_VERSION   = "upgradeFromBuildVersion"
+	PARAM_UPGRADE_ROLLBACK       = "upgradeRollback"
 	PARAM_ZONE                   = "zone"
 	PARAM_ZONE_REGION            = "region"
 	PARAM_CLUSTER_NAME           = "cluster"
@@ -101,7 +112,6 @@ const (
 	PARAM_TENANT_NAME = "tenantName"
 
 	PARAM_TARGET_AGENT_BUILD_VERSION = "targetAgentBuildVersion"
-	PARAM_TARGET_AGENT_PASSWORD      = "targetAgentPassword"
 
 	// for backup
 	PARAM_NEED_BACKUP_TENANT  = "needBackupTenants"
@@ -112,18 +122,23 @@ const (
 	PARAM_BACKUP_MODE         = "backupMode"
 	PARAM_BACKUP_ENCRYPTION   = "backupEncryption"
 	PARAM_BACKUP_PLUS_ARCHIVE = "backupPlusArchive"
+	PARAM_BACKUP_SET_ID       = "backupSetId"
+	PARAM_BACKUP_CLEAN        = "backupClean"
 
 	// for restore
-	PARAM_RESTORE              = "restoreParam"
-	PARAM_UNIT_CONFIG_NAME     = "unitConfigName"
-	PARAM_UNIT_NUM             = "unitNum"
-	PARAM_ZONE_LIST            = "zoneList"
-	PARAM_KMS_ENCRYPT_INFO     = "kmsEncryptInfo"
-	PARAM_POOL_NAME            = "poolName"
-	PARAM_POOLS_NAME           = "poolsName"
-	PARAM_HA_HIGH_THREAD_SCORE = "haHighThreadScore"
-	PARAM_RESTORE_SCN          = "restoreScn"
-	PARAM_NEED_DELETE_RP       = "needDeleteRp"
+	PARAM_RESTORE                 = "restoreParam"
+	PARAM_UNIT_CONFIG_NAME        = "unitConfigName"
+	PARAM_UNIT_NUM                = "unitNum"
+	PARAM_ZONE_LIST               = "zoneList"
+	PARAM_KMS_ENCRYPT_INFO        = "kmsEncryptInfo"
+	PARAM_POOL_NAME               = "poolName"
+	PARAM_POOLS_NAME              = "poolsName"
+	PARAM_HA_HIGH_THREAD_SCORE    = "haHighThreadScore"
+	PARAM_RESTORE_SCN             = "restoreScn"
+	PARAM_NEED_DELETE_RP          = "needDeleteRp"
+	PARAM_RESTORE_DRILL           = "restoreDrill"
+	PARAM_RESTORE_DRILL_REPORT_ID = "restoreDrillReportId"
+	PARAM_RECOVER_TABLE           = "recoverTable"
 
 	PARAM_USER_NAME     = "userName"
 	PARAM_USER_PASSWORD = "userPassword"
@@ -186,6 +201,10 @@ const (
 	TASK_EXEC_UPGRADE_POST_SCRIPT                = "Execute upgrade post script"
 	TASK_EXEC_UPGRADE_HEALTH_CHECKER_SCRIPT      = "Execute upgrade health checker script"
 	TASK_EXEC_UPGRADE_ZONE_HEALTH_CHECKER_SCRIPT = "Execute upgrade zone health checker script"
+	TASK_WAIT_UPGRADE_ZONE_APPROVAL              = "Wait for upgrade zone approval"
+	TASK_RESTORE_AND_RESTART_OBSERVER            = "Restore and restart observer"
+	TASK_CHECK_ROLLBACK_ZONE                     = "Check zone after rollback"
+	TASK_REPORT_UPGRADE_ROLLBACK                 = "Report upgrade rollback"
 	TASK_BACKUP_PARAMETERS                       = "Backup parameters"
 	TASK_RESTORE_PARAMETERS                      = "Restore parameters"
 	TASK_CHECK_ALL_REQUIRED_PKGS                 = "Check all required packages"
@@ -223,6 +242,15 @@ const (
 	TASK_NAME_STOP_ZONE   = "Stop zone %s"
 	TASK_NAME_DELETE_ZONE = "Delete zone %s"
 
+	// task name for zone maintenance
+	TASK_NAME_CHECK_ZONE_MAINTENANCE      = "Check majority without zone %s"
+	TASK_NAME_WAIT_LEADERS_OFF_ZONE       = "Wait for leaders to leave zone %s"
+	TASK_NAME_WAIT_ZONE_SERVERS_AVAILABLE = "Wait for servers of zone %s available"
+	TASK_NAME_RESTORE_ZONE_LEADERS        = "Restore leaders to primary zone"
+
+	// task name for replace server
+	TASK_NAME_WAIT_REPLICA_REBUILD = "Wait for unit migration and replica rebuild"
+
 	// task name for backup
 	TASK_CHECK_BACKUP_CONFIG = "Check backup config"
 	TASK_SET_BACKUP_CONFIG   = "Set backup config"
@@ -230,17 +258,29 @@ const (
 	TASK_OPEN_ARCHIVE_LOG    = "Open archive log"
 	TASK_START_BACKUP        = "Start backup"
 	TASK_WAIT_BACKUP         = "Wait backup Finish"
+	TASK_VALIDATE_BACKUP     = "Validate backup"
+	TASK_DELETE_BACKUP       = "Delete backup"
+	TASK_RESET_ARCHIVE_LOG   = "Reset archive log"
 
 	// task name for restore
-	TASK_PRE_RESTORE_CHECK   = "Pre restore check"
-	TASK_CREATE_RESOURCE     = "Create resource for restore"
-	TASK_RESTORE             = "Start restore"
-	TASK_START_RESTORE       = "Start restore"
-	TASK_WAIT_RESTORE_FINISH = "Wait restore task finish"
-	TASK_ACTIVE_TENANT       = "Active tenant"
-	TASK_UPGRADE_TENANT      = "Upgrade tenant"
-	TASK_CANCEL_RESTORE      = "Cancel restore"
-	TASK_DROP_RESOURCE_POOL  = "Drop resource pool"
+	TASK_PRE_RESTORE_CHECK    = "Pre restore check"
+	TASK_CREATE_RESOURCE      = "Create resource for restore"
+	TASK_RESTORE              = "Start restore"
+	TASK_START_RESTORE        = "Start restore"
+	TASK_WAIT_RESTORE_FINISH  = "Wait restore task finish"
+	TASK_ACTIVE_TENANT        = "Active tenant"
+	TASK_UPGRADE_TENANT       = "Upgrade tenant"
+	TASK_CANCEL_RESTORE       = "Cancel restore"
+	TASK_DROP_RESOURCE_POOL   = "Drop resource pool"
+	TASK_VERIFY_RESTORE_DRILL = "Verify restored tenant"
+	TASK_DROP_SCRATCH_TENANT  = "Drop scratch tenant"
+	TASK_FINISH_RESTORE_DRILL = "Finish restore drill"
+
+	// task name for table restore
+	TASK_PRE_RECOVER_TABLE_CHECK   = "Pre table restore check"
+	TASK_START_RECOVER_TABLE       = "Start table restore"
+	TASK_WAIT_RECOVER_TABLE_FINISH = "Wait table restore finish"
+	TASK_DROP_AUXILIARY_POOL       = "Drop auxiliary resource pool"
 
 	// dag name
 	DAG_EMERGENCY_START                      = "Start local observer"
@@ -258,17 +298,26 @@ const (
 	DAG_UPGRADE_CHECK_OB                     = "Upgrade check OB"
 	DAG_OB_STOP_SVC_UPGRADE                  = "OB stop service upgrade"
 	DAG_OB_ROLLING_UPGRADE                   = "OB rolling upgrade"
+	DAG_OB_UPGRADE_ROLLBACK                  = "OB upgrade rollback"
 	DAG_NAME_LOCAL_SCALE_OUT                 = "Local scale out"
 	DAG_NAME_CLUSTER_SCALE_OUT               = "Cluster scale out"
 	DAG_CLUSTER_SCALE_IN                     = "Cluster scale in"
+	DAG_CLUSTER_REPLACE_SERVER               = "Cluster replace server"
 	DAG_DELETE_ZONE                          = "Delete zone"
+	DAG_ENTER_ZONE_MAINTENANCE               = "Enter zone maintenance"
+	DAG_EXIT_ZONE_MAINTENANCE                = "Exit zone maintenance"
 	DAG_KILL_OBSERVER                        = "Kill observer"
 	DAG_START_OBSERVER_FOR_SCALE_IN_ROLLBACK = "Start observer for scale in rollback"
 	DAG_SET_BACKUP_CONFIG                    = "Set obcluster backup config"
 	DAG_OBCLUSTER_START_FULL_BACKUP          = "Obcluster start full backup"
 	DAG_OBCLUSTER_START_INCREMENT_BACKUP     = "Obcluster start increment backup"
+	DAG_OBCLUSTER_VALIDATE_BACKUP            = "Obcluster validate backup"
+	DAG_CLEAN_BACKUP                         = "Clean backup"
 	DAG_RESTORE_BACKUP                       = "Restore backup"
 	DAG_CANCEL_RESTORE                       = "Cancel restore"
+	DAG_RESTORE_DRILL                        = "Restore drill"
+	DAG_RECOVER_TABLE                        = "Restore table"
+	DAG_REMEDIATE_ARCHIVE_LOG                = "Remediate archive log"
 
 	// rpc retry times
 	MAX_RETRY_RPC_TIMES = 3
@@ -282,6 +331,9 @@ const (
 	ADDL_KEY_SUB_DAGS       = "sub_dags"
 	ADDL_KEY_MAIN_DAG_ID    = "main_dag_id"
 	ADDL_KEY_RESTORE_JOB_ID = "restore_job_id"
+	ADDL_KEY_BACKUP_KEYS    = "backup_keys"
+	ADDL_KEY_UPGRADE_GATES  = "upgrade_gates"
+	ADDL_KEY_ROLLBACK_POINT = "rollback_point"
 )
 
 var (
@@ -446,6 +498,11 @@ func RegisterObScaleInTask() {
 	task.RegisterTaskType(DeleteObserverTask{})
 	task.RegisterTaskType(WaitDeleteServerSuccessTask{})
 	task.RegisterTaskType(DeleteZoneTask{})
+	task.RegisterTaskType(CheckZoneMaintenanceTask{})
+	task.RegisterTaskType(WaitLeadersOffZoneTask{})
+	task.RegisterTaskType(WaitZoneServersAvailableTask{})
+	task.RegisterTaskType(RestoreZoneLeadersTask{})
+	task.RegisterTaskType(WaitReplicaRebuildTask{})
 	task.RegisterTaskType(StartObserverForScaleInRollbackTask{})
 }
 
@@ -477,6 +534,10 @@ func RegisterUpgradeTask() {
 	task.RegisterTaskType(ReinstallAndRestartObTask{})
 	task.RegisterTaskType(StartOneZoneTask{})
 	task.RegisterTaskType(RestoreParametersTask{})
+	task.RegisterTaskType(WaitUpgradeZoneApprovalTask{})
+	task.RegisterTaskType(RestoreAndRestartObTask{})
+	task.RegisterTaskType(CheckRollbackZoneTask{})
+	task.RegisterTaskType(ReportUpgradeRollbackTask{})
 }
 
 func RegisterBackupTask() {
@@ -486,6 +547,9 @@ func RegisterBackupTask() {
 	task.RegisterTaskType(OpenArchiveLogTask{})
 	task.RegisterTaskType(StartBackupTask{})
 	task.RegisterTaskType(WaitBackupTaskFinish{})
+	task.RegisterTaskType(ValidateBackupTask{})
+	task.RegisterTaskType(DeleteBackupTask{})
+	task.RegisterTaskType(ResetArchiveLogTask{})
 }
 
 func RegisterRestoreTask() {
@@ -496,4 +560,14 @@ func RegisterRestoreTask() {
 	task.RegisterTaskType(UpgradeTenantTask{})
 	task.RegisterTaskType(CancelRestoreTask{})
 	task.RegisterTaskType(DropResourcePoolTask{})
+	task.RegisterTaskType(StartRestoreDrillTask{})
+	task.RegisterTaskType(WaitRestoreDrillFinishTask{})
+	task.RegisterTaskType(ActiveScratchTenantTask{})
+	task.RegisterTaskType(VerifyRestoreDrillTask{})
+	task.RegisterTaskType(DropScratchTenantTask{})
+	task.RegisterTaskType(FinishRestoreDrillTask{})
+	task.RegisterTaskType(PreRecoverTableCheckTask{})
+	task.RegisterTaskType(StartRecoverTableTask{})
+	task.RegisterTaskType(WaitRecoverTableFinishTask{})
+	task.RegisterTaskType(DropAuxiliaryPoolTask{})
 }
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/host"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/secure"
+	"github.com/oceanbase/obshell/param"
+)
+
+// CheckHosts evaluates the hosts of the servers specified by the param,
+// which may be the agents to be joined or scaled out, against the requirement profile.
+// The hosts of all the agents in the cluster will be checked if no server is specified.
+func CheckHosts(p *param.HostCheckParam) ([]bo.HostCheckReport, error) {
+	servers := p.Servers
+	if len(servers) == 0 {
+		if !meta.OCS_AGENT.IsClusterAgent() {
+			servers = []meta.AgentInfo{*meta.NewAgentInfoByInterface(meta.OCS_AGENT)}
+		} else {
+			agents, err := agentService.GetAllAgentsDO()
+			if err != nil {
+				return nil, errors.Wrap(err, "get all agents failed")
+			}
+			for _, agent := range agents {
+				servers = append(servers, *meta.NewAgentInfo(agent.Ip, agent.Port))
+			}
+		}
+	}
+
+	// Resolve the profile here rather than on each host, a host to be joined
+	// may have no observer binary to tell the version.
+	version := ""
+	if meta.OCS_AGENT.IsClusterAgent() {
+		if obVersion, err := obclusterService.GetObVersion(); err == nil {
+			version = obVersion
+		}
+	}
+	profile, err := host.ResolveProfileName(p.Profile, version)
+	if err != nil {
+		return nil, err
+	}
+	checkParam := param.HostCheckParam{
+		Profile: profile,
+		DataDir: p.DataDir,
+		RedoDir: p.RedoDir,
+	}
+	reports := make([]bo.HostCheckReport, 0, len(servers))
+	for i := range servers {
+		var report *bo.HostCheckReport
+		var err error
+		if meta.OCS_AGENT.Equal(&servers[i]) {
+			report, err = host.Check(&checkParam)
+		} else {
+			err = secure.SendPostRequest(&servers[i], constant.URI_AGENT_API_PREFIX+constant.URI_HOST_CHECK, checkParam, &report)
+		}
+		if err != nil {
+			log.WithError(err).Warnf("check host of %s failed", servers[i].String())
+			report = newUnreachableHostReport(&servers[i], profile, err)
+		}
+		reports = append(reports, *report)
+	}
+	return reports, nil
+}
+
+// newUnreachableHostReport reports the host which can not be checked as FAIL,
+// the other hosts are still reported.
+func newUnreachableHostReport(server *meta.AgentInfo, profile string, err error) *bo.HostCheckReport {
+	return &bo.HostCheckReport{
+		Agent:   server.String(),
+		Profile: profile,
+		Result:  constant.HOST_CHECK_RESULT_FAIL,
+		Items: []bo.HostCheckItem{
+			{
+				Name:     constant.HOST_CHECK_ITEM_REACHABLE,
+				Expected: "the agent responds to the host check",
+				Actual:   err.Error(),
+				Result:   constant.HOST_CHECK_RESULT_FAIL,
+				Fix:      "make sure the agent is started and reachable from " + meta.OCS_AGENT.String(),
+			},
+		},
+	}
+}
@@ -96,7 +96,7 @@ func GetAllParameters() ([]bo.ClusterParameter, error) {
 
 }
 
-func SetObclusterParameters(params []param.SetSingleObclusterParameterParam) error {
+func SetObclusterParameters(params []param.SetSingleObclusterParameterParam, operator string) error {
 	if len(params) == 0 {
 		return nil
 	}
@@ -110,9 +110,14 @@ func SetObclusterParameters(params []param.SetSingleObclusterParameterParam) err
 	for _, param := range params {
 		setParameterParams := buildSetParameterParam(param)
 		for _, setParameterParam := range setParameterParams {
+			changes, err := buildObclusterParameterChanges(param.Scope, setParameterParam, operator)
+			if err != nil {
+				return err
+			}
 			if err := obclusterService.SetParameter(setParameterParam); err != nil {
 				return err
 			}
+			obclusterService.RecordParameterChanges(changes)
 		}
 	}
 
@@ -0,0 +1,592 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+const PARAMETER_EDIT_LEVEL_READONLY = "READONLY"
+
+type parameterItemKey struct {
+	Category   string
+	TenantName string
+	Server     string
+	Name       string
+}
+
+func newParameterItemKey(item *oceanbase.ParameterSnapshotItem) parameterItemKey {
+	return parameterItemKey{
+		Category:   item.Category,
+		TenantName: item.TenantName,
+		Server:     item.Server,
+		Name:       item.Name,
+	}
+}
+
+// buildObclusterParameterChanges builds the changes of the parameter before it is set,
+// the old value is the value on the target of setParameterParam.
+func buildObclusterParameterChanges(scope string, setParameterParam param.SetParameterParam, operator string) ([]oceanbase.ParameterChange, error) {
+	values, err := obclusterService.GetParameterValues(setParameterParam.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get old value of parameter '%s' failed", setParameterParam.Name)
+	}
+	newChange := func() oceanbase.ParameterChange {
+		return oceanbase.ParameterChange{
+			Category: constant.PARAMETER_CHANGE_CATEGORY_PARAMETER,
+			Scope:    scope,
+			Name:     setParameterParam.Name,
+			NewValue: setParameterParam.Value,
+			Operator: operator,
+		}
+	}
+
+	changes := make([]oceanbase.ParameterChange, 0)
+	if scope == constant.PARAMETER_SCOPE_CLUSTER {
+		oldValues := make([]oceanbase.ObParameters, 0)
+		for _, value := range values {
+			if setParameterParam.Zone != "" && value.Zone != setParameterParam.Zone {
+				continue
+			}
+			if setParameterParam.Server != "" && meta.NewAgentInfo(value.SvrIp, value.SvrPort).String() != setParameterParam.Server {
+				continue
+			}
+			oldValues = append(oldValues, value)
+		}
+
+		isSingleValue := true
+		for _, value := range oldValues {
+			isSingleValue = isSingleValue && value.Value == oldValues[0].Value
+		}
+		if isSingleValue {
+			change := newChange()
+			change.Zone = setParameterParam.Zone
+			change.Server = setParameterParam.Server
+			if len(oldValues) != 0 {
+				change.OldValue = oldValues[0].Value
+			} else {
+				change.OldValueUnknown = true
+			}
+			return append(changes, change), nil
+		}
+		// The value differs between servers, record the change for each server to make it possible to roll back.
+		for _, value := range oldValues {
+			change := newChange()
+			change.Zone = value.Zone
+			change.Server = meta.NewAgentInfo(value.SvrIp, value.SvrPort).String()
+			change.OldValue = value.Value
+			changes = append(changes, change)
+		}
+		return changes, nil
+	}
+
+	tenantIdToNameMap, err := tenantService.GetAllNotMetaTenantIdToNameMap()
+	if err != nil {
+		return nil, err
+	}
+	oldValues := make(map[string]string)
+	for _, value := range values {
+		tenantName, ok := tenantIdToNameMap[value.TenantId]
+		if !ok {
+			continue
+		}
+		if _, exist := oldValues[tenantName]; !exist {
+			oldValues[tenantName] = value.Value
+		}
+	}
+
+	var tenantNames []string
+	switch setParameterParam.Tenant {
+	case "ALL_USER":
+		for id, name := range tenantIdToNameMap {
+			if id != constant.TENANT_SYS_ID {
+				tenantNames = append(tenantNames, name)
+			}
+		}
+	case "":
+		tenantNames = []string{constant.TENANT_SYS}
+	default:
+		tenantNames = []string{setParameterParam.Tenant}
+	}
+	sort.Strings(tenantNames)
+	for _, tenantName := range tenantNames {
+		change := newChange()
+		change.TenantName = tenantName
+		if oldValue, ok := oldValues[tenantName]; ok {
+			change.OldValue = oldValue
+		} else {
+			change.OldValueUnknown = true
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// collectParameterItems collects the current value of all the unhidden parameters and the variables of all tenants.
+// The cluster parameters are collected for each server, and the tenant parameters are collected for each tenant.
+// The parameters which could not be modified are returned as readonly.
+func collectParameterItems() (items map[parameterItemKey]oceanbase.ParameterSnapshotItem, readonly map[string]bool, err error) {
+	parameters, err := obclusterService.GetAllUnhiddenParameters()
+	if err != nil {
+		return nil, nil, err
+	}
+	tenantIdToNameMap, err := tenantService.GetAllNotMetaTenantIdToNameMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items = make(map[parameterItemKey]oceanbase.ParameterSnapshotItem)
+	readonly = make(map[string]bool)
+	for _, parameter := range parameters {
+		item := oceanbase.ParameterSnapshotItem{
+			Category: constant.PARAMETER_CHANGE_CATEGORY_PARAMETER,
+			Scope:    parameter.Scope,
+			Name:     parameter.Name,
+			Value:    parameter.Value,
+		}
+		if parameter.Scope == constant.PARAMETER_SCOPE_TENANT {
+			tenantName, ok := tenantIdToNameMap[parameter.TenantId]
+			if !ok {
+				continue
+			}
+			item.TenantName = tenantName
+		} else {
+			item.Server = meta.NewAgentInfo(parameter.SvrIp, parameter.SvrPort).String()
+		}
+		if parameter.EditLevel == PARAMETER_EDIT_LEVEL_READONLY {
+			readonly[parameter.Name] = true
+		}
+		key := newParameterItemKey(&item)
+		if _, exist := items[key]; !exist {
+			items[key] = item
+		}
+	}
+
+	for _, tenantName := range tenantIdToNameMap {
+		variables, err := tenantService.GetTenantVariables(tenantName, "%")
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get variables of tenant '%s' failed", tenantName)
+		}
+		for _, variable := range variables {
+			item := oceanbase.ParameterSnapshotItem{
+				Category:   constant.PARAMETER_CHANGE_CATEGORY_VARIABLE,
+				Scope:      constant.PARAMETER_SCOPE_TENANT,
+				TenantName: tenantName,
+				Name:       variable.Name,
+				Value:      variable.Value,
+			}
+			items[newParameterItemKey(&item)] = item
+		}
+	}
+	return items, readonly, nil
+}
+
+func diffParameterItems(base, target map[parameterItemKey]oceanbase.ParameterSnapshotItem) []bo.ParameterDiff {
+	diffs := make([]bo.ParameterDiff, 0)
+	for key, item := range base {
+		if targetItem, exist := target[key]; !exist || targetItem.Value != item.Value {
+			diffs = append(diffs, bo.ParameterDiff{
+				Category:    item.Category,
+				Scope:       item.Scope,
+				Name:        item.Name,
+				Server:      item.Server,
+				TenantName:  item.TenantName,
+				BaseValue:   item.Value,
+				TargetValue: targetItem.Value,
+			})
+		}
+	}
+	for key, item := range target {
+		if _, exist := base[key]; !exist {
+			diffs = append(diffs, bo.ParameterDiff{
+				Category:    item.Category,
+				Scope:       item.Scope,
+				Name:        item.Name,
+				Server:      item.Server,
+				TenantName:  item.TenantName,
+				TargetValue: item.Value,
+			})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Category != diffs[j].Category {
+			return diffs[i].Category < diffs[j].Category
+		}
+		if diffs[i].TenantName != diffs[j].TenantName {
+			return diffs[i].TenantName < diffs[j].TenantName
+		}
+		if diffs[i].Name != diffs[j].Name {
+			return diffs[i].Name < diffs[j].Name
+		}
+		return diffs[i].Server < diffs[j].Server
+	})
+	return diffs
+}
+
+func convertParameterChange(change *oceanbase.ParameterChange) bo.ParameterChange {
+	return bo.ParameterChange{
+		Id:              change.Id,
+		Category:        change.Category,
+		Scope:           change.Scope,
+		Name:            change.Name,
+		Zone:            change.Zone,
+		Server:          change.Server,
+		TenantName:      change.TenantName,
+		OldValue:        change.OldValue,
+		OldValueUnknown: change.OldValueUnknown,
+		NewValue:        change.NewValue,
+		Operator:        change.Operator,
+		RollbackOf:      change.RollbackOf,
+		CreateTime:      change.GmtCreate,
+	}
+}
+
+func GetParameterChanges(name string, tenantName string, limit int) ([]bo.ParameterChange, error) {
+	changes, err := obclusterService.ListParameterChanges(name, tenantName, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.ParameterChange, 0, len(changes))
+	for i := range changes {
+		res = append(res, convertParameterChange(&changes[i]))
+	}
+	return res, nil
+}
+
+// checkVariableCanBeSetBySys checks whether the variable could be set by the sys tenant,
+// the charset and collation variables could only be set with the connection of the tenant itself.
+func checkVariableCanBeSetBySys(name string) bool {
+	return !utils.ContainsString(constant.VARIAbLES_COLLATION_OR_CHARACTER, name) &&
+		!utils.ContainsString(constant.CREATE_TENANT_STATEMENT_VARIABLES, name)
+}
+
+func newSetParameterParam(change *oceanbase.ParameterChange, value string) param.SetParameterParam {
+	setParameterParam := param.SetParameterParam{
+		Name:   change.Name,
+		Value:  value,
+		Zone:   change.Zone,
+		Server: change.Server,
+	}
+	if change.Scope == constant.PARAMETER_SCOPE_TENANT {
+		setParameterParam.Tenant = change.TenantName
+	}
+	return setParameterParam
+}
+
+// applyParameterChange sets the value to the target of the change.
+func applyParameterChange(change *oceanbase.ParameterChange, value string) error {
+	if change.Category == constant.PARAMETER_CHANGE_CATEGORY_VARIABLE {
+		return tenantService.SetTenantVariables(change.TenantName, map[string]interface{}{change.Name: value})
+	}
+	return obclusterService.SetParameter(newSetParameterParam(change, value))
+}
+
+// getParameterChangeCurrentValue returns the current value on the target of the change,
+// it is unknown if the value could not be found or differs between the servers of the target.
+func getParameterChangeCurrentValue(change *oceanbase.ParameterChange) (value string, known bool, err error) {
+	if change.Category == constant.PARAMETER_CHANGE_CATEGORY_VARIABLE {
+		variable, err := tenantService.GetTenantVariable(change.TenantName, change.Name)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "get current value of variable '%s' failed", change.Name)
+		}
+		if variable == nil {
+			return "", false, nil
+		}
+		return variable.Value, true, nil
+	}
+	changes, err := buildObclusterParameterChanges(change.Scope, newSetParameterParam(change, change.OldValue), "")
+	if err != nil {
+		return "", false, err
+	}
+	if len(changes) != 1 || changes[0].OldValueUnknown {
+		return "", false, nil
+	}
+	return changes[0].OldValue, true, nil
+}
+
+// RollbackParameterChange sets the parameter or variable back to the old value of the change,
+// and records the rollback as a new change. The change could not be rolled back once the
+// value has been changed again, since the later change would be overridden silently.
+func RollbackParameterChange(id int64, operator string) (*bo.ParameterChange, error) {
+	change, err := obclusterService.GetParameterChange(id)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, errors.Occur(errors.ErrObParameterChangeNotExist, id)
+	}
+	if change.OldValueUnknown {
+		return nil, errors.Occur(errors.ErrObParameterChangeOldValueUnknown, id)
+	}
+	if change.Category == constant.PARAMETER_CHANGE_CATEGORY_VARIABLE && !checkVariableCanBeSetBySys(change.Name) {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "id", fmt.Sprintf("variable '%s' could not be rolled back by sys tenant", change.Name))
+	}
+	currentValue, known, err := getParameterChangeCurrentValue(change)
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		return nil, errors.Occur(errors.ErrObParameterChangeOverridden, change.Name, "unknown", id)
+	}
+	if !strings.EqualFold(currentValue, change.NewValue) {
+		return nil, errors.Occur(errors.ErrObParameterChangeOverridden, change.Name, currentValue, id)
+	}
+
+	rollback := oceanbase.ParameterChange{
+		Category:   change.Category,
+		Scope:      change.Scope,
+		Name:       change.Name,
+		Zone:       change.Zone,
+		Server:     change.Server,
+		TenantName: change.TenantName,
+		OldValue:   currentValue,
+		NewValue:   change.OldValue,
+		Operator:   operator,
+		RollbackOf: change.Id,
+	}
+	if err := applyParameterChange(&rollback, rollback.NewValue); err != nil {
+		return nil, errors.Wrapf(err, "rollback parameter change %d failed", id)
+	}
+	obclusterService.RecordParameterChanges([]oceanbase.ParameterChange{rollback})
+	res := convertParameterChange(&rollback)
+	return &res, nil
+}
+
+func CreateParameterSnapshot(p param.CreateParameterSnapshotParam, operator string) error {
+	snapshot, err := obclusterService.GetParameterSnapshot(p.Name)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		return errors.Occur(errors.ErrObParameterSnapshotAlreadyExists, p.Name)
+	}
+
+	items, _, err := collectParameterItems()
+	if err != nil {
+		return err
+	}
+	snapshotItems := make([]oceanbase.ParameterSnapshotItem, 0, len(items))
+	for _, item := range items {
+		snapshotItems = append(snapshotItems, item)
+	}
+	return obclusterService.CreateParameterSnapshot(&oceanbase.ParameterSnapshot{
+		Name:     p.Name,
+		Comment:  p.Comment,
+		Operator: operator,
+	}, snapshotItems)
+}
+
+func GetParameterSnapshots() ([]bo.ParameterSnapshot, error) {
+	snapshots, err := obclusterService.ListParameterSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.ParameterSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		count, err := obclusterService.CountParameterSnapshotItems(snapshot.Id)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, bo.ParameterSnapshot{
+			Name:       snapshot.Name,
+			Comment:    snapshot.Comment,
+			Operator:   snapshot.Operator,
+			ItemCount:  count,
+			CreateTime: snapshot.GmtCreate,
+		})
+	}
+	return res, nil
+}
+
+func getParameterSnapshot(name string) (*oceanbase.ParameterSnapshot, error) {
+	snapshot, err := obclusterService.GetParameterSnapshot(name)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, errors.Occur(errors.ErrObParameterSnapshotNotExist, name)
+	}
+	return snapshot, nil
+}
+
+func getParameterSnapshotItems(name string) (map[parameterItemKey]oceanbase.ParameterSnapshotItem, error) {
+	snapshot, err := getParameterSnapshot(name)
+	if err != nil {
+		return nil, err
+	}
+	snapshotItems, err := obclusterService.GetParameterSnapshotItems(snapshot.Id)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[parameterItemKey]oceanbase.ParameterSnapshotItem, len(snapshotItems))
+	for i := range snapshotItems {
+		items[newParameterItemKey(&snapshotItems[i])] = snapshotItems[i]
+	}
+	return items, nil
+}
+
+func DeleteParameterSnapshot(name string) error {
+	snapshot, err := getParameterSnapshot(name)
+	if err != nil {
+		return err
+	}
+	return obclusterService.DeleteParameterSnapshot(snapshot.Id)
+}
+
+// DiffParametersWithSnapshot compares the snapshot (as base) with the current parameters and variables (as target).
+func DiffParametersWithSnapshot(name string) ([]bo.ParameterDiff, error) {
+	snapshotItems, err := getParameterSnapshotItems(name)
+	if err != nil {
+		return nil, err
+	}
+	currentItems, _, err := collectParameterItems()
+	if err != nil {
+		return nil, err
+	}
+	return diffParameterItems(snapshotItems, currentItems), nil
+}
+
+func collectTenantParameterItems(tenantName string) (map[parameterItemKey]oceanbase.ParameterSnapshotItem, error) {
+	tenant, err := tenantService.GetTenantByName(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, errors.Occur(errors.ErrObTenantNotExist, tenantName)
+	}
+
+	items := make(map[parameterItemKey]oceanbase.ParameterSnapshotItem)
+	parameters, err := tenantService.GetTenantParameters(tenantName, "%")
+	if err != nil {
+		return nil, err
+	}
+	for _, parameter := range parameters {
+		// The tenant name is omitted to compare the items of different tenants.
+		item := oceanbase.ParameterSnapshotItem{
+			Category: constant.PARAMETER_CHANGE_CATEGORY_PARAMETER,
+			Scope:    constant.PARAMETER_SCOPE_TENANT,
+			Name:     parameter.Name,
+			Value:    parameter.Value,
+		}
+		key := newParameterItemKey(&item)
+		if _, exist := items[key]; !exist {
+			items[key] = item
+		}
+	}
+	variables, err := tenantService.GetTenantVariables(tenantName, "%")
+	if err != nil {
+		return nil, err
+	}
+	for _, variable := range variables {
+		item := oceanbase.ParameterSnapshotItem{
+			Category: constant.PARAMETER_CHANGE_CATEGORY_VARIABLE,
+			Scope:    constant.PARAMETER_SCOPE_TENANT,
+			Name:     variable.Name,
+			Value:    variable.Value,
+		}
+		items[newParameterItemKey(&item)] = item
+	}
+	return items, nil
+}
+
+// DiffTenantParameters compares the tenant parameters and variables of the tenant (as base) with the target tenant.
+func DiffTenantParameters(tenantName string, targetTenantName string) ([]bo.ParameterDiff, error) {
+	baseItems, err := collectTenantParameterItems(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	targetItems, err := collectTenantParameterItems(targetTenantName)
+	if err != nil {
+		return nil, err
+	}
+	return diffParameterItems(baseItems, targetItems), nil
+}
+
+// RollbackParameterSnapshot sets the parameters and variables which differ from the snapshot back to the value in the snapshot.
+// The readonly parameters, the variables which could not be set by sys tenant,
+// and the items which no longer exist (such as the server or the tenant has been removed) are skipped.
+func RollbackParameterSnapshot(name string, operator string) (res []bo.ParameterChange, err error) {
+	snapshotItems, err := getParameterSnapshotItems(name)
+	if err != nil {
+		return nil, err
+	}
+	currentItems, readonly, err := collectParameterItems()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]parameterItemKey, 0, len(snapshotItems))
+	for key := range snapshotItems {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	changes := make([]oceanbase.ParameterChange, 0)
+	defer func() {
+		// Record the applied changes even if the rollback fails halfway.
+		obclusterService.RecordParameterChanges(changes)
+		res = make([]bo.ParameterChange, 0, len(changes))
+		for i := range changes {
+			res = append(res, convertParameterChange(&changes[i]))
+		}
+	}()
+	for _, key := range keys {
+		item := snapshotItems[key]
+		current, exist := currentItems[key]
+		if !exist || current.Value == item.Value {
+			continue
+		}
+		if item.Category == constant.PARAMETER_CHANGE_CATEGORY_PARAMETER && readonly[item.Name] {
+			log.Infof("skip readonly parameter '%s'", item.Name)
+			continue
+		}
+		if item.Category == constant.PARAMETER_CHANGE_CATEGORY_VARIABLE && !checkVariableCanBeSetBySys(item.Name) {
+			log.Infof("skip variable '%s' of tenant '%s' which could not be set by sys tenant", item.Name, item.TenantName)
+			continue
+		}
+
+		change := oceanbase.ParameterChange{
+			Category:   item.Category,
+			Scope:      item.Scope,
+			Name:       item.Name,
+			Server:     item.Server,
+			TenantName: item.TenantName,
+			OldValue:   current.Value,
+			NewValue:   item.Value,
+			Operator:   operator,
+		}
+		if err = applyParameterChange(&change, change.NewValue); err != nil {
+			return nil, errors.Wrapf(err, "rollback %s '%s' failed", item.Category, item.Name)
+		}
+		changes = append(changes, change)
+	}
+	return
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/binary"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	WAIT_REPLICA_REBUILD_INTERVAL = 10 * time.Second
+	WAIT_REPLICA_REBUILD_TIMEOUT  = 12 * time.Hour
+)
+
+// HandleClusterReplaceServer replaces the failed agent with the new agent in one dag.
+// The new server is added into the zone of the failed one, and the failed server is deleted
+// after the units and replicas on it are rebuilt on the other servers.
+func HandleClusterReplaceServer(p param.ClusterReplaceServerParam) (*task.DagDetailDTO, error) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	if p.OldAgent.Equal(&p.NewAgent) {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "newAgent", "the new agent should be different from the old agent")
+	}
+	if meta.OCS_AGENT.Equal(&p.OldAgent) {
+		return nil, errors.Occur(errors.ErrObServerDeleteSelf)
+	}
+
+	oldAgent, err := agentService.FindAgentInstance(&p.OldAgent)
+	if err != nil {
+		return nil, errors.Wrapf(err, "find agent %s failed", p.OldAgent.String())
+	}
+	if oldAgent == nil {
+		return nil, errors.Occur(errors.ErrAgentNotExist, p.OldAgent.String())
+	}
+	server, err := obclusterService.GetOBServerByAgentInfo(p.OldAgent)
+	if err != nil {
+		return nil, errors.Wrap(err, "check server exist failed")
+	}
+	if server == nil {
+		return nil, errors.Occur(errors.ErrObServerNotExist, p.OldAgent.String())
+	}
+	oldServer := meta.ObserverSvrInfo{Ip: server.SvrIp, Port: server.SvrPort}
+
+	obVersion, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob version failed")
+	}
+	target, err := checkScaleOutAgent(param.ScaleOutAgentParam{
+		AgentInfo:           p.NewAgent,
+		Zone:                oldAgent.GetZone(),
+		ObConfigs:           p.ObConfigs,
+		TargetAgentPassword: p.TargetAgentPassword,
+	}, map[string]string{}, obVersion)
+	if err != nil {
+		return nil, err
+	}
+	srvInfo, err := checkScaleOutServer(target)
+	if err != nil {
+		return nil, err
+	}
+	newServer := meta.ObserverSvrInfo{Ip: srvInfo.GetIp(), Port: srvInfo.GetPort()}
+
+	// The zone of the old agent always exists, so there is no new zone to add.
+	template := newClusterScaleOutTemplateBuilder(DAG_CLUSTER_REPLACE_SERVER, false).
+		AddTask(newDeleteObserverTask(), false).
+		AddNode(newWaitReplicaRebuildNode()).
+		AddTask(newWaitDeleteServerSuccessTask(), false).
+		AddTask(newDeleteAgentTask(), false).
+		AddTask(newFinishTask(), false).
+		Build()
+	context := buildClusterScaleOutDagContext([]scaleOutTarget{*target}, []string{}).
+		SetParam(PARAM_DELETE_SERVER, oldServer).
+		SetParam(PARAM_DELETE_AGENTS, []meta.AgentInfo{p.OldAgent}).
+		SetParam(PARAM_REPLACE_NEW_SERVER, newServer)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(template, context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+type WaitReplicaRebuildTask struct {
+	task.Task
+	oldServer    meta.ObserverSvrInfo
+	newServer    meta.ObserverSvrInfo
+	lastProgress *replicaRebuildProgress
+}
+
+// replicaRebuildProgress is the remaining work before the old server could be deleted.
+type replicaRebuildProgress struct {
+	units int64
+	jobs  int64
+	tasks int64
+}
+
+func (p replicaRebuildProgress) finished() bool {
+	return p.units == 0 && p.jobs == 0 && p.tasks == 0
+}
+
+func newWaitReplicaRebuildTask() *WaitReplicaRebuildTask {
+	newTask := &WaitReplicaRebuildTask{
+		Task: *task.NewSubTask(TASK_NAME_WAIT_REPLICA_REBUILD),
+	}
+	newTask.SetCanContinue().
+		SetCanRetry().
+		SetCanCancel().
+		SetCanPass()
+	return newTask
+}
+
+func newWaitReplicaRebuildNode() *task.Node {
+	// Rebuilding replicas of a large server takes much longer than the default task timeout.
+	ctx := task.NewTaskContext().
+		SetParam(task.TIMEOUT_KEY, int((WAIT_REPLICA_REBUILD_TIMEOUT+task.DEFAULT_TIMEOUT)/time.Second))
+	return task.NewNodeWithContext(newWaitReplicaRebuildTask(), false, ctx)
+}
+
+// Execute waits for all the units on the old server migrated and no replica task to the new server left.
+func (t *WaitReplicaRebuildTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_DELETE_SERVER, &t.oldServer); err != nil {
+		return err
+	}
+	if err := t.GetContext().GetParamWithValue(PARAM_REPLACE_NEW_SERVER, &t.newServer); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(WAIT_REPLICA_REBUILD_TIMEOUT)
+	for time.Now().Before(deadline) {
+		t.TimeoutCheck()
+		progress, err := t.getRebuildProgress()
+		if err != nil {
+			return err
+		}
+		if progress.finished() {
+			t.ExecuteLogf("all the units on %s have been migrated and the replicas have been rebuilt", t.oldServer.String())
+			return nil
+		}
+		// Only log when the progress changes, to avoid flooding the task logs.
+		if t.lastProgress == nil || *t.lastProgress != progress {
+			t.ExecuteLogf("%d units are still on %s, %d unit migration jobs and %d replica tasks to %s are in progress",
+				progress.units, t.oldServer.String(), progress.jobs, progress.tasks, t.newServer.String())
+			t.lastProgress = &progress
+		}
+		time.Sleep(WAIT_REPLICA_REBUILD_INTERVAL)
+	}
+	return errors.Occurf(errors.ErrObClusterAsyncOperationTimeout, "rebuild replicas of %s", t.oldServer.String())
+}
+
+func (t *WaitReplicaRebuildTask) getRebuildProgress() (progress replicaRebuildProgress, err error) {
+	if progress.units, err = obclusterService.CountUnitsOnServer(t.oldServer); err != nil {
+		return progress, errors.Wrapf(err, "count units on %s failed", t.oldServer.String())
+	}
+	if progress.jobs, err = tenantService.CountInProgressUnitJobs(constant.MIGRATE_UNIT); err != nil {
+		return progress, errors.Wrap(err, "get unit migration job status failed")
+	}
+	if progress.tasks, err = obclusterService.CountReplicaTasksToServer(t.newServer); err != nil {
+		return progress, errors.Wrapf(err, "count replica tasks to %s failed", t.newServer.String())
+	}
+	return progress, nil
+}
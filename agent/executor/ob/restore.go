@@ -295,8 +295,16 @@ func (t *StartRestoreTask) restoreTenant() (err error) {
 	}
 	locality := strings.Join(localityList, ",")
 
+	restoreParam := *t.param
+	if restoreParam.Decryption, err = resolveRestoreDecryption(t.param.DataBackupUri, t.param.Decryption); err != nil {
+		return err
+	}
+	if restoreParam.Decryption != t.param.Decryption {
+		t.ExecuteLogf("Decrypt the backup with %d stored backup keys", len(*restoreParam.Decryption))
+	}
+
 	t.ExecuteLogf("Restore tenant '%s'", t.tenantName)
-	if err = tenantService.Restore(t.param, locality, resourcePoolList, t.restoreScn); err != nil {
+	if err = tenantService.Restore(&restoreParam, locality, resourcePoolList, t.restoreScn); err != nil {
 		return errors.Wrap(err, "restore tenant")
 	}
 	return nil
@@ -0,0 +1,439 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/pool"
+	"github.com/oceanbase/obshell/agent/executor/tenant"
+	"github.com/oceanbase/obshell/agent/lib/path"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	waitForDropScratchTenant = 600 // seconds
+)
+
+func TenantRestoreDrill(p *param.RestoreDrillParam) (*task.DagDetailDTO, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	if tenant, err := tenantService.GetTenantByName(*p.ScratchTenantName); err != nil {
+		return nil, err
+	} else if tenant != nil {
+		return nil, errors.Occur(errors.ErrObTenantExisted, *p.ScratchTenantName)
+	}
+
+	restoreTime, err := getLatestRestorableTime(p.DataBackupUri, *p.ArchiveLogUri)
+	if err != nil {
+		return nil, err
+	}
+	restoreParam := p.ToRestoreParam(restoreTime)
+	if err = checkRestoreParam(restoreParam); err != nil {
+		return nil, err
+	}
+
+	report := &oceanbase.RestoreDrillReport{
+		ScratchTenant: *p.ScratchTenantName,
+		DataBackupUri: uriWithoutSecret(p.DataBackupUri),
+		ArchiveLogUri: uriWithoutSecret(*p.ArchiveLogUri),
+		RestoreTime:   restoreTime,
+		Status:        constant.RESTORE_DRILL_STATUS_RUNNING,
+	}
+	if err = tenantService.CreateRestoreDrillReport(report); err != nil {
+		return nil, errors.Wrap(err, "create restore drill report")
+	}
+
+	template := buildRestoreDrillTemplate(*p.ScratchTenantName)
+	ctx := buildRestoreTaskContext(restoreParam).
+		SetParam(PARAM_RESTORE_DRILL, *p).
+		SetParam(PARAM_RESTORE_DRILL_REPORT_ID, report.Id)
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		_ = tenantService.UpdateRestoreDrillReport(report.Id, map[string]interface{}{"status": constant.RESTORE_DRILL_STATUS_FAILED})
+		return nil, err
+	}
+	if err = tenantService.UpdateRestoreDrillReport(report.Id, map[string]interface{}{"dag_id": dag.GetID()}); err != nil {
+		return nil, errors.Wrap(err, "update restore drill report")
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// getLatestRestorableTime returns the end of the latest restore window,
+// or nil to restore to the latest point when ob_admin is not available.
+func getLatestRestorableTime(dataURI, logURI string) (*time.Time, error) {
+	if !system.IsFileExist(path.OBAdmin()) {
+		return nil, nil
+	}
+	windows, err := system.GetRestoreWindows(dataURI, logURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "get restore windows")
+	}
+	if len(windows.Windows) == 0 {
+		return nil, nil
+	}
+	endTime := windows.Windows[len(windows.Windows)-1].EndTime
+	return &endTime, nil
+}
+
+func uriWithoutSecret(uri string) string {
+	storage, err := system.GetStorageInterfaceByURI(uri)
+	if err != nil || storage == nil {
+		return ""
+	}
+	return storage.GenerateURIWithoutSecret()
+}
+
+func buildRestoreDrillTemplate(tenantName string) *task.Template {
+	return task.NewTemplateBuilder(fmt.Sprintf("%s_%s", DAG_RESTORE_DRILL, tenantName)).
+		SetMaintenance(task.TenantMaintenance(tenantName)).
+		AddTask(newPreRestoreCheckTask(), false).
+		AddTask(newStartRestoreDrillTask(), false).
+		AddTask(newWaitRestoreDrillFinishTask(), false).
+		AddTask(newActiveScratchTenantTask(), false).
+		AddTask(newVerifyRestoreDrillTask(), false).
+		AddTask(newDropScratchTenantTask(), false).
+		AddTask(newFinishRestoreDrillTask(), false).
+		Build()
+}
+
+func GetRestoreDrillReports(limit int) ([]bo.RestoreDrillReport, error) {
+	if limit <= 0 {
+		limit = constant.RESTORE_DRILL_REPORTS_DEFAULT
+	}
+	reports, err := tenantService.ListRestoreDrillReports(limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.RestoreDrillReport, 0, len(reports))
+	for i := range reports {
+		res = append(res, toRestoreDrillReportBO(&reports[i]))
+	}
+	return res, nil
+}
+
+func GetRestoreDrillReport(id int64) (*bo.RestoreDrillReport, error) {
+	report, err := tenantService.GetRestoreDrillReport(id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, errors.Occur(errors.ErrObRestoreDrillReportNotExist, id)
+	}
+	res := toRestoreDrillReportBO(report)
+	return &res, nil
+}
+
+// toRestoreDrillReportBO reports a running drill as failed once its dag has failed,
+// since the report is only finalized by the last task of the dag.
+func toRestoreDrillReportBO(report *oceanbase.RestoreDrillReport) bo.RestoreDrillReport {
+	res := report.ToBO()
+	if res.Status == constant.RESTORE_DRILL_STATUS_RUNNING && res.DagID != 0 {
+		if dag, err := taskService.GetDagInstance(res.DagID); err == nil && dag.IsFail() {
+			res.Status = constant.RESTORE_DRILL_STATUS_FAILED
+		}
+	}
+	return res
+}
+
+// StartRestoreDrillTask starts restoring the scratch tenant, the scratch tenant
+// is dropped and the drill is failed once the restore fails or is rolled back.
+type StartRestoreDrillTask struct {
+	StartRestoreTask
+}
+
+func newStartRestoreDrillTask() *StartRestoreDrillTask {
+	return &StartRestoreDrillTask{
+		StartRestoreTask: *newStartRestoreTask(),
+	}
+}
+
+func (t *StartRestoreDrillTask) Execute() error {
+	return failRestoreDrillOnError(&t.Task, t.StartRestoreTask.Execute())
+}
+
+func (t *StartRestoreDrillTask) Rollback() error {
+	if err := t.StartRestoreTask.Rollback(); err != nil {
+		return err
+	}
+	return failRestoreDrill(&t.Task)
+}
+
+type WaitRestoreDrillFinishTask struct {
+	WaitRestoreFinshTask
+}
+
+func newWaitRestoreDrillFinishTask() *WaitRestoreDrillFinishTask {
+	return &WaitRestoreDrillFinishTask{
+		WaitRestoreFinshTask: *newWaitRestoreFinshTask(),
+	}
+}
+
+func (t *WaitRestoreDrillFinishTask) Execute() error {
+	return failRestoreDrillOnError(&t.Task, t.WaitRestoreFinshTask.Execute())
+}
+
+type ActiveScratchTenantTask struct {
+	ActiveTenantTask
+}
+
+func newActiveScratchTenantTask() *ActiveScratchTenantTask {
+	return &ActiveScratchTenantTask{
+		ActiveTenantTask: *newActiveTenantTask(),
+	}
+}
+
+func (t *ActiveScratchTenantTask) Execute() error {
+	return failRestoreDrillOnError(&t.Task, t.ActiveTenantTask.Execute())
+}
+
+// failRestoreDrillOnError fails the drill when the restore step failed,
+// since a drill is disposable and the scratch tenant should not be left behind.
+func failRestoreDrillOnError(t *task.Task, err error) error {
+	if err == nil {
+		return nil
+	}
+	t.ExecuteWarnLogf("Restore drill failed: %v", err)
+	if cleanErr := failRestoreDrill(t); cleanErr != nil {
+		t.ExecuteWarnLogf("Clean up restore drill failed: %v", cleanErr)
+	}
+	return err
+}
+
+// failRestoreDrill drops the scratch tenant with its resource pools and marks the report as failed.
+func failRestoreDrill(t *task.Task) (err error) {
+	var reportID int64
+	if err = t.GetContext().GetParamWithValue(PARAM_RESTORE_DRILL_REPORT_ID, &reportID); err != nil {
+		return err
+	}
+	if err = dropScratchTenant(t); err != nil {
+		return err
+	}
+	return tenantService.UpdateRestoreDrillReport(reportID, map[string]interface{}{
+		"status":   constant.RESTORE_DRILL_STATUS_FAILED,
+		"end_time": time.Now(),
+	})
+}
+
+// dropScratchTenant drops the scratch tenant and the resource pools created for it, if they exist.
+func dropScratchTenant(t *task.Task) (err error) {
+	var tenantName, timeStamp string
+	var restoreParam param.RestoreParam
+	if err = t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &tenantName); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetParamWithValue(PARAM_RESTORE, &restoreParam); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetParamWithValue(PARAM_TASK_TIME, &timeStamp); err != nil {
+		return err
+	}
+
+	t.ExecuteLogf("Drop scratch tenant '%s'", tenantName)
+	for i := 0; i < waitForDropScratchTenant; i++ {
+		tenant, err := tenantService.GetTenantByName(tenantName)
+		if err != nil {
+			return errors.Wrap(err, "get tenant")
+		}
+		if tenant == nil {
+			break
+		}
+		if i == 0 {
+			if err = tenantService.DeleteTenant(tenantName); err != nil {
+				return errors.Wrapf(err, "drop tenant %s", tenantName)
+			}
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+
+	pools := buildCreateResourcePoolTaskParam(tenantName, restoreParam.ZoneList, timeStamp)
+	return pool.DropFreeResourcePools(*t, pools)
+}
+
+type VerifyRestoreDrillTask struct {
+	task.Task
+	tenantName string
+	drill      param.RestoreDrillParam
+	reportID   int64
+}
+
+func newVerifyRestoreDrillTask() *VerifyRestoreDrillTask {
+	t := &VerifyRestoreDrillTask{
+		Task: *task.NewSubTask(TASK_VERIFY_RESTORE_DRILL),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *VerifyRestoreDrillTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetParamWithValue(PARAM_RESTORE_DRILL, &t.drill); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetParamWithValue(PARAM_RESTORE_DRILL_REPORT_ID, &t.reportID); err != nil {
+		return err
+	}
+
+	verifications := t.drill.ToVerifications()
+	results := make([]bo.RestoreDrillVerificationResult, 0, len(verifications))
+	if len(verifications) == 0 {
+		t.ExecuteLog("No verification specified")
+	} else {
+		// Failures are recorded in the report rather than returned,
+		// so that the scratch tenant is still dropped afterwards.
+		db, err := tenant.GetConnectionWithPassword(t.tenantName, t.drill.RootPassword)
+		if err != nil {
+			t.ExecuteWarnLogf("Connect to tenant '%s' failed: %v", t.tenantName, err)
+		} else {
+			defer tenant.CloseDbConnection(db)
+		}
+		for _, v := range verifications {
+			result := bo.RestoreDrillVerificationResult{
+				Name: v.Name,
+				Sql:  v.Sql,
+			}
+			if v.Expected != nil {
+				result.Expected = *v.Expected
+			}
+			if db == nil {
+				result.Error = "connect to the scratch tenant failed"
+			} else if result.Actual, err = queryFirstValue(db.Raw(v.Sql).Rows()); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Passed = v.Expected == nil || *v.Expected == result.Actual
+			}
+			if result.Passed {
+				t.ExecuteLogf("Verification '%s' passed, result: %s", v.Name, result.Actual)
+			} else {
+				t.ExecuteWarnLogf("Verification '%s' failed, expected: '%s', actual: '%s', error: '%s'", v.Name, result.Expected, result.Actual, result.Error)
+			}
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return tenantService.UpdateRestoreDrillReport(t.reportID, map[string]interface{}{"verifications": string(data)})
+}
+
+// queryFirstValue returns the first column of the first row as a string.
+func queryFirstValue(rows *sql.Rows, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	return values[0].String, nil
+}
+
+type DropScratchTenantTask struct {
+	task.Task
+}
+
+func newDropScratchTenantTask() *DropScratchTenantTask {
+	t := &DropScratchTenantTask{
+		Task: *task.NewSubTask(TASK_DROP_SCRATCH_TENANT),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *DropScratchTenantTask) Execute() error {
+	return dropScratchTenant(&t.Task)
+}
+
+type FinishRestoreDrillTask struct {
+	task.Task
+	reportID int64
+}
+
+func newFinishRestoreDrillTask() *FinishRestoreDrillTask {
+	t := &FinishRestoreDrillTask{
+		Task: *task.NewSubTask(TASK_FINISH_RESTORE_DRILL),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *FinishRestoreDrillTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_RESTORE_DRILL_REPORT_ID, &t.reportID); err != nil {
+		return err
+	}
+	report, err := tenantService.GetRestoreDrillReport(t.reportID)
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		return errors.Occur(errors.ErrObRestoreDrillReportNotExist, t.reportID)
+	}
+
+	verifications := report.ToBO().Verifications
+	failed := 0
+	for _, v := range verifications {
+		if !v.Passed {
+			failed++
+		}
+	}
+	status := constant.RESTORE_DRILL_STATUS_SUCCESS
+	if failed > 0 {
+		status = constant.RESTORE_DRILL_STATUS_FAILED
+	}
+	t.ExecuteLogf("Restore drill %s, %d of %d verification(s) passed", status, len(verifications)-failed, len(verifications))
+	if err = tenantService.UpdateRestoreDrillReport(t.reportID, map[string]interface{}{
+		"status":   status,
+		"end_time": time.Now(),
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Occur(errors.ErrObRestoreDrillVerifyFailed, failed, len(verifications))
+	}
+	return nil
+}
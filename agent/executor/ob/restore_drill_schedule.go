@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	configservice "github.com/oceanbase/obshell/agent/service/config"
+	"github.com/oceanbase/obshell/param"
+)
+
+// WatchRestoreDrill runs on the maintainer agent, it starts the scheduled
+// restore drill once the interval has passed since the last drill started.
+func runScheduledRestoreDrill() error {
+	schedule, err := getRestoreDrillSchedule()
+	if err != nil {
+		return err
+	}
+	if !schedule.Enabled || schedule.Drill == nil {
+		return nil
+	}
+	interval, err := system.ParseTime(schedule.Interval)
+	if err != nil {
+		return err
+	}
+
+	reports, err := tenantService.ListRestoreDrillReports(1)
+	if err != nil {
+		return errors.Wrap(err, "list restore drill reports")
+	}
+	if len(reports) > 0 {
+		last := toRestoreDrillReportBO(&reports[0])
+		if last.Status == constant.RESTORE_DRILL_STATUS_RUNNING || time.Since(last.StartTime) < interval {
+			return nil
+		}
+	}
+
+	dag, err := TenantRestoreDrill(schedule.Drill)
+	if err != nil {
+		return err
+	}
+	log.Infof("start scheduled restore drill, dag %s", dag.GenericID)
+	return nil
+}
+
+func getRestoreDrillSchedule() (*param.RestoreDrillSchedule, error) {
+	schedule := &param.RestoreDrillSchedule{
+		Interval: constant.RESTORE_DRILL_SCHEDULE_INTERVAL_DEFAULT,
+	}
+	ocsConfig, err := configservice.GetOcsConfig(constant.RESTORE_DRILL_SCHEDULE_CONFIG_KEY)
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrConfigGetFailed, err, constant.RESTORE_DRILL_SCHEDULE_CONFIG_KEY, err.Error())
+	}
+	if ocsConfig == nil {
+		return schedule, nil
+	}
+	if err = json.Unmarshal([]byte(ocsConfig.Value), schedule); err != nil {
+		return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	return schedule, nil
+}
+
+// GetRestoreDrillSchedule returns the schedule without the secrets of the drill.
+func GetRestoreDrillSchedule() (*param.RestoreDrillSchedule, error) {
+	schedule, err := getRestoreDrillSchedule()
+	if err != nil {
+		return nil, err
+	}
+	return maskRestoreDrillSchedule(schedule), nil
+}
+
+func SetRestoreDrillSchedule(p *param.RestoreDrillScheduleParam) (*param.RestoreDrillSchedule, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	schedule, err := getRestoreDrillSchedule()
+	if err != nil {
+		return nil, err
+	}
+	if p.Enabled != nil {
+		schedule.Enabled = *p.Enabled
+	}
+	if p.Interval != nil {
+		schedule.Interval = *p.Interval
+	}
+	if p.Drill != nil {
+		schedule.Drill = p.Drill
+	}
+	if schedule.Enabled && schedule.Drill == nil {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "drill", "the drill must be specified to enable the schedule")
+	}
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	if err = configservice.SaveOcsConfig(constant.RESTORE_DRILL_SCHEDULE_CONFIG_KEY, string(data), "Restore drill schedule"); err != nil {
+		return nil, err
+	}
+	return maskRestoreDrillSchedule(schedule), nil
+}
+
+func maskRestoreDrillSchedule(schedule *param.RestoreDrillSchedule) *param.RestoreDrillSchedule {
+	if schedule.Drill == nil {
+		return schedule
+	}
+	drill := *schedule.Drill
+	drill.DataBackupUri = uriWithoutSecret(drill.DataBackupUri)
+	if drill.ArchiveLogUri != nil {
+		archiveLogUri := uriWithoutSecret(*drill.ArchiveLogUri)
+		drill.ArchiveLogUri = &archiveLogUri
+	}
+	drill.Decryption = nil
+	drill.KmsEncryptInfo = nil
+	drill.RootPassword = nil
+	return &param.RestoreDrillSchedule{
+		Enabled:  schedule.Enabled,
+		Interval: schedule.Interval,
+		Drill:    &drill,
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/lib/path"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/param"
+)
+
+// GetRestorePoint finds the closest restorable point to the requested wall-clock time or scn,
+// together with the backup sets and archive pieces it needs and an estimation of the restore.
+func GetRestorePoint(p *param.RestorePointParam) (*param.RestorePoint, error) {
+	p.Format()
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	if !system.IsFileExist(path.OBAdmin()) {
+		return nil, errors.Occur(errors.ErrEnvironmentWithoutObAdmin)
+	}
+
+	requested, err := requestedRestoreSCN(p)
+	if err != nil {
+		return nil, err
+	}
+
+	materials, err := system.GetRestoreMaterials(p.DataBackupUri, *p.ArchiveLogUri)
+	if err != nil {
+		return nil, err
+	}
+	windows := materials.Windows()
+	if len(windows) == 0 {
+		return nil, errors.Occur(errors.ErrObRestoreWindowsEmpty, uriWithoutSecret(p.DataBackupUri), uriWithoutSecret(*p.ArchiveLogUri))
+	}
+
+	restoreSCN := closestRestorableSCN(windows, requested)
+	point := &param.RestorePoint{
+		RequestedSCN:  requested,
+		RequestedTime: system.SCNToTime(requested),
+		RestoreSCN:    restoreSCN,
+		RestoreTime:   system.SCNToTime(restoreSCN),
+		Adjusted:      restoreSCN != requested,
+	}
+	for _, window := range windows {
+		point.Windows = append(point.Windows, system.RestoreWindow{
+			StartTime: system.SCNToTime(window[0]),
+			EndTime:   system.SCNToTime(window[1]),
+		})
+	}
+
+	base := baseBackupSet(materials, restoreSCN)
+	if base == nil {
+		// Never happens as the restore scn is in the windows.
+		return nil, errors.Occur(errors.ErrObRestoreTimeNotValid, restoreSCN)
+	}
+	for _, set := range backupSetChain(materials, base) {
+		backupType := constant.BACKUP_SET_TYPE_INC
+		if set.PrevFullBackupSetID == 0 {
+			backupType = constant.BACKUP_SET_TYPE_FULL
+		}
+		point.BackupSets = append(point.BackupSets, param.RestorePointBackupSet{
+			BackupSetID:    set.BackupSetID,
+			BackupType:     backupType,
+			MinRestoreSCN:  set.MinRestoreSCN.Val,
+			MinRestoreTime: system.SCNToTime(set.MinRestoreSCN.Val),
+			Bytes:          set.Stats.OutputBytes,
+		})
+	}
+	for _, piece := range materials.ReplayPieces(base) {
+		if piece.StartSCN.Val > restoreSCN {
+			break
+		}
+		point.ArchivePieces = append(point.ArchivePieces, param.RestorePointPiece{
+			DestID:         piece.Key.DestId,
+			RoundID:        piece.Key.RoundId,
+			PieceID:        piece.Key.PieceId,
+			StartSCN:       piece.StartSCN.Val,
+			CheckpointSCN:  piece.CheckPointSCN.Val,
+			StartTime:      system.SCNToTime(piece.StartSCN.Val),
+			CheckpointTime: system.SCNToTime(piece.CheckPointSCN.Val),
+		})
+	}
+
+	fillRestorePointBytesFromCatalog(point, base.TenantId, p.DataBackupUri, *p.ArchiveLogUri)
+	estimateRestoreDuration(point)
+	return point, nil
+}
+
+func requestedRestoreSCN(p *param.RestorePointParam) (int64, error) {
+	if p.SCN != nil {
+		return *p.SCN, nil
+	}
+	timezone := ""
+	if p.Timezone != nil {
+		timezone = *p.Timezone
+	}
+	loc, err := parse.LoadLocation(timezone)
+	if err != nil {
+		return 0, err
+	}
+	t, err := parse.HumanTimeParse(*p.Time, loc)
+	if err != nil {
+		return 0, err
+	}
+	return system.TimeToSCN(t), nil
+}
+
+// closestRestorableSCN returns the scn itself if it is in the windows,
+// otherwise the nearest bound of the windows, the earlier one wins a tie.
+func closestRestorableSCN(windows [][2]int64, scn int64) int64 {
+	closest, distance := int64(0), int64(-1)
+	for _, window := range windows {
+		if window[0] <= scn && scn <= window[1] {
+			return scn
+		}
+		candidate := window[0]
+		if window[1] < scn {
+			candidate = window[1]
+		}
+		d := candidate - scn
+		if d < 0 {
+			d = -d
+		}
+		if distance < 0 || d < distance || (d == distance && candidate < closest) {
+			closest, distance = candidate, d
+		}
+	}
+	return closest
+}
+
+// baseBackupSet returns the latest backup set whose restore window contains the scn,
+// which leaves the least archive log to replay.
+func baseBackupSet(materials *system.RestoreMaterials, scn int64) *system.BackupSet {
+	var base *system.BackupSet
+	for _, set := range materials.BackupSets {
+		window, ok := materials.Window(set)
+		if !ok || scn < window[0] || scn > window[1] {
+			continue
+		}
+		if base == nil || set.MinRestoreSCN.Val > base.MinRestoreSCN.Val ||
+			(set.MinRestoreSCN.Val == base.MinRestoreSCN.Val && set.BackupSetID > base.BackupSetID) {
+			base = set
+		}
+	}
+	return base
+}
+
+// backupSetChain returns the full backup set and the incremental ones up to the base, in order.
+func backupSetChain(materials *system.RestoreMaterials, base *system.BackupSet) []*system.BackupSet {
+	chain := []*system.BackupSet{base}
+	visited := map[int]bool{base.BackupSetID: true}
+	for cur := base; cur.PrevIncBackupSetID > 0 && !visited[cur.PrevIncBackupSetID]; {
+		cur = materials.BackupSets[cur.PrevIncBackupSetID]
+		if cur == nil {
+			break
+		}
+		visited[cur.BackupSetID] = true
+		chain = append(chain, cur)
+	}
+	if full := materials.BackupSets[base.PrevFullBackupSetID]; full != nil && !visited[full.BackupSetID] {
+		chain = append(chain, full)
+	}
+	sort.Slice(chain, func(i, j int) bool {
+		return chain[i].BackupSetID < chain[j].BackupSetID
+	})
+	return chain
+}
+
+// fillRestorePointBytesFromCatalog fills the unknown sizes from the backup catalog of this cluster,
+// which only knows the backups written by this cluster.
+func fillRestorePointBytesFromCatalog(point *param.RestorePoint, tenantID int, dataURI, logURI string) {
+	if dataDest := uriWithoutParams(dataURI); dataDest != "" {
+		if files, err := tenantService.ListBackupSetFiles(tenantID); err != nil {
+			log.Warnf("list backup set files of tenant %d failed: %v", tenantID, err)
+		} else {
+			for i := range point.BackupSets {
+				set := &point.BackupSets[i]
+				for _, file := range files {
+					if set.Bytes == 0 && int(file.BackupSetID) == set.BackupSetID && strings.HasPrefix(file.Path, dataDest) {
+						set.Bytes = file.OutputBytes
+					}
+				}
+			}
+		}
+	}
+
+	if logDest := uriWithoutParams(logURI); logDest != "" && len(point.ArchivePieces) > 0 {
+		if files, err := tenantService.ListArchivelogPieceFiles(tenantID); err != nil {
+			log.Warnf("list archive log piece files of tenant %d failed: %v", tenantID, err)
+		} else {
+			for i := range point.ArchivePieces {
+				piece := &point.ArchivePieces[i]
+				for _, file := range files {
+					if piece.Bytes == 0 && int(file.RoundID) == piece.RoundID && int(file.PieceID) == piece.PieceID && strings.HasPrefix(file.Path, logDest) {
+						piece.Bytes = file.OutputBytes
+					}
+				}
+			}
+		}
+	}
+
+	for _, set := range point.BackupSets {
+		point.EstimatedBytes += set.Bytes
+	}
+	for _, piece := range point.ArchivePieces {
+		point.EstimatedBytes += piece.Bytes
+	}
+}
+
+func uriWithoutParams(uri string) string {
+	storage, err := system.GetStorageInterfaceByURI(uri)
+	if err != nil || storage == nil {
+		return ""
+	}
+	return storage.GenerateURIWhitoutParams()
+}
+
+// estimateRestoreDuration estimates the duration by the throughput of the latest succeeded restores,
+// or by a default throughput if there is none.
+func estimateRestoreDuration(point *param.RestorePoint) {
+	point.Throughput, point.ThroughputSource = constant.RESTORE_POINT_THROUGHPUT_DEFAULT, constant.RESTORE_POINT_SOURCE_DEFAULT
+
+	history, err := tenantService.ListFinishedRestoreHistory(constant.RESTORE_STATUS_SUCCESS, constant.RESTORE_POINT_HISTORY_SAMPLES)
+	if err != nil {
+		log.Warnf("list restore history failed: %v", err)
+	}
+	var bytes int64
+	var elapsed time.Duration
+	for _, job := range history {
+		start, err1 := time.ParseInLocation(time.DateTime, job.StartTimestamp, time.Local)
+		finish, err2 := time.ParseInLocation(time.DateTime, job.FinishTimestamp, time.Local)
+		if err1 != nil || err2 != nil || job.TotalBytes <= 0 || !finish.After(start) {
+			continue
+		}
+		bytes += job.TotalBytes
+		elapsed += finish.Sub(start)
+	}
+	if elapsed >= time.Second && bytes/int64(elapsed/time.Second) > 0 {
+		point.Throughput, point.ThroughputSource = bytes/int64(elapsed/time.Second), constant.RESTORE_POINT_SOURCE_HISTORY
+	}
+
+	point.EstimatedDuration = (point.EstimatedBytes + point.Throughput - 1) / point.Throughput
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/path"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+// ListRestoreSources discovers the tenant backups under the base uri and their restore windows,
+// so that a cluster can be restored from the backups of another cluster.
+func ListRestoreSources(p *param.RestoreSourceParam) ([]param.RestoreSource, error) {
+	p.Format()
+	dataStorage, err := system.GetStorageInterfaceByURI(p.DataBackupBaseUri)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "data_backup_base_uri", err.Error())
+	}
+	logStorage, err := system.GetStorageInterfaceByURI(*p.ArchiveLogBaseUri)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "archive_log_base_uri", err.Error())
+	}
+
+	sources, err := findRestoreSources(dataStorage)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrObRestoreSourceListFailed, dataStorage.GenerateURIWithoutSecret(), err.Error())
+	}
+	if len(sources) == 0 {
+		return nil, errors.Occur(errors.ErrObRestoreSourceNotFound, dataStorage.GenerateURIWithoutSecret())
+	}
+
+	hasObAdmin := system.IsFileExist(path.OBAdmin())
+	for i := range sources {
+		source := &sources[i]
+		dataDest := withSubpath(dataStorage, source.DataSubpath)
+		logDest := withSubpath(logStorage, source.ArchiveLogSubpath)
+		source.DataBackupUri = dataDest.GenerateURIWithoutSecret()
+		source.ArchiveLogUri = logDest.GenerateURIWithoutSecret()
+		if !hasObAdmin {
+			source.Error = errors.Occur(errors.ErrEnvironmentWithoutObAdmin).Error()
+			continue
+		}
+		windows, err := system.GetRestoreWindows(dataDest.GenerateURI(), logDest.GenerateURI())
+		if err != nil {
+			source.Error = err.Error()
+			continue
+		}
+		source.Windows = windows.Windows
+	}
+	return sources, nil
+}
+
+// findRestoreSources finds the tenant backup in the base uri itself, or the tenant backups
+// in the '{cluster_id}/{tenant_id}/{data|clog}' layout of the cluster level backup config.
+func findRestoreSources(storage system.StorageInterface) ([]param.RestoreSource, error) {
+	dirs, err := storage.ListDirs()
+	if err != nil {
+		return nil, err
+	}
+	if utils.ContainsString(dirs, constant.RESTORE_SOURCE_DIR_BACKUP_SETS) {
+		return []param.RestoreSource{{}}, nil
+	}
+
+	sources := make([]param.RestoreSource, 0)
+	for _, clusterDir := range dirs {
+		clusterID, err := strconv.Atoi(clusterDir)
+		if err != nil {
+			continue
+		}
+		tenantDirs, err := storage.NewWithObjectKey(clusterDir).ListDirs()
+		if err != nil {
+			return nil, err
+		}
+		for _, tenantDir := range tenantDirs {
+			tenantID, err := strconv.Atoi(tenantDir)
+			if err != nil {
+				continue
+			}
+			dataSubpath := fmt.Sprintf("%d/%d/%s", clusterID, tenantID, constant.BACKUP_DIR_DATA)
+			backupDirs, err := storage.NewWithObjectKey(dataSubpath).ListDirs()
+			if err != nil {
+				return nil, err
+			}
+			if !utils.ContainsString(backupDirs, constant.RESTORE_SOURCE_DIR_BACKUP_SETS) {
+				continue
+			}
+			sources = append(sources, param.RestoreSource{
+				ClusterID:         clusterID,
+				TenantID:          tenantID,
+				DataSubpath:       dataSubpath,
+				ArchiveLogSubpath: fmt.Sprintf("%d/%d/%s", clusterID, tenantID, constant.BACKUP_DIR_CLOG),
+			})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].ClusterID != sources[j].ClusterID {
+			return sources[i].ClusterID < sources[j].ClusterID
+		}
+		return sources[i].TenantID < sources[j].TenantID
+	})
+	return sources, nil
+}
+
+func withSubpath(storage system.StorageInterface, subpath string) system.StorageInterface {
+	if subpath == "" {
+		return storage
+	}
+	return storage.NewWithObjectKey(subpath)
+}
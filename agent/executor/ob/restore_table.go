@@ -0,0 +1,360 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/pool"
+	"github.com/oceanbase/obshell/agent/executor/zone"
+	"github.com/oceanbase/obshell/agent/lib/path"
+	"github.com/oceanbase/obshell/agent/lib/pkg"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+func TenantRecoverTable(p *param.RecoverTableParam) (*task.DagDetailDTO, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+
+	zone.RenderZoneParams(p.ZoneList)
+	if err := zone.CheckZoneParams(p.ZoneList); err != nil {
+		return nil, err
+	}
+
+	version, err := obclusterService.GetObVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob version")
+	}
+	if pkg.CompareVersion(version, constant.OB_VERSION_4_2_1_0) < 0 {
+		return nil, errors.Occur(errors.ErrObRestoreTableVersionNotSupported, constant.OB_VERSION_4_2_1_0, version)
+	}
+
+	if err := checkRecoverTableTarget(p); err != nil {
+		return nil, err
+	}
+
+	template := buildRecoverTableTemplate(p)
+	ctx := task.NewTaskContext().
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true).
+		SetParam(PARAM_RECOVER_TABLE, *p).
+		SetParam(PARAM_TENANT_NAME, p.TargetTenantName).
+		SetParam(PARAM_TASK_TIME, strconv.Itoa(int(time.Now().UnixMilli())))
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// checkRecoverTableTarget checks the target tenant is able to accept the restored tables.
+func checkRecoverTableTarget(p *param.RecoverTableParam) error {
+	tenant, err := tenantService.GetTenantByName(p.TargetTenantName)
+	if err != nil {
+		return errors.Wrap(err, "get tenant")
+	}
+	if tenant == nil {
+		return errors.Occur(errors.ErrObTenantNotExist, p.TargetTenantName)
+	}
+	if tenant.Status != constant.TENANT_STATUS_NORMAL {
+		return errors.Occur(errors.ErrObTenantStatusNotNormal, p.TargetTenantName, tenant.Status)
+	}
+
+	job, err := tenantService.GetRunningRecoverTableJob(p.TargetTenantName)
+	if err != nil {
+		return errors.Wrap(err, "get running table restore job")
+	}
+	if job != nil {
+		return errors.Occur(errors.ErrObRestoreTableJobRunning, p.TargetTenantName)
+	}
+
+	for _, table := range p.TargetTables() {
+		parts := strings.Split(table, ".")
+		exist, err := tenantService.IsTableExist(tenant.TenantID, parts[0], parts[1])
+		if err != nil {
+			return errors.Wrapf(err, "check table '%s'", table)
+		}
+		if exist {
+			return errors.Occur(errors.ErrObRestoreTableExisted, table, p.TargetTenantName)
+		}
+	}
+	return nil
+}
+
+func buildRecoverTableTemplate(p *param.RecoverTableParam) *task.Template {
+	name := fmt.Sprintf("%s_%s", DAG_RECOVER_TABLE, p.TargetTenantName)
+	return task.NewTemplateBuilder(name).
+		SetMaintenance(task.TenantMaintenance(p.TargetTenantName)).
+		AddTask(newPreRecoverTableCheckTask(), false).
+		AddTask(newStartRecoverTableTask(), false).
+		AddTask(newWaitRecoverTableFinishTask(), false).
+		AddTask(newDropAuxiliaryPoolTask(), false).
+		Build()
+}
+
+// buildAuxiliaryPoolParam returns the resource pools of the auxiliary tenant,
+// which is created by observer to restore the tables and dropped when the job finished.
+func buildAuxiliaryPoolParam(ctx *task.TaskContext) ([]param.CreateResourcePoolTaskParam, error) {
+	var p param.RecoverTableParam
+	if err := ctx.GetParamWithValue(PARAM_RECOVER_TABLE, &p); err != nil {
+		return nil, err
+	}
+	var timestamp string
+	if err := ctx.GetParamWithValue(PARAM_TASK_TIME, &timestamp); err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("%s_%s", constant.RECOVER_TABLE_AUX_POOL_NAME, p.TargetTenantName)
+	return buildCreateResourcePoolTaskParam(prefix, p.ZoneList, timestamp), nil
+}
+
+type PreRecoverTableCheckTask struct {
+	task.Task
+	param *param.RecoverTableParam
+}
+
+func newPreRecoverTableCheckTask() *PreRecoverTableCheckTask {
+	t := &PreRecoverTableCheckTask{
+		Task: *task.NewSubTask(TASK_PRE_RECOVER_TABLE_CHECK),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *PreRecoverTableCheckTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_RECOVER_TABLE, &t.param); err != nil {
+		return err
+	}
+
+	t.ExecuteLogf("Check target tenant '%s'", t.param.TargetTenantName)
+	if err = checkRecoverTableTarget(t.param); err != nil {
+		return err
+	}
+
+	if !system.IsFileExist(path.OBAdmin()) {
+		t.ExecuteLog("Not need to check ob_admin")
+		return nil
+	}
+
+	var scn int64
+	if t.param.Timestamp != nil {
+		t.ExecuteLogf("Check restore time '%s'", t.param.Timestamp.Format("2006-01-02 15:04:05.00"))
+		scn = t.param.Timestamp.UnixNano()
+	} else {
+		scn = *t.param.SCN
+		t.ExecuteLogf("Check restore time '%d'", scn)
+	}
+	if err = system.CheckRestoreTime(t.param.DataBackupUri, *t.param.ArchiveLogUri, scn); err != nil {
+		return errors.Wrap(err, "check restore time")
+	}
+	return nil
+}
+
+func (t *PreRecoverTableCheckTask) GetAdditionalData() map[string]any {
+	return map[string]any{
+		ADDL_KEY_RESTORE_JOB_ID: 0,
+	}
+}
+
+type StartRecoverTableTask struct {
+	task.Task
+	param    *param.RecoverTableParam
+	poolList []param.CreateResourcePoolTaskParam
+}
+
+func newStartRecoverTableTask() *StartRecoverTableTask {
+	t := &StartRecoverTableTask{
+		Task: *task.NewSubTask(TASK_START_RECOVER_TABLE),
+	}
+	t.SetCanRetry().SetCanRollback().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *StartRecoverTableTask) getParams() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_RECOVER_TABLE, &t.param); err != nil {
+		return err
+	}
+	t.poolList, err = buildAuxiliaryPoolParam(t.GetContext())
+	return err
+}
+
+func (t *StartRecoverTableTask) Execute() (err error) {
+	if err = t.getParams(); err != nil {
+		return err
+	}
+
+	job, err := tenantService.GetRunningRecoverTableJob(t.param.TargetTenantName)
+	if err != nil {
+		return errors.Wrap(err, "get running table restore job")
+	}
+	if job == nil {
+		if err = pool.CreatePools(t.Task, t.poolList); err != nil {
+			return err
+		}
+		poolNames := make([]string, 0, len(t.poolList))
+		for _, p := range t.poolList {
+			poolNames = append(poolNames, p.PoolName)
+		}
+
+		recoverParam := *t.param
+		if recoverParam.Decryption, err = resolveRestoreDecryption(t.param.DataBackupUri, t.param.Decryption); err != nil {
+			return err
+		}
+		if recoverParam.Decryption != t.param.Decryption {
+			t.ExecuteLogf("Decrypt the backup with %d stored backup keys", len(*recoverParam.Decryption))
+		}
+
+		t.ExecuteLogf("Restore tables into tenant '%s'", t.param.TargetTenantName)
+		if err = tenantService.RecoverTable(&recoverParam, strings.Join(poolNames, ",")); err != nil {
+			return errors.Wrap(err, "recover table")
+		}
+
+		if job, err = tenantService.GetRunningRecoverTableJob(t.param.TargetTenantName); err != nil {
+			return errors.Wrap(err, "get running table restore job")
+		}
+		if job == nil {
+			// The job may have finished before it could be observed as running.
+			if job, err = tenantService.GetLastRecoverTableJobHistory(t.param.TargetTenantName); err != nil {
+				return errors.Wrap(err, "get table restore job history")
+			}
+		}
+	}
+	var jobID int64
+	if job != nil {
+		jobID = job.JobID
+		t.ExecuteLogf("Table restore job id is %d", jobID)
+	}
+	t.GetContext().SetData(ADDL_KEY_RESTORE_JOB_ID, jobID)
+	return nil
+}
+
+func (t *StartRecoverTableTask) Rollback() (err error) {
+	if err = t.getParams(); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("Try cancel table restore job")
+	if err = tenantService.CancelRecoverTable(t.param.TargetTenantName); err != nil {
+		t.ExecuteWarnLogf("Cancel table restore job failed: %v", err)
+	}
+	for i := 0; i < waitForRestoreTaskFinish; i++ {
+		job, err := tenantService.GetRunningRecoverTableJob(t.param.TargetTenantName)
+		if err != nil {
+			return errors.Wrap(err, "get running table restore job")
+		}
+		if job == nil {
+			break
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+
+	return pool.DropFreeResourcePools(t.Task, t.poolList)
+}
+
+type WaitRecoverTableFinishTask struct {
+	task.Task
+	tenantName string
+	jobID      int64
+}
+
+func newWaitRecoverTableFinishTask() *WaitRecoverTableFinishTask {
+	t := &WaitRecoverTableFinishTask{
+		Task: *task.NewSubTask(TASK_WAIT_RECOVER_TABLE_FINISH),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *WaitRecoverTableFinishTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetDataWithValue(ADDL_KEY_RESTORE_JOB_ID, &t.jobID); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("Wait for table restore job finish")
+	for i := 0; i < waitForRestoreTaskFinish; i++ {
+		job, err := tenantService.GetRunningRecoverTableJob(t.tenantName)
+		if err != nil {
+			return errors.Wrap(err, "get running table restore job")
+		}
+		if job == nil {
+			break
+		}
+		if i%60 == 0 {
+			t.ExecuteLogf("Table restore job %d is %s", job.JobID, job.Status)
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+
+	var history *oceanbase.CdbObRecoverTableJob
+	if t.jobID == 0 {
+		history, err = tenantService.GetLastRecoverTableJobHistory(t.tenantName)
+	} else {
+		history, err = tenantService.GetRecoverTableJobHistory(t.jobID)
+	}
+	if err != nil {
+		return errors.Wrap(err, "get table restore job history")
+	}
+	if history == nil {
+		return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("restore tables into tenant '%s'", t.tenantName))
+	}
+	if history.Result != constant.RECOVER_TABLE_RESULT_SUCCESS {
+		return errors.Occur(errors.ErrObRestoreTableFailed, history.JobID, t.tenantName, history.Comment)
+	}
+	t.ExecuteLog("Table restore job has finished successfully")
+	return nil
+}
+
+func (t *WaitRecoverTableFinishTask) GetAdditionalData() map[string]any {
+	if err := t.GetContext().GetDataWithValue(ADDL_KEY_RESTORE_JOB_ID, &t.jobID); err != nil {
+		return nil
+	}
+	return map[string]any{
+		ADDL_KEY_RESTORE_JOB_ID: t.jobID,
+	}
+}
+
+type DropAuxiliaryPoolTask struct {
+	task.Task
+}
+
+func newDropAuxiliaryPoolTask() *DropAuxiliaryPoolTask {
+	t := &DropAuxiliaryPoolTask{
+		Task: *task.NewSubTask(TASK_DROP_AUXILIARY_POOL),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *DropAuxiliaryPoolTask) Execute() error {
+	poolList, err := buildAuxiliaryPoolParam(t.GetContext())
+	if err != nil {
+		return err
+	}
+	return pool.DropFreeResourcePools(t.Task, poolList)
+}
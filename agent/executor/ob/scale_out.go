@@ -40,6 +40,7 @@ import (
 	oceanbaseModel "github.com/oceanbase/obshell/agent/repository/model/oceanbase"
 	"github.com/oceanbase/obshell/agent/secure"
 	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
 )
 
 var (
@@ -61,24 +62,113 @@ type LocalScaleOutResp struct {
 	ParamWaitStartRetryStage  int `json:"paramWaitStartRetryStage" binding:"required"`
 }
 
-func HandleClusterScaleOut(param param.ClusterScaleOutParam) (*task.DagDetailDTO, error) {
+// scaleOutTarget is an agent to be scaled out by the cluster scale out dag.
+type scaleOutTarget struct {
+	AgentInfo           meta.AgentInfo    `json:"agentInfo"`
+	Zone                string            `json:"zone"`
+	ObConfigs           map[string]string `json:"obConfigs"`
+	TargetVersion       string            `json:"targetVersion"`       // Empty if the agent has the same version as the cluster agent.
+	TargetAgentPassword string            `json:"targetAgentPassword"` // Encrypted for the agent.
+}
+
+// HandleClusterScaleOut scales out all the agents in one dag.
+// The agents are deployed and started in parallel, and rolled back together if any of them fails.
+func HandleClusterScaleOut(p param.ClusterScaleOutParam) (*task.DagDetailDTO, error) {
 	if !meta.OCS_AGENT.IsClusterAgent() {
 		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
 	}
+	scaleOutAgents, err := p.ScaleOutAgents()
+	if err != nil {
+		return nil, err
+	}
+	obVersion, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob version failed")
+	}
+	sharedConfigs := make(map[string]string)
+	for k, v := range p.ObConfigs {
+		sharedConfigs[k] = v
+	}
+	if err := paramToConfig(sharedConfigs); err != nil {
+		return nil, err
+	}
+
+	targets := make([]scaleOutTarget, 0, len(scaleOutAgents))
+	agents := make(map[string]bool)
+	servers := make(map[string]bool)
+	for _, agentParam := range scaleOutAgents {
+		if agents[agentParam.AgentInfo.String()] {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "agents", fmt.Sprintf("%s is duplicated", agentParam.AgentInfo.String()))
+		}
+		agents[agentParam.AgentInfo.String()] = true
+
+		target, err := checkScaleOutAgent(agentParam, sharedConfigs, obVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		srvInfo, err := checkScaleOutServer(target)
+		if err != nil {
+			return nil, err
+		}
+		if servers[srvInfo.String()] {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "agents", fmt.Sprintf("server %s is duplicated", srvInfo.String()))
+		}
+		servers[srvInfo.String()] = true
+		targets = append(targets, *target)
+	}
+
+	newAgents := make([]meta.AgentInfoInterface, 0, len(targets))
+	for i := range targets {
+		newAgents = append(newAgents, &targets[i].AgentInfo)
+	}
+	if err := CheckClockSkewForOperation("scale-out", newAgents...); err != nil {
+		return nil, err
+	}
+
+	// Create Cluster Scale Out Dag
+	dag, err := createClusterScaleOutDag(targets)
+	if err != nil {
+		return nil, err
+	}
+	return dag, nil
+}
+
+// checkScaleOutServer returns the observer of the target, and checks it is not already in the cluster.
+func checkScaleOutServer(target *scaleOutTarget) (*meta.AgentInfo, error) {
+	var err error
+	var rpcPort int
+	rpcPortStr, ok := target.ObConfigs[constant.CONFIG_RPC_PORT]
+	if ok {
+		if rpcPort, err = strconv.Atoi(rpcPortStr); err != nil {
+			return nil, errors.Occur(errors.ErrCommonInvalidPort, rpcPortStr)
+		}
+	} else {
+		rpcPort = constant.DEFAULT_RPC_PORT
+	}
+	srvInfo := meta.NewAgentInfo(target.AgentInfo.Ip, rpcPort)
+	if exist, err := obclusterService.IsServerExist(*srvInfo); err != nil {
+		return nil, err
+	} else if exist {
+		return nil, errors.Occur(errors.ErrAgentAlreadyExists, srvInfo.String())
+	}
+	return srvInfo, nil
+}
+
+// checkScaleOutAgent checks whether the agent can be scaled out, and builds the scale out target of it.
+func checkScaleOutAgent(p param.ScaleOutAgentParam, sharedConfigs map[string]string, obVersion string) (*scaleOutTarget, error) {
 	// Check scaling agent status.
 	var agent meta.AgentStatus
-	if err := http.SendGetRequest(&param.AgentInfo, constant.URI_API_V1+constant.URI_INFO, nil, &agent); err != nil {
-		return nil, errors.Wrapf(err, "get %s status failed", param.AgentInfo.String())
+	if err := http.SendGetRequest(&p.AgentInfo, constant.URI_API_V1+constant.URI_INFO, nil, &agent); err != nil {
+		return nil, errors.Wrapf(err, "get %s status failed", p.AgentInfo.String())
 	}
 	if !agent.IsSingleAgent() {
 		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, agent.AgentInfo.String(), agent.Identity, meta.SINGLE)
 	}
 
 	// check ob version is consistent
-	if obVersion, _, err := binary.GetMyOBVersion(); err != nil {
-		return nil, errors.Wrap(err, "get ob version failed")
-	} else if obVersion != agent.OBVersion {
-		return nil, errors.Occur(errors.ErrAgentOBVersionInconsistent, param.AgentInfo.String(), agent.OBVersion, meta.OCS_AGENT.String(), obVersion)
+	if obVersion != agent.OBVersion {
+		return nil, errors.Occur(errors.ErrAgentOBVersionInconsistent, p.AgentInfo.String(), agent.OBVersion, meta.OCS_AGENT.String(), obVersion)
 	}
 
 	var targetVersion string
@@ -98,36 +188,35 @@ func HandleClusterScaleOut(param param.ClusterScaleOutParam) (*task.DagDetailDTO
 		targetVersion = constant.VERSION
 	}
 
-	param.ObConfigs[constant.CONFIG_HOME_PATH] = agent.HomePath
-	if err := paramToConfig(param.ObConfigs); err != nil {
+	// The configs of the agent override the shared configs.
+	agentConfigs := make(map[string]string)
+	for k, v := range p.ObConfigs {
+		agentConfigs[k] = v
+	}
+	if err := paramToConfig(agentConfigs); err != nil {
 		return nil, err
 	}
-
-	var rpcPort int
-	rpcPortStr, ok := param.ObConfigs[constant.CONFIG_RPC_PORT]
-	if ok {
-		var err error
-		if rpcPort, err = strconv.Atoi(rpcPortStr); err != nil {
-			return nil, errors.Occur(errors.ErrCommonInvalidPort, rpcPortStr)
-		}
-	} else {
-		rpcPort = constant.DEFAULT_RPC_PORT
+	configs := make(map[string]string)
+	for k, v := range sharedConfigs {
+		configs[k] = v
 	}
-	srvInfo := meta.NewAgentInfo(param.AgentInfo.Ip, rpcPort)
-
-	// Check the server is not already in the cluster.
-	if exist, err := obclusterService.IsServerExist(*srvInfo); err != nil {
-		return nil, err
-	} else if exist {
-		return nil, errors.Occur(errors.ErrAgentAlreadyExists, srvInfo.String())
+	for k, v := range agentConfigs {
+		configs[k] = v
 	}
+	configs[constant.CONFIG_HOME_PATH] = agent.HomePath
 
-	// Create Cluster Scale Out Dag
-	dag, err := CreateClusterScaleOutDag(param, targetVersion)
+	// get target agent pk
+	encryptAgentPassword, err := secure.EncryptForAgent(p.TargetAgentPassword, &p.AgentInfo)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "encrypt agent password failed")
 	}
-	return dag, nil
+	return &scaleOutTarget{
+		AgentInfo:           p.AgentInfo,
+		Zone:                p.Zone,
+		ObConfigs:           configs,
+		TargetVersion:       targetVersion,
+		TargetAgentPassword: encryptAgentPassword,
+	}, nil
 }
 
 func HandleLocalScaleOut(params param.LocalScaleOutParam) (*LocalScaleOutResp, error) {
@@ -162,20 +251,23 @@ func HandleLocalScaleOut(params param.LocalScaleOutParam) (*LocalScaleOutResp, e
 	}, nil
 }
 
-func CreateClusterScaleOutDag(param param.ClusterScaleOutParam, targetVersion string) (*task.DagDetailDTO, error) {
-	zone := param.Zone
-	isZoneExist, err := obclusterService.IsZoneExistInOB(zone)
-	if err != nil {
-		return nil, errors.Wrap(err, "check zone exist failed")
-	}
-	// get target agent pk
-	encryptAgentPassword, err := secure.EncryptForAgent(param.TargetAgentPassword, &param.AgentInfo)
-	if err != nil {
-		return nil, errors.Wrap(err, "encrypt agent password failed")
+func createClusterScaleOutDag(targets []scaleOutTarget) (*task.DagDetailDTO, error) {
+	newZones := make([]string, 0)
+	for _, target := range targets {
+		if utils.ContainsString(newZones, target.Zone) {
+			continue
+		}
+		isZoneExist, err := obclusterService.IsZoneExistInOB(target.Zone)
+		if err != nil {
+			return nil, errors.Wrap(err, "check zone exist failed")
+		}
+		if !isZoneExist {
+			newZones = append(newZones, target.Zone)
+		}
 	}
 
-	template := buildClusterScaleOutTaskTemplate(!isZoneExist)
-	context := buildClusterScaleOutDagContext(param, !isZoneExist, targetVersion, encryptAgentPassword)
+	template := buildClusterScaleOutTaskTemplate(len(newZones) > 0)
+	context := buildClusterScaleOutDagContext(targets, newZones)
 	dag, err := clusterTaskService.CreateDagInstanceByTemplate(template, context)
 	if err != nil {
 		return nil, err
@@ -215,23 +307,29 @@ func CreateLocalScaleOutDag(param param.LocalScaleOutParam) (*task.Dag, error) {
 	return dag, nil
 }
 
-func buildClusterScaleOutTaskTemplate(isNewZone bool) *task.Template {
-	templateBuild := task.NewTemplateBuilder(DAG_NAME_CLUSTER_SCALE_OUT).
+func buildClusterScaleOutTaskTemplate(hasNewZone bool) *task.Template {
+	return newClusterScaleOutTemplateBuilder(DAG_NAME_CLUSTER_SCALE_OUT, hasNewZone).
+		AddTask(newFinishTask(), false).
+		Build()
+}
+
+// newClusterScaleOutTemplateBuilder builds the tasks to add the servers and agents into the cluster.
+// The FinishTask must be the last task of the dag, because the scaling agents wait for the dag to reach its last stage.
+func newClusterScaleOutTemplateBuilder(name string, hasNewZone bool) *task.TemplateBuilder {
+	templateBuild := task.NewTemplateBuilder(name).
 		AddTask(newIntegrateSingleObConfigTask(), false).
 		AddTask(newCreateLocalScaleOutDagTask(), false).
 		AddTask(newWaitScalingReadyTask(), false).
 		AddTask(newWaitRemoteDeployTaskFinish(), false).
 		AddTask(newWaitRemoteStartTaskFinish(), false).
 		AddTask(newPrevCheckTask(), false)
-	if isNewZone {
+	if hasNewZone {
 		templateBuild.AddTask(newAddNewZoneTask(), false).
 			AddTask(newStartNewZoneTask(), false)
 	}
-	templateBuild.AddTask(newAddServerTask(), false).
+	return templateBuild.AddTask(newAddServerTask(), false).
 		AddTask(newAddAgentTask(), false).
-		AddTask(newFinishTask(), false)
-
-	return templateBuild.SetMaintenance(task.GlobalMaintenance()).Build()
+		SetMaintenance(task.GlobalMaintenance())
 }
 
 func buildLocalScaleOutTaskTemplate(param param.LocalScaleOutParam) *task.Template {
@@ -250,14 +348,10 @@ func buildLocalScaleOutTaskTemplate(param param.LocalScaleOutParam) *task.Templa
 		Build()
 }
 
-func buildClusterScaleOutDagContext(param param.ClusterScaleOutParam, isNewZone bool, targetVersion string, targetAgentPassword string) *task.TaskContext {
+func buildClusterScaleOutDagContext(targets []scaleOutTarget, newZones []string) *task.TaskContext {
 	context := task.NewTaskContext().
-		SetParam(PARAM_ZONE, param.Zone).
-		SetParam(PARAM_IS_NEW_ZONE, isNewZone).
-		SetParam(PARAM_AGENT_INFO, param.AgentInfo).
-		SetParam(PARAM_CONFIG, param.ObConfigs).
-		SetParam(PARAM_TARGET_AGENT_VERSION, targetVersion).
-		SetParam(PARAM_TARGET_AGENT_PASSWORD, targetAgentPassword)
+		SetParam(PARAM_SCALE_OUT_TARGETS, targets).
+		SetParam(PARAM_NEW_ZONES, newZones)
 	return context
 }
 
@@ -322,22 +416,103 @@ func (t *scaleCoordinateTask) init() error {
 	return nil
 }
 
-func (t *scaleCoordinateTask) initFromData() error {
-	ctx := t.GetContext()
-	if ctx.GetData(PARAM_COORDINATE_DAG_ID) != nil {
-		if err := ctx.GetDataWithValue(PARAM_COORDINATE_DAG_ID, &t.coordinateDagId); err != nil {
-			return err
+func getScaleOutTargets(ctx *task.TaskContext) (targets []scaleOutTarget, err error) {
+	if ctx.GetParam(PARAM_SCALE_OUT_TARGETS) == nil {
+		return getLegacyScaleOutTargets(ctx)
+	}
+	err = ctx.GetParamWithValue(PARAM_SCALE_OUT_TARGETS, &targets)
+	return
+}
+
+// getLegacyScaleOutTargets builds the only target of a scale out dag created before batch scale out
+// was supported, and copies the data of the dag to the target agent, so that the dag could still be
+// retried or rolled back.
+func getLegacyScaleOutTargets(ctx *task.TaskContext) ([]scaleOutTarget, error) {
+	var target scaleOutTarget
+	if err := ctx.GetParamWithValue(PARAM_AGENT_INFO, &target.AgentInfo); err != nil {
+		return nil, err
+	}
+	if err := ctx.GetParamWithValue(PARAM_ZONE, &target.Zone); err != nil {
+		return nil, err
+	}
+	if err := ctx.GetParamWithValue(PARAM_CONFIG, &target.ObConfigs); err != nil {
+		return nil, err
+	}
+	if err := ctx.GetParamWithValue(PARAM_TARGET_AGENT_PASSWORD, &target.TargetAgentPassword); err != nil {
+		return nil, err
+	}
+	if ctx.GetParam(PARAM_TARGET_AGENT_VERSION) != nil {
+		if err := ctx.GetParamWithValue(PARAM_TARGET_AGENT_VERSION, &target.TargetVersion); err != nil {
+			return nil, err
 		}
-	} else {
-		t.coordinateDagId = ""
+	}
+
+	agentKey := target.AgentInfo.String()
+	for _, key := range []string{PARAM_DIRS, PARAM_CONFIG, PARAM_COORDINATE_DAG_ID, PARAM_JOIN_MASTER_INFO,
+		PARAM_WAIT_DEPLOY_RETRY_STAGE, PARAM_WAIT_START_RETRY_STAGE, PARAM_SCALE_OUT_UUID} {
+		if value := ctx.GetData(key); value != nil && ctx.GetAgentDataByAgentKey(agentKey, key) == nil {
+			ctx.SetAgentDataByAgentKey(agentKey, key, value)
+		}
+	}
+	if value := ctx.GetParam(PARAM_ADD_SERVER_SUCCEED); value != nil && ctx.GetAgentDataByAgentKey(agentKey, PARAM_ADD_SERVER_SUCCEED) == nil {
+		ctx.SetAgentDataByAgentKey(agentKey, PARAM_ADD_SERVER_SUCCEED, value)
+	}
+	return []scaleOutTarget{target}, nil
+}
+
+// getScaleOutNewZones returns the zones to be added by the scale out dag.
+func getScaleOutNewZones(ctx *task.TaskContext) (newZones []string, err error) {
+	if ctx.GetParam(PARAM_NEW_ZONES) != nil {
+		err = ctx.GetParamWithValue(PARAM_NEW_ZONES, &newZones)
+		return
+	}
+	// The legacy scale out dag adds at most one zone.
+	var isNewZone bool
+	if err = ctx.GetParamWithValue(PARAM_IS_NEW_ZONE, &isNewZone); err != nil || !isNewZone {
+		return
+	}
+	var zone string
+	if err = ctx.GetParamWithValue(PARAM_ZONE, &zone); err != nil {
+		return
+	}
+	return []string{zone}, nil
+}
+
+// initFromTarget inits the task with the local scale out dag of the target agent,
+// the coordinate dag id is empty if the local scale out dag has not been created.
+func (t *scaleCoordinateTask) initFromTarget(target *scaleOutTarget) error {
+	agentKey := target.AgentInfo.String()
+	t.coordinateAgent = target.AgentInfo
+	t.allAgent = []meta.AgentInfo{target.AgentInfo}
+	t.coordinateDagId = ""
+	if t.GetContext().GetAgentDataByAgentKey(agentKey, PARAM_COORDINATE_DAG_ID) == nil {
 		return nil
 	}
-	if err := ctx.GetDataWithValue(PARAM_COORDINATE_AGENT, &t.coordinateAgent); err != nil {
+	return t.GetContext().GetAgentDataByAgentKeyWithValue(agentKey, PARAM_COORDINATE_DAG_ID, &t.coordinateDagId)
+}
+
+// syncTargetCoordinateDag syncs the local scale out dag of the target agent with the operator of the task.
+func (t *scaleCoordinateTask) syncTargetCoordinateDag(target *scaleOutTarget) error {
+	if err := t.initFromTarget(target); err != nil {
 		return err
 	}
-	if err := ctx.GetDataWithValue(PARAM_ALL_AGENTS, &t.allAgent); err != nil {
+	if _, err := t.syncCoordinateDag(); err != nil {
+		return errors.Wrapf(err, "sync coordinate dag of %s failed", target.AgentInfo.String())
+	}
+	return nil
+}
+
+// syncAllTargetCoordinateDags syncs the local scale out dags of all the target agents.
+func (t *scaleCoordinateTask) syncAllTargetCoordinateDags() error {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
+	for i := range targets {
+		if err := t.syncTargetCoordinateDag(&targets[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -703,16 +878,10 @@ func newIntegrateSingleObConfigTask() *IntegrateSingleObConfigTask {
 }
 
 func (t *IntegrateSingleObConfigTask) Execute() error {
-	var configs map[string]string
-	if err := t.GetContext().GetParamWithValue(PARAM_CONFIG, &configs); err != nil {
-		return err
-	}
-	var agentInfo meta.AgentInfo
-	if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
-	zone := t.GetContext().GetParam(PARAM_ZONE).(string)
-	configs[constant.CONFIG_ZONE] = zone
 
 	// Get cluster name and cluster id.
 	var clusterName, clusterId string
@@ -722,29 +891,37 @@ func (t *IntegrateSingleObConfigTask) Execute() error {
 	if err := observerService.GetOBParatemerByName(PARAM_CLUSTER_ID, &clusterId); err != nil {
 		return errors.Wrap(err, "get cluster id failed")
 	}
-	configs[constant.CONFIG_CLUSTER_NAME] = clusterName
-	configs[constant.CONFIG_CLUSTER_ID] = clusterId
 
-	if err := fillPort(configs); err != nil {
-		return errors.Wrap(err, "fill port failed")
-	}
-	fillDir(configs)
+	for _, target := range targets {
+		configs := target.ObConfigs
+		if configs == nil {
+			configs = make(map[string]string)
+		}
+		configs[constant.CONFIG_ZONE] = target.Zone
+		configs[constant.CONFIG_CLUSTER_NAME] = clusterName
+		configs[constant.CONFIG_CLUSTER_ID] = clusterId
 
-	dirs := make(map[string]string)
-	for _, key := range allDirOrder {
-		dirs[key] = configs[key]
-		delete(configs, key)
-	}
-	t.GetContext().SetData(PARAM_DIRS, dirs)
+		if err := fillPort(configs); err != nil {
+			return errors.Wrap(err, "fill port failed")
+		}
+		fillDir(configs)
 
-	// Set observer configs
-	t.GetContext().SetData(PARAM_CONFIG, configs)
+		dirs := make(map[string]string)
+		for _, key := range allDirOrder {
+			dirs[key] = configs[key]
+			delete(configs, key)
+		}
+
+		// Set observer configs
+		agentKey := target.AgentInfo.String()
+		t.GetContext().SetAgentDataByAgentKey(agentKey, PARAM_DIRS, dirs).
+			SetAgentDataByAgentKey(agentKey, PARAM_CONFIG, configs)
+	}
 	return nil
 }
 
 type CreateLocalScaleOutDagTask struct {
 	scaleCoordinateTask
-	targetAgentPassword string
 }
 
 func newCreateLocalScaleOutDagTask() *CreateLocalScaleOutDagTask {
@@ -756,130 +933,148 @@ func newCreateLocalScaleOutDagTask() *CreateLocalScaleOutDagTask {
 }
 
 func (t *CreateLocalScaleOutDagTask) Execute() error {
-	var agentInfo meta.AgentInfo
-	if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
-	if err := t.GetContext().GetParamWithValue(PARAM_TARGET_AGENT_PASSWORD, &t.targetAgentPassword); err != nil {
+	remoteTaskId, err := clusterTaskService.GetRemoteTaskIdByLocalTaskId(t.GetID())
+	if err != nil {
+		return errors.Wrap(err, "get remote dag id failed")
+	}
+	dagId, err := clusterTaskService.GetDagGenericIDBySubTaskId(remoteTaskId)
+	if err != nil {
+		return errors.Wrap(err, "get dag generic id failed")
+	}
+	allAgents, err := agentService.GetAllAgentsInfoFromOB()
+	if err != nil {
 		return err
 	}
+	for i := range targets {
+		if err := t.createLocalScaleOutDag(&targets[i], dagId, allAgents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *CreateLocalScaleOutDagTask) createLocalScaleOutDag(target *scaleOutTarget, dagId string, allAgents []meta.AgentInfo) error {
+	ctx := t.GetContext()
+	agentKey := target.AgentInfo.String()
+	if ctx.GetAgentDataByAgentKey(agentKey, PARAM_COORDINATE_DAG_ID) != nil {
+		t.ExecuteLogf("local scale out dag of %s has been created", agentKey)
+		return nil
+	}
+
 	// Send rpc to target agent.
-	param, err := t.buildLocalScaleOutParam()
+	param, err := t.buildLocalScaleOutParam(target, dagId, allAgents)
 	if err != nil {
 		return errors.Wrap(err, "build local scale out param failed")
 	}
 
 	if param.TargetVersion != "" {
-		t.ExecuteLogf("create local scale out dag, target version: %s", param.TargetVersion)
+		t.ExecuteLogf("create local scale out dag on %s, target version: %s", agentKey, param.TargetVersion)
 	}
 
 	var resp LocalScaleOutResp
-	if err := secure.SendRequestWithPassword(&agentInfo, constant.URI_OB_RPC_PREFIX+constant.URI_SCALE_OUT, http.POST, t.targetAgentPassword, param, &resp); err != nil {
-		return errors.Wrap(err, "send scale out rpc to target agent failed")
-	}
-	t.ExecuteLogf("create local scale out dag success, genericID:%s", resp.GenericID)
-	t.GetContext().SetData(PARAM_COORDINATE_AGENT, agentInfo)
-	t.GetContext().SetData(PARAM_ALL_AGENTS, []meta.AgentInfo{agentInfo})
-	t.GetContext().SetData(PARAM_JOIN_MASTER_INFO, resp.JoinMasterParam)
-	t.GetContext().SetData(PARAM_WAIT_DEPLOY_RETRY_STAGE, resp.ParamWaitDeployRetryStage)
-	t.GetContext().SetData(PARAM_WAIT_START_RETRY_STAGE, resp.ParamWaitStartRetryStage)
-	t.GetContext().SetData(PARAM_COORDINATE_DAG_ID, resp.GenericID)
+	if err := secure.SendRequestWithPassword(&target.AgentInfo, constant.URI_OB_RPC_PREFIX+constant.URI_SCALE_OUT, http.POST, target.TargetAgentPassword, param, &resp); err != nil {
+		return errors.Wrapf(err, "send scale out rpc to %s failed", agentKey)
+	}
+	t.ExecuteLogf("create local scale out dag on %s success, genericID:%s", agentKey, resp.GenericID)
+	ctx.SetAgentDataByAgentKey(agentKey, PARAM_JOIN_MASTER_INFO, resp.JoinMasterParam).
+		SetAgentDataByAgentKey(agentKey, PARAM_WAIT_DEPLOY_RETRY_STAGE, resp.ParamWaitDeployRetryStage).
+		SetAgentDataByAgentKey(agentKey, PARAM_WAIT_START_RETRY_STAGE, resp.ParamWaitStartRetryStage).
+		SetAgentDataByAgentKey(agentKey, PARAM_COORDINATE_DAG_ID, resp.GenericID)
 	return nil
 }
 
-func (t *CreateLocalScaleOutDagTask) buildLocalScaleOutParam() (*param.LocalScaleOutParam, error) {
-	var agentInfo meta.AgentInfo
+func (t *CreateLocalScaleOutDagTask) buildLocalScaleOutParam(target *scaleOutTarget, dagId string, allAgents []meta.AgentInfo) (*param.LocalScaleOutParam, error) {
 	ctx := t.GetContext()
-	if err := ctx.GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
-		return nil, err
-	}
+	agentKey := target.AgentInfo.String()
 	var dirs map[string]string
-	if err := ctx.GetDataWithValue(PARAM_DIRS, &dirs); err != nil {
+	if err := ctx.GetAgentDataByAgentKeyWithValue(agentKey, PARAM_DIRS, &dirs); err != nil {
 		return nil, err
 	}
 	var configs map[string]string
-	if err := ctx.GetDataWithValue(PARAM_CONFIG, &configs); err != nil {
+	if err := ctx.GetAgentDataByAgentKeyWithValue(agentKey, PARAM_CONFIG, &configs); err != nil {
 		return nil, err
 	}
-	zone := ctx.GetParam(PARAM_ZONE).(string)
-	remoteTaskId, err := clusterTaskService.GetRemoteTaskIdByLocalTaskId(t.GetID())
-	if err != nil {
-		return nil, errors.Wrap(err, "get remote dag id failed")
-	}
-	dagId, err := clusterTaskService.GetDagGenericIDBySubTaskId(remoteTaskId)
-	if err != nil {
-		return nil, errors.Wrap(err, "get dag generic id failed")
-	}
-	cipherPassword, err := secure.EncryptForAgent(meta.OCEANBASE_PWD, &agentInfo)
+	cipherPassword, err := secure.EncryptForAgent(meta.OCEANBASE_PWD, &target.AgentInfo)
 	if err != nil {
 		return nil, err
 	}
-	allAgents, err := agentService.GetAllAgentsInfoFromOB()
-	if err != nil {
-		return nil, err
+	// Reuse the uuid when retrying, so that the local scale out dag created before can be found.
+	uuidStr, ok := ctx.GetAgentDataByAgentKey(agentKey, PARAM_SCALE_OUT_UUID).(string)
+	if !ok {
+		uuidStr = uuid.New().String()
+		ctx.SetAgentDataByAgentKey(agentKey, PARAM_SCALE_OUT_UUID, uuidStr)
 	}
-	uuid := uuid.New().String()
-	t.GetContext().SetAgentData(meta.OCS_AGENT, PARAM_SCALE_OUT_UUID, uuid)
 
-	var targetVersion string
-	if err := ctx.GetParamWithValue(PARAM_TARGET_AGENT_VERSION, &targetVersion); err != nil {
-		return nil, err
-	}
 	param := param.LocalScaleOutParam{
 		ScaleOutParam: param.ScaleOutParam{
 			AgentInfo: meta.OCS_AGENT.GetAgentInfo(),
 			ObConfigs: configs,
-			Zone:      zone,
+			Zone:      target.Zone,
 		},
 		Dirs:                         dirs,
 		CoordinateDagId:              dagId,
 		AllAgents:                    allAgents,
 		RootPwd:                      cipherPassword,
-		Uuid:                         uuid,
+		Uuid:                         uuidStr,
 		ParamExpectDeployNextStage:   paramExpectDeployNextStage,
 		ParamExpectStartNextStage:    paramExpectStartNextStage,
 		ParamExpectRollbackNextStage: paramExpectRollbackNextStage,
-		TargetVersion:                targetVersion,
+		TargetVersion:                target.TargetVersion,
 	}
 	return &param, nil
 }
 
 func (t *CreateLocalScaleOutDagTask) Rollback() error {
-	t.initFromData()
-	if t.coordinateDagId != "" {
-		// Local scale out dag has been created.
-		if _, err := t.syncCoordinateDag(); err != nil {
-			return errors.Wrap(err, "sync coordinate dag failed")
-		}
-	} else {
-		var agentInfo meta.AgentInfo
-		if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		if err := t.rollbackTarget(&targets[i]); err != nil {
 			return err
 		}
-		t.coordinateAgent = agentInfo
-		if t.GetContext().GetData(PARAM_SCALE_OUT_UUID) == nil {
-			return nil
-		}
-		dag, err := t.getCoordinaterLastMaintainDag()
-		if err != nil {
-			return errors.Wrap(err, "get coordinater last maintain dag failed")
-		}
-		if dag.AdditionalData == nil {
-			return errors.Occur(errors.ErrCommonUnexpected, "get coordinater last maintain dag failed, additional data is nil")
-		}
-		additionalData := *(dag.AdditionalData)
-		if dag == nil || additionalData[PARAM_SCALE_OUT_UUID].(string) != t.GetContext().GetData(PARAM_SCALE_OUT_UUID).(string) {
-			t.ExecuteInfoLog("no need to rollback")
-			return nil
-		}
-		t.coordinateDagId = dag.GenericID
-		// Sync coordinate dag.
+	}
+	return nil
+}
+
+func (t *CreateLocalScaleOutDagTask) rollbackTarget(target *scaleOutTarget) error {
+	if err := t.initFromTarget(target); err != nil {
+		return err
+	}
+	if t.coordinateDagId != "" {
+		// Local scale out dag has been created.
 		if _, err := t.syncCoordinateDag(); err != nil {
-			return errors.Wrap(err, "sync coordinate dag failed")
-		} else {
-			t.ExecuteInfoLog("sync coordinate dag successfully")
+			return errors.Wrapf(err, "sync coordinate dag of %s failed", target.AgentInfo.String())
 		}
+		return nil
 	}
+
+	uuidStr, ok := t.GetContext().GetAgentDataByAgentKey(target.AgentInfo.String(), PARAM_SCALE_OUT_UUID).(string)
+	if !ok {
+		return nil
+	}
+	dag, err := t.getCoordinaterLastMaintainDag()
+	if err != nil {
+		return errors.Wrap(err, "get coordinater last maintain dag failed")
+	}
+	if dag == nil || dag.AdditionalData == nil {
+		return errors.Occur(errors.ErrCommonUnexpected, "get coordinater last maintain dag failed, additional data is nil")
+	}
+	additionalData := *(dag.AdditionalData)
+	if dagUuid, _ := additionalData[PARAM_SCALE_OUT_UUID].(string); dagUuid != uuidStr {
+		t.ExecuteInfoLogf("no need to rollback %s", target.AgentInfo.String())
+		return nil
+	}
+	t.coordinateDagId = dag.GenericID
+	// Sync coordinate dag.
+	if _, err := t.syncCoordinateDag(); err != nil {
+		return errors.Wrapf(err, "sync coordinate dag of %s failed", target.AgentInfo.String())
+	}
+	t.ExecuteInfoLogf("sync coordinate dag of %s successfully", target.AgentInfo.String())
 	return nil
 }
 
@@ -911,11 +1106,26 @@ func newWaitScalingReadyTask() *WaitScalingReadyTask {
 	return newTask
 }
 
-// Execute will wait for the 'Be Scaling Agent Task' of local scale out dag to complete.
+// Execute will wait for the 'Be Scaling Agent Task' of each local scale out dag to complete.
 func (t *WaitScalingReadyTask) Execute() error {
-	t.initFromData()
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		if err := t.waitScalingReady(&targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *WaitScalingReadyTask) waitScalingReady(target *scaleOutTarget) error {
+	if err := t.initFromTarget(target); err != nil {
+		return err
+	}
 	var expectStage int
-	if err := t.GetContext().GetDataWithValue(PARAM_WAIT_DEPLOY_RETRY_STAGE, &expectStage); err != nil {
+	if err := t.GetContext().GetAgentDataByAgentKeyWithValue(target.AgentInfo.String(), PARAM_WAIT_DEPLOY_RETRY_STAGE, &expectStage); err != nil {
 		return err
 	}
 	expectStage -= 2
@@ -927,7 +1137,7 @@ func (t *WaitScalingReadyTask) Execute() error {
 			continue
 		}
 		if dag.Nodes[expectStage].IsSucceed() {
-			t.ExecuteInfoLogf("local scale out dag task %s is succeed", dag.Nodes[expectStage].Name)
+			t.ExecuteInfoLogf("local scale out dag task %s of %s is succeed", dag.Nodes[expectStage].Name, target.AgentInfo.String())
 			return nil
 		}
 		if dag.Nodes[expectStage].IsFailed() {
@@ -940,11 +1150,7 @@ func (t *WaitScalingReadyTask) Execute() error {
 }
 
 func (t *WaitScalingReadyTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	return nil
+	return t.syncAllTargetCoordinateDags()
 }
 
 type WaitRemoteDeployTaskFinish struct {
@@ -959,10 +1165,29 @@ func newWaitRemoteDeployTaskFinish() *WaitRemoteDeployTaskFinish {
 	return newTask
 }
 
+// Execute waits for the deploy task of each local scale out dag to finish,
+// the local scale out dags deploy in parallel once the cluster scale out dag reaches this stage.
 func (t *WaitRemoteDeployTaskFinish) Execute() error {
-	t.initFromData()
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		if err := t.waitRemoteTaskFinish(&targets[i], PARAM_WAIT_DEPLOY_RETRY_STAGE, "wait remote deploy task finish"); err != nil {
+			return errors.Wrapf(err, "wait remote deploy task of %s finish failed", targets[i].AgentInfo.String())
+		}
+	}
+	return nil
+}
+
+// waitRemoteTaskFinish retries the failed wait task at stage key of the local scale out dag,
+// and waits for the next task to finish.
+func (t *scaleCoordinateTask) waitRemoteTaskFinish(target *scaleOutTarget, stageKey string, action string) error {
+	if err := t.initFromTarget(target); err != nil {
+		return err
+	}
 	var expectStage int
-	if err := t.GetContext().GetDataWithValue(PARAM_WAIT_DEPLOY_RETRY_STAGE, &expectStage); err != nil {
+	if err := t.GetContext().GetAgentDataByAgentKeyWithValue(target.AgentInfo.String(), stageKey, &expectStage); err != nil {
 		return err
 	}
 	expectStage -= 1
@@ -971,6 +1196,8 @@ func (t *WaitRemoteDeployTaskFinish) Execute() error {
 		dag, err := t.getCoordinateDag()
 		if err != nil {
 			t.ExecuteErrorLogf("get remote dag failed, %s", err.Error())
+			time.Sleep(1 * time.Second)
+			continue
 		}
 		if dag.Nodes[expectStage].IsFailed() {
 			t.tryOperateDag(task.RETRY_STR, expectStage)
@@ -994,7 +1221,7 @@ func (t *WaitRemoteDeployTaskFinish) Execute() error {
 		}
 		time.Sleep(WAIT_REMOTE_TASK_FINISH_INTERVAL)
 	}
-	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "wait remote deploy task finish")
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("%s of %s", action, target.AgentInfo.String()))
 }
 
 func (t *scaleCoordinateTask) getMessage(dag *task.DagDetailDTO, node *task.NodeDetailDTO) error {
@@ -1011,11 +1238,7 @@ func (t *scaleCoordinateTask) getMessage(dag *task.DagDetailDTO, node *task.Node
 }
 
 func (t *WaitRemoteDeployTaskFinish) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	return nil
+	return t.syncAllTargetCoordinateDags()
 }
 
 type WaitRemoteStartTaskFinish struct {
@@ -1031,48 +1254,20 @@ func newWaitRemoteStartTaskFinish() *WaitRemoteStartTaskFinish {
 }
 
 func (t *WaitRemoteStartTaskFinish) Execute() error {
-	t.initFromData()
-	var expectStage int
-	if err := t.GetContext().GetDataWithValue(PARAM_WAIT_START_RETRY_STAGE, &expectStage); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
-	expectStage -= 1
-	for i := 0; i < DEFAULT_REMOTE_REQUEST_RETRY_TIMES; i++ {
-		dag, err := t.getCoordinateDag()
-		if err != nil {
-			t.ExecuteErrorLogf("get remote dag failed, %s", err.Error())
-		}
-		if dag.Nodes[expectStage].IsFailed() {
-			t.tryOperateDag(task.RETRY_STR, expectStage)
-			time.Sleep(1 * time.Second)
-			continue
-		} else if dag.Nodes[expectStage].IsSucceed() {
-			break
+	for i := range targets {
+		if err := t.waitRemoteTaskFinish(&targets[i], PARAM_WAIT_START_RETRY_STAGE, "wait remote start observer task finish"); err != nil {
+			return errors.Wrapf(err, "wait remote start observer task of %s finish failed", targets[i].AgentInfo.String())
 		}
-		time.Sleep(1 * time.Second)
 	}
-
-	// Wait for succeed.
-	for i := 0; i < WAIT_REMOTE_TASK_FINISH_TIMES; i++ {
-		dag, err := t.getCoordinateDag()
-		if err != nil {
-			return errors.Wrap(err, "get remote dag failed")
-		}
-		node := dag.Nodes[expectStage+1]
-		if node.IsFinished() {
-			return t.getMessage(dag, node)
-		}
-		time.Sleep(WAIT_REMOTE_TASK_FINISH_INTERVAL)
-	}
-	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "wait remote start observer task finish")
+	return nil
 }
 
 func (t *WaitRemoteStartTaskFinish) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	return nil
+	return t.syncAllTargetCoordinateDags()
 }
 
 type PrevCheckTask struct {
@@ -1089,31 +1284,29 @@ func newPrevCheckTask() *PrevCheckTask {
 
 func (t *PrevCheckTask) Execute() error {
 	t.ExecuteLog("PrevCheckTask execute")
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
-	}
-	isNewZone, ok := t.GetContext().GetParam(PARAM_IS_NEW_ZONE).(bool)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_IS_NEW_ZONE)
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
+		return err
 	}
-	/* check if the zone is exist */
-	isZoneExist, err := obclusterService.IsZoneExistInOB(zone)
+	newZones, err := getScaleOutNewZones(t.GetContext())
 	if err != nil {
-		return errors.Wrap(err, "check if the zone is exist failed")
+		return err
 	}
-	if isZoneExist == isNewZone {
-		return errors.Occur(errors.ErrCommonUnexpected, "zone has been changed")
+	/* check if the zone is exist */
+	for _, target := range targets {
+		isZoneExist, err := obclusterService.IsZoneExistInOB(target.Zone)
+		if err != nil {
+			return errors.Wrap(err, "check if the zone is exist failed")
+		}
+		if isZoneExist == utils.ContainsString(newZones, target.Zone) {
+			return errors.Occurf(errors.ErrCommonUnexpected, "zone %s has been changed", target.Zone)
+		}
 	}
 	return nil
 }
 
 func (t *PrevCheckTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	return nil
+	return t.syncAllTargetCoordinateDags()
 }
 
 type AddNewZoneTask struct {
@@ -1130,36 +1323,50 @@ func newAddNewZoneTask() *AddNewZoneTask {
 
 func (t *AddNewZoneTask) Execute() error {
 	t.ExecuteLog("AddZoneTask execute")
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
+	newZones, err := getScaleOutNewZones(t.GetContext())
+	if err != nil {
+		return err
 	}
-	/* add a new zone */
-	if err := obclusterService.AddZone(zone); err != nil {
-		return errors.Wrapf(err, "add zone %s failed", zone)
+	/* add new zones */
+	for _, zone := range newZones {
+		exist, err := obclusterService.IsZoneExistInOB(zone)
+		if err != nil {
+			return errors.Wrapf(err, "check zone %s exist failed", zone)
+		}
+		if exist {
+			// The zone has been added before retry.
+			continue
+		}
+		if err := obclusterService.AddZone(zone); err != nil {
+			return errors.Wrapf(err, "add zone %s failed", zone)
+		}
 	}
 	return nil
 }
 
 func (t *AddNewZoneTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
+	if err := t.syncAllTargetCoordinateDags(); err != nil {
+		return err
 	}
 	t.ExecuteLog("AddZoneTask rollback")
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
-	}
-	/* delete a zone */
-	exist, err := obclusterService.IsZoneExistInOB(zone)
+	newZones, err := getScaleOutNewZones(t.GetContext())
 	if err != nil {
-		return errors.Wrapf(err, "check zone %s exist failed", zone)
+		return err
 	}
-	if !exist {
-		return nil
+	/* delete new zones */
+	for _, zone := range newZones {
+		exist, err := obclusterService.IsZoneExistInOB(zone)
+		if err != nil {
+			return errors.Wrapf(err, "check zone %s exist failed", zone)
+		}
+		if !exist {
+			continue
+		}
+		if err := obclusterService.DeleteZone(zone); err != nil {
+			return errors.Wrapf(err, "delete zone %s failed", zone)
+		}
 	}
-	return obclusterService.DeleteZone(zone)
+	return nil
 }
 
 type StartNewZoneTask struct {
@@ -1176,35 +1383,38 @@ func newStartNewZoneTask() *StartNewZoneTask {
 
 func (t *StartNewZoneTask) Execute() error {
 	t.ExecuteLog("StartZoneTask execute")
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
+	newZones, err := getScaleOutNewZones(t.GetContext())
+	if err != nil {
+		return err
 	}
-	/* start zone */
-	if err := obclusterService.StartZone(zone); err != nil {
-		return errors.Wrapf(err, "start zone %s failed", zone)
+	/* start new zones */
+	for _, zone := range newZones {
+		if err := obclusterService.StartZone(zone); err != nil {
+			return errors.Wrapf(err, "start zone %s failed", zone)
+		}
 	}
 	return nil
 }
 
 func (t *StartNewZoneTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
+	if err := t.syncAllTargetCoordinateDags(); err != nil {
+		return err
 	}
 	t.ExecuteLog("StartZoneTask rollback")
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
+	newZones, err := getScaleOutNewZones(t.GetContext())
+	if err != nil {
+		return err
 	}
-	/* stop zone */
-	if err := obclusterService.StopZone(zone); err != nil {
-		return errors.Wrapf(err, "stop zone %s failed", zone)
+	/* stop new zones */
+	for _, zone := range newZones {
+		if err := obclusterService.StopZone(zone); err != nil {
+			return errors.Wrapf(err, "stop zone %s failed", zone)
+		}
 	}
 	return nil
 }
 
-/* add server task: add observer to ob cluster */
+/* add server task: add observers to ob cluster */
 type AddServerTask struct {
 	scaleCoordinateTask
 }
@@ -1216,85 +1426,90 @@ func newAddServerTask() *AddServerTask {
 	newTask.SetCanContinue().SetCanRetry().SetCanRollback()
 	return newTask
 }
-func (t *AddServerTask) Execute() error {
-	t.ExecuteLog("AddServerTask execute")
-	var agentInfo meta.AgentInfo
-	ctx := t.GetContext()
-	if err := ctx.GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
-		return err
-	}
+
+// getTargetServerInfo returns the observer of the target agent by the integrated configs.
+func (t *scaleCoordinateTask) getTargetServerInfo(target *scaleOutTarget) (*meta.AgentInfo, error) {
 	var configs map[string]string
-	if err := ctx.GetDataWithValue(PARAM_CONFIG, &configs); err != nil {
-		return err
-	}
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
+	if err := t.GetContext().GetAgentDataByAgentKeyWithValue(target.AgentInfo.String(), PARAM_CONFIG, &configs); err != nil {
+		return nil, err
 	}
-
 	port, err := strconv.Atoi(configs[constant.CONFIG_RPC_PORT])
 	if err != nil {
-		return errors.Wrap(err, "convert port to integer failed")
+		return nil, errors.Wrap(err, "convert rpc port to integer failed")
 	}
+	return meta.NewAgentInfo(target.AgentInfo.Ip, port), nil
+}
 
-	serverInfo := meta.NewAgentInfo(agentInfo.Ip, port)
-	err = obclusterService.AddServer(*serverInfo, zone)
+func (t *AddServerTask) Execute() error {
+	t.ExecuteLog("AddServerTask execute")
+	targets, err := getScaleOutTargets(t.GetContext())
 	if err != nil {
-		return errors.Wrapf(err, "add server %s failed", serverInfo.String())
+		return err
+	}
+	for i := range targets {
+		target := &targets[i]
+		serverInfo, err := t.getTargetServerInfo(target)
+		if err != nil {
+			return err
+		}
+		exist, err := obclusterService.IsServerExistWithZone(*serverInfo, target.Zone)
+		if err != nil {
+			return errors.Wrapf(err, "check server %s exist failed", serverInfo.String())
+		}
+		if !exist {
+			if err = obclusterService.AddServer(*serverInfo, target.Zone); err != nil {
+				return errors.Wrapf(err, "add server %s failed", serverInfo.String())
+			}
+		}
+		t.GetContext().SetAgentDataByAgentKey(target.AgentInfo.String(), PARAM_ADD_SERVER_SUCCEED, true)
 	}
-
-	t.GetContext().SetParam(PARAM_ADD_SERVER_SUCCEED, true)
-
 	return nil
 }
 
 func (t *AddServerTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
+		return err
 	}
-	var agentInfo meta.AgentInfo
-	ctx := t.GetContext()
-	if err := ctx.GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	for i := range targets {
+		if err := t.rollbackTarget(&targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *AddServerTask) rollbackTarget(target *scaleOutTarget) error {
+	if err := t.syncTargetCoordinateDag(target); err != nil {
 		return err
 	}
-	if agentInfo.Equal(meta.OCS_AGENT) {
-		agentService.BeScalingOutAgent(t.GetContext().GetParam(PARAM_ZONE).(string))
+	if target.AgentInfo.Equal(meta.OCS_AGENT) {
+		agentService.BeScalingOutAgent(target.Zone)
 		if err := scalingSelfRollback(); err != nil {
 			return err
 		}
 	}
 
-	if t.GetContext().GetParam(PARAM_ADD_SERVER_SUCCEED) == nil {
+	if t.GetContext().GetAgentDataByAgentKey(target.AgentInfo.String(), PARAM_ADD_SERVER_SUCCEED) == nil {
 		// If add server falied, should not delete from obcluster.
 		return nil
 	}
 
-	var configs map[string]string
-	if err := ctx.GetDataWithValue(PARAM_CONFIG, &configs); err != nil {
-		return err
-	}
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
-	}
-
-	port, err := strconv.Atoi(configs[constant.CONFIG_RPC_PORT])
+	serverInfo, err := t.getTargetServerInfo(target)
 	if err != nil {
-		return errors.Wrap(err, "convert rpc port to integer failed")
+		return err
 	}
-	serverInfo := meta.NewAgentInfo(agentInfo.Ip, port)
 
 	// Check whether addserver task execute successfully.
-	exist, err := obclusterService.IsServerExistWithZone(*serverInfo, zone)
+	exist, err := obclusterService.IsServerExistWithZone(*serverInfo, target.Zone)
 	if err != nil {
-		return errors.Wrapf(err, "check server %s exist failed", agentInfo.String())
+		return errors.Wrapf(err, "check server %s exist failed", serverInfo.String())
 	}
 	if !exist {
 		return nil
 	}
 
-	if err = obclusterService.DeleteServerInZone(*serverInfo, zone); err != nil {
+	if err = obclusterService.DeleteServerInZone(*serverInfo, target.Zone); err != nil {
 		return errors.Wrapf(err, "delete server %s failed", serverInfo.String())
 	}
 	return nil
@@ -1313,21 +1528,27 @@ func newAddAgentTask() *AddAgentTask {
 }
 
 func (t *AddAgentTask) Execute() error {
-	var agentInfo meta.AgentInfo
-	ctx := t.GetContext()
-	if err := ctx.GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
+	for i := range targets {
+		if err := t.addAgent(&targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *AddAgentTask) addAgent(target *scaleOutTarget) error {
+	ctx := t.GetContext()
+	agentKey := target.AgentInfo.String()
 	var configs map[string]string
-	if err := ctx.GetDataWithValue(PARAM_CONFIG, &configs); err != nil {
+	if err := ctx.GetAgentDataByAgentKeyWithValue(agentKey, PARAM_CONFIG, &configs); err != nil {
 		return err
 	}
-	zone, ok := t.GetContext().GetParam(PARAM_ZONE).(string)
-	if !ok {
-		return errors.Occur(errors.ErrTaskParamNotSet, PARAM_ZONE)
-	}
 	var scalingAgent param.JoinMasterParam
-	if err := ctx.GetDataWithValue(PARAM_JOIN_MASTER_INFO, &scalingAgent); err != nil {
+	if err := ctx.GetAgentDataByAgentKeyWithValue(agentKey, PARAM_JOIN_MASTER_INFO, &scalingAgent); err != nil {
 		return err
 	}
 	mysqlPort, err := strconv.Atoi(configs[constant.CONFIG_MYSQL_PORT])
@@ -1339,40 +1560,43 @@ func (t *AddAgentTask) Execute() error {
 		return errors.Wrap(err, "get rpc port failed")
 	}
 	agentInstance := oceanbaseModel.AllAgent{
-		Ip:           agentInfo.Ip,
-		Port:         agentInfo.Port,
+		Ip:           target.AgentInfo.Ip,
+		Port:         target.AgentInfo.Port,
 		Identity:     string(meta.CLUSTER_AGENT),
 		Os:           scalingAgent.Os,
 		Architecture: scalingAgent.Architecture,
 		Version:      scalingAgent.Version,
-		Zone:         zone,
+		Zone:         target.Zone,
 		HomePath:     scalingAgent.HomePath,
 		PublicKey:    scalingAgent.PublicKey,
 		MysqlPort:    mysqlPort,
 		RpcPort:      rpcPort,
 	}
 	if err := agentService.AddAgentInOB(agentInstance); err != nil {
-		return errors.Wrap(err, "add agent failed")
+		return errors.Wrapf(err, "add agent %s failed", agentKey)
 	}
 	return nil
 }
+
 func (t *AddAgentTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	var agentInfo meta.AgentInfo
-	if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
-	if agentInfo.Equal(meta.OCS_AGENT) {
-		agentService.BeScalingOutAgent(t.GetContext().GetParam(PARAM_ZONE).(string))
-		if err := scalingSelfRollback(); err != nil {
+	for i := range targets {
+		target := &targets[i]
+		if err := t.syncTargetCoordinateDag(target); err != nil {
 			return err
 		}
-	}
-	if err := agentService.DeleteAgentInOB(&agentInfo); err != nil {
-		t.ExecuteErrorLogf("delete agent %s failed", agentInfo.String())
+		if target.AgentInfo.Equal(meta.OCS_AGENT) {
+			agentService.BeScalingOutAgent(target.Zone)
+			if err := scalingSelfRollback(); err != nil {
+				return err
+			}
+		}
+		if err := agentService.DeleteAgentInOB(&target.AgentInfo); err != nil {
+			t.ExecuteErrorLogf("delete agent %s failed", target.AgentInfo.String())
+		}
 	}
 	return nil
 }
@@ -1390,16 +1614,25 @@ func newFinishTask() *FinishTask {
 }
 
 func (t *FinishTask) Execute() error {
-	t.initFromData()
 	t.ExecuteLog("FinishTask execute")
-	var agentInfo meta.AgentInfo
-	if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
+	for _, target := range targets {
+		if err := t.waitClusterAgent(&target.AgentInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitClusterAgent waits for the agent to become cluster agent.
+func (t *FinishTask) waitClusterAgent(agentInfo *meta.AgentInfo) error {
 	var agent meta.AgentStatus
 	for i := 0; i < DEFAULT_REMOTE_REQUEST_RETRY_TIMES; i++ {
 		// Get the identity of the agent.
-		if err := http.SendGetRequest(&agentInfo, constant.URI_API_V1+constant.URI_INFO, nil, &agent); err != nil {
+		if err := http.SendGetRequest(agentInfo, constant.URI_API_V1+constant.URI_INFO, nil, &agent); err != nil {
 			t.ExecuteWarnLogf("send info api to %s failed", agentInfo.String())
 			time.Sleep(1 * time.Second)
 			continue
@@ -1411,27 +1644,28 @@ func (t *FinishTask) Execute() error {
 		continue
 	}
 	if !agent.IsClusterAgent() {
-		return errors.Occur(errors.ErrCommonUnexpected, "agent has not been cluster agent")
+		return errors.Occurf(errors.ErrCommonUnexpected, "agent %s has not been cluster agent", agentInfo.String())
 	}
 	return nil
 }
 
 func (t *FinishTask) Rollback() error {
-	t.initFromData()
-	if _, err := t.syncCoordinateDag(); err != nil {
-		return errors.Wrap(err, "sync coordinate dag failed")
-	}
-	var agentInfo meta.AgentInfo
-	if err := t.GetContext().GetParamWithValue(PARAM_AGENT_INFO, &agentInfo); err != nil {
+	targets, err := getScaleOutTargets(t.GetContext())
+	if err != nil {
 		return err
 	}
-	if agentInfo.Equal(meta.OCS_AGENT) {
-		agentService.BeScalingOutAgent(t.GetContext().GetParam(PARAM_ZONE).(string))
-		if err := scalingSelfRollback(); err != nil {
+	for i := range targets {
+		target := &targets[i]
+		if err := t.syncTargetCoordinateDag(target); err != nil {
 			return err
 		}
+		if target.AgentInfo.Equal(meta.OCS_AGENT) {
+			agentService.BeScalingOutAgent(target.Zone)
+			if err := scalingSelfRollback(); err != nil {
+				return err
+			}
+		}
 	}
-
 	return nil
 }
 
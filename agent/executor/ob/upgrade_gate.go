@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+func checkUpgradeApproval(p *obUpgradeParams) error {
+	req := p.RequestParam
+	if !req.ZoneApproval {
+		return nil
+	}
+	if req.Mode != PARAM_ROLLING_UPGRADE {
+		return errors.Occur(errors.ErrObUpgradeApprovalNotSupported)
+	}
+	p.zoneApproval = true
+
+	if req.ApprovalTimeout != "" {
+		timeout, err := parse.TimeParse(req.ApprovalTimeout)
+		if err != nil {
+			return err
+		}
+		if timeout <= 0 {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "approvalTimeout", "must be greater than 0")
+		}
+		p.approvalTimeout = timeout
+	}
+
+	p.approvalTimeoutAction = strings.ToUpper(req.ApprovalTimeoutAction)
+	switch p.approvalTimeoutAction {
+	case "":
+		p.approvalTimeoutAction = constant.UPGRADE_GATE_TIMEOUT_ACTION_ABORT
+	case constant.UPGRADE_GATE_TIMEOUT_ACTION_APPROVE, constant.UPGRADE_GATE_TIMEOUT_ACTION_ABORT:
+	default:
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "approvalTimeoutAction",
+			fmt.Sprintf("must be '%s' or '%s'", constant.UPGRADE_GATE_TIMEOUT_ACTION_APPROVE, constant.UPGRADE_GATE_TIMEOUT_ACTION_ABORT))
+	}
+	return nil
+}
+
+func newWaitUpgradeZoneApprovalNode(zone string, idx int, p *obUpgradeParams) *task.Node {
+	// The task waits longer than the approval timeout, and waits forever without it.
+	timeout := int(constant.UPGRADE_GATE_TASK_TIMEOUT / time.Second)
+	if p.approvalTimeout > 0 {
+		timeout = p.approvalTimeout + int(task.DEFAULT_TIMEOUT/time.Second)
+	}
+	ctx := task.NewTaskContext()
+	ctx.SetParam(PARAM_UPGRADE_ROUTE_INDEX, idx).
+		SetParam(PARAM_ZONE, zone).
+		SetParam(PARAM_APPROVAL_TIMEOUT, p.approvalTimeout).
+		SetParam(PARAM_APPROVAL_ACTION, p.approvalTimeoutAction).
+		SetParam(task.TIMEOUT_KEY, timeout)
+	return task.NewNodeWithContext(newWaitUpgradeZoneApprovalTask(), false, ctx)
+}
+
+// WaitUpgradeZoneApprovalTask pauses the rolling upgrade after the zone is upgraded,
+// until the gate of the zone is approved or aborted through the api.
+type WaitUpgradeZoneApprovalTask struct {
+	task.Task
+	zone            string
+	routeIndex      int
+	approvalTimeout int
+	timeoutAction   string
+}
+
+func newWaitUpgradeZoneApprovalTask() *WaitUpgradeZoneApprovalTask {
+	newTask := &WaitUpgradeZoneApprovalTask{
+		Task: *task.NewSubTask(TASK_WAIT_UPGRADE_ZONE_APPROVAL),
+	}
+	newTask.SetCanRetry().SetCanCancel()
+	return newTask
+}
+
+func (t *WaitUpgradeZoneApprovalTask) getParams() (err error) {
+	ctx := t.GetContext()
+	if err = ctx.GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return
+	}
+	if err = ctx.GetParamWithValue(PARAM_UPGRADE_ROUTE_INDEX, &t.routeIndex); err != nil {
+		return
+	}
+	if err = ctx.GetParamWithValue(PARAM_APPROVAL_TIMEOUT, &t.approvalTimeout); err != nil {
+		return
+	}
+	return ctx.GetParamWithValue(PARAM_APPROVAL_ACTION, &t.timeoutAction)
+}
+
+func (t *WaitUpgradeZoneApprovalTask) Execute() error {
+	if err := t.getParams(); err != nil {
+		return err
+	}
+	dag, err := taskService.GetDagBySubTaskId(t.GetID())
+	if err != nil {
+		return errors.Wrap(err, "get upgrade dag")
+	}
+
+	gate := &oceanbase.UpgradeZoneGate{
+		DagID:         dag.GetID(),
+		RouteIndex:    t.routeIndex,
+		Zone:          t.zone,
+		TaskID:        t.GetID(),
+		TimeoutAction: t.timeoutAction,
+	}
+	if t.approvalTimeout > 0 {
+		deadline := time.Now().Add(time.Duration(t.approvalTimeout) * time.Second)
+		gate.Deadline = &deadline
+	}
+	if gate, err = obclusterService.OpenUpgradeZoneGate(gate); err != nil {
+		return errors.Wrap(err, "open upgrade zone gate")
+	}
+
+	genericID := task.ConvertIDToGenericID(dag.GetID(), false, dag.GetDagType())
+	if gate.Deadline != nil {
+		t.ExecuteInfoLogf("Zone %s is upgraded, paused until approved or aborted, %s automatically at %s",
+			t.zone, strings.ToLower(gate.TimeoutAction), gate.Deadline.Format(time.DateTime))
+	} else {
+		t.ExecuteInfoLogf("Zone %s is upgraded, paused until approved or aborted", t.zone)
+	}
+	t.ExecuteLogf("Run 'obshell cluster upgrade approve %s' or 'obshell cluster upgrade abort %s' to continue", genericID, genericID)
+
+	for {
+		current, err := obclusterService.GetUpgradeZoneGate(gate.Id)
+		if err != nil {
+			t.ExecuteWarnLogf("get upgrade zone gate failed: %v", err)
+		} else if current != nil {
+			switch current.Status {
+			case constant.UPGRADE_GATE_STATUS_APPROVED:
+				t.ExecuteInfoLogf("Zone %s is approved: %s", t.zone, current.Comment)
+				return nil
+			case constant.UPGRADE_GATE_STATUS_ABORTED:
+				return errors.Occur(errors.ErrObUpgradeAborted, t.zone, current.Comment)
+			}
+			if current.Deadline != nil && time.Now().After(*current.Deadline) {
+				status := constant.UPGRADE_GATE_STATUS_ABORTED
+				if current.TimeoutAction == constant.UPGRADE_GATE_TIMEOUT_ACTION_APPROVE {
+					status = constant.UPGRADE_GATE_STATUS_APPROVED
+				}
+				// Check the closed gate right now, or retry after the interval if failed.
+				if _, err := obclusterService.CloseUpgradeZoneGate(current.Id, status, "approval timed out"); err != nil {
+					t.ExecuteWarnLogf("close upgrade zone gate failed: %v", err)
+				} else {
+					continue
+				}
+			}
+		}
+		time.Sleep(constant.UPGRADE_GATE_CHECK_INTERVAL)
+		t.TimeoutCheck()
+	}
+}
+
+func (t *WaitUpgradeZoneApprovalTask) GetAdditionalData() map[string]any {
+	if t.IsPending() || t.IsReady() {
+		return nil
+	}
+	gate, err := obclusterService.GetUpgradeZoneGateByTask(t.GetID())
+	if err != nil || gate == nil {
+		return nil
+	}
+	data := map[string]any{
+		"status": gate.Status,
+	}
+	if gate.Deadline != nil {
+		data["deadline"] = gate.Deadline.Format(time.DateTime)
+		data["timeout_action"] = gate.TimeoutAction
+	}
+	return map[string]any{
+		ADDL_KEY_UPGRADE_GATES: map[string]any{
+			fmt.Sprintf("%d/%s", gate.RouteIndex, gate.Zone): data,
+		},
+	}
+}
+
+// ApproveUpgradeZone continues the rolling upgrade paused after the zone.
+func ApproveUpgradeZone(p *param.UpgradeGateParam) (*bo.UpgradeZoneGate, error) {
+	return closeUpgradeZoneGate(p, constant.UPGRADE_GATE_STATUS_APPROVED)
+}
+
+// AbortUpgradeZone fails the rolling upgrade paused after the zone,
+// retry the failed task to ask for approval again.
+func AbortUpgradeZone(p *param.UpgradeGateParam) (*bo.UpgradeZoneGate, error) {
+	return closeUpgradeZoneGate(p, constant.UPGRADE_GATE_STATUS_ABORTED)
+}
+
+func closeUpgradeZoneGate(p *param.UpgradeGateParam, status string) (*bo.UpgradeZoneGate, error) {
+	gates, err := listUpgradeZoneGates(p.DagID)
+	if err != nil {
+		return nil, err
+	}
+	for _, gate := range gates {
+		if gate.Status != constant.UPGRADE_GATE_STATUS_WAITING || (p.Zone != "" && gate.Zone != p.Zone) {
+			continue
+		}
+		comment := p.Comment
+		if comment == "" {
+			comment = fmt.Sprintf("%s at %s", strings.ToLower(status), time.Now().Format(time.DateTime))
+		}
+		ok, err := obclusterService.CloseUpgradeZoneGate(gate.Id, status, comment)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		gate.Status, gate.Comment = status, comment
+		return toUpgradeZoneGateBO(&gate, p.DagID), nil
+	}
+	return nil, errors.Occur(errors.ErrObUpgradeGateNotWaiting, p.DagID)
+}
+
+func ListUpgradeZoneGates(dagGenericID string) ([]bo.UpgradeZoneGate, error) {
+	gates, err := listUpgradeZoneGates(dagGenericID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.UpgradeZoneGate, 0, len(gates))
+	for i := range gates {
+		res = append(res, *toUpgradeZoneGateBO(&gates[i], dagGenericID))
+	}
+	return res, nil
+}
+
+func listUpgradeZoneGates(dagGenericID string) ([]oceanbase.UpgradeZoneGate, error) {
+	dagID, _, err := task.ConvertGenericID(dagGenericID)
+	if err != nil {
+		return nil, err
+	}
+	return obclusterService.ListUpgradeZoneGates(dagID)
+}
+
+func toUpgradeZoneGateBO(gate *oceanbase.UpgradeZoneGate, dagGenericID string) *bo.UpgradeZoneGate {
+	return &bo.UpgradeZoneGate{
+		DagID:         dagGenericID,
+		RouteIndex:    gate.RouteIndex,
+		Zone:          gate.Zone,
+		Status:        gate.Status,
+		Deadline:      gate.Deadline,
+		TimeoutAction: gate.TimeoutAction,
+		Comment:       gate.Comment,
+		CreateTime:    gate.GmtCreate,
+		UpdateTime:    gate.GmtModified,
+	}
+}
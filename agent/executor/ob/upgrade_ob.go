@@ -43,19 +43,27 @@ const (
 )
 
 type obUpgradeParams struct {
-	currentVersion  string
-	targetVersion   string
-	rollingUpgrade  bool
-	upgradeRoute    []RouteNode
-	dbaObZones      []oceanbase.DbaObZones
-	allAgents       []meta.AgentInfo
-	agentsInZoneMap map[string][]meta.AgentInfo
-	agents          []meta.AgentInfo
-	RequestParam    *param.ObUpgradeParam
+	currentVersion   string
+	fromBuildVersion string // Build version before upgrade, in the format of the upgrade route.
+	targetVersion    string
+	rollingUpgrade   bool
+	upgradeRoute     []RouteNode
+	dbaObZones       []oceanbase.DbaObZones
+	allAgents        []meta.AgentInfo
+	agentsInZoneMap  map[string][]meta.AgentInfo
+	agents           []meta.AgentInfo
+	RequestParam     *param.ObUpgradeParam
+
+	zoneApproval          bool
+	approvalTimeout       int // In seconds, 0 means no timeout.
+	approvalTimeoutAction string
 }
 
 func CheckAndUpgradeOb(param param.ObUpgradeParam) (*task.DagDetailDTO, error) {
 	log.Info("check and upgrade ob")
+	if err := CheckClockSkewForOperation("upgrade"); err != nil {
+		return nil, err
+	}
 	p, err := preCheckForObUpgrade(param)
 	if err != nil {
 		log.WithError(err).Error("pre check for ob upgrade failed")
@@ -89,7 +97,8 @@ func buildCheckAndUpgradeObTaskContext(p *obUpgradeParams) *task.TaskContext {
 		SetParam(PARAM_DISTRIBUTION, distribution).
 		SetParam(PARAM_RELEASE_DISTRIBUTION, p.RequestParam.Release).
 		SetParam(PARAM_UPGRADE_ROUTE, p.upgradeRoute).
-		SetParam(PARAM_ROLLING_UPGRADE, p.rollingUpgrade)
+		SetParam(PARAM_ROLLING_UPGRADE, p.rollingUpgrade).
+		SetParam(PARAM_UPGRADE_FROM_VERSION, p.fromBuildVersion)
 	return ctx
 }
 
@@ -159,6 +168,9 @@ func newRollingUpgradeProcessTemplate(idx int, p *obUpgradeParams) *task.Templat
 			AddNode(newReinstallAndRestartObNode(dbaObZone.Zone, agents, idx)).
 			AddNode(newExecZoneHealthCheckerNode(dbaObZone.Zone, idx)).
 			AddNode(newStartZoneNode(dbaObZone.Zone))
+		if p.zoneApproval {
+			builder.AddNode(newWaitUpgradeZoneApprovalNode(dbaObZone.Zone, idx, p))
+		}
 	}
 	builder.
 		AddNode(newExecPostScriptNode("", idx)).
@@ -290,14 +302,19 @@ func preCheckForObUpgrade(param param.ObUpgradeParam) (p *obUpgradeParams, err e
 		return nil, err
 	}
 	p = &obUpgradeParams{
-		RequestParam:   &param,
-		currentVersion: strings.Split(currentBuildVersion, "_")[0],
+		RequestParam:     &param,
+		currentVersion:   strings.Split(currentBuildVersion, "_")[0],
+		fromBuildVersion: strings.ReplaceAll(currentBuildVersion, "_", "-"),
 	}
 
 	if err = checkUpgradeMode(&param); err != nil {
 		return
 	}
 
+	if err = checkUpgradeApproval(p); err != nil {
+		return nil, err
+	}
+
 	if err = checkUpgradeDir(&param.UpgradeDir); err != nil {
 		return nil, err
 	}
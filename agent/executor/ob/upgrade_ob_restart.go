@@ -17,6 +17,8 @@
 package ob
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -37,6 +39,8 @@ type ReinstallAndRestartObTask struct {
 	realExecAgent  meta.AgentInfo
 	rpmPkgHomePath string
 	zone           string
+	routeIndex     int
+	backupDir      string
 }
 
 func newReinstallAndRestartObNode(zone string, agents []meta.AgentInfo, idx int) *task.Node {
@@ -68,15 +72,27 @@ func (t *ReinstallAndRestartObTask) getParams() (err error) {
 	if err != nil {
 		return err
 	}
-	node := upgradeRoute[int(upgradeRouteIndex)]
+	t.routeIndex = int(upgradeRouteIndex)
+	node := upgradeRoute[t.routeIndex]
 	var rpmPkgInfo rpmPacakgeInstallInfo
 	if err = t.GetContext().GetAgentDataByAgentKeyWithValue(t.realExecAgent.String(), node.BuildVersion, &rpmPkgInfo); err != nil {
 		return err
 	}
 	t.rpmPkgHomePath = rpmPkgInfo.RpmPkgHomepath
+
+	var upgradeCheckTaskDir string
+	if err = t.GetLocalDataWithValue(PARAM_UPGRADE_CHECK_TASK_DIR, &upgradeCheckTaskDir); err != nil {
+		return err
+	}
+	t.backupDir = observerBackupDir(upgradeCheckTaskDir, t.routeIndex)
 	return nil
 }
 
+// observerBackupDir returns the directory where the observer is backed up before upgrade process idx.
+func observerBackupDir(upgradeCheckTaskDir string, idx int) string {
+	return filepath.Join(upgradeCheckTaskDir, fmt.Sprintf("observer-%d", idx))
+}
+
 func (t *ReinstallAndRestartObTask) Execute() (err error) {
 	if err = t.getParams(); err != nil {
 		return err
@@ -97,6 +113,9 @@ func (t *ReinstallAndRestartObTask) reinstallAndRestartOb() (err error) {
 	if err = stopObserver(t); err != nil {
 		return err
 	}
+	if err = t.backupOb(); err != nil {
+		return err
+	}
 	t.ExecuteLog("reinstall ob")
 	if err = t.installNewOb(t.rpmPkgHomePath); err != nil {
 		return err
@@ -106,7 +125,7 @@ func (t *ReinstallAndRestartObTask) reinstallAndRestartOb() (err error) {
 		return err
 	}
 	t.ExecuteLog("wait all observer available")
-	return t.waitAllObSeverAvailable()
+	return waitAllObSeverAvailable(t)
 }
 
 func (t *ReinstallAndRestartObTask) installNewOb(upgradePath string) (err error) {
@@ -117,6 +136,66 @@ func (t *ReinstallAndRestartObTask) installNewOb(upgradePath string) (err error)
 	return nil
 }
 
+// backupOb backs up the files which will be overwritten by the new ob,
+// the backup is kept when retrying so that it is always the ob before this upgrade process.
+func (t *ReinstallAndRestartObTask) backupOb() (err error) {
+	if _, err = os.Stat(t.backupDir); err == nil {
+		t.ExecuteLogf("ob has been backed up to '%s'", t.backupDir)
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	t.ExecuteLogf("backup ob to '%s'", t.backupDir)
+	if err = backupFilesForInstallObserver(t.rpmPkgHomePath, global.HomePath, t.backupDir); err != nil {
+		return errors.Wrap(err, "backup ob failed")
+	}
+	return nil
+}
+
+// backupFilesForInstallObserver copies the files in home which will be overwritten
+// by copyFilesForInstallObserver from src to dest.
+func backupFilesForInstallObserver(src, home, dest string) error {
+	tmpDir := dest + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return err
+	}
+	err := filepath.WalkDir(src, func(srcPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if srcPath == src {
+			return nil
+		}
+		if strings.Contains(entry.Name(), constant.PROC_OBSHELL) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, srcPath)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(home, rel)
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return system.CopyFile(target, filepath.Join(tmpDir, rel))
+	})
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dest)
+}
+
 func copyFilesForInstallObserver(src, dest string) (err error) {
 	in, err := os.Open(src)
 	if err != nil {
@@ -159,7 +238,7 @@ func copyFilesForInstallObserver(src, dest string) (err error) {
 	return nil
 }
 
-func (t *ReinstallAndRestartObTask) waitAllObSeverAvailable() (err error) {
+func waitAllObSeverAvailable(t task.ExecutableTask) (err error) {
 	log.Info("wait all observer available")
 	for i := 0; i < constant.TICK_NUM_FOR_OB_STATUS_CHECK; i++ {
 		allObserverIsAvailable, _ := isAllObSeverAvailable()
@@ -0,0 +1,410 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/global"
+	"github.com/oceanbase/obshell/agent/lib/path"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+type obUpgradeRollbackPlan struct {
+	upgradeDag   *task.Dag
+	failedCtx    *task.TaskContext
+	point        *param.UpgradeRollbackPoint
+	zoneAgents   map[string][]meta.AgentInfo
+	obParameters []oceanbase.ObParameters
+}
+
+// GetUpgradeRollbackPoint returns the point which the failed rolling upgrade will be rolled back to,
+// without rolling back anything.
+func GetUpgradeRollbackPoint(p *param.UpgradeRollbackParam) (*param.UpgradeRollbackPoint, error) {
+	plan, err := planObUpgradeRollback(p.DagID)
+	if err != nil {
+		return nil, err
+	}
+	return plan.point, nil
+}
+
+// RollbackObUpgrade rolls back the zones switched by the failed upgrade process of a rolling upgrade.
+// The rollback dag takes over the maintenance of the failed upgrade dag,
+// so the failed upgrade dag can not be retried any more.
+func RollbackObUpgrade(p *param.UpgradeRollbackParam) (*task.DagDetailDTO, error) {
+	plan, err := planObUpgradeRollback(p.DagID)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("rollback ob upgrade '%s' to %+v", p.DagID, plan.point)
+	template := buildObUpgradeRollbackTemplate(plan)
+	ctx := buildObUpgradeRollbackTaskContext(plan)
+	dag, err := taskService.CreateDagInstanceToTakeOver(plan.upgradeDag, template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// planObUpgradeRollback finds out the failed upgrade process and the zones switched by it.
+func planObUpgradeRollback(dagGenericID string) (*obUpgradeRollbackPlan, error) {
+	dagID, agent, err := task.ConvertGenericID(dagGenericID)
+	if err != nil {
+		return nil, err
+	}
+	if agent != nil {
+		return nil, errors.Occur(errors.ErrObUpgradeRollbackNotRolling, dagGenericID)
+	}
+	dag, err := taskService.GetDagInstance(dagID)
+	if err != nil {
+		return nil, err
+	}
+	if !dag.IsFail() || !strings.HasPrefix(dag.GetName(), DAG_OB_ROLLING_UPGRADE) {
+		return nil, errors.Occur(errors.ErrObUpgradeRollbackNotRolling, dagGenericID)
+	}
+	if dag.GetContext() != nil && dag.GetContext().GetParam(task.TAKEN_OVER_BY) != nil {
+		return nil, errors.Occur(errors.ErrTaskDagTakenOver, dagGenericID, dag.GetContext().GetParam(task.TAKEN_OVER_BY))
+	}
+
+	nodes, err := taskService.GetNodes(dag)
+	if err != nil {
+		return nil, err
+	}
+	var failedNode *task.Node
+	routeIndex := -1
+	preScriptStarted, postScriptStarted := false, false
+	var zones []param.UpgradeRollbackZone
+	zoneAgents := make(map[string][]meta.AgentInfo)
+	for _, node := range nodes {
+		if node.IsPending() || node.IsReady() {
+			break
+		}
+		switch node.GetName() {
+		case TASK_BACKUP_PARAMETERS:
+			// Each upgrade process starts with backing up parameters.
+			routeIndex++
+			preScriptStarted, postScriptStarted = false, false
+			zones = nil
+			zoneAgents = make(map[string][]meta.AgentInfo)
+		case TASK_EXEC_UPGRADE_PRE_SCRIPT:
+			preScriptStarted = true
+		case TASK_EXEC_UPGRADE_POST_SCRIPT:
+			postScriptStarted = true
+		}
+		switch node.GetTaskType() {
+		case reflect.TypeOf(StopZoneTask{}):
+			var zone string
+			if err = node.GetContext().GetParamWithValue(PARAM_ZONE, &zone); err != nil {
+				return nil, err
+			}
+			zones = append(zones, param.UpgradeRollbackZone{Zone: zone})
+		case reflect.TypeOf(ReinstallAndRestartObTask{}):
+			var agents []meta.AgentInfo
+			if err = node.GetContext().GetParamWithValue(task.EXECUTE_AGENTS, &agents); err != nil {
+				return nil, err
+			}
+			if len(zones) > 0 {
+				zones[len(zones)-1].BinarySwitched = true
+				zoneAgents[zones[len(zones)-1].Zone] = agents
+			}
+		}
+		if node.IsFail() {
+			failedNode = node
+			break
+		}
+	}
+	if failedNode == nil || routeIndex < 0 {
+		return nil, errors.Occur(errors.ErrObUpgradeRollbackNothing, dagGenericID)
+	}
+	if postScriptStarted {
+		return nil, errors.Occur(errors.ErrObUpgradeRollbackFinalized, routeIndex, dagGenericID)
+	}
+
+	failedCtx := failedNode.GetContext()
+	upgradeRoute, err := getUpgradeRouteForTask(failedCtx)
+	if err != nil {
+		return nil, err
+	}
+	if routeIndex >= len(upgradeRoute) {
+		return nil, errors.Occur(errors.ErrCommonUnexpected, fmt.Sprintf("upgrade process %d is out of the upgrade route", routeIndex))
+	}
+	point := &param.UpgradeRollbackPoint{
+		UpgradeDagID: dagGenericID,
+		RouteIndex:   routeIndex,
+		FromVersion:  upgradeRoute[routeIndex].BuildVersion,
+		FailedTask:   failedNode.GetName(),
+	}
+	if routeIndex > 0 {
+		point.ToVersion = upgradeRoute[routeIndex-1].BuildVersion
+	} else if fromVersion, ok := failedCtx.GetParam(PARAM_UPGRADE_FROM_VERSION).(string); ok {
+		point.ToVersion = fromVersion
+	}
+	// Roll back the latest switched zone first.
+	for i := len(zones) - 1; i >= 0; i-- {
+		point.Zones = append(point.Zones, zones[i])
+	}
+
+	plan := &obUpgradeRollbackPlan{
+		upgradeDag: dag,
+		failedCtx:  failedCtx,
+		point:      point,
+		zoneAgents: zoneAgents,
+	}
+	if preScriptStarted {
+		if err = failedCtx.GetParamWithValue(PARAM_OB_PARAMETERS, &plan.obParameters); err != nil {
+			return nil, err
+		}
+		point.RestoreParameters = len(plan.obParameters) > 0
+	}
+	if len(point.Zones) == 0 && !point.RestoreParameters {
+		return nil, errors.Occur(errors.ErrObUpgradeRollbackNothing, dagGenericID)
+	}
+	return plan, nil
+}
+
+func buildObUpgradeRollbackTemplate(plan *obUpgradeRollbackPlan) *task.Template {
+	name := fmt.Sprintf("%s %s", DAG_OB_UPGRADE_ROLLBACK, plan.point.FromVersion)
+	builder := task.NewTemplateBuilder(name).SetMaintenance(task.GlobalMaintenance())
+	for _, zone := range plan.point.Zones {
+		builder.AddNode(newStopZoneNode(zone.Zone))
+		if zone.BinarySwitched {
+			builder.AddNode(newRestoreAndRestartObNode(zone.Zone, plan.zoneAgents[zone.Zone]))
+		}
+		builder.
+			AddNode(newStartZoneNode(zone.Zone)).
+			AddNode(newCheckRollbackZoneNode(zone.Zone))
+	}
+	if plan.point.RestoreParameters {
+		builder.AddTask(newRestoreParametersTask(), false)
+	}
+	builder.AddTask(newReportUpgradeRollbackTask(), false)
+	return builder.Build()
+}
+
+func buildObUpgradeRollbackTaskContext(plan *obUpgradeRollbackPlan) *task.TaskContext {
+	ctx := task.NewTaskContext()
+	ctx.SetParam(PARAM_UPGRADE_ROLLBACK, plan.point)
+	if plan.point.RestoreParameters {
+		ctx.SetParam(PARAM_OB_PARAMETERS, plan.obParameters)
+	}
+	// Hand over the upgrade dirs of each agent, where the observer is backed up.
+	for agentKey := range plan.failedCtx.AgentData {
+		for _, key := range []string{PARAM_UPGRADE_CHECK_TASK_DIR, DATA_BACKUP_DIR} {
+			if value := plan.failedCtx.GetAgentDataByAgentKey(agentKey, key); value != nil {
+				ctx.SetAgentDataByAgentKey(agentKey, key, value)
+			}
+		}
+	}
+	return ctx
+}
+
+// normalizeBuildVersion converts the build version of observer to the format of the upgrade route,
+// such as '4.2.1.0_100000102023092807-7b0f43693565654bb1d7343d728b2bfd1d4f0ce2(Sep 28 2023 16:43:47)'
+// to '4.2.1.0-100000102023092807'.
+func normalizeBuildVersion(buildVersion string) string {
+	return strings.ReplaceAll(strings.Split(buildVersion, "-")[0], "_", "-")
+}
+
+// RestoreAndRestartObTask restores the observer backed up before the failed upgrade process and restarts it.
+type RestoreAndRestartObTask struct {
+	task.Task
+	zone  string
+	point param.UpgradeRollbackPoint
+}
+
+func newRestoreAndRestartObNode(zone string, agents []meta.AgentInfo) *task.Node {
+	ctx := task.NewTaskContext()
+	ctx.SetParam(task.EXECUTE_AGENTS, agents).
+		SetParam(PARAM_ZONE, zone)
+	return task.NewNodeWithContext(&RestoreAndRestartObTask{
+		Task: *task.NewSubTask(TASK_RESTORE_AND_RESTART_OBSERVER).
+			SetCanContinue().
+			SetCanRetry()},
+		true, ctx)
+}
+
+func (t *RestoreAndRestartObTask) getParams() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return err
+	}
+	return t.GetContext().GetParamWithValue(PARAM_UPGRADE_ROLLBACK, &t.point)
+}
+
+func (t *RestoreAndRestartObTask) Execute() (err error) {
+	if err = t.getParams(); err != nil {
+		return err
+	}
+	t.ExecuteLog("stop ob")
+	if err = stopObserver(t); err != nil {
+		return err
+	}
+	if err = t.restoreOb(); err != nil {
+		return err
+	}
+	t.ExecuteLog("start ob")
+	if err = startObserver(t, nil); err != nil {
+		return err
+	}
+	t.ExecuteLog("wait all observer available")
+	return waitAllObSeverAvailable(t)
+}
+
+func (t *RestoreAndRestartObTask) restoreOb() (err error) {
+	var upgradeCheckTaskDir string
+	if err = t.GetLocalDataWithValue(PARAM_UPGRADE_CHECK_TASK_DIR, &upgradeCheckTaskDir); err != nil {
+		return err
+	}
+	backupDir := observerBackupDir(upgradeCheckTaskDir, t.point.RouteIndex)
+	if _, err = os.Stat(backupDir); err == nil {
+		t.ExecuteLogf("restore ob from '%s' to '%s'", backupDir, global.HomePath)
+		if err = copyFilesForInstallObserver(backupDir, global.HomePath); err != nil {
+			return errors.Wrap(err, "restore ob failed")
+		}
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if t.point.ToVersion != "" {
+		// The observer is backed up right before it is replaced.
+		t.ExecuteLogf("ob is not replaced, no need to restore")
+		return nil
+	}
+	// The upgrade dag did not back up the observer, only the bin dir backed up before the upgrade can be used.
+	if t.point.RouteIndex != 0 {
+		return errors.Occur(errors.ErrObUpgradeRollbackBackupNotFound, backupDir)
+	}
+	var binBackupDir string
+	if err = t.GetLocalDataWithValue(DATA_BACKUP_DIR, &binBackupDir); err != nil {
+		return err
+	}
+	if _, err = os.Stat(binBackupDir); err != nil {
+		return errors.Occur(errors.ErrObUpgradeRollbackBackupNotFound, binBackupDir)
+	}
+	t.ExecuteLogf("restore ob from '%s' to '%s'", binBackupDir, path.BinDir())
+	if err = copyFilesForInstallObserver(binBackupDir, path.BinDir()); err != nil {
+		return errors.Wrap(err, "restore ob failed")
+	}
+	return nil
+}
+
+// CheckRollbackZoneTask checks the servers in the zone run the build version before the failed upgrade process.
+type CheckRollbackZoneTask struct {
+	task.Task
+	zone  string
+	point param.UpgradeRollbackPoint
+}
+
+func newCheckRollbackZoneNode(zone string) *task.Node {
+	ctx := task.NewTaskContext().SetParam(PARAM_ZONE, zone)
+	return task.NewNodeWithContext(&CheckRollbackZoneTask{
+		Task: *task.NewSubTask(TASK_CHECK_ROLLBACK_ZONE).
+			SetCanContinue().
+			SetCanRetry()},
+		false, ctx)
+}
+
+func (t *CheckRollbackZoneTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return err
+	}
+	if err = t.GetContext().GetParamWithValue(PARAM_UPGRADE_ROLLBACK, &t.point); err != nil {
+		return err
+	}
+	t.ExecuteLog("wait all observer available")
+	if err = waitAllObSeverAvailable(t); err != nil {
+		return err
+	}
+	servers, err := obclusterService.GetOBServersByZone(t.zone)
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		buildVersion := normalizeBuildVersion(server.BuildVersion)
+		svr := fmt.Sprintf("%s:%d", server.SvrIp, server.SvrPort)
+		t.ExecuteLogf("%s runs %s", svr, buildVersion)
+		if t.point.ToVersion != "" && buildVersion != t.point.ToVersion {
+			return errors.Occur(errors.ErrObUpgradeRollbackVersionWrong, svr, buildVersion, t.point.ToVersion)
+		}
+	}
+	return nil
+}
+
+// ReportUpgradeRollbackTask reports the point which the rolling upgrade is rolled back to.
+type ReportUpgradeRollbackTask struct {
+	task.Task
+}
+
+func newReportUpgradeRollbackTask() *ReportUpgradeRollbackTask {
+	newTask := &ReportUpgradeRollbackTask{
+		Task: *task.NewSubTask(TASK_REPORT_UPGRADE_ROLLBACK),
+	}
+	newTask.SetCanContinue().SetCanRetry()
+	return newTask
+}
+
+func (t *ReportUpgradeRollbackTask) Execute() (err error) {
+	var point param.UpgradeRollbackPoint
+	if err = t.GetContext().GetParamWithValue(PARAM_UPGRADE_ROLLBACK, &point); err != nil {
+		return err
+	}
+	for i := range point.Zones {
+		servers, err := obclusterService.GetOBServersByZone(point.Zones[i].Zone)
+		if err != nil {
+			return err
+		}
+		point.Zones[i].ServerVersions = make(map[string]string)
+		for _, server := range servers {
+			svr := fmt.Sprintf("%s:%d", server.SvrIp, server.SvrPort)
+			point.Zones[i].ServerVersions[svr] = normalizeBuildVersion(server.BuildVersion)
+		}
+	}
+
+	zones := make([]string, 0, len(point.Zones))
+	for _, zone := range point.Zones {
+		zones = append(zones, zone.Zone)
+	}
+	sort.Strings(zones)
+	toVersion := point.ToVersion
+	if toVersion == "" {
+		toVersion = "the version before upgrade"
+	}
+	t.ExecuteInfoLogf("Upgrade process %d of task '%s' is rolled back from %s to %s, zones: [%s], parameters restored: %t",
+		point.RouteIndex, point.UpgradeDagID, point.FromVersion, toVersion, strings.Join(zones, ","), point.RestoreParameters)
+	t.GetContext().SetParam(ADDL_KEY_ROLLBACK_POINT, point)
+	return nil
+}
+
+func (t *ReportUpgradeRollbackTask) GetAdditionalData() map[string]any {
+	var point param.UpgradeRollbackPoint
+	if err := t.GetContext().GetParamWithValue(ADDL_KEY_ROLLBACK_POINT, &point); err != nil {
+		return nil
+	}
+	return map[string]any{
+		ADDL_KEY_ROLLBACK_POINT: point,
+	}
+}
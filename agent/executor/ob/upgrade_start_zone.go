@@ -48,6 +48,10 @@ func (t *StartOneZoneTask) Execute() (err error) {
 	if err = t.getParams(); err != nil {
 		return err
 	}
+	if zoneIsActive, _ := obclusterService.IsZoneActive(t.zone); zoneIsActive {
+		t.ExecuteLogf("%s is already active", t.zone)
+		return nil
+	}
 	t.ExecuteLogf("start %s", t.zone)
 	if err = obclusterService.StartZone(t.zone); err != nil {
 		return
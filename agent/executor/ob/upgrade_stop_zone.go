@@ -64,6 +64,12 @@ func (t *StopZoneTask) Execute() (err error) {
 	if err = t.getParams(); err != nil {
 		return err
 	}
+	if zoneIsInactive, err := obclusterService.IsZoneInactive(t.zone); err != nil {
+		return errors.Wrap(err, "check zone status failed")
+	} else if zoneIsInactive {
+		t.ExecuteLogf("%s is already inactive", t.zone)
+		return nil
+	}
 	t.ExecuteLog("stop zone " + t.zone)
 	if err = obclusterService.StopZone(t.zone); err != nil {
 		return
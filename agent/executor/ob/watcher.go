@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	ammodels "github.com/prometheus/alertmanager/api/v2/models"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/coordinator"
+	"github.com/oceanbase/obshell/agent/executor/alarm"
+	alarmconstant "github.com/oceanbase/obshell/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/agent/meta"
+)
+
+// StartWatchers starts the periodical checks of the cluster,
+// each of them only works on the maintainer agent.
+func StartWatchers() {
+	go watchOnMaintainer("archive lag", constant.ARCHIVE_WATCHER_CHECK_INTERVAL, archiveWatcher.check)
+	go watchOnMaintainer("restore drill", constant.RESTORE_DRILL_SCHEDULE_CHECK_INTERVAL, runScheduledRestoreDrill)
+	go watchOnMaintainer("config drift", constant.CONFIG_DRIFT_CHECK_INTERVAL, func() error {
+		_, err := configDriftWatcher.check()
+		return err
+	})
+	go watchOnMaintainer("clock skew", constant.CLOCK_SKEW_CHECK_INTERVAL, func() error {
+		_, err := clockSkewWatcher.check()
+		return err
+	})
+}
+
+// watchOnMaintainer runs check every interval while the agent is the maintainer,
+// the other agents only check on demand.
+func watchOnMaintainer(name string, interval time.Duration, check func() error) {
+	log.Infof("%s watcher starting", name)
+	for {
+		time.Sleep(interval)
+		if !meta.OCS_AGENT.IsClusterAgent() || coordinator.OCS_COORDINATOR == nil || !coordinator.OCS_COORDINATOR.IsMaintainer() {
+			continue
+		}
+		if err := check(); err != nil {
+			log.WithError(err).Warnf("check %s failed", name)
+		}
+	}
+}
+
+// alertTracker keeps the firing alert of each target of a watcher, so that the
+// alert is resolved once the target recovers or fires by another rule.
+type alertTracker struct {
+	name string
+	// ttl is how long a posted alert stays firing in alertmanager
+	// without being refreshed by the watcher.
+	ttl    time.Duration
+	alerts map[string]*ammodels.PostableAlert
+}
+
+func newAlertTracker(name string, interval time.Duration) *alertTracker {
+	return &alertTracker{
+		name:   name,
+		ttl:    3 * interval,
+		alerts: make(map[string]*ammodels.PostableAlert),
+	}
+}
+
+// update posts the alerts firing now keyed by their targets and resolves the
+// others, an alert keeps its start time while it fires by the same rule.
+// The caller should hold the lock of the watcher.
+func (t *alertTracker) update(firing map[string]*ammodels.PostableAlert) {
+	now := time.Now()
+	alerts := make(ammodels.PostableAlerts, 0)
+	for target, alert := range firing {
+		alert.StartsAt = strfmt.DateTime(now)
+		if last, ok := t.alerts[target]; ok && sameAlertRule(last, alert) {
+			alert.StartsAt = last.StartsAt
+		}
+		alert.EndsAt = strfmt.DateTime(now.Add(t.ttl))
+		alerts = append(alerts, alert)
+	}
+	for target, alert := range t.alerts {
+		if current, ok := firing[target]; !ok || !sameAlertRule(current, alert) {
+			log.Infof("%s alarm %s of %s resolved", t.name, alert.Labels[alarmconstant.LabelRuleName], target)
+			alert.EndsAt = strfmt.DateTime(now)
+			alerts = append(alerts, alert)
+		}
+	}
+	t.alerts = firing
+
+	if len(alerts) == 0 {
+		return
+	}
+	if err := alarm.PostAlerts(context.Background(), alerts); err != nil {
+		// The alertmanager is optional, the alarm is still recorded in the log.
+		log.WithError(err).Debugf("post %s alerts failed", t.name)
+	}
+}
+
+func sameAlertRule(a, b *ammodels.PostableAlert) bool {
+	return a.Labels[alarmconstant.LabelRuleName] == b.Labels[alarmconstant.LabelRuleName]
+}
@@ -0,0 +1,426 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/meta"
+	oceanbaseModel "github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/agent/service/tenant"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+const (
+	LS_ROLE_LEADER = "LEADER"
+
+	// the stages before stopping observers in the enter zone maintenance dag
+	zoneMaintenancePrepareStages = 3
+)
+
+// EnterZoneMaintenance creates a dag to put the zone into maintenance.
+// The dag stops the zone, which drains the leaders off it and keeps them from coming back
+// even if the zone has the highest priority in the primary zone of a tenant, waits for the
+// leaders to leave and then kills the observers in it.
+func EnterZoneMaintenance(zone string) (*task.DagDetailDTO, error) {
+	if err := checkZoneMaintenanceRequest(zone); err != nil {
+		return nil, err
+	}
+	if active, err := obclusterService.IsZoneActive(zone); err != nil {
+		return nil, errors.Wrap(err, "check zone status failed")
+	} else if !active {
+		return nil, errors.Occur(errors.ErrObZoneNotActive, zone)
+	}
+	if exist, err := obclusterService.HasOtherStopTask(zone); err != nil {
+		return nil, errors.Wrap(err, "check if has other stop task failed")
+	} else if exist {
+		return nil, errors.Occur(errors.ErrObServerStoppedInMultiZone)
+	}
+	if err := checkZoneMaintenanceSafety(zone, log.Infof); err != nil {
+		return nil, err
+	}
+
+	template := task.NewTemplateBuilder(DAG_ENTER_ZONE_MAINTENANCE).
+		SetMaintenance(task.UnMaintenance()).
+		AddTask(newCheckZoneMaintenanceTask(zone), false).
+		AddNode(newStopZoneNodeForDelete(zone)).
+		AddTask(newWaitLeadersOffZoneTask(zone), false).
+		AddTask(newCreateSubStopDagTask(), true).
+		AddTask(newCheckSubStopDagReadyTask(), false).
+		AddTask(newRetrySubStopDagTask(), false).
+		AddTask(newWaitSubStopDagFinishTask(), true).
+		AddTask(newPassSubStopDagTask(), false).
+		Build()
+	ctx, err := buildZoneMaintenanceTaskContext(zone, constant.URI_OB_RPC_PREFIX+constant.URI_STOP, zoneMaintenancePrepareStages+SUB_STOP_DAG_EXPECT_MAIN_NEXT_STAGE)
+	if err != nil {
+		return nil, err
+	}
+	dag, err := localTaskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// ExitZoneMaintenance creates a dag to bring the zone back from maintenance.
+// The dag starts the observers and the zone, and then switches leaders back to the primary zone.
+func ExitZoneMaintenance(zone string) (*task.DagDetailDTO, error) {
+	if err := checkZoneMaintenanceRequest(zone); err != nil {
+		return nil, err
+	}
+
+	template := task.NewTemplateBuilder(DAG_EXIT_ZONE_MAINTENANCE).
+		SetMaintenance(task.UnMaintenance()).
+		AddTask(newCreateSubStartDagTask(), true).
+		AddTask(newCheckSubStartDagReadyTask(), false).
+		AddTask(newRetrySubStartDagTask(), false).
+		AddTask(newWaitSubStartDagFinishTask(), true).
+		AddTask(newStartZoneTask(), false).
+		AddTask(newPassSubStartDagTask(), false).
+		AddTask(newWaitZoneServersAvailableTask(zone), false).
+		AddTask(newRestoreZoneLeadersTask(), false).
+		Build()
+	ctx, err := buildZoneMaintenanceTaskContext(zone, constant.URI_OB_RPC_PREFIX+constant.URI_START, SUB_START_DAG_EXPECT_MAIN_NEXT_STAGE)
+	if err != nil {
+		return nil, err
+	}
+	dag, err := localTaskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func checkZoneMaintenanceRequest(zone string) error {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	if exist, err := obclusterService.IsZoneExistInOB(zone); err != nil {
+		return errors.Wrap(err, "check zone exist failed")
+	} else if !exist {
+		return errors.Occur(errors.ErrObZoneNotExist, zone)
+	}
+	// The leaders can not be handled when the observer of current agent is stopped.
+	if meta.OCS_AGENT.GetZone() == zone {
+		return errors.Occur(errors.ErrObZoneMaintenanceSelf, zone)
+	}
+	return nil
+}
+
+// buildZoneMaintenanceTaskContext builds the context for the sub dags to stop or start the observers in the zone.
+func buildZoneMaintenanceTaskContext(zone string, uri string, expectMainNextStage int) (*task.TaskContext, error) {
+	agents, err := agentService.GetAllAgentsInfo()
+	if err != nil {
+		return nil, err
+	}
+	scope := param.Scope{Type: SCOPE_ZONE, Target: []string{zone}}
+	zoneAgents, err := GenerateTargetAgentList(scope)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("agents in zone %s are %v", zone, zoneAgents)
+	ctx := task.NewTaskContext().
+		SetParam(task.EXECUTE_AGENTS, agents).
+		SetParam(PARAM_ALL_AGENTS, agents).
+		SetParam(PARAM_ZONE, zone).
+		SetParam(PARAM_SCOPE, scope).
+		SetParam(PARAM_FORCE_PASS_DAG, param.ForcePassDagParam{}).
+		SetParam(PARAM_URI, uri).
+		SetParam(PARAM_EXPECT_MAIN_NEXT_STAGE, expectMainNextStage)
+	for _, agent := range zoneAgents {
+		ctx.SetAgentData(&agent, DATA_SUB_DAG_NEED_EXEC_CMD, true)
+	}
+	return ctx, nil
+}
+
+func isPaxosReplica(replicaType string) bool {
+	replicaType = strings.ToUpper(replicaType)
+	return replicaType == constant.REPLICA_TYPE_FULL || replicaType == "F"
+}
+
+// checkZoneMaintenanceSafety checks that every tenant keeps the majority of paxos replicas by locality
+// and every log stream keeps the majority of paxos members in sync without the zone.
+func checkZoneMaintenanceSafety(zone string, infoFunc func(string, ...interface{})) error {
+	tenants, err := tenantService.GetAllNotMetaTenantIdToNameMap()
+	if err != nil {
+		return errors.Wrap(err, "get all tenants failed")
+	}
+	tenantIds := make([]int, 0, len(tenants))
+	for tenantId := range tenants {
+		tenantIds = append(tenantIds, tenantId)
+	}
+	sort.Ints(tenantIds)
+	for _, tenantId := range tenantIds {
+		replicaInfoMap, err := tenantService.GetTenantReplicaInfoMap(tenantId)
+		if err != nil {
+			return errors.Wrapf(err, "get locality of tenant '%s' failed", tenants[tenantId])
+		}
+		total, remain := 0, 0
+		for replicaZone, replicaType := range replicaInfoMap {
+			if !isPaxosReplica(replicaType) {
+				continue
+			}
+			total++
+			if replicaZone != zone {
+				remain++
+			}
+		}
+		infoFunc("tenant '%s' has %d of %d paxos replicas without zone %s", tenants[tenantId], remain, total, zone)
+		if remain <= total/2 {
+			return errors.Occur(errors.ErrObZoneMaintenanceMajorityLost, tenants[tenantId], zone)
+		}
+	}
+
+	logInfos, err := obclusterService.GetLogInfosInZone(zone)
+	if err != nil {
+		return errors.Wrap(err, "get log infos in zone failed")
+	}
+	for _, logStat := range logInfos {
+		if alive, err := obclusterService.IsLsMultiPaxosAliveWithoutZone(logStat.LsId, logStat.TenantId, zone); err != nil {
+			return errors.Wrap(err, "check multi paxos member alive failed")
+		} else if !alive {
+			infoFunc("the log stream %d of tenant %d has no majority in sync without zone %s.", logStat.LsId, logStat.TenantId, zone)
+			return errors.Occur(errors.ErrObClusterMultiPaxosNotAlive)
+		}
+	}
+	return nil
+}
+
+// tenantLogStream is a log stream of the tenant with all the replicas of it.
+type tenantLogStream struct {
+	tenantName  string
+	primaryZone string
+	lsId        int64
+	replicas    []oceanbaseModel.ObLsLocation
+}
+
+func (ls *tenantLogStream) leader() *oceanbaseModel.ObLsLocation {
+	for i := range ls.replicas {
+		if ls.replicas[i].Role == LS_ROLE_LEADER {
+			return &ls.replicas[i]
+		}
+	}
+	return nil
+}
+
+// leaderCandidate returns the full replica follower in the zones to be the new leader,
+// the one in prefer zone is returned first.
+func (ls *tenantLogStream) leaderCandidate(zones []string, preferZone string) *oceanbaseModel.ObLsLocation {
+	var candidate *oceanbaseModel.ObLsLocation
+	for i, replica := range ls.replicas {
+		if replica.Role == LS_ROLE_LEADER || !isPaxosReplica(replica.ReplicaType) || !utils.ContainsString(zones, replica.Zone) {
+			continue
+		}
+		if replica.Zone == preferZone {
+			return &ls.replicas[i]
+		}
+		if candidate == nil {
+			candidate = &ls.replicas[i]
+		}
+	}
+	return candidate
+}
+
+// listTenantLogStreams lists the log streams of all the tenants except meta tenants.
+func listTenantLogStreams() ([]tenantLogStream, error) {
+	tenants, err := tenantService.GetAllNotMetaTenantIdToNameMap()
+	if err != nil {
+		return nil, errors.Wrap(err, "get all tenants failed")
+	}
+	tenantIds := make([]int, 0, len(tenants))
+	for tenantId := range tenants {
+		tenantIds = append(tenantIds, tenantId)
+	}
+	sort.Ints(tenantIds)
+
+	logStreams := make([]tenantLogStream, 0)
+	for _, tenantId := range tenantIds {
+		primaryZone, err := tenantService.GetTenantPrimaryZone(tenantId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get primary zone of tenant '%s' failed", tenants[tenantId])
+		}
+		locations, err := tenantService.ListLsLocations(tenantId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list log stream locations of tenant '%s' failed", tenants[tenantId])
+		}
+		// The locations are ordered by log stream id.
+		for _, location := range locations {
+			if len(logStreams) == 0 || logStreams[len(logStreams)-1].tenantName != tenants[tenantId] || logStreams[len(logStreams)-1].lsId != location.LsId {
+				logStreams = append(logStreams, tenantLogStream{
+					tenantName:  tenants[tenantId],
+					primaryZone: primaryZone,
+					lsId:        location.LsId,
+				})
+			}
+			ls := &logStreams[len(logStreams)-1]
+			ls.replicas = append(ls.replicas, location)
+		}
+	}
+	return logStreams, nil
+}
+
+type CheckZoneMaintenanceTask struct {
+	task.Task
+	zone string
+}
+
+func newCheckZoneMaintenanceTask(zone string) *CheckZoneMaintenanceTask {
+	newTask := &CheckZoneMaintenanceTask{
+		Task: *task.NewSubTask(fmt.Sprintf(TASK_NAME_CHECK_ZONE_MAINTENANCE, zone)),
+	}
+	newTask.SetCanContinue().SetCanRetry().SetCanCancel()
+	return newTask
+}
+
+func (t *CheckZoneMaintenanceTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return err
+	}
+	return checkZoneMaintenanceSafety(t.zone, t.ExecuteLogf)
+}
+
+type WaitLeadersOffZoneTask struct {
+	task.Task
+	zone string
+}
+
+func newWaitLeadersOffZoneTask(zone string) *WaitLeadersOffZoneTask {
+	newTask := &WaitLeadersOffZoneTask{
+		Task: *task.NewSubTask(fmt.Sprintf(TASK_NAME_WAIT_LEADERS_OFF_ZONE, zone)),
+	}
+	newTask.SetCanContinue().SetCanRetry().SetCanCancel()
+	return newTask
+}
+
+// Execute waits for no leader in the stopped zone and the majority of each log stream in sync without the zone.
+func (t *WaitLeadersOffZoneTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return err
+	}
+	for i := 0; i < constant.TICK_NUM_FOR_OB_STATUS_CHECK; i++ {
+		leaderCount, err := t.countLeadersInZone()
+		if err != nil {
+			return err
+		}
+		if leaderCount == 0 {
+			t.ExecuteLogf("no leader in %s, check log streams in sync", t.zone)
+			if err := checkZoneMaintenanceSafety(t.zone, t.ExecuteLogf); err == nil {
+				return nil
+			} else {
+				t.ExecuteWarnLogf("check majority without %s failed: %v", t.zone, err)
+			}
+		} else {
+			t.ExecuteLogf("%d leaders are still in %s", leaderCount, t.zone)
+		}
+		time.Sleep(constant.TICK_INTERVAL_FOR_OB_STATUS_CHECK)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "wait for leaders switched off zone")
+}
+
+func (t *WaitLeadersOffZoneTask) countLeadersInZone() (int, error) {
+	logStreams, err := listTenantLogStreams()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := range logStreams {
+		if leader := logStreams[i].leader(); leader != nil && leader.Zone == t.zone {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type WaitZoneServersAvailableTask struct {
+	task.Task
+}
+
+func newWaitZoneServersAvailableTask(zone string) *WaitZoneServersAvailableTask {
+	newTask := &WaitZoneServersAvailableTask{
+		Task: *task.NewSubTask(fmt.Sprintf(TASK_NAME_WAIT_ZONE_SERVERS_AVAILABLE, zone)),
+	}
+	newTask.SetCanContinue().SetCanRetry().SetCanCancel()
+	return newTask
+}
+
+// Execute waits for all the servers active and in sync, there is no other stopped zone when entering maintenance.
+func (t *WaitZoneServersAvailableTask) Execute() error {
+	return waitAllObSeverAvailable(t)
+}
+
+type RestoreZoneLeadersTask struct {
+	task.Task
+	zone string
+}
+
+func newRestoreZoneLeadersTask() *RestoreZoneLeadersTask {
+	newTask := &RestoreZoneLeadersTask{
+		Task: *task.NewSubTask(TASK_NAME_RESTORE_ZONE_LEADERS),
+	}
+	newTask.SetCanContinue().SetCanRetry().SetCanCancel().SetCanPass()
+	return newTask
+}
+
+// Execute switches the leaders back to the zones with the highest priority in the primary zone of the tenant,
+// only the tenants whose primary zone contains the zone in maintenance are handled.
+func (t *RestoreZoneLeadersTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_ZONE, &t.zone); err != nil {
+		return err
+	}
+	logStreams, err := listTenantLogStreams()
+	if err != nil {
+		return err
+	}
+	for i := range logStreams {
+		ls := &logStreams[i]
+		if ls.primaryZone == constant.PRIMARY_ZONE_RANDOM {
+			continue
+		}
+		priorities := tenant.ParsePrimaryZone(ls.primaryZone)
+		if len(priorities) == 0 {
+			continue
+		}
+		primaryZones := strings.Split(priorities[0], ",")
+		if !utils.ContainsString(primaryZones, t.zone) {
+			continue
+		}
+		leader := ls.leader()
+		if leader != nil && utils.ContainsString(primaryZones, leader.Zone) {
+			continue
+		}
+		target := ls.leaderCandidate(primaryZones, t.zone)
+		if target == nil {
+			t.ExecuteWarnLogf("log stream %d of tenant '%s' has no full replica in %s, skip it", ls.lsId, ls.tenantName, priorities[0])
+			continue
+		}
+		t.ExecuteLogf("switch leader of log stream %d of tenant '%s' to %s:%d", ls.lsId, ls.tenantName, target.SvrIp, target.SvrPort)
+		if err := tenantService.SwitchLsLeader(ls.tenantName, ls.lsId, target.SvrIp, target.SvrPort); err != nil {
+			return errors.Wrapf(err, "switch leader of log stream %d of tenant '%s' failed", ls.lsId, ls.tenantName)
+		}
+	}
+	return nil
+}
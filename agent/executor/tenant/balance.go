@@ -0,0 +1,643 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	tenantservice "github.com/oceanbase/obshell/agent/service/tenant"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	// the balance job may not be generated if the tenant is already balanced
+	WAIT_BALANCE_JOB_RETRY_TIMES = 6
+
+	BALANCE_JOB_STATUS_COMPLETED      = "COMPLETED"
+	TRANSFER_PARTITION_TASK_COMPLETED = "COMPLETED"
+	LS_REPLICA_TYPE_FULL              = "FULL"
+	LS_ROLE_LEADER                    = "LEADER"
+)
+
+var balanceConflictJobTypes = []string{
+	constant.ALTER_TENANT_LOCALITY,
+	constant.ALTER_RESOURCE_TENANT_UNIT_NUM,
+	constant.ALTER_TENANT_PRIMARY_ZONE,
+}
+
+func listInProgressTenantJobs(tenantId int) ([]*bo.DbaObTenantJobBo, error) {
+	jobs := make([]*bo.DbaObTenantJobBo, 0)
+	for _, jobType := range balanceConflictJobTypes {
+		jobBo, err := tenantService.GetInProgressTenantJobBo(jobType, tenantId)
+		if err != nil {
+			return nil, errors.Wrap(err, "Get in progress tenant job failed")
+		}
+		if jobBo != nil {
+			jobs = append(jobs, jobBo)
+		}
+	}
+	return jobs, nil
+}
+
+// checkBalanceConflict makes sure that there is no locality, unit num or primary zone job
+// in progress, the balance would be done by these jobs themselves.
+func checkBalanceConflict(tenantId int) error {
+	jobs, err := listInProgressTenantJobs(tenantId)
+	if err != nil {
+		return err
+	}
+	if len(jobs) != 0 {
+		return errors.Occur(errors.ErrObTenantJobConflict, jobs[0].JobType)
+	}
+	return nil
+}
+
+// getLeaderZones returns the zones that the leaders should be located in,
+// nil means the leaders can be located in any zone.
+func getLeaderZones(tenant *oceanbase.DbaObTenant, zone string) ([]string, error) {
+	priorities := tenantservice.ParsePrimaryZone(tenant.PrimaryZone)
+	if priorities[0] == constant.PRIMARY_ZONE_RANDOM {
+		if zone == "" {
+			return nil, nil
+		}
+		return []string{zone}, nil
+	}
+	firstPriority := strings.Split(priorities[0], ",")
+	if zone == "" {
+		return firstPriority, nil
+	}
+	for _, z := range firstPriority {
+		if z == zone {
+			return []string{zone}, nil
+		}
+	}
+	return nil, errors.Occur(errors.ErrObBalanceZoneNotInPrimaryZone, zone, tenant.PrimaryZone, tenant.TenantName)
+}
+
+func inZones(zone string, zones []string) bool {
+	if zones == nil {
+		return true
+	}
+	for _, z := range zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+func groupLsLocations(locations []oceanbase.ObLsLocation) (lsIds []int64, lsLocations map[int64][]oceanbase.ObLsLocation) {
+	lsLocations = make(map[int64][]oceanbase.ObLsLocation)
+	for _, location := range locations {
+		if _, ok := lsLocations[location.LsId]; !ok {
+			lsIds = append(lsIds, location.LsId)
+		}
+		lsLocations[location.LsId] = append(lsLocations[location.LsId], location)
+	}
+	return
+}
+
+func GetTenantBalance(tenantName string) (*bo.TenantBalance, error) {
+	tenant, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := tenantService.ListLsLocations(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list log streams of tenant %s", tenantName)
+	}
+	tabletCounts, err := tenantService.ListLsTabletCounts(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to count tablets of tenant %s", tenantName)
+	}
+	units, err := tenantService.ListTenantUnitLoads(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list units of tenant %s", tenantName)
+	}
+	leaderZones, err := getLeaderZones(tenant, "")
+	if err != nil {
+		return nil, err
+	}
+	lsTabletCount := make(map[int64]int)
+	for _, count := range tabletCounts {
+		lsTabletCount[count.LsId] = count.TabletCount
+	}
+
+	balance := &bo.TenantBalance{
+		TenantName:     tenant.TenantName,
+		PrimaryZone:    tenant.PrimaryZone,
+		LeaderBalanced: true,
+		Servers:        make([]bo.ServerBalance, 0),
+		LogStreams:     make([]bo.LogStreamDistribution, 0),
+		BalanceJobs:    make([]bo.BalanceJob, 0),
+		TransferTasks:  make([]bo.TransferPartitionTask, 0),
+		TenantJobs:     make([]bo.TenantJob, 0),
+	}
+	servers := make(map[string]*bo.ServerBalance)
+	for _, unit := range units {
+		servers[fmt.Sprintf("%s:%d", unit.SvrIp, unit.SvrPort)] = &bo.ServerBalance{
+			Zone:    unit.Zone,
+			SvrIp:   unit.SvrIp,
+			SvrPort: unit.SvrPort,
+			Unit:    unit.ToBO(),
+		}
+	}
+	lsIds, lsLocations := groupLsLocations(locations)
+	for _, lsId := range lsIds {
+		ls := bo.LogStreamDistribution{
+			LsId:        lsId,
+			TabletCount: lsTabletCount[lsId],
+			Replicas:    make([]bo.LsReplica, 0),
+		}
+		for _, location := range lsLocations[lsId] {
+			key := fmt.Sprintf("%s:%d", location.SvrIp, location.SvrPort)
+			server, ok := servers[key]
+			if !ok {
+				server = &bo.ServerBalance{Zone: location.Zone, SvrIp: location.SvrIp, SvrPort: location.SvrPort}
+				servers[key] = server
+			}
+			server.LsCount++
+			server.TabletCount += ls.TabletCount
+			if location.Role == LS_ROLE_LEADER {
+				ls.Leader = key
+				ls.LeaderZone = location.Zone
+				server.LsLeaderCount++
+				server.TabletLeaderCount += ls.TabletCount
+			}
+			ls.Replicas = append(ls.Replicas, location.ToBO())
+		}
+		if !inZones(ls.LeaderZone, leaderZones) {
+			balance.LeaderBalanced = false
+		}
+		balance.LogStreams = append(balance.LogStreams, ls)
+	}
+	for _, server := range servers {
+		balance.Servers = append(balance.Servers, *server)
+	}
+	sort.Slice(balance.Servers, func(i, j int) bool {
+		if balance.Servers[i].Zone != balance.Servers[j].Zone {
+			return balance.Servers[i].Zone < balance.Servers[j].Zone
+		}
+		return fmt.Sprintf("%s:%d", balance.Servers[i].SvrIp, balance.Servers[i].SvrPort) < fmt.Sprintf("%s:%d", balance.Servers[j].SvrIp, balance.Servers[j].SvrPort)
+	})
+
+	job, err := tenantService.GetInProgressBalanceJob(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get balance job of tenant %s", tenantName)
+	}
+	if job != nil {
+		balance.BalanceJobs = append(balance.BalanceJobs, job.ToBO())
+	}
+	transferTasks, err := tenantService.ListTransferPartitionTasks(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list transfer partition tasks of tenant %s", tenantName)
+	}
+	for _, transferTask := range transferTasks {
+		balance.TransferTasks = append(balance.TransferTasks, transferTask.ToBO())
+	}
+	tenantJobs, err := listInProgressTenantJobs(tenant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenantJob := range tenantJobs {
+		balance.TenantJobs = append(balance.TenantJobs, bo.TenantJob{
+			JobId:     tenantJob.JobId,
+			JobType:   tenantJob.JobType,
+			JobStatus: tenantJob.JobStatus,
+		})
+	}
+	return balance, nil
+}
+
+func createTenantBalanceDag(dagName string, tenantName string, newTask task.ExecutableTask, context *task.TaskContext) (*task.DagDetailDTO, error) {
+	template := task.NewTemplateBuilder(dagName).
+		SetMaintenance(task.TenantMaintenance(tenantName)).
+		AddTask(newTask, false).Build()
+	context.SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(template, context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func TriggerTenantBalance(tenantName string) (*task.DagDetailDTO, error) {
+	tenant, err := checkTenantExistAndStatus(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBalanceConflict(tenant.TenantID); err != nil {
+		return nil, err
+	}
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_ID, tenant.TenantID)
+	return createTenantBalanceDag(DAG_TRIGGER_TENANT_BALANCE, tenantName, newTriggerTenantBalanceTask(), context)
+}
+
+// findTransferObject returns the table id and the object id of the partition to transfer.
+func findTransferObject(tenant *oceanbase.DbaObTenant, p *param.TransferPartitionParam) (int64, int64, error) {
+	locations, err := tenantService.ListTableLocations(tenant.TenantID, *p.DatabaseName, *p.TableName)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "Failed to get table %s.%s of tenant %s", *p.DatabaseName, *p.TableName, tenant.TenantName)
+	}
+	if len(locations) == 0 {
+		return 0, 0, errors.Occur(errors.ErrObTableNotExist, *p.DatabaseName, *p.TableName, tenant.TenantName)
+	}
+	partitionName, subpartitionName := "", ""
+	if p.PartitionName != nil {
+		partitionName = *p.PartitionName
+	}
+	if p.SubpartitionName != nil {
+		subpartitionName = *p.SubpartitionName
+	}
+	if locations[0].PartitionName != "" && partitionName == "" {
+		return 0, 0, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "partition_name", "partition name is required for the partitioned table")
+	}
+	if locations[0].SubpartitionName != "" && subpartitionName == "" {
+		return 0, 0, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "subpartition_name", "subpartition name is required for the subpartitioned table")
+	}
+	for _, location := range locations {
+		if location.PartitionName == partitionName && location.SubpartitionName == subpartitionName {
+			return location.TableId, location.ObjectId, nil
+		}
+	}
+	name := partitionName
+	if subpartitionName != "" {
+		name = partitionName + "." + subpartitionName
+	}
+	return 0, 0, errors.Occur(errors.ErrObBalancePartitionNotExist, name, *p.DatabaseName, *p.TableName, tenant.TenantName)
+}
+
+func TransferPartition(tenantName string, p *param.TransferPartitionParam) (*task.DagDetailDTO, error) {
+	tenant, err := checkTenantExistAndStatus(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBalanceConflict(tenant.TenantID); err != nil {
+		return nil, err
+	}
+	locations, err := tenantService.ListLsLocations(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list log streams of tenant %s", tenantName)
+	}
+	if _, lsLocations := groupLsLocations(locations); lsLocations[*p.DestLsId] == nil {
+		return nil, errors.Occur(errors.ErrObBalanceLsNotExist, *p.DestLsId, tenantName)
+	}
+	tableId, objectId, err := findTransferObject(tenant, p)
+	if err != nil {
+		return nil, err
+	}
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_ID, tenant.TenantID).
+		SetParam(PARAM_TABLE_ID, tableId).
+		SetParam(PARAM_OBJECT_ID, objectId).
+		SetParam(PARAM_LS_ID, *p.DestLsId)
+	return createTenantBalanceDag(DAG_TRANSFER_PARTITION, tenantName, newTransferPartitionTask(), context)
+}
+
+func SwitchLeaderToPrimaryZone(tenantName string, p *param.SwitchLeaderParam) (*task.DagDetailDTO, error) {
+	tenant, err := checkTenantExistAndStatus(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	zone := ""
+	if p.Zone != nil {
+		zone = *p.Zone
+	}
+	if _, err := getLeaderZones(tenant, zone); err != nil {
+		return nil, err
+	}
+	if err := checkBalanceConflict(tenant.TenantID); err != nil {
+		return nil, err
+	}
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_ID, tenant.TenantID).
+		SetParam(PARAM_ZONE_NAME, zone)
+	return createTenantBalanceDag(DAG_SWITCH_LEADER, tenantName, newSwitchLeaderTask(), context)
+}
+
+type TriggerTenantBalanceTask struct {
+	task.Task
+	tenantId int
+}
+
+func newTriggerTenantBalanceTask() *TriggerTenantBalanceTask {
+	newTask := &TriggerTenantBalanceTask{
+		Task: *task.NewSubTask(TASK_NAME_TRIGGER_TENANT_BALANCE),
+	}
+	newTask.SetCanCancel().SetCanContinue().SetCanRetry().SetCanPass()
+	return newTask
+}
+
+func waitBalanceJobFinished(t task.Task, tenantId int, jobId int64) error {
+	retryTimes := constant.CHECK_JOB_RETRY_TIMES
+	for retryTimes > 0 {
+		t.TimeoutCheck()
+		job, err := tenantService.GetInProgressBalanceJob(tenantId)
+		if err != nil {
+			return errors.Wrap(err, "Get balance job failed")
+		}
+		if job == nil || job.JobId != jobId {
+			history, err := tenantService.GetBalanceJobHistory(tenantId, jobId)
+			if err != nil {
+				return errors.Wrap(err, "Get balance job history failed")
+			}
+			if history == nil {
+				return errors.Occurf(errors.ErrObTenantJobNotExist, "balance job %d", jobId)
+			}
+			if history.Status != BALANCE_JOB_STATUS_COMPLETED {
+				return errors.Occur(errors.ErrObTenantJobFailed, jobId, history.Status)
+			}
+			return nil
+		}
+		t.ExecuteLogf("Balance job %d is %s", jobId, job.Status)
+		retryTimes--
+		time.Sleep(constant.CHECK_JOB_INTERVAL)
+	}
+	return errors.Occur(errors.ErrObTenantJobWaitTimeout, jobId)
+}
+
+func (t *TriggerTenantBalanceTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_ID, &t.tenantId); err != nil {
+		return err
+	}
+	tenantName, err := tenantService.GetTenantName(t.tenantId)
+	if err != nil {
+		return errors.Wrap(err, "Get tenant name failed")
+	}
+	if err := checkBalanceConflict(t.tenantId); err != nil {
+		return err
+	}
+
+	job, err := tenantService.GetInProgressBalanceJob(t.tenantId)
+	if err != nil {
+		return errors.Wrap(err, "Get balance job failed")
+	}
+	if job != nil {
+		t.ExecuteLogf("There is already an in-progress balance job %d, wait for it", job.JobId)
+		return waitBalanceJobFinished(t.Task, t.tenantId, job.JobId)
+	}
+
+	t.ExecuteLogf("Enable rebalance and transfer of tenant %s", tenantName)
+	if err := tenantService.EnableTenantRebalance(tenantName); err != nil {
+		return errors.Wrap(err, "Enable tenant rebalance failed")
+	}
+	db, err := GetConnection(tenantName)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	t.ExecuteLogf("Trigger partition balance of tenant %s", tenantName)
+	if err := tenantService.TriggerPartitionBalance(db); err != nil {
+		return errors.Wrap(err, "Trigger partition balance failed")
+	}
+
+	for i := 0; i < WAIT_BALANCE_JOB_RETRY_TIMES; i++ {
+		job, err := tenantService.GetInProgressBalanceJob(t.tenantId)
+		if err != nil {
+			return errors.Wrap(err, "Get balance job failed")
+		}
+		if job != nil {
+			t.ExecuteLogf("Wait for balance job %d to finish", job.JobId)
+			return waitBalanceJobFinished(t.Task, t.tenantId, job.JobId)
+		}
+		time.Sleep(constant.CHECK_JOB_INTERVAL)
+	}
+	t.ExecuteLogf("No balance job is generated, tenant %s is already balanced", tenantName)
+	return nil
+}
+
+type TransferPartitionTask struct {
+	task.Task
+	tenantId int
+	tableId  int64
+	objectId int64
+	lsId     int64
+}
+
+func newTransferPartitionTask() *TransferPartitionTask {
+	newTask := &TransferPartitionTask{
+		Task: *task.NewSubTask(TASK_NAME_TRANSFER_PARTITION),
+	}
+	newTask.SetCanCancel().SetCanContinue().SetCanRetry().SetCanPass()
+	return newTask
+}
+
+func (t *TransferPartitionTask) waitTransferFinished(taskId int64) error {
+	retryTimes := constant.CHECK_JOB_RETRY_TIMES
+	for retryTimes > 0 {
+		t.TimeoutCheck()
+		transferTask, err := tenantService.GetTransferPartitionTask(t.tenantId, t.tableId, t.objectId)
+		if err != nil {
+			return errors.Wrap(err, "Get transfer partition task failed")
+		}
+		if transferTask == nil || transferTask.TaskId != taskId {
+			history, err := tenantService.GetTransferPartitionTaskHistory(t.tenantId, taskId)
+			if err != nil {
+				return errors.Wrap(err, "Get transfer partition task history failed")
+			}
+			if history == nil {
+				return errors.Occurf(errors.ErrObTenantJobNotExist, "transfer partition task %d", taskId)
+			}
+			if history.Status != TRANSFER_PARTITION_TASK_COMPLETED {
+				return errors.Occur(errors.ErrObTenantJobFailed, taskId, history.Status)
+			}
+			return nil
+		}
+		t.ExecuteLogf("Transfer partition task %d is %s", taskId, transferTask.Status)
+		retryTimes--
+		time.Sleep(constant.CHECK_JOB_INTERVAL)
+	}
+	return errors.Occur(errors.ErrObTenantJobWaitTimeout, taskId)
+}
+
+func (t *TransferPartitionTask) Execute() error {
+	ctx := t.GetContext()
+	if err := ctx.GetParamWithValue(PARAM_TENANT_ID, &t.tenantId); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_TABLE_ID, &t.tableId); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_OBJECT_ID, &t.objectId); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_LS_ID, &t.lsId); err != nil {
+		return err
+	}
+	tenantName, err := tenantService.GetTenantName(t.tenantId)
+	if err != nil {
+		return errors.Wrap(err, "Get tenant name failed")
+	}
+
+	transferTask, err := tenantService.GetTransferPartitionTask(t.tenantId, t.tableId, t.objectId)
+	if err != nil {
+		return errors.Wrap(err, "Get transfer partition task failed")
+	}
+	if transferTask == nil {
+		lsId, err := tenantService.GetObjectLsId(t.tenantId, t.tableId, t.objectId)
+		if err != nil {
+			return errors.Wrap(err, "Get log stream of partition failed")
+		}
+		if lsId == t.lsId {
+			t.ExecuteLogf("Partition %d of table %d is already in log stream %d", t.objectId, t.tableId, t.lsId)
+			return nil
+		}
+		t.ExecuteLogf("Transfer partition %d of table %d to log stream %d", t.objectId, t.tableId, t.lsId)
+		if err := tenantService.TransferPartition(tenantName, t.tableId, t.objectId, t.lsId); err != nil {
+			return errors.Wrap(err, "Transfer partition failed")
+		}
+		if transferTask, err = tenantService.GetTransferPartitionTask(t.tenantId, t.tableId, t.objectId); err != nil {
+			return errors.Wrap(err, "Get transfer partition task failed")
+		} else if transferTask == nil {
+			// The task has been finished already.
+			return nil
+		}
+	} else if transferTask.DestLs != t.lsId {
+		t.ExecuteLogf("There is already an in-progress task to transfer partition %d to log stream %d", t.objectId, transferTask.DestLs)
+		return errors.Occur(errors.ErrObTenantJobConflict, "TRANSFER PARTITION")
+	}
+	return t.waitTransferFinished(transferTask.TaskId)
+}
+
+type SwitchLeaderTask struct {
+	task.Task
+	tenantId int
+	zone     string
+}
+
+func newSwitchLeaderTask() *SwitchLeaderTask {
+	newTask := &SwitchLeaderTask{
+		Task: *task.NewSubTask(TASK_NAME_SWITCH_LEADER_TO_PRIMARY_ZONE),
+	}
+	newTask.SetCanCancel().SetCanContinue().SetCanRetry().SetCanPass()
+	return newTask
+}
+
+// switchLeaders switches the leader of the log streams which are led out of the zones,
+// and returns the log streams being switched.
+func (t *SwitchLeaderTask) switchLeaders(tenantName string, zones []string) ([]int64, error) {
+	locations, err := tenantService.ListLsLocations(t.tenantId)
+	if err != nil {
+		return nil, errors.Wrap(err, "List log stream locations failed")
+	}
+	switched := make([]int64, 0)
+	lsIds, lsLocations := groupLsLocations(locations)
+	for _, lsId := range lsIds {
+		var target *oceanbase.ObLsLocation
+		isLeaderInZones := false
+		for i, location := range lsLocations[lsId] {
+			if location.Role == LS_ROLE_LEADER && inZones(location.Zone, zones) {
+				isLeaderInZones = true
+				break
+			}
+			if target == nil && location.ReplicaType == LS_REPLICA_TYPE_FULL && inZones(location.Zone, zones) {
+				target = &lsLocations[lsId][i]
+			}
+		}
+		if isLeaderInZones {
+			continue
+		}
+		if target == nil {
+			t.ExecuteWarnLogf("Log stream %d has no full replica in zone %s, skip it", lsId, strings.Join(zones, ","))
+			continue
+		}
+		t.ExecuteLogf("Switch leader of log stream %d to %s:%d", lsId, target.SvrIp, target.SvrPort)
+		if err := tenantService.SwitchLsLeader(tenantName, lsId, target.SvrIp, target.SvrPort); err != nil {
+			return nil, errors.Wrapf(err, "Switch leader of log stream %d failed", lsId)
+		}
+		switched = append(switched, lsId)
+	}
+	return switched, nil
+}
+
+func (t *SwitchLeaderTask) waitLeadersSwitched(tenantName string, lsIds []int64, zones []string) error {
+	retryTimes := constant.CHECK_JOB_RETRY_TIMES
+	for retryTimes > 0 {
+		t.TimeoutCheck()
+		locations, err := tenantService.ListLsLocations(t.tenantId)
+		if err != nil {
+			return errors.Wrap(err, "List log stream locations failed")
+		}
+		leaderZones := make(map[int64]string)
+		for _, location := range locations {
+			if location.Role == LS_ROLE_LEADER {
+				leaderZones[location.LsId] = location.Zone
+			}
+		}
+		finished := true
+		for _, lsId := range lsIds {
+			if !inZones(leaderZones[lsId], zones) {
+				finished = false
+				break
+			}
+		}
+		if finished {
+			return nil
+		}
+		retryTimes--
+		time.Sleep(constant.CHECK_JOB_INTERVAL)
+	}
+	return errors.Occur(errors.ErrObBalanceLeaderSwitchTimeout, tenantName, strings.Join(zones, ","))
+}
+
+func (t *SwitchLeaderTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_ID, &t.tenantId); err != nil {
+		return err
+	}
+	if err := t.GetContext().GetParamWithValue(PARAM_ZONE_NAME, &t.zone); err != nil {
+		return err
+	}
+	tenantName, err := tenantService.GetTenantName(t.tenantId)
+	if err != nil {
+		return errors.Wrap(err, "Get tenant name failed")
+	}
+	tenant, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if err := checkBalanceConflict(t.tenantId); err != nil {
+		return err
+	}
+	zones, err := getLeaderZones(tenant, t.zone)
+	if err != nil {
+		return err
+	}
+	if zones == nil {
+		t.ExecuteLogf("The primary zone of tenant %s is RANDOM, no need to switch leaders", tenantName)
+		return nil
+	}
+
+	switched, err := t.switchLeaders(tenantName, zones)
+	if err != nil {
+		return err
+	}
+	if len(switched) == 0 {
+		t.ExecuteLogf("All leaders of tenant %s are already in zone %s", tenantName, strings.Join(zones, ","))
+		return nil
+	}
+	t.ExecuteLogf("Wait for the leaders of %d log streams to be switched", len(switched))
+	return t.waitLeadersSwitched(tenantName, switched, zones)
+}
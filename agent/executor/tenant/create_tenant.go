@@ -274,7 +274,7 @@ func checkZoneResourceForUnit(zone string, unitName string, unitNum int) error {
 		return errors.Occur(errors.ErrObTenantUnitNumExceedsLimit, unitNum, len(source), zone)
 	}
 	for _, server := range source {
-		gatheredUnitInfo, err := gatherAllUnitsOnServer(server.SvrIp, server.SvrPort)
+		gatheredUnitInfo, err := GatherAllUnitsOnServer(server.SvrIp, server.SvrPort)
 		if err != nil {
 			return err
 		}
@@ -302,19 +302,19 @@ func checkZoneResourceForUnit(zone string, unitName string, unitNum int) error {
 	return checkErr
 }
 
-type gatheredUnitInfo struct {
+type GatheredUnitInfo struct {
 	MinCpu      float64
 	MaxCpu      float64
 	MemorySize  int64
 	LogDiskSize int64
 }
 
-func gatherAllUnitsOnServer(svrIp string, svrPort int) (*gatheredUnitInfo, error) {
+func GatherAllUnitsOnServer(svrIp string, svrPort int) (*GatheredUnitInfo, error) {
 	units, err := obclusterService.GetObUnitsOnServer(svrIp, svrPort)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Get all units on server %s failed.", meta.NewAgentInfo(svrIp, svrPort).String())
 	}
-	used := &gatheredUnitInfo{}
+	used := &GatheredUnitInfo{}
 	for _, unit := range units {
 		used.MaxCpu += unit.MaxCpu
 		used.MinCpu += unit.MinCpu
@@ -28,7 +28,11 @@ import (
 )
 
 func DeleteDatabase(tenantName, databaseName string, password *string) error {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	tenantInfo, err := checkMysqlTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -41,7 +45,11 @@ func DeleteDatabase(tenantName, databaseName string, password *string) error {
 }
 
 func CreateDatabase(tenantName string, param *param.CreateDatabaseParam) error {
-	db, err := GetConnectionWithPassword(tenantName, param.RootPassword)
+	tenantInfo, err := checkMysqlTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, param.RootPassword)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -54,10 +62,14 @@ func CreateDatabase(tenantName string, param *param.CreateDatabaseParam) error {
 }
 
 func AlterDatabase(tenantName string, databaseName string, param *param.ModifyDatabaseParam) error {
+	tenantInfo, err := checkMysqlTenant(tenantName)
+	if err != nil {
+		return err
+	}
 	if param.Collation == nil && param.ReadOnly == nil {
 		return nil
 	}
-	db, err := GetConnectionWithPassword(tenantName, param.RootPassword)
+	db, err := getTenantConnection(tenantInfo, param.RootPassword)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -83,7 +95,15 @@ func GetDatabase(tenantName, databaseName string, password *string) (*bo.Databas
 }
 
 func ListDatabases(tenantName string, password *string) ([]bo.Database, error) {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	// The schemas are listed in place of databases for oracle mode tenant.
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if isOracleTenant(tenantInfo) {
+		return listOracleSchemas(tenantInfo, password)
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
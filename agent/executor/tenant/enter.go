@@ -62,6 +62,9 @@ const (
 	PARAM_PRIMARY_ZONE                 = "primaryZone"
 	PARAM_ZONE_WITH_UNIT               = "zoneWithUnit"
 	PARAM_TIMESTAMP                    = "timestamp"
+	PARAM_TABLE_ID                     = "tableId"
+	PARAM_OBJECT_ID                    = "objectId"
+	PARAM_LS_ID                        = "lsId"
 
 	// tenant task
 	TASK_NAME_CREATE_AND_ATTACH_RESOURCE_POOL = "Create and attach resource pools"
@@ -85,6 +88,9 @@ const (
 	TASK_NAME_ATTACH_TENANT_RESOURCE_POOL     = "Attach tenant resource pool"
 	TASK_NAME_ALTER_TENANT_LOCALITY           = "Alter tenant locality"
 	TASK_NAME_ALTER_TENANT_PRIMARY_ZONE       = "Alter tenant primary zone"
+	TASK_NAME_TRIGGER_TENANT_BALANCE          = "Trigger tenant balance"
+	TASK_NAME_TRANSFER_PARTITION              = "Transfer partition"
+	TASK_NAME_SWITCH_LEADER_TO_PRIMARY_ZONE   = "Switch leaders to primary zone"
 
 	// tenant dag
 	DAG_CREATE_TENANT              = "Create tenant %s"
@@ -94,6 +100,9 @@ const (
 	DAG_SCALE_IN_TENANT_REPLICA    = "Scale in tenant replicas"
 	DAG_MODIFY_TENANT_REPLICA      = "Modify tenant replicas"
 	DAG_MODIFY_TENANT_PRIMARY_ZONE = "Modify tenant primary zone"
+	DAG_TRIGGER_TENANT_BALANCE     = "Trigger tenant balance"
+	DAG_TRANSFER_PARTITION         = "Transfer partition"
+	DAG_SWITCH_LEADER              = "Switch leaders to primary zone"
 
 	TENANT_NAME_PATTERN = `^[a-zA-Z0-9-_~#+]+$`
 
@@ -153,4 +162,7 @@ func RegisterTenantTask() {
 	task.RegisterTaskType(AlterResourcePoolUnitNumTask{})
 	task.RegisterTaskType(AlterResourcePoolUnitConfTask{})
 	task.RegisterTaskType(ModifyTenantWhitelistTask{})
+	task.RegisterTaskType(TriggerTenantBalanceTask{})
+	task.RegisterTaskType(TransferPartitionTask{})
+	task.RegisterTaskType(SwitchLeaderTask{})
 }
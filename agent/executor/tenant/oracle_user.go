@@ -0,0 +1,748 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	obmodel "github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+	"github.com/oceanbase/obshell/utils"
+)
+
+// oraclePrivileges is the privileges granted to a user or role in oracle mode tenant.
+type oraclePrivileges struct {
+	sysPrivileges    []obmodel.OracleDbaSysPriv
+	rolePrivileges   []obmodel.OracleDbaRolePriv
+	objectPrivileges []obmodel.OracleDbaTabPriv
+}
+
+func listOraclePrivileges(db *gorm.DB, grantee string) (*oraclePrivileges, error) {
+	sysPrivileges, err := tenantService.ListOracleSysPrivileges(db, grantee)
+	if err != nil {
+		return nil, errors.Wrap(err, "query system privileges failed")
+	}
+	rolePrivileges, err := tenantService.ListOracleRolePrivileges(db, grantee)
+	if err != nil {
+		return nil, errors.Wrap(err, "query granted roles failed")
+	}
+	objectPrivileges, err := tenantService.ListOracleObjectPrivileges(db, grantee)
+	if err != nil {
+		return nil, errors.Wrap(err, "query object privileges failed")
+	}
+	return &oraclePrivileges{
+		sysPrivileges:    sysPrivileges,
+		rolePrivileges:   rolePrivileges,
+		objectPrivileges: objectPrivileges,
+	}, nil
+}
+
+func (p *oraclePrivileges) globalPrivilegesOf(grantee string) []string {
+	privileges := make([]string, 0)
+	for _, privilege := range p.sysPrivileges {
+		if privilege.Grantee == grantee {
+			privileges = append(privileges, strings.ReplaceAll(privilege.Privilege, " ", "_"))
+		}
+	}
+	return privileges
+}
+
+func (p *oraclePrivileges) grantedRolesOf(grantee string) []string {
+	roles := make([]string, 0)
+	for _, privilege := range p.rolePrivileges {
+		if privilege.Grantee == grantee {
+			roles = append(roles, privilege.GrantedRole)
+		}
+	}
+	return roles
+}
+
+func (p *oraclePrivileges) granteesOf(role string) []string {
+	grantees := make([]string, 0)
+	for _, privilege := range p.rolePrivileges {
+		if privilege.GrantedRole == role {
+			grantees = append(grantees, privilege.Grantee)
+		}
+	}
+	return grantees
+}
+
+func (p *oraclePrivileges) objectPrivilegesOf(grantee string) []bo.ObjectPrivilege {
+	objectPrivilegeMap := make(map[string][]string)
+	objects := make([]string, 0)
+	for _, privilege := range p.objectPrivileges {
+		if privilege.Grantee != grantee {
+			continue
+		}
+		object := fmt.Sprintf("%s.%s", privilege.Owner, privilege.TableName)
+		if _, ok := objectPrivilegeMap[object]; !ok {
+			objects = append(objects, object)
+		}
+		objectPrivilegeMap[object] = append(objectPrivilegeMap[object], privilege.Privilege)
+	}
+	result := make([]bo.ObjectPrivilege, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, bo.ObjectPrivilege{
+			Object:     object,
+			Privileges: objectPrivilegeMap[object],
+		})
+	}
+	return result
+}
+
+func verifyOraclePrivileges(privileges []string, availablePrivileges []string) error {
+	for _, privilege := range privileges {
+		if !utils.ContainsString(availablePrivileges, strings.ToUpper(privilege)) {
+			return errors.Occur(errors.ErrObUserPrivilegeNotSupported, privilege)
+		}
+	}
+	return nil
+}
+
+// normalizeOracleName resolves the user, role or schema name the way oracle does:
+// a name in double quotes is case sensitive and kept as is, others are upper-cased.
+func normalizeOracleName(name string) (string, error) {
+	if len(name) > 2 && strings.HasPrefix(name, "\"") && strings.HasSuffix(name, "\"") {
+		name = name[1 : len(name)-1]
+	} else {
+		name = strings.ToUpper(name)
+	}
+	if name == "" || strings.Contains(name, "\"") {
+		return "", errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "name", fmt.Sprintf("'%s' is not a valid oracle name", name))
+	}
+	return name, nil
+}
+
+func normalizeOracleNames(names []string) ([]string, error) {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized, err := normalizeOracleName(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, normalized)
+	}
+	return result, nil
+}
+
+// normalizeOracleObjectName normalizes each part of the object in the format of 'OWNER.NAME'.
+func normalizeOracleObjectName(object string) (string, error) {
+	parts := strings.Split(object, ".")
+	if len(parts) != 2 {
+		return "", errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "object", fmt.Sprintf("object '%s' should be in the format of 'OWNER.NAME'", object))
+	}
+	parts, err := normalizeOracleNames(parts)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// checkOraclePassword checks the password which is quoted with double quotes in the statement.
+func checkOraclePassword(password string) error {
+	if strings.Contains(password, "\"") {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "password", "double quotes are not allowed in the password of oracle mode tenant")
+	}
+	return nil
+}
+
+// normalizeOracleObjectPrivileges verifies the object privileges and returns them with the normalized object names.
+func normalizeOracleObjectPrivileges(objectPrivileges []param.ObjectPrivilegeParam) ([]param.ObjectPrivilegeParam, error) {
+	result := make([]param.ObjectPrivilegeParam, 0, len(objectPrivileges))
+	for _, objectPrivilege := range objectPrivileges {
+		object, err := normalizeOracleObjectName(objectPrivilege.Object)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyOraclePrivileges(objectPrivilege.Privileges, constant.OB_ORACLE_OBJECT_PRIVILEGES); err != nil {
+			return nil, err
+		}
+		result = append(result, param.ObjectPrivilegeParam{
+			Object:     object,
+			Privileges: toUpper(objectPrivilege.Privileges),
+		})
+	}
+	return result, nil
+}
+
+func toUpper(strs []string) []string {
+	result := make([]string, 0, len(strs))
+	for _, str := range strs {
+		result = append(result, strings.ToUpper(str))
+	}
+	return result
+}
+
+func createOracleUser(tenantInfo *obmodel.DbaObTenant, param *param.CreateUserParam) error {
+	tenantName := tenantInfo.TenantName
+	if err := verifyOraclePrivileges(param.GlobalPrivileges, constant.OB_ORACLE_SYSTEM_PRIVILEGES); err != nil {
+		return err
+	}
+	userName, err := normalizeOracleName(param.UserName)
+	if err != nil {
+		return err
+	}
+	roles, err := normalizeOracleNames(param.Roles)
+	if err != nil {
+		return err
+	}
+	if err := checkOraclePassword(param.Password); err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, param.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get connection of tenant %s", tenantName)
+	}
+	if err := tenantService.CreateOracleUser(db, userName, param.Password); err != nil {
+		return errors.Wrapf(err, "create user '%s' failed", userName)
+	}
+	if err := tenantService.GrantOracleSysPrivileges(db, userName, toUpper(param.GlobalPrivileges)); err != nil {
+		return errors.Wrapf(err, "grant system privileges to user '%s' failed", userName)
+	}
+	if err := tenantService.GrantOracleRoles(db, userName, roles); err != nil {
+		return errors.Wrapf(err, "grant roles to user '%s' failed", userName)
+	}
+	return nil
+}
+
+func dropOracleUser(tenantInfo *obmodel.DbaObTenant, userName string, password *string) error {
+	tenantName := tenantInfo.TenantName
+	userName, err := normalizeOracleName(userName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get connection of tenant %s", tenantName)
+	}
+	if exist, err := tenantService.IsOracleUserExist(db, userName); err != nil {
+		return errors.Wrapf(err, "check user '%s' exist failed", userName)
+	} else if !exist {
+		return nil
+	}
+	if err := tenantService.DropOracleUser(db, userName); err != nil {
+		return errors.Wrapf(err, "drop user '%s' failed", userName)
+	}
+	return nil
+}
+
+func listOracleUsers(tenantInfo *obmodel.DbaObTenant, password *string) ([]bo.ObUser, error) {
+	tenantName := tenantInfo.TenantName
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	users, err := tenantService.ListOracleUsers(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query user list of tenant %s", tenantName)
+	}
+	privileges, err := listOraclePrivileges(db, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query privileges of tenant %s", tenantName)
+	}
+	schemas := oracleSchemaNames(users)
+	result := make([]bo.ObUser, 0)
+	for _, user := range users {
+		if utils.ContainsString(constant.OB_INNER_USERS, user.UserName) {
+			continue
+		}
+		result = append(result, *buildOracleUser(tenantName, &user, privileges, schemas))
+	}
+	return result, nil
+}
+
+func getOracleUser(tenantInfo *obmodel.DbaObTenant, userName string, password *string) (*bo.ObUser, error) {
+	tenantName := tenantInfo.TenantName
+	userName, err := normalizeOracleName(userName)
+	if err != nil {
+		return nil, err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	user, err := tenantService.GetOracleUser(db, userName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query user %s of tenant %s", userName, tenantName)
+	}
+	if user == nil {
+		return nil, errors.Occur(errors.ErrObUserNotExist, userName, tenantName)
+	}
+	users, err := tenantService.ListOracleUsers(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query user list of tenant %s", tenantName)
+	}
+	privileges, err := listOraclePrivileges(db, userName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query privileges of user %s of tenant %s", userName, tenantName)
+	}
+	return buildOracleUser(tenantName, user, privileges, oracleSchemaNames(users)), nil
+}
+
+func oracleSchemaNames(users []obmodel.OracleDbaUser) []string {
+	schemas := make([]string, 0, len(users))
+	for _, user := range users {
+		schemas = append(schemas, user.UserName)
+	}
+	return schemas
+}
+
+func buildOracleUser(tenantName string, user *obmodel.OracleDbaUser, privileges *oraclePrivileges, allSchemas []string) *bo.ObUser {
+	obUser := &bo.ObUser{
+		UserName:         user.UserName,
+		IsLocked:         strings.Contains(strings.ToUpper(user.AccountStatus), "LOCKED"),
+		GlobalPrivileges: privileges.globalPrivilegesOf(user.UserName),
+		GrantedRoles:     privileges.grantedRolesOf(user.UserName),
+		DbPrivileges:     make([]bo.DbPrivilege, 0),
+		ObjectPrivileges: privileges.objectPrivilegesOf(user.UserName),
+	}
+
+	// The schema of oracle mode is the user itself, and the 'ANY' system
+	// privileges make all the schemas accessible.
+	accessibleSchemas := []string{user.UserName}
+	for _, privilege := range obUser.GlobalPrivileges {
+		if strings.Contains(privilege, "_ANY_") {
+			accessibleSchemas = allSchemas
+			break
+		}
+	}
+	if len(accessibleSchemas) == 1 {
+		for _, objectPrivilege := range obUser.ObjectPrivileges {
+			owner := strings.Split(objectPrivilege.Object, ".")[0]
+			if !utils.ContainsString(accessibleSchemas, owner) {
+				accessibleSchemas = append(accessibleSchemas, owner)
+			}
+		}
+	}
+	obUser.AccessibleDatabases = accessibleSchemas
+	obUser.ConnectionStrings = []bo.ObproxyAndConnectionString{
+		{
+			Type:             constant.OB_CONNECTION_TYPE_DIRECT,
+			ConnectionString: fmt.Sprintf("obclient -h%s -P%d -u%s@%s -p", meta.OCS_AGENT.GetIp(), meta.MYSQL_PORT, obUser.UserName, tenantName),
+		},
+	}
+	return obUser
+}
+
+func lockOracleUser(tenantInfo *obmodel.DbaObTenant, userName string, password *string) error {
+	tenantName := tenantInfo.TenantName
+	userName, err := normalizeOracleName(userName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = tenantService.LockOracleUser(db, userName); err != nil {
+		return errors.Wrapf(err, "Failed to lock user %s of tenant %s", userName, tenantName)
+	}
+	return nil
+}
+
+func unlockOracleUser(tenantInfo *obmodel.DbaObTenant, userName string, password *string) error {
+	tenantName := tenantInfo.TenantName
+	userName, err := normalizeOracleName(userName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = tenantService.UnlockOracleUser(db, userName); err != nil {
+		return errors.Wrapf(err, "Failed to unlock user %s of tenant %s", userName, tenantName)
+	}
+	return nil
+}
+
+func changeOracleUserPassword(tenantInfo *obmodel.DbaObTenant, userName string, p *param.ChangeUserPasswordParam) error {
+	tenantName := tenantInfo.TenantName
+	userName, err := normalizeOracleName(userName)
+	if err != nil {
+		return err
+	}
+	if err = checkOraclePassword(p.NewPassword); err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = tenantService.ChangeOracleUserPassword(db, userName, p.NewPassword); err != nil {
+		return errors.Wrapf(err, "Failed to change password of user %s of tenant %s", userName, tenantName)
+	}
+	return nil
+}
+
+// modifyOracleGlobalPrivilege makes the system privileges of the user or role same as the desired ones.
+func modifyOracleGlobalPrivilege(tenantInfo *obmodel.DbaObTenant, grantee string, p *param.ModifyUserGlobalPrivilegeParam) error {
+	tenantName := tenantInfo.TenantName
+	grantee, err := normalizeOracleName(grantee)
+	if err != nil {
+		return err
+	}
+	if err := verifyOraclePrivileges(p.GlobalPrivileges, constant.OB_ORACLE_SYSTEM_PRIVILEGES); err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	privileges, err := listOraclePrivileges(db, grantee)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to query privileges of %s of tenant %s", grantee, tenantName)
+	}
+	privilegesToGrant, privilegesToRevoke := utils.Difference(toUpper(p.GlobalPrivileges), privileges.globalPrivilegesOf(grantee))
+	if err = tenantService.GrantOracleSysPrivileges(db, grantee, privilegesToGrant); err != nil {
+		return errors.Wrapf(err, "Failed to grant privilege to %s of tenant %s", grantee, tenantName)
+	}
+	if err = tenantService.RevokeOracleSysPrivileges(db, grantee, privilegesToRevoke); err != nil {
+		return errors.Wrapf(err, "Failed to revoke privilege from %s of tenant %s", grantee, tenantName)
+	}
+	return nil
+}
+
+// checkOracleTenant returns the tenant if it is an oracle mode tenant.
+func checkOracleTenant(tenantName string) (*obmodel.DbaObTenant, error) {
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if !isOracleTenant(tenantInfo) {
+		return nil, errors.Occur(errors.ErrObTenantModeNotSupported, constant.MYSQL_MODE)
+	}
+	return tenantInfo, nil
+}
+
+// checkMysqlTenant returns error for oracle mode tenant, whose schemas are
+// managed as users rather than databases.
+func checkMysqlTenant(tenantName string) (*obmodel.DbaObTenant, error) {
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if isOracleTenant(tenantInfo) {
+		return nil, errors.Occur(errors.ErrObTenantModeNotSupported, constant.ORACAL_MODE)
+	}
+	return tenantInfo, nil
+}
+
+func checkOracleGrantee(db *gorm.DB, tenantName, grantee string) error {
+	isUser, err := tenantService.IsOracleUserExist(db, grantee)
+	if err != nil {
+		return errors.Wrapf(err, "check user '%s' exist failed", grantee)
+	}
+	if isUser {
+		return nil
+	}
+	isRole, err := tenantService.IsOracleRoleExist(db, grantee)
+	if err != nil {
+		return errors.Wrapf(err, "check role '%s' exist failed", grantee)
+	}
+	if !isRole {
+		return errors.Occur(errors.ErrObUserNotExist, grantee, tenantName)
+	}
+	return nil
+}
+
+// ModifyGrantedRoles makes the roles granted to the user or role same as the desired ones.
+// Only oracle mode tenant is supported.
+func ModifyGrantedRoles(tenantName, grantee string, p *param.ModifyGrantedRolesParam) error {
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	grantee, err = normalizeOracleName(grantee)
+	if err != nil {
+		return err
+	}
+	desiredRoles, err := normalizeOracleNames(p.Roles)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = checkOracleGrantee(db, tenantName, grantee); err != nil {
+		return err
+	}
+	for _, role := range desiredRoles {
+		if exist, err := tenantService.IsOracleRoleExist(db, role); err != nil {
+			return errors.Wrapf(err, "check role '%s' exist failed", role)
+		} else if !exist {
+			return errors.Occur(errors.ErrObRoleNotExist, role, tenantName)
+		}
+	}
+	privileges, err := listOraclePrivileges(db, grantee)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to query privileges of %s of tenant %s", grantee, tenantName)
+	}
+	rolesToGrant, rolesToRevoke := utils.Difference(desiredRoles, privileges.grantedRolesOf(grantee))
+	if err = tenantService.GrantOracleRoles(db, grantee, rolesToGrant); err != nil {
+		return errors.Wrapf(err, "Failed to grant roles to %s of tenant %s", grantee, tenantName)
+	}
+	if err = tenantService.RevokeOracleRoles(db, grantee, rolesToRevoke); err != nil {
+		return errors.Wrapf(err, "Failed to revoke roles from %s of tenant %s", grantee, tenantName)
+	}
+	return nil
+}
+
+// ModifyObjectPrivilege makes the object privileges of the user or role same as the desired ones.
+// Only oracle mode tenant is supported.
+func ModifyObjectPrivilege(tenantName, grantee string, p *param.ModifyObjectPrivilegeParam) error {
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	grantee, err = normalizeOracleName(grantee)
+	if err != nil {
+		return err
+	}
+	desiredObjectPrivileges, err := normalizeOracleObjectPrivileges(p.ObjectPrivileges)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = checkOracleGrantee(db, tenantName, grantee); err != nil {
+		return err
+	}
+	privileges, err := listOraclePrivileges(db, grantee)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to query privileges of %s of tenant %s", grantee, tenantName)
+	}
+	current := privileges.objectPrivilegesOf(grantee)
+	for _, desired := range desiredObjectPrivileges {
+		var currentPrivileges []string
+		for _, objectPrivilege := range current {
+			if objectPrivilege.Object == desired.Object {
+				currentPrivileges = objectPrivilege.Privileges
+				break
+			}
+		}
+		privilegesToGrant, privilegesToRevoke := utils.Difference(desired.Privileges, currentPrivileges)
+		if err = tenantService.GrantOracleObjectPrivileges(db, grantee, desired.Object, privilegesToGrant); err != nil {
+			return errors.Wrapf(err, "Failed to grant privilege of %s to %s of tenant %s", desired.Object, grantee, tenantName)
+		}
+		if err = tenantService.RevokeOracleObjectPrivileges(db, grantee, desired.Object, privilegesToRevoke); err != nil {
+			return errors.Wrapf(err, "Failed to revoke privilege of %s from %s of tenant %s", desired.Object, grantee, tenantName)
+		}
+	}
+	for _, objectPrivilege := range current {
+		found := false
+		for _, desired := range desiredObjectPrivileges {
+			if desired.Object == objectPrivilege.Object {
+				found = true
+				break
+			}
+		}
+		if !found {
+			if err = tenantService.RevokeOracleObjectPrivileges(db, grantee, objectPrivilege.Object, objectPrivilege.Privileges); err != nil {
+				return errors.Wrapf(err, "Failed to revoke privilege of %s from %s of tenant %s", objectPrivilege.Object, grantee, tenantName)
+			}
+		}
+	}
+	return nil
+}
+
+func CreateRole(tenantName string, p *param.CreateRoleParam) error {
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	if err := verifyOraclePrivileges(p.GlobalPrivileges, constant.OB_ORACLE_SYSTEM_PRIVILEGES); err != nil {
+		return err
+	}
+	roleName, err := normalizeOracleName(p.RoleName)
+	if err != nil {
+		return err
+	}
+	roles, err := normalizeOracleNames(p.Roles)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if err = tenantService.CreateOracleRole(db, roleName); err != nil {
+		return errors.Wrapf(err, "create role '%s' failed", roleName)
+	}
+	if err = tenantService.GrantOracleSysPrivileges(db, roleName, toUpper(p.GlobalPrivileges)); err != nil {
+		return errors.Wrapf(err, "grant system privileges to role '%s' failed", roleName)
+	}
+	if err = tenantService.GrantOracleRoles(db, roleName, roles); err != nil {
+		return errors.Wrapf(err, "grant roles to role '%s' failed", roleName)
+	}
+	return nil
+}
+
+func DropRole(tenantName, roleName string, password *string) error {
+	roleName, err := normalizeOracleName(roleName)
+	if err != nil {
+		return err
+	}
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	if exist, err := tenantService.IsOracleRoleExist(db, roleName); err != nil {
+		return errors.Wrapf(err, "check role '%s' exist failed", roleName)
+	} else if !exist {
+		return nil
+	}
+	if err = tenantService.DropOracleRole(db, roleName); err != nil {
+		return errors.Wrapf(err, "drop role '%s' failed", roleName)
+	}
+	return nil
+}
+
+func ListRoles(tenantName string, password *string) ([]bo.ObRole, error) {
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	roles, err := tenantService.ListOracleRoles(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query role list of tenant %s", tenantName)
+	}
+	users, err := tenantService.ListOracleUsers(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query user list of tenant %s", tenantName)
+	}
+	privileges, err := listOraclePrivileges(db, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query privileges of tenant %s", tenantName)
+	}
+	userNames := oracleSchemaNames(users)
+	result := make([]bo.ObRole, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, *buildOracleRole(role.Role, privileges, userNames))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RoleName < result[j].RoleName
+	})
+	return result, nil
+}
+
+func GetRole(tenantName, roleName string, password *string) (*bo.ObRole, error) {
+	roleName, err := normalizeOracleName(roleName)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := ListRoles(tenantName, password)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.RoleName == roleName {
+			return &role, nil
+		}
+	}
+	return nil, errors.Occur(errors.ErrObRoleNotExist, roleName, tenantName)
+}
+
+func buildOracleRole(roleName string, privileges *oraclePrivileges, userNames []string) *bo.ObRole {
+	role := &bo.ObRole{
+		RoleName:         roleName,
+		GlobalPrivileges: privileges.globalPrivilegesOf(roleName),
+		GrantedRoles:     privileges.grantedRolesOf(roleName),
+		ObjectPrivileges: privileges.objectPrivilegesOf(roleName),
+		UserGrantees:     make([]string, 0),
+		RoleGrantees:     make([]string, 0),
+	}
+	for _, grantee := range privileges.granteesOf(roleName) {
+		if utils.ContainsString(userNames, grantee) {
+			role.UserGrantees = append(role.UserGrantees, grantee)
+		} else {
+			role.RoleGrantees = append(role.RoleGrantees, grantee)
+		}
+	}
+	return role
+}
+
+func ModifyRoleGlobalPrivilege(tenantName, roleName string, p *param.ModifyUserGlobalPrivilegeParam) error {
+	tenantInfo, err := checkOracleTenant(tenantName)
+	if err != nil {
+		return err
+	}
+	return modifyOracleGlobalPrivilege(tenantInfo, roleName, p)
+}
+
+// listOracleSchemas lists the schemas of oracle mode tenant in the form of databases.
+func listOracleSchemas(tenantInfo *obmodel.DbaObTenant, password *string) ([]bo.Database, error) {
+	tenantName := tenantInfo.TenantName
+	db, err := getTenantConnection(tenantInfo, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	users, err := tenantService.ListOracleUsers(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list schemas of tenant %s", tenantName)
+	}
+	schemas := make([]bo.Database, 0)
+	for _, user := range users {
+		if utils.ContainsString(constant.OB_INNER_USERS, user.UserName) {
+			continue
+		}
+		schemas = append(schemas, bo.Database{
+			DbName:     user.UserName,
+			CreateTime: user.Created.UnixMicro(),
+			ConnectionUrls: []bo.ObproxyAndConnectionString{
+				{
+					Type:             constant.OB_CONNECTION_TYPE_DIRECT,
+					ConnectionString: fmt.Sprintf("jdbc:oceanbase://%s:%d/%s", meta.OCS_AGENT.GetIp(), meta.MYSQL_PORT, user.UserName),
+				},
+			},
+		})
+	}
+	return schemas, nil
+}
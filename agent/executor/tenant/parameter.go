@@ -17,6 +17,9 @@
 package tenant
 
 import (
+	"fmt"
+
+	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/engine/task"
 	"github.com/oceanbase/obshell/agent/errors"
 	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
@@ -53,7 +56,7 @@ func GetTenantParameter(tenantName string, parameterName string) (*oceanbase.GvO
 	return parameter, nil
 }
 
-func SetTenantParameters(tenantName string, parameters map[string]interface{}) error {
+func SetTenantParameters(tenantName string, parameters map[string]interface{}, operator string) error {
 	tenant, err := checkTenantExistAndStatus(tenantName)
 	if err != nil {
 		return err
@@ -64,9 +67,30 @@ func SetTenantParameters(tenantName string, parameters map[string]interface{}) e
 	}
 
 	transferNumber(parameters)
+	changes := make([]oceanbase.ParameterChange, 0, len(parameters))
+	for name, value := range parameters {
+		change := oceanbase.ParameterChange{
+			Category:   constant.PARAMETER_CHANGE_CATEGORY_PARAMETER,
+			Scope:      constant.PARAMETER_SCOPE_TENANT,
+			Name:       name,
+			TenantName: tenant.TenantName,
+			NewValue:   fmt.Sprint(value),
+			Operator:   operator,
+		}
+		if parameter, err := tenantService.GetTenantParameter(tenant.TenantID, name); err != nil {
+			return errors.Wrapf(err, "get old value of parameter '%s' failed", name)
+		} else if parameter != nil {
+			change.OldValue = parameter.Value
+		} else {
+			change.OldValueUnknown = true
+		}
+		changes = append(changes, change)
+	}
+
 	if err := tenantService.SetTenantParameters(tenant.TenantName, parameters); err != nil {
 		return errors.Wrap(err, "set tenant parameters failed")
 	}
+	obclusterService.RecordParameterChanges(changes)
 	return nil
 }
 
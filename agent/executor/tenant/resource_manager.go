@@ -0,0 +1,394 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/service/tenant"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CONSUMER_GROUP_MAPPING_ATTRIBUTE_USER   = "USER"
+	CONSUMER_GROUP_MAPPING_ATTRIBUTE_COLUMN = "COLUMN"
+
+	// The user of the oracle mode tenant is mapped by the attribute ORACLE_USER.
+	CONSUMER_GROUP_MAPPING_ATTRIBUTE_ORACLE_USER = "ORACLE_USER"
+)
+
+func checkPercentage(name string, value *int, min int) error {
+	if value != nil && (*value < min || *value > 100) {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, name, fmt.Sprintf("%s should be in [%d, 100]", name, min))
+	}
+	return nil
+}
+
+func checkPlanDirectiveValues(mgmtP1, utilizationLimit, minIops, maxIops, weightIops *int) error {
+	if err := checkPercentage("mgmt_p1", mgmtP1, 0); err != nil {
+		return err
+	}
+	if err := checkPercentage("utilization_limit", utilizationLimit, 1); err != nil {
+		return err
+	}
+	if err := checkPercentage("min_iops", minIops, 0); err != nil {
+		return err
+	}
+	if err := checkPercentage("max_iops", maxIops, 0); err != nil {
+		return err
+	}
+	if err := checkPercentage("weight_iops", weightIops, 0); err != nil {
+		return err
+	}
+	if minIops != nil && maxIops != nil && *minIops > *maxIops {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_iops", "min_iops should be smaller than or equal to max_iops")
+	}
+	return nil
+}
+
+func checkResourcePlanExist(conn *tenant.ResourceManagerConn, tenantName string, planName string) error {
+	plan, err := tenantService.GetResourcePlan(conn, planName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get resource plan %s of tenant %s", planName, tenantName)
+	}
+	if plan == nil {
+		return errors.Occur(errors.ErrObResourcePlanNotExist, planName, tenantName)
+	}
+	return nil
+}
+
+func checkConsumerGroupExist(conn *tenant.ResourceManagerConn, tenantName string, groupName string) error {
+	group, err := tenantService.GetConsumerGroup(conn, groupName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get consumer group %s of tenant %s", groupName, tenantName)
+	}
+	if group == nil {
+		return errors.Occur(errors.ErrObConsumerGroupNotExist, groupName, tenantName)
+	}
+	return nil
+}
+
+// getResourceManagerConnection returns the connection of the tenant with its mode,
+// the resource manager is managed in the syntax of the tenant mode.
+func getResourceManagerConnection(tenantName string, password *string) (*tenant.ResourceManagerConn, error) {
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	db, err := getTenantConnection(tenantInfo, password)
+	if err != nil {
+		CloseDbConnection(db)
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	return &tenant.ResourceManagerConn{DB: db, Oracle: isOracleTenant(tenantInfo)}, nil
+}
+
+func CreateResourcePlan(tenantName string, param *param.CreateResourcePlanParam) error {
+	if param.PlanName == "" {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "plan_name", "resource plan name is empty")
+	}
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if err = tenantService.CreateResourcePlan(conn, param.PlanName, param.Comment); err != nil {
+		return errors.Wrapf(err, "Failed to create resource plan %s of tenant %s", param.PlanName, tenantName)
+	}
+	return nil
+}
+
+func DeleteResourcePlan(tenantName string, planName string, password *string) error {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if err = checkResourcePlanExist(conn, tenantName, planName); err != nil {
+		return err
+	}
+	if err = tenantService.DeleteResourcePlan(conn, planName); err != nil {
+		return errors.Wrapf(err, "Failed to delete resource plan %s of tenant %s", planName, tenantName)
+	}
+	return nil
+}
+
+func ListResourcePlans(tenantName string, password *string) ([]bo.ResourcePlan, error) {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseDbConnection(conn.DB)
+	plans, err := tenantService.ListResourcePlans(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list resource plans of tenant %s", tenantName)
+	}
+	directives, err := tenantService.ListPlanDirectives(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list plan directives of tenant %s", tenantName)
+	}
+	activePlan, err := tenantService.GetActiveResourcePlan(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get active resource plan of tenant %s", tenantName)
+	}
+
+	res := make([]bo.ResourcePlan, 0, len(plans))
+	for _, plan := range plans {
+		planBO := plan.ToBO()
+		planBO.IsActive = strings.EqualFold(plan.Plan, activePlan)
+		for _, directive := range directives {
+			if directive.Plan == plan.Plan {
+				planBO.Directives = append(planBO.Directives, directive.ToBO())
+			}
+		}
+		res = append(res, *planBO)
+	}
+	return res, nil
+}
+
+func CreateConsumerGroup(tenantName string, param *param.CreateConsumerGroupParam) error {
+	if param.GroupName == "" {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "group_name", "consumer group name is empty")
+	}
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if err = tenantService.CreateConsumerGroup(conn, param.GroupName, param.Comment); err != nil {
+		return errors.Wrapf(err, "Failed to create consumer group %s of tenant %s", param.GroupName, tenantName)
+	}
+	return nil
+}
+
+func DeleteConsumerGroup(tenantName string, groupName string, password *string) error {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if err = checkConsumerGroupExist(conn, tenantName, groupName); err != nil {
+		return err
+	}
+	if err = tenantService.DeleteConsumerGroup(conn, groupName); err != nil {
+		return errors.Wrapf(err, "Failed to delete consumer group %s of tenant %s", groupName, tenantName)
+	}
+	return nil
+}
+
+func ListConsumerGroups(tenantName string, password *string) ([]bo.ConsumerGroup, error) {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseDbConnection(conn.DB)
+	groups, err := tenantService.ListConsumerGroups(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list consumer groups of tenant %s", tenantName)
+	}
+	res := make([]bo.ConsumerGroup, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, group.ToBO())
+	}
+	return res, nil
+}
+
+func CreatePlanDirective(tenantName string, planName string, param *param.CreatePlanDirectiveParam) error {
+	if err := checkPlanDirectiveValues(param.MgmtP1, param.UtilizationLimit, param.MinIops, param.MaxIops, param.WeightIops); err != nil {
+		return err
+	}
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if err = checkResourcePlanExist(conn, tenantName, planName); err != nil {
+		return err
+	}
+	if err = checkConsumerGroupExist(conn, tenantName, param.GroupName); err != nil {
+		return err
+	}
+	if err = tenantService.CreatePlanDirective(conn, planName, param); err != nil {
+		return errors.Wrapf(err, "Failed to create directive for consumer group %s in resource plan %s of tenant %s", param.GroupName, planName, tenantName)
+	}
+	return nil
+}
+
+func ModifyPlanDirective(tenantName string, planName string, groupName string, param *param.ModifyPlanDirectiveParam) error {
+	if err := checkPlanDirectiveValues(param.MgmtP1, param.UtilizationLimit, param.MinIops, param.MaxIops, param.WeightIops); err != nil {
+		return err
+	}
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	directive, err := tenantService.GetPlanDirective(conn, planName, groupName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get directive for consumer group %s in resource plan %s of tenant %s", groupName, planName, tenantName)
+	}
+	if directive == nil {
+		return errors.Occur(errors.ErrObPlanDirectiveNotExist, groupName, planName)
+	}
+	minIops, maxIops := param.MinIops, param.MaxIops
+	if minIops == nil {
+		minIops = &directive.MinIops
+	}
+	if maxIops == nil {
+		maxIops = &directive.MaxIops
+	}
+	if *minIops > *maxIops {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_iops", "min_iops should be smaller than or equal to max_iops")
+	}
+	if err = tenantService.UpdatePlanDirective(conn, planName, groupName, param); err != nil {
+		return errors.Wrapf(err, "Failed to modify directive for consumer group %s in resource plan %s of tenant %s", groupName, planName, tenantName)
+	}
+	return nil
+}
+
+func DeletePlanDirective(tenantName string, planName string, groupName string, password *string) error {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	directive, err := tenantService.GetPlanDirective(conn, planName, groupName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get directive for consumer group %s in resource plan %s of tenant %s", groupName, planName, tenantName)
+	}
+	if directive == nil {
+		return errors.Occur(errors.ErrObPlanDirectiveNotExist, groupName, planName)
+	}
+	if err = tenantService.DeletePlanDirective(conn, planName, groupName); err != nil {
+		return errors.Wrapf(err, "Failed to delete directive for consumer group %s in resource plan %s of tenant %s", groupName, planName, tenantName)
+	}
+	return nil
+}
+
+func SetConsumerGroupMapping(tenantName string, param *param.ConsumerGroupMappingParam) error {
+	param.Attribute = strings.ToUpper(param.Attribute)
+	if param.Attribute != CONSUMER_GROUP_MAPPING_ATTRIBUTE_USER && param.Attribute != CONSUMER_GROUP_MAPPING_ATTRIBUTE_COLUMN {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "attribute", fmt.Sprintf("attribute should be %s or %s", CONSUMER_GROUP_MAPPING_ATTRIBUTE_USER, CONSUMER_GROUP_MAPPING_ATTRIBUTE_COLUMN))
+	}
+	if param.Value == "" {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "value", "mapping value is empty")
+	}
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if param.ConsumerGroup != "" {
+		if err = checkConsumerGroupExist(conn, tenantName, param.ConsumerGroup); err != nil {
+			return err
+		}
+	}
+	attribute, value := param.Attribute, param.Value
+	if conn.Oracle && attribute == CONSUMER_GROUP_MAPPING_ATTRIBUTE_USER {
+		attribute = CONSUMER_GROUP_MAPPING_ATTRIBUTE_ORACLE_USER
+		if value, err = normalizeOracleName(value); err != nil {
+			return err
+		}
+	}
+	if err = tenantService.SetConsumerGroupMapping(conn, attribute, value, param.ConsumerGroup); err != nil {
+		return errors.Wrapf(err, "Failed to set consumer group mapping %s '%s' of tenant %s", param.Attribute, param.Value, tenantName)
+	}
+	return nil
+}
+
+func ListConsumerGroupMappings(tenantName string, password *string) ([]bo.ConsumerGroupMapping, error) {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseDbConnection(conn.DB)
+	mappings, err := tenantService.ListConsumerGroupMappings(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list consumer group mappings of tenant %s", tenantName)
+	}
+	res := make([]bo.ConsumerGroupMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		res = append(res, mapping.ToBO())
+	}
+	return res, nil
+}
+
+func ActivateResourcePlan(tenantName string, param *param.ActivateResourcePlanParam) error {
+	conn, err := getResourceManagerConnection(tenantName, param.RootPassword)
+	if err != nil {
+		return err
+	}
+	defer CloseDbConnection(conn.DB)
+	if param.PlanName != "" {
+		if err = checkResourcePlanExist(conn, tenantName, param.PlanName); err != nil {
+			return err
+		}
+	}
+	if err = tenantService.SetActiveResourcePlan(conn, param.PlanName); err != nil {
+		return errors.Wrapf(err, "Failed to activate resource plan '%s' of tenant %s", param.PlanName, tenantName)
+	}
+	return nil
+}
+
+func GetResourceManagerUsage(tenantName string, password *string) (*bo.ResourceManagerUsage, error) {
+	conn, err := getResourceManagerConnection(tenantName, password)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseDbConnection(conn.DB)
+	activePlan, err := tenantService.GetActiveResourcePlan(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get active resource plan of tenant %s", tenantName)
+	}
+	usage := &bo.ResourceManagerUsage{
+		ActivePlan:   activePlan,
+		Directives:   make([]bo.ResourcePlanDirective, 0),
+		Mappings:     make([]bo.ConsumerGroupMapping, 0),
+		GroupIoStats: make([]bo.ConsumerGroupIoStat, 0),
+	}
+	if activePlan != "" {
+		directives, err := tenantService.ListPlanDirectivesOfPlan(conn, activePlan)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to list directives of resource plan %s of tenant %s", activePlan, tenantName)
+		}
+		for _, directive := range directives {
+			usage.Directives = append(usage.Directives, directive.ToBO())
+		}
+	}
+	mappings, err := tenantService.ListConsumerGroupMappings(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list consumer group mappings of tenant %s", tenantName)
+	}
+	for _, mapping := range mappings {
+		usage.Mappings = append(usage.Mappings, mapping.ToBO())
+	}
+	// The io statistics view may be absent in some versions, so it is best-effort.
+	stats, err := tenantService.ListConsumerGroupIoStats(conn)
+	if err != nil {
+		log.Warnf("Failed to list consumer group io stats of tenant %s: %s", tenantName, err.Error())
+	} else {
+		for _, stat := range stats {
+			usage.GroupIoStats = append(usage.GroupIoStats, stat.ToBO())
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,322 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+func getTenantForTable(tenantName string) (*oceanbase.DbaObTenant, error) {
+	tenant, err := tenantService.GetTenantByName(tenantName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Get tenant failed")
+	}
+	if tenant == nil {
+		return nil, errors.Occur(errors.ErrObTenantNotExist, tenantName)
+	}
+	return tenant, nil
+}
+
+// buildPartitions groups the replicas by tablet, keeping the order of the locations.
+func buildPartitions(locations []oceanbase.ObTableLocation) []bo.TablePartition {
+	partitions := make([]bo.TablePartition, 0)
+	index := make(map[int64]int)
+	for _, location := range locations {
+		i, ok := index[location.TabletId]
+		if !ok {
+			i = len(partitions)
+			index[location.TabletId] = i
+			partitions = append(partitions, bo.TablePartition{
+				PartitionName:    location.PartitionName,
+				SubpartitionName: location.SubpartitionName,
+				TabletId:         location.TabletId,
+				LsId:             location.LsId,
+				Replicas:         make([]bo.TableReplica, 0),
+			})
+		}
+		if location.Role == "LEADER" {
+			partitions[i].Leader = fmt.Sprintf("%s:%d", location.SvrIp, location.SvrPort)
+		}
+		partitions[i].Replicas = append(partitions[i].Replicas, location.ToReplicaBO())
+	}
+	return partitions
+}
+
+// leaderDataSize sums the data size of the leader replicas, so that each
+// tablet is only counted once.
+func leaderDataSize(partitions []bo.TablePartition) (size int64) {
+	for _, partition := range partitions {
+		for _, replica := range partition.Replicas {
+			if replica.Role == "LEADER" {
+				size += replica.DataSize
+			}
+		}
+	}
+	return
+}
+
+func buildTables(tenant *oceanbase.DbaObTenant, databaseName string, locations []oceanbase.ObTableLocation) ([]bo.ObTable, map[int64][]bo.TablePartition, error) {
+	statistics, err := tenantService.ListTableStatistics(tenant.TenantID, databaseName)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Failed to list table statistics of tenant %s", tenant.TenantName)
+	}
+	rowCounts := make(map[string]int64)
+	for _, statistic := range statistics {
+		rowCounts[statistic.TableName] = statistic.NumRows
+	}
+
+	tables := make([]bo.ObTable, 0)
+	tableLocations := make(map[int64][]oceanbase.ObTableLocation)
+	for _, location := range locations {
+		if _, ok := tableLocations[location.TableId]; !ok {
+			tables = append(tables, bo.ObTable{
+				DatabaseName: location.DatabaseName,
+				TableName:    location.TableName,
+				TableId:      location.TableId,
+				RowCount:     rowCounts[location.TableName],
+				// Tables inherit the primary zone of the tenant.
+				PrimaryZone: tenant.PrimaryZone,
+			})
+		}
+		tableLocations[location.TableId] = append(tableLocations[location.TableId], location)
+	}
+	tablePartitions := make(map[int64][]bo.TablePartition)
+	for i := range tables {
+		partitions := buildPartitions(tableLocations[tables[i].TableId])
+		tables[i].PartitionCount = len(partitions)
+		tables[i].DataSize = leaderDataSize(partitions)
+		tablePartitions[tables[i].TableId] = partitions
+	}
+	return tables, tablePartitions, nil
+}
+
+func ListTables(tenantName, databaseName string) ([]bo.ObTable, error) {
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := tenantService.ListTableLocations(tenant.TenantID, databaseName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list tables of database %s of tenant %s", databaseName, tenantName)
+	}
+	tables, _, err := buildTables(tenant, databaseName, locations)
+	return tables, err
+}
+
+func GetTable(tenantName, databaseName, tableName string) (*bo.ObTableDetail, error) {
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := tenantService.ListTableLocations(tenant.TenantID, databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get table %s.%s of tenant %s", databaseName, tableName, tenantName)
+	}
+	tables, partitions, err := buildTables(tenant, databaseName, locations)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, errors.Occur(errors.ErrObTableNotExist, databaseName, tableName, tenantName)
+	}
+	indexes, err := listTableIndexes(tenant, tables[0].TableId)
+	if err != nil {
+		return nil, err
+	}
+	return &bo.ObTableDetail{
+		ObTable:    tables[0],
+		Partitions: partitions[tables[0].TableId],
+		Indexes:    indexes,
+	}, nil
+}
+
+func getTableId(tenant *oceanbase.DbaObTenant, databaseName, tableName string) (int64, []oceanbase.ObTableLocation, error) {
+	locations, err := tenantService.ListTableLocations(tenant.TenantID, databaseName, tableName)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "Failed to get table %s.%s of tenant %s", databaseName, tableName, tenant.TenantName)
+	}
+	if len(locations) == 0 {
+		return 0, nil, errors.Occur(errors.ErrObTableNotExist, databaseName, tableName, tenant.TenantName)
+	}
+	return locations[0].TableId, locations, nil
+}
+
+func ListTablePartitions(tenantName, databaseName, tableName string) ([]bo.TablePartition, error) {
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	_, locations, err := getTableId(tenant, databaseName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return buildPartitions(locations), nil
+}
+
+func ListTableIndexes(tenantName, databaseName, tableName string) ([]bo.TableIndex, error) {
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	tableId, _, err := getTableId(tenant, databaseName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return listTableIndexes(tenant, tableId)
+}
+
+func listTableIndexes(tenant *oceanbase.DbaObTenant, tableId int64) ([]bo.TableIndex, error) {
+	locations, err := tenantService.ListIndexLocations(tenant.TenantID, tableId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list indexes of table %d of tenant %s", tableId, tenant.TenantName)
+	}
+	indexes := make([]bo.TableIndex, 0)
+	indexLocations := make(map[int64][]oceanbase.ObTableLocation)
+	for _, location := range locations {
+		if _, ok := indexLocations[location.TableId]; !ok {
+			indexes = append(indexes, bo.TableIndex{
+				IndexName:    location.IndexName,
+				IndexTableId: location.TableId,
+			})
+		}
+		indexLocations[location.TableId] = append(indexLocations[location.TableId], location)
+	}
+	for i := range indexes {
+		indexes[i].Partitions = buildPartitions(indexLocations[indexes[i].IndexTableId])
+		indexes[i].PartitionCount = len(indexes[i].Partitions)
+		indexes[i].DataSize = leaderDataSize(indexes[i].Partitions)
+	}
+	return indexes, nil
+}
+
+func GetTableDdl(tenantName, databaseName, tableName string, password *string) (*bo.TableDdl, error) {
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err = getTableId(tenant, databaseName, tableName); err != nil {
+		return nil, err
+	}
+	db, err := getTenantConnection(tenant, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
+	}
+	var ddl string
+	if isOracleTenant(tenant) {
+		ddl, err = tenantService.GetOracleTableDdl(db, databaseName, tableName)
+	} else {
+		ddl, err = tenantService.GetTableDdl(db, databaseName, tableName)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get ddl of table %s.%s of tenant %s", databaseName, tableName, tenantName)
+	}
+	return &bo.TableDdl{
+		DatabaseName: databaseName,
+		TableName:    tableName,
+		Ddl:          ddl,
+	}, nil
+}
+
+// parseServer parses the server in the format of 'ip' or 'ip:port',
+// the port will be 0 if it is not specified.
+func parseServer(server string) (string, int, error) {
+	if server == "" {
+		return "", 0, nil
+	}
+	host, portStr, err := net.SplitHostPort(server)
+	if err != nil {
+		host = strings.Trim(server, "[]")
+		if net.ParseIP(host) == nil {
+			return "", 0, errors.Occur(errors.ErrCommonInvalidAddress, server)
+		}
+		return host, 0, nil
+	}
+	if net.ParseIP(host) == nil {
+		return "", 0, errors.Occur(errors.ErrCommonInvalidIp, host)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, errors.Occur(errors.ErrCommonInvalidPort, portStr)
+	}
+	return host, port, nil
+}
+
+// ListTablets returns the tablets of the tenant grouped by server,
+// server and database are optional filters. The counts and the size of each
+// server cover all the tablets, while the tablets are paged by limit and offset.
+func ListTablets(tenantName, server, databaseName string, limit, offset int) ([]bo.ServerTablets, error) {
+	if limit < 0 || offset < 0 {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "limit", "limit and offset should not be negative")
+	}
+	tenant, err := getTenantForTable(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	svrIp, svrPort, err := parseServer(server)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := tenantService.ListServerTabletStats(tenant.TenantID, svrIp, svrPort, databaseName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list tablets of tenant %s", tenantName)
+	}
+	locations, err := tenantService.ListTabletLocations(tenant.TenantID, svrIp, svrPort, databaseName, limit, offset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list tablets of tenant %s", tenantName)
+	}
+	servers := make(map[string]*bo.ServerTablets)
+	for _, stat := range stats {
+		servers[fmt.Sprintf("%s:%d", stat.SvrIp, stat.SvrPort)] = &bo.ServerTablets{
+			Zone:        stat.Zone,
+			SvrIp:       stat.SvrIp,
+			SvrPort:     stat.SvrPort,
+			TabletCount: stat.TabletCount,
+			LeaderCount: stat.LeaderCount,
+			DataSize:    stat.DataSize,
+			Tablets:     make([]bo.Tablet, 0),
+		}
+	}
+	for _, location := range locations {
+		// The replica may be located between the two queries, it will be listed next time.
+		if serverTablets, ok := servers[fmt.Sprintf("%s:%d", location.SvrIp, location.SvrPort)]; ok {
+			serverTablets.Tablets = append(serverTablets.Tablets, location.ToTabletBO())
+		}
+	}
+	res := make([]bo.ServerTablets, 0, len(servers))
+	for _, serverTablets := range servers {
+		res = append(res, *serverTablets)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Zone != res[j].Zone {
+			return res[i].Zone < res[j].Zone
+		}
+		if res[i].SvrIp != res[j].SvrIp {
+			return res[i].SvrIp < res[j].SvrIp
+		}
+		return res[i].SvrPort < res[j].SvrPort
+	})
+	return res, nil
+}
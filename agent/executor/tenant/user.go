@@ -31,10 +31,16 @@ import (
 )
 
 func CreateUser(tenantName string, param *param.CreateUserParam) error {
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return createOracleUser(tenantInfo, param)
+	}
 	var db *gorm.DB
 	defer CloseDbConnection(db)
-	var err error
-	db, err = GetConnectionWithPassword(tenantName, param.RootPassword)
+	db, err = getTenantConnection(tenantInfo, param.RootPassword)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get connection of tenant %s", tenantName)
 	}
@@ -65,10 +71,16 @@ func CreateUser(tenantName string, param *param.CreateUserParam) error {
 }
 
 func DropUser(tenantName, userName string, param *param.DropUserParam) error {
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return dropOracleUser(tenantInfo, userName, param.RootPassword)
+	}
 	var db *gorm.DB
 	defer CloseDbConnection(db)
-	var err error
-	db, err = GetConnectionWithPassword(tenantName, param.RootPassword)
+	db, err = getTenantConnection(tenantInfo, param.RootPassword)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get connection of tenant %s", tenantName)
 	}
@@ -89,7 +101,14 @@ func DropUser(tenantName, userName string, param *param.DropUserParam) error {
 }
 
 func ListUsers(tenantName string, password *string) ([]bo.ObUser, error) {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if isOracleTenant(tenantInfo) {
+		return listOracleUsers(tenantInfo, password)
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -143,7 +162,14 @@ func ListUsers(tenantName string, password *string) ([]bo.ObUser, error) {
 }
 
 func ChangeUserPassword(tenantName, userName string, p *param.ChangeUserPasswordParam) error {
-	db, err := GetConnectionWithPassword(tenantName, p.RootPassword)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return changeOracleUserPassword(tenantInfo, userName, p)
+	}
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -198,15 +224,22 @@ func verifyPrivilege(privileges []string) error {
 }
 
 func ModifyUserGlobalPrivilege(tenantName, userName string, p *param.ModifyUserGlobalPrivilegeParam) error {
-	err := verifyPrivilege(p.GlobalPrivileges)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return modifyOracleGlobalPrivilege(tenantInfo, userName, p)
+	}
+	err = verifyPrivilege(p.GlobalPrivileges)
 	if err != nil {
 		return err
 	}
-	obuser, userErr := GetUser(tenantName, userName, p.RootPassword)
+	obuser, userErr := getUser(tenantInfo, userName, p.RootPassword)
 	if userErr != nil {
 		return userErr
 	}
-	db, err := GetConnectionWithPassword(tenantName, p.RootPassword)
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -224,17 +257,22 @@ func ModifyUserGlobalPrivilege(tenantName, userName string, p *param.ModifyUserG
 }
 
 func ModifyUserDbPrivilege(tenantName, userName string, p *param.ModifyUserDbPrivilegeParam) error {
+	// Oracle mode tenant has no database privileges, use object privileges instead.
+	tenantInfo, err := checkMysqlTenant(tenantName)
+	if err != nil {
+		return err
+	}
 	for _, dbPrivilege := range p.DbPrivileges {
 		err := verifyPrivilege(dbPrivilege.Privileges)
 		if err != nil {
 			return err
 		}
 	}
-	obuser, userErr := GetUser(tenantName, userName, p.RootPassword)
+	obuser, userErr := getUser(tenantInfo, userName, p.RootPassword)
 	if userErr != nil {
 		return userErr
 	}
-	db, err := GetConnectionWithPassword(tenantName, p.RootPassword)
+	db, err := getTenantConnection(tenantInfo, p.RootPassword)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -291,7 +329,14 @@ func ModifyUserDbPrivilege(tenantName, userName string, p *param.ModifyUserDbPri
 }
 
 func LockUser(tenantName, userName string, password *string) error {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return lockOracleUser(tenantInfo, userName, password)
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -304,7 +349,14 @@ func LockUser(tenantName, userName string, password *string) error {
 }
 
 func UnlockUser(tenantName, userName string, password *string) error {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if isOracleTenant(tenantInfo) {
+		return unlockOracleUser(tenantInfo, userName, password)
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -317,7 +369,19 @@ func UnlockUser(tenantName, userName string, password *string) error {
 }
 
 func GetUser(tenantName, userName string, password *string) (*bo.ObUser, error) {
-	db, err := GetConnectionWithPassword(tenantName, password)
+	tenantInfo, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	return getUser(tenantInfo, userName, password)
+}
+
+func getUser(tenantInfo *obmodel.DbaObTenant, userName string, password *string) (*bo.ObUser, error) {
+	tenantName := tenantInfo.TenantName
+	if isOracleTenant(tenantInfo) {
+		return getOracleUser(tenantInfo, userName, password)
+	}
+	db, err := getTenantConnection(tenantInfo, password)
 	defer CloseDbConnection(db)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to get db connection of tenant %s", tenantName)
@@ -24,6 +24,7 @@ import (
 	"github.com/oceanbase/obshell/agent/meta"
 	"github.com/oceanbase/obshell/agent/repository/db/oceanbase"
 	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	obmodel "github.com/oceanbase/obshell/agent/repository/model/oceanbase"
 	"github.com/oceanbase/obshell/agent/service/tenant"
 
 	"gorm.io/gorm"
@@ -51,17 +52,38 @@ func GetConnection(tenantName string) (*gorm.DB, error) {
 	if tenantName == constant.TENANT_SYS {
 		return oceanbase.GetInstance()
 	} else {
+		tenantInfo, err := checkTenantExist(tenantName)
+		if err != nil {
+			return nil, err
+		}
 		passwordMap := tenant.GetPasswordMap()
 		password, _ := passwordMap.Get(tenantName)
-		return oceanbase.LoadGormWithTenant(tenantName, password)
+		return oceanbase.LoadGormWithTenantUser(tenantName, GetTenantRootUser(tenantInfo), password)
 	}
 }
 
+// isOracleTenant checks whether the tenant is in ORACLE mode.
+func isOracleTenant(tenantInfo *obmodel.DbaObTenant) bool {
+	return tenantInfo.Mode == constant.ORACAL_MODE
+}
+
+// GetTenantRootUser returns 'SYS' for the oracle mode tenant and 'root' for others.
+func GetTenantRootUser(tenantInfo *obmodel.DbaObTenant) string {
+	if isOracleTenant(tenantInfo) {
+		return constant.ORACLE_TENANT_ROOT_USER
+	}
+	return constant.MYSQL_TENANT_ROOT_USER
+}
+
 func IsEmptyRootPassword(tenantName string) (bool, error) {
 	if tenantName == constant.TENANT_SYS {
 		return meta.OCEANBASE_PWD == "", nil
 	} else {
-		if err := oceanbase.LoadGormWithTenantForTest(tenantName, ""); err != nil {
+		tenantInfo, err := checkTenantExist(tenantName)
+		if err != nil {
+			return false, err
+		}
+		if err := oceanbase.LoadGormWithTenantUserForTest(tenantName, GetTenantRootUser(tenantInfo), ""); err != nil {
 			if strings.Contains(err.Error(), "Access denied") {
 				return false, nil
 			} else {
@@ -76,11 +98,24 @@ func GetConnectionWithPassword(tenantName string, password *string) (*gorm.DB, e
 	if tenantName == constant.TENANT_SYS {
 		return oceanbase.GetInstance()
 	} else {
-		if password != nil {
-			return oceanbase.LoadGormWithTenant(tenantName, *password)
-		} else {
-			return oceanbase.LoadGormWithTenant(tenantName, "")
+		tenantInfo, err := checkTenantExist(tenantName)
+		if err != nil {
+			return nil, err
 		}
+		return getTenantConnection(tenantInfo, password)
+	}
+}
+
+// getTenantConnection connects to the tenant loaded by the caller with its root user,
+// so the requests which have loaded the tenant need no more query for its mode.
+func getTenantConnection(tenantInfo *obmodel.DbaObTenant, password *string) (*gorm.DB, error) {
+	if tenantInfo.TenantName == constant.TENANT_SYS {
+		return oceanbase.GetInstance()
+	}
+	if password != nil {
+		return oceanbase.LoadGormWithTenantUser(tenantInfo.TenantName, GetTenantRootUser(tenantInfo), *password)
+	} else {
+		return oceanbase.LoadGormWithTenantUser(tenantInfo.TenantName, GetTenantRootUser(tenantInfo), "")
 	}
 }
 
@@ -17,6 +17,7 @@
 package tenant
 
 import (
+	"fmt"
 	"regexp"
 
 	"github.com/gin-gonic/gin"
@@ -73,6 +74,11 @@ func SetTenantVariables(c *gin.Context, tenantName string, param param.SetTenant
 	}
 	transferNumber(param.Variables)
 
+	changes, err := buildTenantVariableChanges(tenantName, param.Variables, common.GetOperator(c))
+	if err != nil {
+		return err
+	}
+
 	needConnectTenant := false
 	for k := range param.Variables {
 		if utils.ContainsString(constant.VARIAbLES_COLLATION_OR_CHARACTER, k) {
@@ -90,6 +96,7 @@ func SetTenantVariables(c *gin.Context, tenantName string, param param.SetTenant
 			}
 			return errors.Wrap(err, "set tenant variables failed")
 		}
+		obclusterService.RecordParameterChanges(changes)
 	} else {
 		executeAgent, err := GetExecuteAgentForTenant(tenantName)
 		if err != nil {
@@ -100,6 +107,7 @@ func SetTenantVariables(c *gin.Context, tenantName string, param param.SetTenant
 			if err := tenantService.SetTenantVariablesWithTenant(tenantName, param.TenantPassword, param.Variables); err != nil {
 				return err
 			}
+			obclusterService.RecordParameterChanges(changes)
 		} else {
 			common.ForwardRequest(c, executeAgent, param)
 			return nil
@@ -109,6 +117,31 @@ func SetTenantVariables(c *gin.Context, tenantName string, param param.SetTenant
 	return nil
 }
 
+func buildTenantVariableChanges(tenantName string, variables map[string]interface{}, operator string) ([]oceanbase.ParameterChange, error) {
+	changes := make([]oceanbase.ParameterChange, 0, len(variables))
+	for name, value := range variables {
+		change := oceanbase.ParameterChange{
+			Category:   constant.PARAMETER_CHANGE_CATEGORY_VARIABLE,
+			Scope:      constant.PARAMETER_SCOPE_TENANT,
+			Name:       name,
+			TenantName: tenantName,
+			NewValue:   fmt.Sprint(value),
+			Operator:   operator,
+		}
+		variable, err := tenantService.GetTenantVariable(tenantName, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get old value of variable '%s' failed", name)
+		}
+		if variable != nil {
+			change.OldValue = variable.Value
+		} else {
+			change.OldValueUnknown = true
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
 func timeZoneErrorReporter(timeZone interface{}, err error) error {
 	if v, ok := timeZone.(string); ok {
 		pattern := `^[A-Za-z]+/[A-Za-z]+$`
@@ -17,10 +17,12 @@ package unit
 
 import (
 	"github.com/oceanbase/obshell/agent/service/obcluster"
+	"github.com/oceanbase/obshell/agent/service/tenant"
 	"github.com/oceanbase/obshell/agent/service/unit"
 )
 
 var (
 	unitService      = unit.UnitService{}
+	tenantService    = tenant.TenantService{}
 	obclusterService = obcluster.ObclusterService{}
 )
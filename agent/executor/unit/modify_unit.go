@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unit
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/agent/executor/tenant"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+func isModifyResourceUnitConfigParamsEmpty(param *param.ModifyResourceUnitConfigParams) bool {
+	return param.MaxCpu == nil && param.MinCpu == nil && param.MemorySize == nil &&
+		param.LogDiskSize == nil && param.MaxIops == nil && param.MinIops == nil
+}
+
+// buildTargetUnitConfig merges the modification into the current unit config.
+func buildTargetUnitConfig(current *oceanbase.DbaObUnitConfig, param *param.ModifyResourceUnitConfigParams) (*bo.ObUnitConfig, error) {
+	target := oceanbase.ConvertDbaObUnitConfigToObUnit(current)
+	if param.MaxCpu != nil {
+		target.MaxCpu = *param.MaxCpu
+	}
+	if param.MinCpu != nil {
+		target.MinCpu = *param.MinCpu
+	}
+	if param.MemorySize != nil {
+		memorySize, pass := parse.CapacityParser(*param.MemorySize)
+		if !pass {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "memory_size", *param.MemorySize)
+		}
+		target.MemorySize = int64(memorySize)
+	}
+	if param.LogDiskSize != nil {
+		logDiskSize, pass := parse.CapacityParser(*param.LogDiskSize)
+		if !pass {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "log_disk_size", *param.LogDiskSize)
+		}
+		target.LogDiskSize = int64(logDiskSize)
+	}
+	if param.MaxIops != nil {
+		if *param.MaxIops <= 0 {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "max_iops", "max_iops should be positive.")
+		}
+		target.MaxIops = uint(*param.MaxIops)
+	}
+	if param.MinIops != nil {
+		if *param.MinIops <= 0 {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_iops", "min_iops should be positive.")
+		}
+		target.MinIops = uint(*param.MinIops)
+	}
+	return target, nil
+}
+
+func validateTargetUnitConfig(target *bo.ObUnitConfig) error {
+	if target.MaxCpu < constant.RESOURCE_UNIT_CONFIG_CPU_MINE {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "max_cpu", "min value is 1.")
+	}
+	if target.MinCpu < constant.RESOURCE_UNIT_CONFIG_CPU_MINE {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_cpu", "min value is 1.")
+	}
+	if target.MaxCpu < target.MinCpu {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_cpu", "min_cpu should not be greater than max_cpu.")
+	}
+	if target.MaxIops < target.MinIops {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "min_iops", "min_iops should not be greater than max_iops.")
+	}
+	if limit := ob.GetClusterUnitSpecLimit(); limit != nil {
+		if limit.MinMemory > 0 && float64(target.MemorySize) < limit.MinMemory*parse.GB {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "memory_size", fmt.Sprintf("min value is %vG.", limit.MinMemory))
+		}
+		if target.MaxCpu < float64(limit.MinCpu) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "max_cpu", fmt.Sprintf("min value is %d.", limit.MinCpu))
+		}
+	}
+	return nil
+}
+
+// CheckModifyUnitConfig checks whether the unit config could be modified in place,
+// and reports the pools and tenants which will be affected.
+// The servers without enough free resource are reported with 'Passed' false.
+func CheckModifyUnitConfig(name string, param *param.ModifyResourceUnitConfigParams) (*bo.UnitConfigModifyImpact, error) {
+	if isModifyResourceUnitConfigParamsEmpty(param) {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "body", "nothing to modify.")
+	}
+	current, err := unitService.GetUnitConfigByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.Occur(errors.ErrObResourceUnitConfigNotExist, name)
+	}
+	target, err := buildTargetUnitConfig(current, param)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTargetUnitConfig(target); err != nil {
+		return nil, err
+	}
+
+	impact := &bo.UnitConfigModifyImpact{
+		Current:         oceanbase.ConvertDbaObUnitConfigToObUnit(current),
+		Target:          target,
+		AffectedTenants: make([]string, 0),
+		AffectedPools:   make([]bo.UnitConfigAffectedPool, 0),
+		Servers:         make([]bo.UnitConfigServerCheck, 0),
+		Passed:          true,
+	}
+	if err := gatherAffectedPools(current.UnitConfigId, impact); err != nil {
+		return nil, err
+	}
+	if err := checkServersForUnitConfig(current, target, impact); err != nil {
+		return nil, err
+	}
+	return impact, nil
+}
+
+func gatherAffectedPools(unitConfigId int, impact *bo.UnitConfigModifyImpact) error {
+	pools, err := unitService.GetResourcePoolsByUnitConfigId(unitConfigId)
+	if err != nil {
+		return errors.Wrap(err, "Get resource pools of unit config failed.")
+	}
+	tenants := make(map[int]string)
+	for _, pool := range pools {
+		affected := bo.UnitConfigAffectedPool{
+			PoolName: pool.Name,
+			PoolId:   pool.ResourcePoolID,
+			ZoneList: pool.ZoneList,
+			UnitNum:  pool.UnitNum,
+			TenantId: pool.TenantId,
+		}
+		if pool.TenantId != 0 {
+			if _, ok := tenants[pool.TenantId]; !ok {
+				tenantName, err := tenantService.GetTenantName(pool.TenantId)
+				if err != nil {
+					return errors.Wrapf(err, "Get name of tenant %d failed.", pool.TenantId)
+				}
+				tenants[pool.TenantId] = tenantName
+				impact.AffectedTenants = append(impact.AffectedTenants, tenantName)
+			}
+			affected.TenantName = tenants[pool.TenantId]
+		}
+		impact.AffectedPools = append(impact.AffectedPools, affected)
+	}
+	return nil
+}
+
+func checkServersForUnitConfig(current *oceanbase.DbaObUnitConfig, target *bo.ObUnitConfig, impact *bo.UnitConfigModifyImpact) error {
+	units, err := unitService.GetUnitsByUnitConfigId(current.UnitConfigId)
+	if err != nil {
+		return errors.Wrap(err, "Get units of unit config failed.")
+	}
+	unitCount := make(map[meta.ObserverSvrInfo]int)
+	for _, unit := range units {
+		unitCount[meta.ObserverSvrInfo{Ip: unit.SvrIp, Port: unit.SvrPort}]++
+	}
+	if len(unitCount) == 0 {
+		return nil
+	}
+
+	resourceMap, err := obclusterService.GetAllObserverResourceMap()
+	if err != nil {
+		return errors.Wrap(err, "Get servers's info failed.")
+	}
+	for server, count := range unitCount {
+		capacity, ok := resourceMap[server]
+		if !ok {
+			return errors.Occur(errors.ErrObServerNotExist, server.String())
+		}
+		used, err := tenant.GatherAllUnitsOnServer(server.GetIp(), server.GetPort())
+		if err != nil {
+			return err
+		}
+		check := bo.UnitConfigServerCheck{
+			SvrIp:           server.GetIp(),
+			SvrPort:         server.GetPort(),
+			Zone:            capacity.Zone,
+			UnitCount:       count,
+			CpuFree:         capacity.CpuCapacityMax - used.MaxCpu,
+			CpuRequired:     float64(count) * (target.MaxCpu - current.MaxCpu),
+			MemoryFree:      capacity.MemCapacity - used.MemorySize,
+			MemoryRequired:  int64(count) * (target.MemorySize - current.MemorySize),
+			LogDiskFree:     capacity.LogDiskCapacity - used.LogDiskSize,
+			LogDiskRequired: int64(count) * (target.LogDiskSize - current.LogDiskSize),
+			Passed:          true,
+		}
+		minCpuFree := capacity.CpuCapacity - used.MinCpu
+		minCpuRequired := float64(count) * (target.MinCpu - current.MinCpu)
+		switch {
+		case check.CpuRequired > check.CpuFree || minCpuRequired > minCpuFree:
+			check.NotEnoughResource = "CPU"
+		case check.MemoryRequired > check.MemoryFree:
+			check.NotEnoughResource = "MEMORY_SIZE"
+		case check.LogDiskRequired > check.LogDiskFree:
+			check.NotEnoughResource = "LOG_DISK_SIZE"
+		}
+		if check.NotEnoughResource != "" {
+			log.Infof("server %s has no enough %s for unit config %s", server.String(), check.NotEnoughResource, current.Name)
+			check.Passed = false
+			impact.Passed = false
+		}
+		impact.Servers = append(impact.Servers, check)
+	}
+	return nil
+}
+
+// ModifyUnitConfig alters the unit config in place after the resource precheck passed.
+func ModifyUnitConfig(name string, param *param.ModifyResourceUnitConfigParams) (*bo.UnitConfigModifyImpact, error) {
+	impact, err := CheckModifyUnitConfig(name, param)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range impact.Servers {
+		if !server.Passed {
+			return impact, errors.Occur(errors.ErrObResourceUnitConfigResourceNotEnough, meta.NewAgentInfo(server.SvrIp, server.SvrPort).String(), server.NotEnoughResource, name)
+		}
+	}
+	if err := unitService.AlterUnit(name, *param); err != nil {
+		return nil, err
+	}
+	return impact, nil
+}
@@ -76,6 +76,36 @@ func CapacityParser(capacity string) (int, bool) {
 	return 0, false
 }
 
+// BoolParser parses the boolean config value in all the spellings accepted by observer,
+// such as 'true', 'False', 'ON', 'y' and '0'.
+func BoolParser(value string) (bool, bool) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "TRUE", "T", "ON", "YES", "Y", "1":
+		return true, true
+	case "FALSE", "F", "OFF", "NO", "N", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// ConfigValueEqual compares the config values case-insensitively,
+// the capacities and booleans in different formats are considered equal, such as '1G' and '1024M'.
+func ConfigValueEqual(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	if capacityA, ok := CapacityParser(a); ok {
+		capacityB, ok := CapacityParser(b)
+		return ok && capacityA == capacityB
+	}
+	if boolA, ok := BoolParser(a); ok {
+		boolB, ok := BoolParser(b)
+		return ok && boolA == boolB
+	}
+	return false
+}
+
 func FormatCapacity(bytes int64) string {
 	switch {
 	case bytes >= EB:
@@ -17,9 +17,11 @@
 package parse
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/oceanbase/obshell/agent/errors"
 )
@@ -65,3 +67,57 @@ func TimeParse(input string) (int, error) {
 		return 0, errors.Occur(errors.ErrCommonInvalidTimeDuration, input, "invalid time unit")
 	}
 }
+
+// humanTimeLayouts are the accepted layouts of a wall-clock time,
+// fractional seconds are accepted after the seconds field.
+var humanTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// LoadLocation returns the location of the timezone, which is either an IANA name
+// such as 'Asia/Shanghai' or an UTC offset such as '+08:00'. Empty means the local timezone.
+func LoadLocation(timezone string) (*time.Location, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return time.Local, nil
+	}
+
+	pattern := regexp.MustCompile(`^(?:UTC|GMT)?([+-])([0-9]{1,2})(?::?([0-9]{2}))?$`)
+	if matches := pattern.FindStringSubmatch(strings.ToUpper(timezone)); matches != nil {
+		hour, _ := strconv.Atoi(matches[2])
+		minute := 0
+		if matches[3] != "" {
+			minute, _ = strconv.Atoi(matches[3])
+		}
+		if hour > 14 || minute > 59 {
+			return nil, errors.Occur(errors.ErrCommonInvalidTimezone, timezone, "offset out of range")
+		}
+		offset := hour*60*60 + minute*60
+		if matches[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", matches[1], hour, minute), offset), nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrCommonInvalidTimezone, timezone, err.Error())
+	}
+	return loc, nil
+}
+
+// HumanTimeParse parses a wall-clock time in the location.
+// The location is ignored if the input carries its own offset.
+func HumanTimeParse(input string, loc *time.Location) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	for _, layout := range humanTimeLayouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Occur(errors.ErrCommonInvalidTime, input, "expect a time like '2006-01-02 15:04:05' or '2006-01-02T15:04:05+08:00'")
+}
@@ -43,6 +43,13 @@ type TenantKey struct {
 	TenantId int `json:"tenant_id"`
 }
 
+type PieceKey struct {
+	TenantKey
+	DestId  int `json:"dest_id"`
+	RoundId int `json:"round_id"`
+	PieceId int `json:"piece_id"`
+}
+
 type RestoreWindows struct {
 	Windows []RestoreWindow `json:"restore_windows"`
 }
@@ -54,19 +61,41 @@ type RestoreWindow struct {
 
 // ArchiveInfo contains the information of archive log, which only contains the key, start scn and checkpoint scn but not the display time of scn.
 type ArchiveInfo struct {
-	Key           TenantKey `json:"key"`
-	StartSCN      SCN       `json:"start_scn"`
-	CheckPointSCN SCN       `json:"checkpoint_scn"`
+	Key           PieceKey `json:"key"`
+	StartSCN      SCN      `json:"start_scn"`
+	CheckPointSCN SCN      `json:"checkpoint_scn"`
+}
+
+type BackupStats struct {
+	InputBytes  int64 `json:"input_bytes"`
+	OutputBytes int64 `json:"output_bytes"`
 }
 
 type BackupSet struct {
 	TenantKey
-	BackupSetID         int  `json:"backup_set_id"`
-	PlusArchivelog      bool `json:"plus_archivelog"`
-	PrevFullBackupSetID int  `json:"prev_full_backup_set_id"`
-	PrevIncBackupSetID  int  `json:"prev_inc_backup_set_id"`
-	StartReplaySCN      SCN  `json:"start_replay_scn"`
-	MinRestoreSCN       SCN  `json:"min_restore_scn"`
+	BackupSetID         int         `json:"backup_set_id"`
+	PlusArchivelog      bool        `json:"plus_archivelog"`
+	PrevFullBackupSetID int         `json:"prev_full_backup_set_id"`
+	PrevIncBackupSetID  int         `json:"prev_inc_backup_set_id"`
+	StartReplaySCN      SCN         `json:"start_replay_scn"`
+	MinRestoreSCN       SCN         `json:"min_restore_scn"`
+	Stats               BackupStats `json:"stats"`
+}
+
+// RestoreMaterials are the backup sets and archive pieces dumped by ob_admin.
+type RestoreMaterials struct {
+	BackupSets map[int]*BackupSet
+	Pieces     []ArchiveInfo // Sorted by start scn.
+}
+
+// SCNToTime converts the scn, which is the nanoseconds since epoch, to time.
+func SCNToTime(scn int64) time.Time {
+	return time.Unix(0, scn)
+}
+
+// TimeToSCN converts the time to scn.
+func TimeToSCN(t time.Time) int64 {
+	return t.UnixNano()
 }
 
 func ExecCommand(command string) (string, error) {
@@ -105,18 +134,23 @@ func formateBackupInfo(context string) []string {
 	return infos
 }
 
-func getLogPointAndDataSet(clogCtx, dataCtx string) ([][]int64, map[int]*BackupSet, error) {
+func getLogPointAndDataSet(clogCtx, dataCtx string) ([]ArchiveInfo, map[int]*BackupSet, error) {
 	clogSet := formateBackupInfo(clogCtx)
-	var logPointSet [][]int64
+	var logPointSet []ArchiveInfo
 
 	for _, logPoint := range clogSet {
 		var logPointData ArchiveInfo
 		if err := json.Unmarshal([]byte(logPoint), &logPointData); err != nil {
 			return nil, nil, errors.Wrap(err, "Failed to parse logPoint data")
 		}
-		logPointSet = append(logPointSet, []int64{logPointData.StartSCN.Val, logPointData.CheckPointSCN.Val})
+		logPointSet = append(logPointSet, logPointData)
 	}
 
+	// sort logPointSet by start scn
+	sort.Slice(logPointSet, func(i, j int) bool {
+		return logPointSet[i].StartSCN.Val < logPointSet[j].StartSCN.Val
+	})
+
 	dataSet := make(map[int]*BackupSet)
 	for _, data := range formateBackupInfo(dataCtx) {
 		var backupSet BackupSet
@@ -128,7 +162,7 @@ func getLogPointAndDataSet(clogCtx, dataCtx string) ([][]int64, map[int]*BackupS
 	return logPointSet, dataSet, nil
 }
 
-func getRestoreWindows(dataURI, logURI string) ([][2]int64, error) {
+func getRestoreMaterials(dataURI, logURI string) (*RestoreMaterials, error) {
 	log.Info("Get archive log context")
 	archiveLogCtx, err := getOBAdminCtxByURI(logURI)
 	if err != nil {
@@ -145,45 +179,84 @@ func getRestoreWindows(dataURI, logURI string) ([][2]int64, error) {
 	if err != nil {
 		return nil, err
 	}
+	return &RestoreMaterials{BackupSets: dataSet, Pieces: logPointSet}, nil
+}
 
-	// sort logPointSet by start scn
-	sort.Slice(logPointSet, func(i, j int) bool {
-		return logPointSet[i][0] < logPointSet[j][0]
-	})
-
-	var restoreWindows [][2]int64
-	for _, data := range dataSet {
-		if data.PlusArchivelog {
-			// plugs 备份暂未实现
-			continue
-		}
+// IsRestorable checks whether the backup set can be the base of a restore,
+// which requires all its previous backup sets.
+func (m *RestoreMaterials) IsRestorable(data *BackupSet) bool {
+	if data.PlusArchivelog {
+		// plugs 备份暂未实现
+		return false
+	}
+	if data.PrevFullBackupSetID > 0 && m.BackupSets[data.PrevFullBackupSetID] == nil {
+		return false
+	}
+	if data.PrevIncBackupSetID > 0 && m.BackupSets[data.PrevIncBackupSetID] == nil {
+		return false
+	}
+	return true
+}
 
-		if data.PrevFullBackupSetID > 0 && dataSet[data.PrevFullBackupSetID] == nil {
+// ReplayPieces returns the continuous archive pieces from the one containing the start replay scn of the backup set.
+func (m *RestoreMaterials) ReplayPieces(data *BackupSet) []ArchiveInfo {
+	logPointSet := m.Pieces
+	for i := 0; i < len(logPointSet); i++ {
+		if !(logPointSet[i].StartSCN.Val <= data.StartReplaySCN.Val && data.StartReplaySCN.Val <= logPointSet[i].CheckPointSCN.Val) {
 			continue
 		}
-		if data.PrevIncBackupSetID > 0 && dataSet[data.PrevIncBackupSetID] == nil {
-			continue
+		j := i
+		for (j < len(logPointSet)-1) && (logPointSet[j+1].StartSCN.Val == logPointSet[j].CheckPointSCN.Val) {
+			j++
 		}
+		return logPointSet[i : j+1]
+	}
+	return nil
+}
 
-		for i := 0; i < len(logPointSet); {
-			if !(logPointSet[i][0] <= data.StartReplaySCN.Val && data.StartReplaySCN.Val <= logPointSet[i][1]) {
-				i++
-				continue
-			}
-			for (i < len(logPointSet)-1) && (logPointSet[i+1][0] == logPointSet[i][1]) {
-				i++
-			}
-			restoreWindows = append(restoreWindows, [2]int64{data.MinRestoreSCN.Val, logPointSet[i][1]})
-			break
+// Window returns the restore window based on the backup set, and false if there is no such window.
+func (m *RestoreMaterials) Window(data *BackupSet) ([2]int64, bool) {
+	if !m.IsRestorable(data) {
+		return [2]int64{}, false
+	}
+	pieces := m.ReplayPieces(data)
+	if len(pieces) == 0 {
+		return [2]int64{}, false
+	}
+	return [2]int64{data.MinRestoreSCN.Val, pieces[len(pieces)-1].CheckPointSCN.Val}, true
+}
+
+// Windows returns the merged restore windows.
+func (m *RestoreMaterials) Windows() [][2]int64 {
+	var restoreWindows [][2]int64
+	for _, data := range m.BackupSets {
+		if window, ok := m.Window(data); ok {
+			restoreWindows = append(restoreWindows, window)
 		}
 	}
 
 	log.Infof("restoreWindows: %+v", restoreWindows)
-	return mergeWindows(restoreWindows), nil
+	return mergeWindows(restoreWindows)
+}
+
+func getRestoreWindows(dataURI, logURI string) ([][2]int64, error) {
+	materials, err := getRestoreMaterials(dataURI, logURI)
+	if err != nil {
+		return nil, err
+	}
+	return materials.Windows(), nil
+}
+
+// GetRestoreMaterials dumps the backup sets and archive pieces by ob_admin.
+func GetRestoreMaterials(dataURI, logURI string) (*RestoreMaterials, error) {
+	return getRestoreMaterials(dataURI, logURI)
 }
 
 func mergeWindows(intervals [][2]int64) [][2]int64 {
 	ans := make([][2]int64, 0)
+	if len(intervals) == 0 {
+		return ans
+	}
 	slices.SortFunc(intervals, func(a, b [2]int64) int {
 		return int(a[0] - b[0])
 	})
@@ -252,8 +325,8 @@ func GetRestoreWindows(dataURI, logURI string) (*RestoreWindows, error) {
 	res := new(RestoreWindows)
 	for _, window := range windows {
 		res.Windows = append(res.Windows, RestoreWindow{
-			StartTime: time.Unix(0, window[0]),
-			EndTime:   time.Unix(0, window[1]),
+			StartTime: SCNToTime(window[0]),
+			EndTime:   SCNToTime(window[1]),
 		})
 	}
 
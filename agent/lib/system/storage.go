@@ -19,12 +19,20 @@ package system
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/aws/aws-sdk-go/aws"
@@ -46,6 +54,10 @@ const (
 	appID      = "appid"
 	s3Region   = "s3_region"
 	deleteMode = "delete_mode"
+
+	addressingModel = "addressing_model"
+
+	azblobApiVersion = "2020-10-02"
 )
 
 type StorageInterface interface {
@@ -56,6 +68,36 @@ type StorageInterface interface {
 	GetResourceType() string
 	CheckWritePermission() error
 	NewWithObjectKey(string) StorageInterface
+	GetUsage() (*StorageUsage, error)
+	ListDirs() ([]string, error)
+}
+
+// StorageUsage is the footprint of the objects under the path,
+// the capacity is only available for the file system.
+type StorageUsage struct {
+	UsedBytes      int64 `json:"used_bytes"`
+	ObjectCount    int64 `json:"object_count"`
+	TotalBytes     int64 `json:"total_bytes"`
+	AvailableBytes int64 `json:"available_bytes"`
+}
+
+// objectPrefix returns the prefix to list the objects under the key.
+func objectPrefix(objectKey string) string {
+	if objectKey == "" {
+		return ""
+	}
+	return strings.TrimRight(objectKey, "/") + "/"
+}
+
+// trimDirs turns the common prefixes returned by a delimited listing into child dir names.
+func trimDirs(prefix string, commonPrefixes []string) []string {
+	dirs := make([]string, 0, len(commonPrefixes))
+	for _, p := range commonPrefixes {
+		if dir := strings.Trim(strings.TrimPrefix(p, prefix), "/"); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
 }
 
 type OSSConfig struct {
@@ -103,18 +145,72 @@ func (c *OSSConfig) GetResourceType() string {
 	return constant.PROTOCOL_OSS
 }
 
-func (c *OSSConfig) CheckWritePermission() error {
+func (c *OSSConfig) newBucket() (*oss.Bucket, error) {
 	client, err := oss.New(c.Host, c.AccessID, c.AccessKey)
 	if err != nil {
-		return errors.Wrap(err, "create oss client")
+		return nil, errors.Wrap(err, "create oss client")
 	}
 	log.Info("OSS client created")
 
 	ossBucket, err := client.Bucket(c.BucketName)
 	if err != nil {
-		return errors.Wrap(err, "get oss bucket")
+		return nil, errors.Wrap(err, "get oss bucket")
 	}
 	log.Infof("OSS bucket %s created: %#+v", c.BucketName, ossBucket)
+	return ossBucket, nil
+}
+
+func (c *OSSConfig) GetUsage() (*StorageUsage, error) {
+	ossBucket, err := c.newBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &StorageUsage{}
+	token := ""
+	for {
+		res, err := ossBucket.ListObjectsV2(oss.Prefix(objectPrefix(c.ObjectKey)), oss.ContinuationToken(token))
+		if err != nil {
+			return nil, errors.Wrap(err, "list oss objects")
+		}
+		for _, object := range res.Objects {
+			usage.UsedBytes += object.Size
+			usage.ObjectCount++
+		}
+		if !res.IsTruncated {
+			return usage, nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+func (c *OSSConfig) ListDirs() ([]string, error) {
+	ossBucket, err := c.newBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectPrefix(c.ObjectKey)
+	dirs := make([]string, 0)
+	token := ""
+	for {
+		res, err := ossBucket.ListObjectsV2(oss.Prefix(prefix), oss.Delimiter("/"), oss.ContinuationToken(token))
+		if err != nil {
+			return nil, errors.Wrap(err, "list oss dirs")
+		}
+		dirs = append(dirs, trimDirs(prefix, res.CommonPrefixes)...)
+		if !res.IsTruncated {
+			return dirs, nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+func (c *OSSConfig) CheckWritePermission() error {
+	ossBucket, err := c.newBucket()
+	if err != nil {
+		return err
+	}
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -170,11 +266,11 @@ func (c *COSConfig) GenerateURIWithoutSecret() (res string) {
 	return
 }
 
-func (c *COSConfig) CheckWritePermission() error {
+func (c *COSConfig) newClient() (*cos.Client, error) {
 	cosURL := fmt.Sprintf("https://%s.%s", c.BucketName, c.Host)
 	u, err := url.Parse(cosURL)
 	if err != nil {
-		return errors.Wrap(err, "parse cos uri")
+		return nil, errors.Wrap(err, "parse cos uri")
 	}
 
 	b := &cos.BaseURL{
@@ -187,6 +283,60 @@ func (c *COSConfig) CheckWritePermission() error {
 		},
 	})
 	log.Info("COS client created")
+	return client, nil
+}
+
+func (c *COSConfig) GetUsage() (*StorageUsage, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &StorageUsage{}
+	opt := &cos.BucketGetOptions{Prefix: objectPrefix(c.ObjectKey)}
+	for {
+		res, _, err := client.Bucket.Get(context.Background(), opt)
+		if err != nil {
+			return nil, errors.Wrap(err, "list cos objects")
+		}
+		for _, object := range res.Contents {
+			usage.UsedBytes += object.Size
+			usage.ObjectCount++
+		}
+		if !res.IsTruncated {
+			return usage, nil
+		}
+		opt.Marker = res.NextMarker
+	}
+}
+
+func (c *COSConfig) ListDirs() ([]string, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectPrefix(c.ObjectKey)
+	dirs := make([]string, 0)
+	opt := &cos.BucketGetOptions{Prefix: prefix, Delimiter: "/"}
+	for {
+		res, _, err := client.Bucket.Get(context.Background(), opt)
+		if err != nil {
+			return nil, errors.Wrap(err, "list cos dirs")
+		}
+		dirs = append(dirs, trimDirs(prefix, res.CommonPrefixes)...)
+		if !res.IsTruncated {
+			return dirs, nil
+		}
+		opt.Marker = res.NextMarker
+	}
+}
+
+func (c *COSConfig) CheckWritePermission() error {
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -203,9 +353,13 @@ func (c *COSConfig) CheckWritePermission() error {
 	return nil
 }
 
+// S3Config is used for AWS S3 and the S3 compatible storages, such as MinIO,
+// Ceph RGW and GCS with HMAC keys, which usually need a custom endpoint in host
+// and the path-style addressing model. The TLS is disabled if host starts with 'http://'.
 type S3Config struct {
 	BaseConf
-	S3Region string
+	S3Region        string
+	AddressingModel string
 }
 
 func (c *S3Config) NewWithObjectKey(subpath string) StorageInterface {
@@ -229,6 +383,9 @@ func (c *S3Config) GenerateURIWithoutSecret() (res string) {
 	if c.S3Region != "" {
 		res += fmt.Sprintf("&%s=%s", s3Region, c.S3Region)
 	}
+	if c.AddressingModel != "" {
+		res += fmt.Sprintf("&%s=%s", addressingModel, c.AddressingModel)
+	}
 	if c.DeleteMode != "" {
 		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
 	}
@@ -239,30 +396,100 @@ func (c *S3Config) GenerateURIWhitoutParams() string {
 	return fmt.Sprintf("%s%s/%s", constant.PREFIX_S3, c.BucketName, c.ObjectKey)
 }
 
-func (c *S3Config) GenerateQueryParams() string {
-	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+func (c *S3Config) GenerateQueryParams() (res string) {
+	res = fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+	if c.S3Region != "" {
+		res += fmt.Sprintf("&%s=%s", s3Region, c.S3Region)
+	}
+	if c.AddressingModel != "" {
+		res += fmt.Sprintf("&%s=%s", addressingModel, c.AddressingModel)
+	}
+	return
 }
 
-func (c *S3Config) CheckWritePermission() (err error) {
+func (c *S3Config) newService() (svc *s3.S3, err error) {
 	var sess *session.Session
-	if c.S3Region != "" {
+	if c.S3Region != "" && !c.isCustomEndpoint() {
 		sess, err = session.NewSession(&aws.Config{
 			Region:      aws.String(c.S3Region),
 			Credentials: credentials.NewStaticCredentials(c.AccessID, c.AccessKey, ""),
 		})
 	} else {
+		region := c.S3Region
+		if region == "" {
+			region = "auto"
+		}
 		sess, err = session.NewSession(&aws.Config{
-			Region:      aws.String("auto"),
-			Endpoint:    aws.String(c.Host),
-			Credentials: credentials.NewStaticCredentials(c.AccessID, c.AccessKey, ""),
+			Region:           aws.String(region),
+			Endpoint:         aws.String(c.Host),
+			DisableSSL:       aws.Bool(strings.HasPrefix(c.Host, "http://")),
+			S3ForcePathStyle: aws.Bool(c.AddressingModel == constant.ADDRESSING_MODEL_PATH),
+			Credentials:      credentials.NewStaticCredentials(c.AccessID, c.AccessKey, ""),
 		})
 	}
 	if err != nil {
-		return errors.Wrap(err, "create s3 session")
+		return nil, errors.Wrap(err, "create s3 session")
 	}
 
-	svc := s3.New(sess)
+	svc = s3.New(sess)
 	log.Info("S3 client created")
+	return svc, nil
+}
+
+func (c *S3Config) GetUsage() (*StorageUsage, error) {
+	svc, err := c.newService()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &StorageUsage{}
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.BucketName),
+		Prefix: aws.String(objectPrefix(c.ObjectKey)),
+	}
+	err = svc.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			usage.UsedBytes += aws.Int64Value(object.Size)
+			usage.ObjectCount++
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list s3 objects")
+	}
+	return usage, nil
+}
+
+func (c *S3Config) ListDirs() ([]string, error) {
+	svc, err := c.newService()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectPrefix(c.ObjectKey)
+	dirs := make([]string, 0)
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(c.BucketName),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	err = svc.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, p := range page.CommonPrefixes {
+			dirs = append(dirs, trimDirs(prefix, []string{aws.StringValue(p.Prefix)})...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list s3 dirs")
+	}
+	return dirs, nil
+}
+
+func (c *S3Config) CheckWritePermission() error {
+	svc, err := c.newService()
+	if err != nil {
+		return err
+	}
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -291,6 +518,204 @@ func (c *S3Config) CheckWritePermission() (err error) {
 	return nil
 }
 
+// isCustomEndpoint returns true if the host is not an AWS endpoint, or path-style is required,
+// in which case the requests are sent to the host instead of the endpoint resolved by region.
+func (c *S3Config) isCustomEndpoint() bool {
+	return c.AddressingModel == constant.ADDRESSING_MODEL_PATH ||
+		(c.Host != "" && !strings.Contains(c.Host, "amazonaws.com"))
+}
+
+// AzblobConfig is used for Azure Blob storage, the bucket is the container and
+// the access id is the storage account name. The host is the blob service endpoint,
+// such as 'https://account.blob.core.windows.net', or 'http://127.0.0.1:10000/account' for Azurite.
+type AzblobConfig struct {
+	BaseConf
+}
+
+func (c *AzblobConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(AzblobConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *AzblobConfig) GetResourceType() string {
+	return constant.PROTOCOL_AZBLOB
+}
+
+func (c *AzblobConfig) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *AzblobConfig) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *AzblobConfig) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey)
+}
+
+func (c *AzblobConfig) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+}
+
+func (c *AzblobConfig) ListDirs() ([]string, error) {
+	prefix := objectPrefix(c.ObjectKey)
+	dirs := make([]string, 0)
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("prefix", prefix)
+	query.Set("delimiter", "/")
+	for {
+		body, err := c.doRequest(http.MethodGet, "", query, http.StatusOK)
+		if err != nil {
+			return nil, errors.Wrap(err, "list azblob dirs")
+		}
+		var res azblobListResult
+		if err = xml.Unmarshal(body, &res); err != nil {
+			return nil, errors.Wrap(err, "parse azblob dirs")
+		}
+		dirs = append(dirs, trimDirs(prefix, res.Prefixes)...)
+		if res.NextMarker == "" {
+			return dirs, nil
+		}
+		query.Set("marker", res.NextMarker)
+	}
+}
+
+func (c *AzblobConfig) CheckWritePermission() error {
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	if _, err := c.doRequest(http.MethodPut, testFile, nil, http.StatusCreated); err != nil {
+		return errors.Wrap(err, "put azblob object")
+	}
+	log.Infof("put azblob object %s", testFile)
+
+	if _, err := c.doRequest(http.MethodDelete, testFile, nil, http.StatusAccepted); err != nil {
+		return errors.Wrap(err, "delete azblob object")
+	}
+	return nil
+}
+
+type azblobListResult struct {
+	Blobs []struct {
+		ContentLength int64 `xml:"Properties>Content-Length"`
+	} `xml:"Blobs>Blob"`
+	Prefixes   []string `xml:"Blobs>BlobPrefix>Name"`
+	NextMarker string   `xml:"NextMarker"`
+}
+
+func (c *AzblobConfig) GetUsage() (*StorageUsage, error) {
+	usage := &StorageUsage{}
+	query := url.Values{}
+	query.Set("restype", "container")
+	query.Set("comp", "list")
+	query.Set("prefix", objectPrefix(c.ObjectKey))
+	for {
+		body, err := c.doRequest(http.MethodGet, "", query, http.StatusOK)
+		if err != nil {
+			return nil, errors.Wrap(err, "list azblob objects")
+		}
+		var res azblobListResult
+		if err = xml.Unmarshal(body, &res); err != nil {
+			return nil, errors.Wrap(err, "parse azblob objects")
+		}
+		for _, blob := range res.Blobs {
+			usage.UsedBytes += blob.ContentLength
+			usage.ObjectCount++
+		}
+		if res.NextMarker == "" {
+			return usage, nil
+		}
+		query.Set("marker", res.NextMarker)
+	}
+}
+
+// doRequest sends the request to the blob, or the container if blob is empty, and returns the body.
+func (c *AzblobConfig) doRequest(method, blob string, query url.Values, expectedStatus int) ([]byte, error) {
+	endpoint := strings.TrimRight(c.Host, "/")
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	reqURL := fmt.Sprintf("%s/%s", endpoint, c.BucketName)
+	if blob != "" {
+		reqURL += "/" + blob
+	}
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azblobApiVersion)
+	if method == http.MethodPut {
+		req.Header.Set("x-ms-blob-type", "BlockBlob")
+	}
+	if err = c.sign(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != expectedStatus {
+		if len(body) > 1024 {
+			body = body[:1024]
+		}
+		return nil, fmt.Errorf("unexpected status '%s': %s", resp.Status, string(body))
+	}
+	return body, nil
+}
+
+// sign signs the request with the shared key of the storage account.
+func (c *AzblobConfig) sign(req *http.Request) error {
+	key, err := base64.StdEncoding.DecodeString(c.AccessKey)
+	if err != nil {
+		return errors.Wrap(err, "decode azblob access key")
+	}
+
+	msHeaders := make([]string, 0)
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, fmt.Sprintf("%s:%s\n", lower, req.Header.Get(name)))
+		}
+	}
+	sort.Strings(msHeaders)
+
+	// The fields are verb, content encoding, language, length, md5, type, date,
+	// if-modified-since, if-match, if-none-match, if-unmodified-since and range.
+	stringToSign := req.Method + strings.Repeat("\n", 12) +
+		strings.Join(msHeaders, "") +
+		fmt.Sprintf("/%s%s", c.AccessID, req.URL.EscapedPath())
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		stringToSign += fmt.Sprintf("\n%s:%s", strings.ToLower(name), strings.Join(query[name], ","))
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", c.AccessID, base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+	return nil
+}
+
 type NFSConfig struct {
 	Path string
 }
@@ -322,6 +747,52 @@ func (c *NFSConfig) GenerateURIWithoutSecret() (res string) {
 	return fmt.Sprintf("%s%s", constant.PREFIX_FILE, c.Path)
 }
 
+func (c *NFSConfig) GetUsage() (*StorageUsage, error) {
+	usage := &StorageUsage{}
+	err := filepath.Walk(c.Path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			usage.UsedBytes += info.Size()
+			usage.ObjectCount++
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "walk nfs path")
+	}
+
+	fsPath, _, err := GetFsId(c.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "get file system")
+	}
+	disk, err := GetDiskInfo(fsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "get disk info")
+	}
+	usage.TotalBytes = int64(disk.TotalSizeBytes)
+	usage.AvailableBytes = int64(disk.AvailableSizeBytes)
+	return usage, nil
+}
+
+func (c *NFSConfig) ListDirs() ([]string, error) {
+	entries, err := os.ReadDir(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, errors.Wrap(err, "read nfs dir")
+	}
+	dirs := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs, nil
+}
+
 func (c *NFSConfig) CheckWritePermission() error {
 	if err := os.MkdirAll(c.Path, 0755); err != nil {
 		return err
@@ -350,6 +821,8 @@ func GetStorageInterfaceByURI(uri string) (StorageInterface, error) {
 		return GetCOSStorage(uri)
 	} else if strings.HasPrefix(uri, constant.PREFIX_S3) {
 		return GetS3Storage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		return GetAzblobStorage(uri)
 	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
 		return GetNFSStorage(uri)
 	} else {
@@ -364,6 +837,8 @@ func GetResourceType(uri string) (t string, err error) {
 		t = constant.PROTOCOL_COS
 	} else if strings.HasPrefix(uri, constant.PREFIX_S3) {
 		t = constant.PROTOCOL_S3
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		t = constant.PROTOCOL_AZBLOB
 	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
 		t = constant.PROTOCOL_FILE
 	} else {
@@ -403,9 +878,86 @@ func GetS3Storage(url string) (StorageInterface, error) {
 		return nil, errors.Wrap(err, "parse s3 config")
 	}
 	conf.S3Region = params.Get(s3Region)
+	conf.AddressingModel = params.Get(addressingModel)
+	if conf.AddressingModel != "" && conf.AddressingModel != constant.ADDRESSING_MODEL_VIRTUAL && conf.AddressingModel != constant.ADDRESSING_MODEL_PATH {
+		return nil, errors.Occur(errors.ErrObStorageURIInvalid, fmt.Sprintf("addressing_model must be '%s' or '%s'", constant.ADDRESSING_MODEL_VIRTUAL, constant.ADDRESSING_MODEL_PATH))
+	}
+	if conf.AddressingModel == constant.ADDRESSING_MODEL_PATH && conf.Host == "" {
+		return nil, errors.Occur(errors.ErrObStorageURIInvalid, "s3 host is required for path-style addressing")
+	}
+	if err = checkStorageHost(conf.Host); err != nil {
+		return nil, err
+	}
+	if conf.S3Region == "" && !conf.isCustomEndpoint() {
+		// Amazon S3 signs the requests with the region, so it could not be "auto" like the custom endpoints.
+		if conf.S3Region = awsRegionFromHost(conf.Host); conf.S3Region == "" {
+			return nil, errors.Occur(errors.ErrObStorageURIInvalid, "s3_region is required for amazon s3 unless it could be derived from the host")
+		}
+	}
+	return conf, nil
+}
+
+// awsRegionFromHost derives the region from the amazon s3 host, such as
+// 's3.us-west-2.amazonaws.com', 's3-us-west-2.amazonaws.com' or 'bucket.s3.dualstack.us-west-2.amazonaws.com'.
+// The legacy global endpoint 's3.amazonaws.com' is in 'us-east-1'.
+func awsRegionFromHost(host string) string {
+	if host == "" {
+		return ""
+	}
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.Split(host, ":")[0]
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if label != "amazonaws" {
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			region := strings.TrimPrefix(labels[j], "s3-")
+			if region == "s3" || region == "dualstack" || region == "s3-accelerate" {
+				continue
+			}
+			if strings.Count(region, "-") >= 2 {
+				return region
+			}
+			break
+		}
+		if i > 0 && labels[i-1] == "s3" {
+			return "us-east-1"
+		}
+	}
+	return ""
+}
+
+func GetAzblobStorage(url string) (StorageInterface, error) {
+	conf := &AzblobConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_AZBLOB)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse azblob config")
+	}
+	if conf.Host == "" || conf.AccessID == "" || conf.AccessKey == "" {
+		return nil, errors.Occur(errors.ErrObStorageURIInvalid, "azblob host, access_id and access_key are required")
+	}
+	if _, err := base64.StdEncoding.DecodeString(conf.AccessKey); err != nil {
+		return nil, errors.Occur(errors.ErrObStorageURIInvalid, "azblob access_key should be base64 encoded")
+	}
+	if err := checkStorageHost(conf.Host); err != nil {
+		return nil, err
+	}
 	return conf, nil
 }
 
+// checkStorageHost checks the scheme of the host if specified, only http and https are supported.
+func checkStorageHost(host string) error {
+	if idx := strings.Index(host, "://"); idx != -1 {
+		if scheme := host[:idx]; scheme != "http" && scheme != "https" {
+			return errors.Occur(errors.ErrObStorageURIInvalid, fmt.Sprintf("unsupported scheme '%s' of host", scheme))
+		}
+	}
+	return nil
+}
+
 func GetNFSStorage(url string) (StorageInterface, error) {
 	conf := &NFSConfig{}
 	conf.Path = strings.TrimPrefix(url, constant.PREFIX_FILE)
@@ -50,6 +50,13 @@ var tableList = []interface{}{
 	oceanbase.AgentBinaryInfo{},
 	oceanbase.AgentBinaryChunk{},
 	oceanbase.OcsConfig{},
+	oceanbase.RestoreDrillReport{},
+	oceanbase.BackupEncryptionKey{},
+	oceanbase.BackupSetEncryptionKey{},
+	oceanbase.UpgradeZoneGate{},
+	oceanbase.ParameterChange{},
+	oceanbase.ParameterSnapshot{},
+	oceanbase.ParameterSnapshotItem{},
 }
 
 // createGormDbByConfig will create an ob db instance according to the configuration and
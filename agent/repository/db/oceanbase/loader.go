@@ -115,10 +115,16 @@ func loadOceanbaseUntilSucc() {
 
 // LoadTmpInstanceWithTenant creates a db instance according to the configuration.
 func LoadGormWithTenant(tenant string, password string) (*gorm.DB, error) {
+	return LoadGormWithTenantUser(tenant, constant.MYSQL_TENANT_ROOT_USER, password)
+}
+
+// LoadGormWithTenantUser creates a db instance of the tenant with the specific user,
+// such as 'SYS' for the oracle mode tenant.
+func LoadGormWithTenantUser(tenant string, user string, password string) (*gorm.DB, error) {
 	dsConfig := config.NewObDataSourceConfig().
 		SetPassword(password).
 		SetParseTime(true).
-		SetUsername("root@" + tenant).
+		SetUsername(user + "@" + tenant).
 		SetDBName("").
 		SetTryTimes(10)
 	dsConfig.SetLoggerLevel(logger.Silent)
@@ -135,10 +141,14 @@ func LoadGormWithTenant(tenant string, password string) (*gorm.DB, error) {
 
 // LoadTmpInstanceWithTenant creates a db instance according to the configuration.
 func LoadGormWithTenantForTest(tenant string, password string) error {
+	return LoadGormWithTenantUserForTest(tenant, constant.MYSQL_TENANT_ROOT_USER, password)
+}
+
+func LoadGormWithTenantUserForTest(tenant string, user string, password string) error {
 	dsConfig := config.NewObDataSourceConfig().
 		SetPassword(password).
 		SetParseTime(true).
-		SetUsername("root@" + tenant).
+		SetUsername(user + "@" + tenant).
 		SetDBName("").
 		SetTryTimes(10)
 	dsConfig.SetLoggerLevel(logger.Silent)
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type LsReplica struct {
+	Zone        string `json:"zone"`
+	SvrIp       string `json:"svr_ip"`
+	SvrPort     int    `json:"svr_port"`
+	Role        string `json:"role"`
+	ReplicaType string `json:"replica_type"`
+}
+
+type LogStreamDistribution struct {
+	LsId        int64       `json:"ls_id"`
+	Leader      string      `json:"leader"`
+	LeaderZone  string      `json:"leader_zone"`
+	TabletCount int         `json:"tablet_count"`
+	Replicas    []LsReplica `json:"replicas"`
+}
+
+type UnitLoad struct {
+	UnitId        int64   `json:"unit_id"`
+	MaxCpu        float64 `json:"max_cpu"`
+	MinCpu        float64 `json:"min_cpu"`
+	MemorySize    int64   `json:"memory_size"`
+	LogDiskSize   int64   `json:"log_disk_size"`
+	LogDiskInUse  int64   `json:"log_disk_in_use"`
+	DataDiskInUse int64   `json:"data_disk_in_use"`
+	Status        string  `json:"status"`
+}
+
+// ServerBalance is the log stream and tablet distribution of a tenant on an observer,
+// the tablets of a log stream are led by the server which leads the log stream.
+type ServerBalance struct {
+	Zone              string    `json:"zone"`
+	SvrIp             string    `json:"svr_ip"`
+	SvrPort           int       `json:"svr_port"`
+	LsCount           int       `json:"ls_count"`
+	LsLeaderCount     int       `json:"ls_leader_count"`
+	TabletCount       int       `json:"tablet_count"`
+	TabletLeaderCount int       `json:"tablet_leader_count"`
+	Unit              *UnitLoad `json:"unit"`
+}
+
+type BalanceJob struct {
+	JobId           int64     `json:"job_id"`
+	CreateTime      time.Time `json:"create_time"`
+	JobType         string    `json:"job_type"`
+	BalanceStrategy string    `json:"balance_strategy"`
+	Status          string    `json:"status"`
+	Comment         string    `json:"comment"`
+}
+
+type TransferPartitionTask struct {
+	TaskId       int64     `json:"task_id"`
+	CreateTime   time.Time `json:"create_time"`
+	TableId      int64     `json:"table_id"`
+	ObjectId     int64     `json:"object_id"`
+	DestLs       int64     `json:"dest_ls"`
+	BalanceJobId int64     `json:"balance_job_id"`
+	Status       string    `json:"status"`
+	Comment      string    `json:"comment"`
+}
+
+type TenantJob struct {
+	JobId     int    `json:"job_id"`
+	JobType   string `json:"job_type"`
+	JobStatus string `json:"job_status"`
+}
+
+// TenantBalance is the leader and partition distribution of a tenant,
+// LeaderBalanced is false if any log stream is led out of the first priority primary zone.
+type TenantBalance struct {
+	TenantName     string                  `json:"tenant_name"`
+	PrimaryZone    string                  `json:"primary_zone"`
+	LeaderBalanced bool                    `json:"leader_balanced"`
+	Servers        []ServerBalance         `json:"servers"`
+	LogStreams     []LogStreamDistribution `json:"log_streams"`
+	BalanceJobs    []BalanceJob            `json:"balance_jobs"`
+	TransferTasks  []TransferPartitionTask `json:"transfer_tasks"`
+	TenantJobs     []TenantJob             `json:"tenant_jobs"`
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+// ConfigDrift is an observer config persisted in sqlite whose value differs from the live parameter.
+type ConfigDrift struct {
+	Level          string `json:"level"` // GLOBAL, ZONE or SERVER, the level of the persisted config which takes effect.
+	Name           string `json:"name"`
+	Zone           string `json:"zone"`
+	Agent          string `json:"agent"`
+	Server         string `json:"server"` // The observer, ip:rpc_port.
+	PersistedValue string `json:"persisted_value"`
+	LiveValue      string `json:"live_value"`
+}
+
+type ConfigDriftReport struct {
+	CheckTime time.Time     `json:"check_time"`
+	Drifts    []ConfigDrift `json:"drifts"`
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+type HostCheckItem struct {
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Result   string `json:"result"`
+	Fix      string `json:"fix,omitempty"`
+}
+
+type HostCheckReport struct {
+	Agent   string          `json:"agent"`
+	Profile string          `json:"profile"`
+	Result  string          `json:"result"`
+	Items   []HostCheckItem `json:"items"`
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type ParameterChange struct {
+	Id              int64     `json:"id"`
+	Category        string    `json:"category"` // PARAMETER or VARIABLE
+	Scope           string    `json:"scope"`    // CLUSTER or TENANT
+	Name            string    `json:"name"`
+	Zone            string    `json:"zone"`
+	Server          string    `json:"server"`
+	TenantName      string    `json:"tenant_name"`
+	OldValue        string    `json:"old_value"`
+	OldValueUnknown bool      `json:"old_value_unknown"` // No value was found before the change, so it could not be rolled back.
+	NewValue        string    `json:"new_value"`
+	Operator        string    `json:"operator"`
+	RollbackOf      int64     `json:"rollback_of"` // The id of the change rolled back by this change, 0 if not a rollback.
+	CreateTime      time.Time `json:"create_time"`
+}
+
+type ParameterSnapshot struct {
+	Name       string    `json:"name"`
+	Comment    string    `json:"comment"`
+	Operator   string    `json:"operator"`
+	ItemCount  int64     `json:"item_count"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+// ParameterDiff is a parameter or variable whose value differs between the base and the target.
+// The value is empty if the item does not exist on that side.
+type ParameterDiff struct {
+	Category    string `json:"category"`
+	Scope       string `json:"scope"`
+	Name        string `json:"name"`
+	Server      string `json:"server"`
+	TenantName  string `json:"tenant_name"`
+	BaseValue   string `json:"base_value"`
+	TargetValue string `json:"target_value"`
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+type ResourcePlanDirective struct {
+	Plan             string `json:"plan"`
+	GroupOrSubplan   string `json:"group_or_subplan"`
+	Comments         string `json:"comments"`
+	MgmtP1           int    `json:"mgmt_p1"`
+	UtilizationLimit int    `json:"utilization_limit"`
+	MinIops          int    `json:"min_iops"`
+	MaxIops          int    `json:"max_iops"`
+	WeightIops       int    `json:"weight_iops"`
+}
+
+type ResourcePlan struct {
+	PlanId     int64                   `json:"plan_id"`
+	Plan       string                  `json:"plan"`
+	Comments   string                  `json:"comments"`
+	Status     string                  `json:"status"`
+	IsActive   bool                    `json:"is_active"`
+	Directives []ResourcePlanDirective `json:"directives"`
+}
+
+type ConsumerGroup struct {
+	ConsumerGroupId int64  `json:"consumer_group_id"`
+	ConsumerGroup   string `json:"consumer_group"`
+	Comments        string `json:"comments"`
+}
+
+type ConsumerGroupMapping struct {
+	Attribute     string `json:"attribute"`
+	Value         string `json:"value"`
+	ConsumerGroup string `json:"consumer_group"`
+	Status        string `json:"status"`
+}
+
+type ConsumerGroupIoStat struct {
+	SvrIp     string `json:"svr_ip"`
+	SvrPort   int    `json:"svr_port"`
+	GroupName string `json:"group_name"`
+	Mode      string `json:"mode"`
+	MinIops   int64  `json:"min_iops"`
+	MaxIops   int64  `json:"max_iops"`
+	RealIops  int64  `json:"real_iops"`
+}
+
+// ResourceManagerUsage is the current effective resource plan of a tenant
+// together with the io usage of each consumer group.
+type ResourceManagerUsage struct {
+	ActivePlan   string                  `json:"active_plan"`
+	Directives   []ResourcePlanDirective `json:"directives"`
+	Mappings     []ConsumerGroupMapping  `json:"mappings"`
+	GroupIoStats []ConsumerGroupIoStat   `json:"group_io_stats"`
+}
@@ -16,6 +16,8 @@
 
 package bo
 
+import "time"
+
 type RestoreInfo struct {
 	TenantId          int64  `json:"tenant_id"`
 	JobID             int64  `json:"job_id"`
@@ -62,3 +64,35 @@ type CdbObRestoreHistory struct {
 	Comment              string `json:"comment"`
 	FinishTimestamp      string `json:"finish_timestamp"`
 }
+
+type RestoreDrillVerificationResult struct {
+	Name     string `json:"name"`
+	Sql      string `json:"sql"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+	Error    string `json:"error,omitempty"`
+}
+
+type RestoreDrillReport struct {
+	Id            int64                            `json:"id"`
+	DagID         int64                            `json:"dag_id"`
+	ScratchTenant string                           `json:"scratch_tenant"`
+	DataBackupUri string                           `json:"data_backup_uri"`
+	ArchiveLogUri string                           `json:"archive_log_uri"`
+	RestoreTime   *time.Time                       `json:"restore_time"`
+	Status        string                           `json:"status"`
+	StartTime     time.Time                        `json:"start_time"`
+	EndTime       *time.Time                       `json:"end_time"`
+	Verifications []RestoreDrillVerificationResult `json:"verifications"`
+}
+
+type BackupEncryptionKey struct {
+	TenantID     int        `json:"tenant_id"`
+	TenantName   string     `json:"tenant_name"`
+	Version      int        `json:"version"`
+	Active       bool       `json:"active"`
+	CreateTime   time.Time  `json:"create_time"`
+	RetiredTime  *time.Time `json:"retired_time"`
+	BackupSetIDs []int64    `json:"backup_set_ids"` // Backup sets encrypted with this version.
+}
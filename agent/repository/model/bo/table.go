@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+// ObTable is the summary of a user table, the data size only counts
+// the leader replica of each tablet.
+type ObTable struct {
+	DatabaseName   string `json:"database_name"`
+	TableName      string `json:"table_name"`
+	TableId        int64  `json:"table_id"`
+	RowCount       int64  `json:"row_count"`
+	DataSize       int64  `json:"data_size"`
+	PartitionCount int    `json:"partition_count"`
+	PrimaryZone    string `json:"primary_zone"`
+}
+
+type ObTableDetail struct {
+	ObTable
+	Partitions []TablePartition `json:"partitions"`
+	Indexes    []TableIndex     `json:"indexes"`
+}
+
+type TableReplica struct {
+	Zone         string `json:"zone"`
+	SvrIp        string `json:"svr_ip"`
+	SvrPort      int    `json:"svr_port"`
+	Role         string `json:"role"`
+	ReplicaType  string `json:"replica_type"`
+	DataSize     int64  `json:"data_size"`
+	RequiredSize int64  `json:"required_size"`
+}
+
+type TablePartition struct {
+	PartitionName    string         `json:"partition_name"`
+	SubpartitionName string         `json:"subpartition_name"`
+	TabletId         int64          `json:"tablet_id"`
+	LsId             int64          `json:"ls_id"`
+	Leader           string         `json:"leader"`
+	Replicas         []TableReplica `json:"replicas"`
+}
+
+type TableIndex struct {
+	IndexName      string           `json:"index_name"`
+	IndexTableId   int64            `json:"index_table_id"`
+	DataSize       int64            `json:"data_size"`
+	PartitionCount int              `json:"partition_count"`
+	Partitions     []TablePartition `json:"partitions"`
+}
+
+type TableDdl struct {
+	DatabaseName string `json:"database_name"`
+	TableName    string `json:"table_name"`
+	Ddl          string `json:"ddl"`
+}
+
+type Tablet struct {
+	DatabaseName     string `json:"database_name"`
+	TableName        string `json:"table_name"`
+	IndexName        string `json:"index_name"`
+	PartitionName    string `json:"partition_name"`
+	SubpartitionName string `json:"subpartition_name"`
+	TabletId         int64  `json:"tablet_id"`
+	LsId             int64  `json:"ls_id"`
+	Role             string `json:"role"`
+	DataSize         int64  `json:"data_size"`
+	RequiredSize     int64  `json:"required_size"`
+}
+
+// ServerTablets is the tablets of a tenant located on an observer.
+type ServerTablets struct {
+	Zone        string   `json:"zone"`
+	SvrIp       string   `json:"svr_ip"`
+	SvrPort     int      `json:"svr_port"`
+	TabletCount int      `json:"tablet_count"`
+	LeaderCount int      `json:"leader_count"`
+	DataSize    int64    `json:"data_size"`
+	Tablets     []Tablet `json:"tablets"`
+}
@@ -52,3 +52,12 @@ type ObUser struct {
 	DbPrivileges        []DbPrivilege                `json:"db_privileges"`
 	ObjectPrivileges    []ObjectPrivilege            `json:"object_privileges"`
 }
+
+type ObRole struct {
+	RoleName         string            `json:"role_name"`
+	GlobalPrivileges []string          `json:"global_privileges"`
+	GrantedRoles     []string          `json:"granted_roles"`
+	ObjectPrivileges []ObjectPrivilege `json:"object_privileges"`
+	UserGrantees     []string          `json:"user_grantees"`
+	RoleGrantees     []string          `json:"role_grantees"`
+}
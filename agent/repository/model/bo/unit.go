@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+type UnitConfigAffectedPool struct {
+	PoolName   string `json:"pool_name"`
+	PoolId     int    `json:"pool_id"`
+	ZoneList   string `json:"zone_list"`
+	UnitNum    int    `json:"unit_num"`
+	TenantId   int    `json:"tenant_id"`
+	TenantName string `json:"tenant_name"` // empty if the pool has not been granted to a tenant
+}
+
+// UnitConfigServerCheck is the free resource check result on a server
+// which hosts units of the unit config to be modified.
+type UnitConfigServerCheck struct {
+	SvrIp             string  `json:"svr_ip"`
+	SvrPort           int     `json:"svr_port"`
+	Zone              string  `json:"zone"`
+	UnitCount         int     `json:"unit_count"` // number of units of the unit config on this server
+	CpuFree           float64 `json:"cpu_free"`
+	CpuRequired       float64 `json:"cpu_required"`
+	MemoryFree        int64   `json:"memory_free"`
+	MemoryRequired    int64   `json:"memory_required"`
+	LogDiskFree       int64   `json:"log_disk_free"`
+	LogDiskRequired   int64   `json:"log_disk_required"`
+	Passed            bool    `json:"passed"`
+	NotEnoughResource string  `json:"not_enough_resource,omitempty"`
+}
+
+type UnitConfigModifyImpact struct {
+	Current         *ObUnitConfig            `json:"current"`
+	Target          *ObUnitConfig            `json:"target"`
+	AffectedTenants []string                 `json:"affected_tenants"`
+	AffectedPools   []UnitConfigAffectedPool `json:"affected_pools"`
+	Servers         []UnitConfigServerCheck  `json:"servers"`
+	Passed          bool                     `json:"passed"`
+}
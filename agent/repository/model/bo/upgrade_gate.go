@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type UpgradeZoneGate struct {
+	DagID         string     `json:"dag_id"` // Generic id of the upgrade dag.
+	RouteIndex    int        `json:"route_index"`
+	Zone          string     `json:"zone"`
+	Status        string     `json:"status"`
+	Deadline      *time.Time `json:"deadline"`
+	TimeoutAction string     `json:"timeout_action"`
+	Comment       string     `json:"comment"`
+	CreateTime    time.Time  `json:"create_time"`
+	UpdateTime    time.Time  `json:"update_time"`
+}
@@ -30,6 +30,17 @@ type CdbOBArchivelog struct {
 	Path     string `gorm:"column:PATH"`
 }
 
+type CdbObArchivelogProgress struct {
+	TenantID             int       `gorm:"column:TENANT_ID" json:"tenant_id"`
+	DestID               int64     `gorm:"column:DEST_ID" json:"dest_id"`
+	RoundID              int64     `gorm:"column:ROUND_ID" json:"round_id"`
+	Status               string    `gorm:"column:STATUS" json:"status"`
+	CheckpointScn        int64     `gorm:"column:CHECKPOINT_SCN" json:"checkpoint_scn"`
+	CheckpointScnDisplay time.Time `gorm:"column:CHECKPOINT_SCN_DISPLAY" json:"checkpoint_scn_display"`
+	LagSeconds           int64     `gorm:"column:LAG_SECONDS" json:"lag_seconds"`
+	Comment              string    `gorm:"column:COMMENT" json:"comment"`
+}
+
 type CdbObBackupTask struct {
 	TenantID              int64     `gorm:"column:TENANT_ID" json:"tenant_id"`
 	TaskID                int64     `gorm:"column:TASK_ID" json:"task_id"`
@@ -58,3 +69,53 @@ type CdbObBackupTask struct {
 	Comment               string    `gorm:"column:COMMENT" json:"comment"`
 	Path                  string    `gorm:"column:PATH" json:"path"`
 }
+
+type CdbObBackupSetFile struct {
+	TenantID            int64     `gorm:"column:TENANT_ID" json:"tenant_id"`
+	BackupSetID         int64     `gorm:"column:BACKUP_SET_ID" json:"backup_set_id"`
+	DestID              int64     `gorm:"column:DEST_ID" json:"dest_id"`
+	Incarnation         int64     `gorm:"column:INCARNATION" json:"incarnation"`
+	BackupType          string    `gorm:"column:BACKUP_TYPE" json:"backup_type"`
+	PrevFullBackupSetID int64     `gorm:"column:PREV_FULL_BACKUP_SET_ID" json:"prev_full_backup_set_id"`
+	PrevIncBackupSetID  int64     `gorm:"column:PREV_INC_BACKUP_SET_ID" json:"prev_inc_backup_set_id"`
+	StartTimestamp      time.Time `gorm:"column:START_TIMESTAMP" json:"start_timestamp"`
+	EndTimestamp        time.Time `gorm:"column:END_TIMESTAMP" json:"end_timestamp"`
+	Status              string    `gorm:"column:STATUS" json:"status"`
+	FileStatus          string    `gorm:"column:FILE_STATUS" json:"file_status"`
+	PlusArchivelog      string    `gorm:"column:PLUS_ARCHIVELOG" json:"plus_archivelog"`
+	StartReplayScn      int64     `gorm:"column:START_REPLAY_SCN" json:"start_replay_scn"`
+	MinRestoreScn       int64     `gorm:"column:MIN_RESTORE_SCN" json:"min_restore_scn"`
+	InputBytes          int64     `gorm:"column:INPUT_BYTES" json:"input_bytes"`
+	OutputBytes         int64     `gorm:"column:OUTPUT_BYTES" json:"output_bytes"`
+	EncryptionMode      string    `gorm:"column:ENCRYPTION_MODE" json:"encryption_mode"`
+	Path                string    `gorm:"column:PATH" json:"path"`
+}
+
+type CdbObArchivelogPieceFile struct {
+	TenantID             int64     `gorm:"column:TENANT_ID" json:"tenant_id"`
+	DestID               int64     `gorm:"column:DEST_ID" json:"dest_id"`
+	RoundID              int64     `gorm:"column:ROUND_ID" json:"round_id"`
+	PieceID              int64     `gorm:"column:PIECE_ID" json:"piece_id"`
+	Incarnation          int64     `gorm:"column:INCARNATION" json:"incarnation"`
+	Status               string    `gorm:"column:STATUS" json:"status"`
+	StartScn             int64     `gorm:"column:START_SCN" json:"start_scn"`
+	CheckpointScn        int64     `gorm:"column:CHECKPOINT_SCN" json:"checkpoint_scn"`
+	EndScn               int64     `gorm:"column:END_SCN" json:"end_scn"`
+	StartScnDisplay      time.Time `gorm:"column:START_SCN_DISPLAY" json:"start_scn_display"`
+	CheckpointScnDisplay time.Time `gorm:"column:CHECKPOINT_SCN_DISPLAY" json:"checkpoint_scn_display"`
+	InputBytes           int64     `gorm:"column:INPUT_BYTES" json:"input_bytes"`
+	OutputBytes          int64     `gorm:"column:OUTPUT_BYTES" json:"output_bytes"`
+	FileStatus           string    `gorm:"column:FILE_STATUS" json:"file_status"`
+	Path                 string    `gorm:"column:PATH" json:"path"`
+}
+
+type CdbObBackupDeleteJob struct {
+	TenantID       int64     `gorm:"column:TENANT_ID" json:"tenant_id"`
+	JobID          int64     `gorm:"column:JOB_ID" json:"job_id"`
+	Type           string    `gorm:"column:TYPE" json:"type"`
+	Parameter      string    `gorm:"column:PARAMETER" json:"parameter"`
+	StartTimestamp time.Time `gorm:"column:START_TIMESTAMP" json:"start_timestamp"`
+	Status         string    `gorm:"column:STATUS" json:"status"`
+	Result         int64     `gorm:"column:RESULT" json:"result"`
+	Comment        string    `gorm:"column:COMMENT" json:"comment"`
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+)
+
+// BackupEncryptionKey is a version of the backup encryption password of a tenant.
+// Passwords maps each agent to the password encrypted with the public key of the agent.
+type BackupEncryptionKey struct {
+	Id          int64      `gorm:"primaryKey;autoIncrement;not null"`
+	TenantID    int        `gorm:"not null;uniqueIndex:idx_tenant_version"`
+	TenantName  string     `gorm:"type:varchar(128);not null"`
+	Version     int        `gorm:"not null;uniqueIndex:idx_tenant_version"`
+	Passwords   string     `gorm:"type:text;not null"`
+	Active      bool       `gorm:"not null"`
+	GmtCreate   time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+	RetiredTime *time.Time `gorm:"type:TIMESTAMP NULL"`
+}
+
+// BackupSetEncryptionKey records the key version which encrypted the backup set.
+type BackupSetEncryptionKey struct {
+	TenantID    int       `gorm:"primaryKey;autoIncrement:false"`
+	BackupSetID int64     `gorm:"primaryKey;autoIncrement:false"`
+	KeyVersion  int       `gorm:"not null"`
+	BackupDest  string    `gorm:"type:varchar(1024);not null"` // Data backup dest without secret.
+	GmtCreate   time.Time `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+}
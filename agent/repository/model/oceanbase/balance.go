@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+)
+
+type ObLsLocation struct {
+	LsId        int64  `gorm:"column:LS_ID"`
+	Zone        string `gorm:"column:ZONE"`
+	SvrIp       string `gorm:"column:SVR_IP"`
+	SvrPort     int    `gorm:"column:SVR_PORT"`
+	Role        string `gorm:"column:ROLE"`
+	ReplicaType string `gorm:"column:REPLICA_TYPE"`
+}
+
+func (l *ObLsLocation) ToBO() bo.LsReplica {
+	return bo.LsReplica{
+		Zone:        l.Zone,
+		SvrIp:       l.SvrIp,
+		SvrPort:     l.SvrPort,
+		Role:        l.Role,
+		ReplicaType: l.ReplicaType,
+	}
+}
+
+type ObLsTabletCount struct {
+	LsId        int64 `gorm:"column:LS_ID"`
+	TabletCount int   `gorm:"column:TABLET_COUNT"`
+}
+
+type GvObUnit struct {
+	SvrIp         string  `gorm:"column:SVR_IP"`
+	SvrPort       int     `gorm:"column:SVR_PORT"`
+	Zone          string  `gorm:"column:ZONE"`
+	UnitId        int64   `gorm:"column:UNIT_ID"`
+	MaxCpu        float64 `gorm:"column:MAX_CPU"`
+	MinCpu        float64 `gorm:"column:MIN_CPU"`
+	MemorySize    int64   `gorm:"column:MEMORY_SIZE"`
+	LogDiskSize   int64   `gorm:"column:LOG_DISK_SIZE"`
+	LogDiskInUse  int64   `gorm:"column:LOG_DISK_IN_USE"`
+	DataDiskInUse int64   `gorm:"column:DATA_DISK_IN_USE"`
+	Status        string  `gorm:"column:STATUS"`
+}
+
+func (u *GvObUnit) ToBO() *bo.UnitLoad {
+	return &bo.UnitLoad{
+		UnitId:        u.UnitId,
+		MaxCpu:        u.MaxCpu,
+		MinCpu:        u.MinCpu,
+		MemorySize:    u.MemorySize,
+		LogDiskSize:   u.LogDiskSize,
+		LogDiskInUse:  u.LogDiskInUse,
+		DataDiskInUse: u.DataDiskInUse,
+		Status:        u.Status,
+	}
+}
+
+type ObBalanceJob struct {
+	JobId           int64     `gorm:"column:JOB_ID"`
+	CreateTime      time.Time `gorm:"column:CREATE_TIME"`
+	JobType         string    `gorm:"column:JOB_TYPE"`
+	BalanceStrategy string    `gorm:"column:BALANCE_STRATEGY"`
+	Status          string    `gorm:"column:STATUS"`
+	Comment         string    `gorm:"column:COMMENT"`
+}
+
+func (j *ObBalanceJob) ToBO() bo.BalanceJob {
+	return bo.BalanceJob{
+		JobId:           j.JobId,
+		CreateTime:      j.CreateTime,
+		JobType:         j.JobType,
+		BalanceStrategy: j.BalanceStrategy,
+		Status:          j.Status,
+		Comment:         j.Comment,
+	}
+}
+
+type ObTransferPartitionTask struct {
+	TaskId       int64     `gorm:"column:TASK_ID"`
+	CreateTime   time.Time `gorm:"column:CREATE_TIME"`
+	TableId      int64     `gorm:"column:TABLE_ID"`
+	ObjectId     int64     `gorm:"column:OBJECT_ID"`
+	DestLs       int64     `gorm:"column:DEST_LS"`
+	BalanceJobId int64     `gorm:"column:BALANCE_JOB_ID"`
+	Status       string    `gorm:"column:STATUS"`
+	Comment      string    `gorm:"column:COMMENT"`
+}
+
+func (t *ObTransferPartitionTask) ToBO() bo.TransferPartitionTask {
+	return bo.TransferPartitionTask{
+		TaskId:       t.TaskId,
+		CreateTime:   t.CreateTime,
+		TableId:      t.TableId,
+		ObjectId:     t.ObjectId,
+		DestLs:       t.DestLs,
+		BalanceJobId: t.BalanceJobId,
+		Status:       t.Status,
+		Comment:      t.Comment,
+	}
+}
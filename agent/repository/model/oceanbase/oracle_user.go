@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+type OracleDbaUser struct {
+	UserName      string    `gorm:"column:USERNAME"`
+	AccountStatus string    `gorm:"column:ACCOUNT_STATUS"`
+	Created       time.Time `gorm:"column:CREATED"`
+}
+
+type OracleDbaRole struct {
+	Role string `gorm:"column:ROLE"`
+}
+
+type OracleDbaSysPriv struct {
+	Grantee     string `gorm:"column:GRANTEE"`
+	Privilege   string `gorm:"column:PRIVILEGE"`
+	AdminOption string `gorm:"column:ADMIN_OPTION"`
+}
+
+type OracleDbaRolePriv struct {
+	Grantee     string `gorm:"column:GRANTEE"`
+	GrantedRole string `gorm:"column:GRANTED_ROLE"`
+	AdminOption string `gorm:"column:ADMIN_OPTION"`
+}
+
+type OracleDbaTabPriv struct {
+	Grantee   string `gorm:"column:GRANTEE"`
+	Owner     string `gorm:"column:OWNER"`
+	TableName string `gorm:"column:TABLE_NAME"`
+	Privilege string `gorm:"column:PRIVILEGE"`
+}
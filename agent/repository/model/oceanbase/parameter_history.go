@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+)
+
+// ParameterChange records a change of a parameter or a tenant variable made by obshell.
+// Zone, Server and TenantName are the target of the change, all empty means the whole cluster.
+type ParameterChange struct {
+	Id              int64     `gorm:"primaryKey;autoIncrement;not null"`
+	Category        string    `gorm:"type:varchar(16);not null"` // PARAMETER or VARIABLE
+	Scope           string    `gorm:"type:varchar(16);not null"` // CLUSTER or TENANT
+	Name            string    `gorm:"type:varchar(128);not null;index"`
+	Zone            string    `gorm:"type:varchar(128);not null;default:''"`
+	Server          string    `gorm:"type:varchar(128);not null;default:''"`
+	TenantName      string    `gorm:"type:varchar(128);not null;default:''"`
+	OldValue        string    `gorm:"type:text"`
+	OldValueUnknown bool      `gorm:"not null;default:false"` // No value was found before the change, so it could not be rolled back.
+	NewValue        string    `gorm:"type:text"`
+	Operator        string    `gorm:"type:varchar(128);not null;default:''"`
+	RollbackOf      int64     `gorm:"not null;default:0"` // The id of the change rolled back by this change.
+	GmtCreate       time.Time `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+}
+
+// ParameterSnapshot is a named copy of all the parameters and tenant variables at a point in time.
+type ParameterSnapshot struct {
+	Id        int64     `gorm:"primaryKey;autoIncrement;not null"`
+	Name      string    `gorm:"type:varchar(128);not null;uniqueIndex"`
+	Comment   string    `gorm:"type:varchar(1024);not null;default:''"`
+	Operator  string    `gorm:"type:varchar(128);not null;default:''"`
+	GmtCreate time.Time `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+}
+
+// ParameterSnapshotItem is the value of a parameter or a variable in the snapshot.
+// The cluster parameters are saved for each server, the tenant parameters and variables are saved for each tenant.
+type ParameterSnapshotItem struct {
+	SnapshotId int64  `gorm:"primaryKey;autoIncrement:false"`
+	Category   string `gorm:"primaryKey;type:varchar(16)"`
+	TenantName string `gorm:"primaryKey;type:varchar(128)"`
+	Server     string `gorm:"primaryKey;type:varchar(128)"`
+	Name       string `gorm:"primaryKey;type:varchar(128)"`
+	Scope      string `gorm:"type:varchar(16);not null"`
+	Value      string `gorm:"type:text"`
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "github.com/oceanbase/obshell/agent/repository/model/bo"
+
+type DbaRsrcPlan struct {
+	PlanId   int64  `gorm:"column:PLAN_ID"`
+	Plan     string `gorm:"column:PLAN"`
+	Comments string `gorm:"column:COMMENTS"`
+	Status   string `gorm:"column:STATUS"`
+}
+
+func (p *DbaRsrcPlan) ToBO() *bo.ResourcePlan {
+	return &bo.ResourcePlan{
+		PlanId:     p.PlanId,
+		Plan:       p.Plan,
+		Comments:   p.Comments,
+		Status:     p.Status,
+		Directives: make([]bo.ResourcePlanDirective, 0),
+	}
+}
+
+type DbaRsrcPlanDirective struct {
+	Plan             string `gorm:"column:PLAN"`
+	GroupOrSubplan   string `gorm:"column:GROUP_OR_SUBPLAN"`
+	Comments         string `gorm:"column:COMMENTS"`
+	MgmtP1           int    `gorm:"column:MGMT_P1"`
+	UtilizationLimit int    `gorm:"column:UTILIZATION_LIMIT"`
+	MinIops          int    `gorm:"column:MIN_IOPS"`
+	MaxIops          int    `gorm:"column:MAX_IOPS"`
+	WeightIops       int    `gorm:"column:WEIGHT_IOPS"`
+}
+
+func (d *DbaRsrcPlanDirective) ToBO() bo.ResourcePlanDirective {
+	return bo.ResourcePlanDirective{
+		Plan:             d.Plan,
+		GroupOrSubplan:   d.GroupOrSubplan,
+		Comments:         d.Comments,
+		MgmtP1:           d.MgmtP1,
+		UtilizationLimit: d.UtilizationLimit,
+		MinIops:          d.MinIops,
+		MaxIops:          d.MaxIops,
+		WeightIops:       d.WeightIops,
+	}
+}
+
+type DbaRsrcConsumerGroup struct {
+	ConsumerGroupId int64  `gorm:"column:CONSUMER_GROUP_ID"`
+	ConsumerGroup   string `gorm:"column:CONSUMER_GROUP"`
+	Comments        string `gorm:"column:COMMENTS"`
+}
+
+func (g *DbaRsrcConsumerGroup) ToBO() bo.ConsumerGroup {
+	return bo.ConsumerGroup{
+		ConsumerGroupId: g.ConsumerGroupId,
+		ConsumerGroup:   g.ConsumerGroup,
+		Comments:        g.Comments,
+	}
+}
+
+type DbaRsrcGroupMapping struct {
+	Attribute     string `gorm:"column:ATTRIBUTE"`
+	Value         string `gorm:"column:VALUE"`
+	ConsumerGroup string `gorm:"column:CONSUMER_GROUP"`
+	Status        string `gorm:"column:STATUS"`
+}
+
+func (m *DbaRsrcGroupMapping) ToBO() bo.ConsumerGroupMapping {
+	return bo.ConsumerGroupMapping{
+		Attribute:     m.Attribute,
+		Value:         m.Value,
+		ConsumerGroup: m.ConsumerGroup,
+		Status:        m.Status,
+	}
+}
+
+type GvObGroupIoStat struct {
+	SvrIp     string `gorm:"column:SVR_IP"`
+	SvrPort   int    `gorm:"column:SVR_PORT"`
+	GroupName string `gorm:"column:GROUP_NAME"`
+	Mode      string `gorm:"column:MODE"`
+	MinIops   int64  `gorm:"column:MIN_IOPS"`
+	MaxIops   int64  `gorm:"column:MAX_IOPS"`
+	RealIops  int64  `gorm:"column:REAL_IOPS"`
+}
+
+func (s *GvObGroupIoStat) ToBO() bo.ConsumerGroupIoStat {
+	return bo.ConsumerGroupIoStat{
+		SvrIp:     s.SvrIp,
+		SvrPort:   s.SvrPort,
+		GroupName: s.GroupName,
+		Mode:      s.Mode,
+		MinIops:   s.MinIops,
+		MaxIops:   s.MaxIops,
+		RealIops:  s.RealIops,
+	}
+}
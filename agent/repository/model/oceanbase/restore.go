@@ -122,3 +122,15 @@ func (i *CdbObRestoreHistory) ToBO() *bo.CdbObRestoreHistory {
 	}
 	return res
 }
+
+type CdbObRecoverTableJob struct {
+	TenantId         int64  `json:"tenant_id" gorm:"column:TENANT_ID"`
+	JobID            int64  `json:"job_id" gorm:"column:JOB_ID"`
+	TargetTenantName string `json:"target_tenant_name" gorm:"column:TARGET_TENANT_NAME"`
+	AuxTenantName    string `json:"aux_tenant_name" gorm:"column:AUX_TENANT_NAME"`
+	Status           string `json:"status" gorm:"column:STATUS"`
+	StartTimestamp   string `json:"start_timestamp" gorm:"column:START_TIMESTAMP"`
+	EndTimestamp     string `json:"end_timestamp" gorm:"column:END_TIMESTAMP"`
+	Result           string `json:"result" gorm:"column:RESULT"`
+	Comment          string `json:"comment" gorm:"column:COMMENT"`
+}
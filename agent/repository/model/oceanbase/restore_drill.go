@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+)
+
+type RestoreDrillReport struct {
+	Id            int64      `gorm:"primaryKey;autoIncrement;not null"`
+	DagID         int64      `gorm:"not null;index"`
+	ScratchTenant string     `gorm:"type:varchar(128);not null"`
+	DataBackupUri string     `gorm:"type:varchar(1024);not null"`
+	ArchiveLogUri string     `gorm:"type:varchar(1024);not null"`
+	RestoreTime   *time.Time `gorm:"type:TIMESTAMP NULL"`
+	Status        string     `gorm:"type:varchar(32);not null"`
+	Verifications string     `gorm:"type:text"`
+	StartTime     time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+	EndTime       *time.Time `gorm:"type:TIMESTAMP NULL"`
+	GmtCreate     time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+	GmtModify     time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+}
+
+func (r *RestoreDrillReport) ToBO() bo.RestoreDrillReport {
+	report := bo.RestoreDrillReport{
+		Id:            r.Id,
+		DagID:         r.DagID,
+		ScratchTenant: r.ScratchTenant,
+		DataBackupUri: r.DataBackupUri,
+		ArchiveLogUri: r.ArchiveLogUri,
+		RestoreTime:   r.RestoreTime,
+		Status:        r.Status,
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+		Verifications: make([]bo.RestoreDrillVerificationResult, 0),
+	}
+	if r.Verifications != "" {
+		_ = json.Unmarshal([]byte(r.Verifications), &report.Verifications)
+	}
+	return report
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "github.com/oceanbase/obshell/agent/repository/model/bo"
+
+// ObTableLocation is a replica of a tablet in CDB_OB_TABLE_LOCATIONS,
+// joined with its size in CDB_OB_TABLET_REPLICAS.
+type ObTableLocation struct {
+	DatabaseName     string `gorm:"column:DATABASE_NAME"`
+	TableName        string `gorm:"column:TABLE_NAME"`
+	TableId          int64  `gorm:"column:TABLE_ID"`
+	TableType        string `gorm:"column:TABLE_TYPE"`
+	PartitionName    string `gorm:"column:PARTITION_NAME"`
+	SubpartitionName string `gorm:"column:SUBPARTITION_NAME"`
+	IndexName        string `gorm:"column:INDEX_NAME"`
+	DataTableId      int64  `gorm:"column:DATA_TABLE_ID"`
+	ObjectId         int64  `gorm:"column:OBJECT_ID"`
+	TabletId         int64  `gorm:"column:TABLET_ID"`
+	LsId             int64  `gorm:"column:LS_ID"`
+	Zone             string `gorm:"column:ZONE"`
+	SvrIp            string `gorm:"column:SVR_IP"`
+	SvrPort          int    `gorm:"column:SVR_PORT"`
+	Role             string `gorm:"column:ROLE"`
+	ReplicaType      string `gorm:"column:REPLICA_TYPE"`
+	DataSize         int64  `gorm:"column:DATA_SIZE"`
+	RequiredSize     int64  `gorm:"column:REQUIRED_SIZE"`
+}
+
+// ObServerTabletStat is the count and the size of the tablet replicas on an observer.
+type ObServerTabletStat struct {
+	Zone        string `gorm:"column:ZONE"`
+	SvrIp       string `gorm:"column:SVR_IP"`
+	SvrPort     int    `gorm:"column:SVR_PORT"`
+	TabletCount int    `gorm:"column:TABLET_COUNT"`
+	LeaderCount int    `gorm:"column:LEADER_COUNT"`
+	DataSize    int64  `gorm:"column:DATA_SIZE"`
+}
+
+func (l *ObTableLocation) ToReplicaBO() bo.TableReplica {
+	return bo.TableReplica{
+		Zone:         l.Zone,
+		SvrIp:        l.SvrIp,
+		SvrPort:      l.SvrPort,
+		Role:         l.Role,
+		ReplicaType:  l.ReplicaType,
+		DataSize:     l.DataSize,
+		RequiredSize: l.RequiredSize,
+	}
+}
+
+func (l *ObTableLocation) ToTabletBO() bo.Tablet {
+	return bo.Tablet{
+		DatabaseName:     l.DatabaseName,
+		TableName:        l.TableName,
+		IndexName:        l.IndexName,
+		PartitionName:    l.PartitionName,
+		SubpartitionName: l.SubpartitionName,
+		TabletId:         l.TabletId,
+		LsId:             l.LsId,
+		Role:             l.Role,
+		DataSize:         l.DataSize,
+		RequiredSize:     l.RequiredSize,
+	}
+}
+
+type ObTableStatistic struct {
+	DatabaseName string `gorm:"column:DATABASE_NAME"`
+	TableName    string `gorm:"column:TABLE_NAME"`
+	NumRows      int64  `gorm:"column:NUM_ROWS"`
+}
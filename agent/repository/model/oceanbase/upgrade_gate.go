@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+)
+
+// UpgradeZoneGate is the approval gate after a zone is upgraded in a rolling upgrade,
+// the upgrade waits until the gate is approved, aborted or timed out.
+type UpgradeZoneGate struct {
+	Id            int64      `gorm:"primaryKey;autoIncrement;not null"`
+	DagID         int64      `gorm:"not null;uniqueIndex:idx_dag_route_zone"`
+	RouteIndex    int        `gorm:"not null;uniqueIndex:idx_dag_route_zone"`
+	Zone          string     `gorm:"type:varchar(128);not null;uniqueIndex:idx_dag_route_zone"`
+	TaskID        int64      `gorm:"not null;index"`
+	Status        string     `gorm:"type:varchar(16);not null"`
+	Deadline      *time.Time `gorm:"type:TIMESTAMP NULL"`
+	TimeoutAction string     `gorm:"type:varchar(16);not null"`
+	Comment       string     `gorm:"type:varchar(512);not null;default:''"`
+	GmtCreate     time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+	GmtModified   time.Time  `gorm:"type:TIMESTAMP;default:CURRENT_TIMESTAMP"`
+}
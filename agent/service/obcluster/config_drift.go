@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package obcluster
+
+import (
+	"github.com/oceanbase/obshell/agent/constant"
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+// GetServerParameters returns the cluster parameters and the parameters of sys tenant on the observer.
+func (s *ObserverService) GetServerParameters(svrIp string, svrPort int, names []string) (params []oceanbase.ObParameters, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(GV_OB_PARAMETERS).
+		Where("SVR_IP = ? AND SVR_PORT = ? AND NAME IN ?", svrIp, svrPort, names).
+		Where("TENANT_ID IS NULL OR TENANT_ID = ?", constant.TENANT_SYS_ID).
+		Scan(&params).Error
+	return
+}
@@ -30,4 +30,6 @@ const (
 	GV_OB_PARAMETERS    = "oceanbase.GV$OB_PARAMETERS"
 	GV_OB_SERVERS       = "oceanbase.GV$OB_SERVERS"
 	CDB_OB_LS_LOCATIONS = "oceanbase.CDB_OB_LS_LOCATIONS"
+
+	CDB_OB_LS_REPLICA_TASKS = "oceanbase.CDB_OB_LS_REPLICA_TASKS"
 )
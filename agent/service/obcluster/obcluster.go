@@ -811,6 +811,28 @@ func (ObclusterService *ObclusterService) GetObUnitsOnServer(svrIp string, svrPo
 	return
 }
 
+// CountUnitsOnServer returns the number of units on the server or migrating from the server.
+func (*ObclusterService) CountUnitsOnServer(svrInfo meta.ObserverSvrInfo) (count int64, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return 0, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_UNITS).
+		Where("(SVR_IP = ? AND SVR_PORT = ?) OR (MIGRATE_FROM_SVR_IP = ? AND MIGRATE_FROM_SVR_PORT = ?)", svrInfo.GetIp(), svrInfo.GetPort(), svrInfo.GetIp(), svrInfo.GetPort()).
+		Count(&count).Error
+	return
+}
+
+// CountReplicaTasksToServer returns the number of the log stream replica tasks whose target is the server.
+func (*ObclusterService) CountReplicaTasksToServer(svrInfo meta.ObserverSvrInfo) (count int64, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return 0, err
+	}
+	err = oceanbaseDb.Table(CDB_OB_LS_REPLICA_TASKS).Where("TARGET_REPLICA_SVR_IP = ? AND TARGET_REPLICA_SVR_PORT = ?", svrInfo.GetIp(), svrInfo.GetPort()).Count(&count).Error
+	return
+}
+
 func (ObclusterService *ObclusterService) IsLsMultiPaxosAlive(lsId int, tenantId int, svrInfo meta.ObserverSvrInfo) (bool, error) {
 	oceanbaseDb, err := oceanbasedb.GetInstance()
 	if err != nil {
@@ -831,6 +853,35 @@ func (ObclusterService *ObclusterService) IsLsMultiPaxosAlive(lsId int, tenantId
 	}
 }
 
+// IsLsMultiPaxosAliveWithoutZone returns true if the majority of paxos members of the log stream
+// is in sync without the servers in the zone.
+func (*ObclusterService) IsLsMultiPaxosAliveWithoutZone(lsId int, tenantId int, zone string) (bool, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	if err = oceanbaseDb.Raw("select count(*) from oceanbase.GV$OB_LOG_STAT as a inner join oceanbase.GV$OB_LOG_STAT as b on a.tenant_id = b.tenant_id and a.ls_id = b.ls_id and b.role = 'LEADER' and b.paxos_member_list like concat('%',a.svr_ip,':',a.svr_port,'%') and a.in_sync = 'YES' and a.ls_id = ? AND a.tenant_id = ? and (a.svr_ip, a.svr_port) not in (select svr_ip, svr_port from oceanbase.DBA_OB_SERVERS where zone = ?)", lsId, tenantId, zone).Count(&count).Error; err != nil {
+		return false, err
+	}
+	var paxosMember int64
+	if err = oceanbaseDb.Table(GV_OB_LOG_STAT).Select("paxos_replica_num").Where("ls_id = ? AND tenant_id = ? AND ROLE = 'LEADER'", lsId, tenantId).Scan(&paxosMember).Error; err != nil {
+		return false, err
+	}
+	return count > paxosMember/2, nil
+}
+
+// GetLogInfosInZone returns the log streams which have replica in the servers of the zone,
+// only contains tenant_id and ls_id.
+func (*ObclusterService) GetLogInfosInZone(zone string) (logStats []oceanbase.ObLogStat, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(GV_OB_LOG_STAT).Distinct("TENANT_ID", "LS_ID").Where("(SVR_IP, SVR_PORT) IN (SELECT SVR_IP, SVR_PORT FROM oceanbase.DBA_OB_SERVERS WHERE ZONE = ?)", zone).Find(&logStats).Error
+	return
+}
+
 // GetLogInfosInServer returns the log stat in target server
 // only contains tenant_id and ls_id.
 func (*ObclusterService) GetLogInfosInServer(svrInfo meta.ObserverSvrInfo) (logStats []oceanbase.ObLogStat, err error) {
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package obcluster
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+const parameterSnapshotItemBatchSize = 500
+
+// GetParameterValues returns the values of the parameter on all the servers and tenants.
+func (*ObclusterService) GetParameterValues(name string) (params []oceanbase.ObParameters, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(GV_OB_PARAMETERS).Where("NAME = ?", name).Scan(&params).Error
+	return
+}
+
+func (*ObclusterService) SaveParameterChanges(changes []oceanbase.ParameterChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Create(&changes).Error
+}
+
+// RecordParameterChanges records the changes which have been applied,
+// the failure is only logged since the change has taken effect.
+func (s *ObclusterService) RecordParameterChanges(changes []oceanbase.ParameterChange) {
+	if err := s.SaveParameterChanges(changes); err != nil {
+		log.WithError(err).Warn("save parameter changes failed")
+	}
+}
+
+// ListParameterChanges returns the latest changes filtered by the name and the tenant, all if the filter is empty.
+func (*ObclusterService) ListParameterChanges(name string, tenantName string, limit int) (changes []oceanbase.ParameterChange, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := oceanbaseDb.Model(&oceanbase.ParameterChange{})
+	if name != "" {
+		query = query.Where("name = ?", name)
+	}
+	if tenantName != "" {
+		query = query.Where("tenant_name = ?", tenantName)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err = query.Order("id DESC").Scan(&changes).Error
+	return
+}
+
+func (*ObclusterService) GetParameterChange(id int64) (change *oceanbase.ParameterChange, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.ParameterChange{}).Where("id = ?", id).Scan(&change).Error
+	return
+}
+
+func (*ObclusterService) CreateParameterSnapshot(snapshot *oceanbase.ParameterSnapshot, items []oceanbase.ParameterSnapshotItem) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(snapshot).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		for i := range items {
+			items[i].SnapshotId = snapshot.Id
+		}
+		return tx.CreateInBatches(items, parameterSnapshotItemBatchSize).Error
+	})
+}
+
+func (*ObclusterService) GetParameterSnapshot(name string) (snapshot *oceanbase.ParameterSnapshot, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.ParameterSnapshot{}).Where("name = ?", name).Scan(&snapshot).Error
+	return
+}
+
+func (*ObclusterService) ListParameterSnapshots() (snapshots []oceanbase.ParameterSnapshot, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.ParameterSnapshot{}).Order("id").Scan(&snapshots).Error
+	return
+}
+
+func (*ObclusterService) CountParameterSnapshotItems(snapshotId int64) (count int64, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return 0, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.ParameterSnapshotItem{}).Where("snapshot_id = ?", snapshotId).Count(&count).Error
+	return
+}
+
+func (*ObclusterService) GetParameterSnapshotItems(snapshotId int64) (items []oceanbase.ParameterSnapshotItem, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.ParameterSnapshotItem{}).Where("snapshot_id = ?", snapshotId).Scan(&items).Error
+	return
+}
+
+func (*ObclusterService) DeleteParameterSnapshot(snapshotId int64) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("snapshot_id = ?", snapshotId).Delete(&oceanbase.ParameterSnapshotItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", snapshotId).Delete(&oceanbase.ParameterSnapshot{}).Error
+	})
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package obcluster
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+// OpenUpgradeZoneGate makes the gate wait for approval, the gate approved already is kept as it is.
+// It returns the gate saved.
+func (s *ObclusterService) OpenUpgradeZoneGate(gate *oceanbase.UpgradeZoneGate) (res *oceanbase.UpgradeZoneGate, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Transaction(func(tx *gorm.DB) error {
+		var existed oceanbase.UpgradeZoneGate
+		if err := tx.Where("dag_id = ? and route_index = ? and zone = ?", gate.DagID, gate.RouteIndex, gate.Zone).Limit(1).Find(&existed).Error; err != nil {
+			return err
+		}
+		if existed.Id == 0 {
+			gate.Status = constant.UPGRADE_GATE_STATUS_WAITING
+			if err := tx.Create(gate).Error; err != nil {
+				return err
+			}
+			res = gate
+			return nil
+		}
+		if existed.Status == constant.UPGRADE_GATE_STATUS_ABORTED {
+			// The task is retried after aborted, ask for approval again.
+			existed.Status = constant.UPGRADE_GATE_STATUS_WAITING
+			existed.Deadline = gate.Deadline
+			existed.TaskID = gate.TaskID
+			existed.Comment = ""
+			existed.GmtModified = time.Now()
+			if err := tx.Save(&existed).Error; err != nil {
+				return err
+			}
+		}
+		res = &existed
+		return nil
+	})
+	return
+}
+
+func (s *ObclusterService) GetUpgradeZoneGate(id int64) (gate *oceanbase.UpgradeZoneGate, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.UpgradeZoneGate{}).Where("id = ?", id).Scan(&gate).Error
+	return
+}
+
+func (s *ObclusterService) GetUpgradeZoneGateByTask(taskID int64) (gate *oceanbase.UpgradeZoneGate, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.UpgradeZoneGate{}).Where("task_id = ?", taskID).Scan(&gate).Error
+	return
+}
+
+func (s *ObclusterService) ListUpgradeZoneGates(dagID int64) (gates []oceanbase.UpgradeZoneGate, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.UpgradeZoneGate{}).Where("dag_id = ?", dagID).Order("id").Scan(&gates).Error
+	return
+}
+
+// CloseUpgradeZoneGate changes the waiting gate to the status,
+// and returns false if the gate is not waiting any more.
+func (s *ObclusterService) CloseUpgradeZoneGate(id int64, status string, comment string) (bool, error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return false, err
+	}
+	res := oceanbaseDb.Model(&oceanbase.UpgradeZoneGate{}).
+		Where("id = ? and status = ?", id, constant.UPGRADE_GATE_STATUS_WAITING).
+		Updates(map[string]interface{}{"status": status, "comment": comment, "gmt_modified": time.Now()})
+	return res.RowsAffected > 0, res.Error
+}
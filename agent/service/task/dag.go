@@ -207,41 +207,61 @@ func (s *taskService) CreateDagInstanceByTemplate(template *task.Template, ctx *
 		return nil, err
 	}
 	var dag *task.Dag
-	err = db.Transaction(func(tx *gorm.DB) error {
-		if template.IsMaintenance() {
-			if err := s.StartMaintenance(tx, template); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) (err error) {
+		dag, err = s.createDagInstance(tx, template, ctx, dagInstanceBO)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrTaskCreateFailed, err, template.Name)
+	}
+	return dag, nil
+}
+
+// CreateDagInstanceToTakeOver creates a dag by template to take over the failed dag.
+// The maintenance held by the failed dag is handed over to the new dag,
+// and the failed dag can not be retried, rolled back or passed any more.
+func (s *taskService) CreateDagInstanceToTakeOver(failedDag *task.Dag, template *task.Template, ctx *task.TaskContext) (*task.Dag, error) {
+	if !failedDag.IsFail() {
+		return nil, errors.Occur(errors.ErrTaskDagStateInvalid, failedDag.GetState())
+	}
+	if err := s.checkDagNotTakenOver(failedDag); err != nil {
+		return nil, err
+	}
+	dagInstanceBO, err := s.newDagInstanceBO(template, ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "create dag instace bo failed")
+	}
+
+	db, err := s.getDbInstance()
+	if err != nil {
+		return nil, err
+	}
+	var dag *task.Dag
+	err = db.Transaction(func(tx *gorm.DB) (err error) {
+		if failedDag.IsMaintenance() {
+			if err = s.StopMaintenance(tx, failedDag); err != nil {
 				return err
 			}
 		}
-
-		dagInstanceBO, err = s.insertNewDag(tx, dagInstanceBO)
-		if err != nil {
+		if dag, err = s.createDagInstance(tx, template, ctx, dagInstanceBO); err != nil {
 			return err
 		}
 
-		dag, err = s.convertDagInstance(dagInstanceBO)
-		if err != nil {
-			return err
-		}
-
-		if err := s.UpdateMaintenanceTask(tx, dag); err != nil {
-			return err
+		failedCtx := failedDag.GetContext()
+		if failedCtx == nil {
+			failedCtx = task.NewTaskContext()
 		}
-
-		nodeInstancesBO, err := s.newNodes(template, ctx)
+		failedCtx.SetParam(task.TAKEN_OVER_BY, task.ConvertToGenericID(dag, dag.GetDagType()))
+		ctxStr, err := s.encodeTaskContext(failedCtx)
 		if err != nil {
 			return err
 		}
-		nodes := template.GetNodes()
-		for idx, nodeInstanceBO := range nodeInstancesBO {
-			node := nodes[idx]
-			nodeInstanceBO, err = s.insertNewNode(tx, node, nodeInstanceBO, dagInstanceBO.Id)
-			if err != nil {
-				return err
-			}
-			if err := s.insertNewSubTasks(tx, nodeInstanceBO, node); err != nil {
-				return err
-			}
+		resp := tx.Model(s.getDagModel()).Where("id=? and state=?", failedDag.GetID(), task.FAILED).Update("context", []byte(ctxStr))
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if resp.RowsAffected == 0 {
+			return errors.Occur(errors.ErrGormNoRowAffected, "failed to take over dag")
 		}
 		return nil
 	})
@@ -251,6 +271,56 @@ func (s *taskService) CreateDagInstanceByTemplate(template *task.Template, ctx *
 	return dag, nil
 }
 
+func (s *taskService) createDagInstance(tx *gorm.DB, template *task.Template, ctx *task.TaskContext, dagInstanceBO *bo.DagInstance) (*task.Dag, error) {
+	if template.IsMaintenance() {
+		if err := s.StartMaintenance(tx, template); err != nil {
+			return nil, err
+		}
+	}
+
+	dagInstanceBO, err := s.insertNewDag(tx, dagInstanceBO)
+	if err != nil {
+		return nil, err
+	}
+
+	dag, err := s.convertDagInstance(dagInstanceBO)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.UpdateMaintenanceTask(tx, dag); err != nil {
+		return nil, err
+	}
+
+	nodeInstancesBO, err := s.newNodes(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes := template.GetNodes()
+	for idx, nodeInstanceBO := range nodeInstancesBO {
+		node := nodes[idx]
+		nodeInstanceBO, err = s.insertNewNode(tx, node, nodeInstanceBO, dagInstanceBO.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.insertNewSubTasks(tx, nodeInstanceBO, node); err != nil {
+			return nil, err
+		}
+	}
+	return dag, nil
+}
+
+// checkDagNotTakenOver checks whether the failed dag has been taken over by another dag.
+func (s *taskService) checkDagNotTakenOver(dag *task.Dag) error {
+	if dag.GetContext() == nil {
+		return nil
+	}
+	if takenOverBy, ok := dag.GetContext().GetParam(task.TAKEN_OVER_BY).(string); ok && takenOverBy != "" {
+		return errors.Occur(errors.ErrTaskDagTakenOver, task.ConvertToGenericID(dag, dag.GetDagType()), takenOverBy)
+	}
+	return nil
+}
+
 // insertNewDag creates a new dag based on BO in the transaction.
 func (s *taskService) insertNewDag(tx *gorm.DB, dagInstanceBO *bo.DagInstance) (*bo.DagInstance, error) {
 	dagInstance := s.convertDagInstanceBOToDO(dagInstanceBO)
@@ -316,6 +386,9 @@ func (s *taskService) getNodesCanRollback(dag *task.Dag) ([]*task.Node, error) {
 	if dag.GetState() != task.FAILED {
 		return nil, errors.Occur(errors.ErrTaskDagOperatorRollbackNotFailedDag)
 	}
+	if err := s.checkDagNotTakenOver(dag); err != nil {
+		return nil, err
+	}
 
 	nodes, err := s.GetNodes(dag)
 	if err != nil {
@@ -379,6 +452,9 @@ func (s *taskService) getNodesCanPass(dag *task.Dag) ([]*task.Node, error) {
 	if !dag.IsFail() {
 		return nil, errors.Occur(errors.ErrTaskDagOperatorPassNotFailedDag)
 	}
+	if err := s.checkDagNotTakenOver(dag); err != nil {
+		return nil, err
+	}
 	nodes, err := s.GetNodes(dag)
 	if err != nil {
 		return nil, err
@@ -406,6 +482,9 @@ func (s *taskService) getNodeCanRetry(dag *task.Dag) (*task.Node, error) {
 	if !dag.IsFail() {
 		return nil, errors.Occur(errors.ErrTaskDagOperatorRetryNotFailedDag)
 	}
+	if err := s.checkDagNotTakenOver(dag); err != nil {
+		return nil, err
+	}
 	node, err := s.GetNodeByStage(dag.GetID(), dag.GetStage())
 	if err != nil {
 		return nil, err
@@ -55,6 +55,9 @@ type DagServiceInterface interface {
 	// Create dag, node, subTasks based on template and context
 	CreateDagInstanceByTemplate(*task.Template, *task.TaskContext) (*task.Dag, error)
 
+	// Create dag based on template and context to take over the failed dag
+	CreateDagInstanceToTakeOver(*task.Dag, *task.Template, *task.TaskContext) (*task.Dag, error)
+
 	GetDagInstance(int64) (*task.Dag, error)
 
 	GetUnfinishedDagInstance() (*task.Dag, error)
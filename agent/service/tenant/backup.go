@@ -19,6 +19,7 @@ package tenant
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -38,6 +39,19 @@ func (s *TenantService) GetArchiveLogByID(tenantID int) (res *oceanbase.CdbOBArc
 	return
 }
 
+// ListArchiveLogProgress returns the archive log status of all tenants, the lag
+// is calculated by observer to avoid the clock and time zone of the agent.
+func (s *TenantService) ListArchiveLogProgress() (progress []oceanbase.CdbObArchivelogProgress, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_ARCHIVELOG).
+		Select("TENANT_ID, DEST_ID, ROUND_ID, STATUS, CHECKPOINT_SCN, CHECKPOINT_SCN_DISPLAY, TIMESTAMPDIFF(SECOND, CHECKPOINT_SCN_DISPLAY, NOW()) AS LAG_SECONDS, COMMENT").
+		Order("TENANT_ID").Scan(&progress).Error
+	return
+}
+
 func (s *TenantService) GetArchiveDestByID(tenantID int) (value string, err error) {
 	oceanbaseDb, err := oceanbasedb.GetInstance()
 	if err != nil {
@@ -278,3 +292,66 @@ func (s *TenantService) GetLastBackupTask(tenantID int) (task *oceanbase.CdbObBa
 	err = oceanbaseDb.Table(CDB_OB_BACKUP_TASK_HISTORY).Where("TENANT_ID = ?", tenantID).Order("START_TIMESTAMP desc").Limit(1).Scan(&task).Error
 	return
 }
+
+func (s *TenantService) ListBackupTaskHistory(tenantID int, since time.Time) (tasks []oceanbase.CdbObBackupTask, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_TASK_HISTORY).Where("TENANT_ID = ? and START_TIMESTAMP >= ?", tenantID, since).Order("START_TIMESTAMP").Scan(&tasks).Error
+	return
+}
+
+func (s *TenantService) ListBackupSetFiles(tenantID int) (files []oceanbase.CdbObBackupSetFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_SET_FILES).Where("TENANT_ID = ?", tenantID).Order("BACKUP_SET_ID").Scan(&files).Error
+	return
+}
+
+func (s *TenantService) GetBackupSetFile(tenantID int, backupSetID int64) (file *oceanbase.CdbObBackupSetFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_SET_FILES).Where("TENANT_ID = ? and BACKUP_SET_ID = ?", tenantID, backupSetID).Scan(&file).Error
+	return
+}
+
+func (s *TenantService) ListArchivelogPieceFiles(tenantID int) (pieces []oceanbase.CdbObArchivelogPieceFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_ARCHIVELOG_PIECE_FILES).Where("TENANT_ID = ?", tenantID).Order("ROUND_ID, PIECE_ID").Scan(&pieces).Error
+	return
+}
+
+func (s *TenantService) ListBackupDeleteJobs(tenantID int) (jobs []oceanbase.CdbObBackupDeleteJob, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_DELETE_JOBS).Where("TENANT_ID = ?", tenantID).Scan(&jobs).Error
+	return
+}
+
+func (s *TenantService) DeleteBackupSet(tenantName string, backupSetID int64) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM DELETE BACKUPSET %d TENANT = %s", backupSetID, tenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) DeleteBackupPiece(tenantName string, pieceID int64) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM DELETE BACKUPPIECE %d TENANT = %s", pieceID, tenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+func (s *TenantService) GetActiveBackupKey(tenantID int) (key *oceanbase.BackupEncryptionKey, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.BackupEncryptionKey{}).Where("tenant_id = ? and active = ?", tenantID, true).Scan(&key).Error
+	return
+}
+
+func (s *TenantService) ListBackupKeys(tenantID int) (keys []oceanbase.BackupEncryptionKey, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.BackupEncryptionKey{}).Where("tenant_id = ?", tenantID).Order("version").Scan(&keys).Error
+	return
+}
+
+func (s *TenantService) GetBackupKey(tenantID int, version int) (key *oceanbase.BackupEncryptionKey, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.BackupEncryptionKey{}).Where("tenant_id = ? and version = ?", tenantID, version).Scan(&key).Error
+	return
+}
+
+// RotateBackupKey retires the active key of the tenant and activates the key as the next version.
+func (s *TenantService) RotateBackupKey(key *oceanbase.BackupEncryptionKey) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Transaction(func(tx *gorm.DB) error {
+		var version int
+		if err := tx.Model(&oceanbase.BackupEncryptionKey{}).Where("tenant_id = ?", key.TenantID).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&oceanbase.BackupEncryptionKey{}).Where("tenant_id = ? and active = ?", key.TenantID, true).
+			Updates(map[string]interface{}{"active": false, "retired_time": time.Now()}).Error; err != nil {
+			return err
+		}
+		key.Version = version + 1
+		key.Active = true
+		return tx.Create(key).Error
+	})
+}
+
+func (s *TenantService) UpdateBackupKeyPasswords(id int64, passwords string) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Model(&oceanbase.BackupEncryptionKey{}).Where("id = ?", id).Update("passwords", passwords).Error
+}
+
+func (s *TenantService) SaveBackupSetKey(record *oceanbase.BackupSetEncryptionKey) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Clauses(clause.OnConflict{DoNothing: true}).Create(record).Error
+}
+
+func (s *TenantService) ListBackupSetKeys(tenantID int) (records []oceanbase.BackupSetEncryptionKey, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.BackupSetEncryptionKey{}).Where("tenant_id = ?", tenantID).Order("backup_set_id").Scan(&records).Error
+	return
+}
+
+func (s *TenantService) ListBackupSetKeysByDest(dest string) (records []oceanbase.BackupSetEncryptionKey, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.BackupSetEncryptionKey{}).Where("backup_dest = ?", dest).Order("backup_set_id").Scan(&records).Error
+	return
+}
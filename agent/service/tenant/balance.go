@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/agent/meta"
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+const (
+	SQL_ENABLE_TENANT_REBALANCE   = "ALTER SYSTEM SET enable_rebalance = true TENANT = `%s`"
+	SQL_ENABLE_TENANT_TRANSFER    = "ALTER SYSTEM SET enable_transfer = true TENANT = `%s`"
+	SQL_TRIGGER_PARTITION_BALANCE = "CALL DBMS_BALANCE.TRIGGER_PARTITION_BALANCE()"
+	SQL_TRANSFER_PARTITION        = "ALTER SYSTEM TRANSFER PARTITION TABLE_ID = %d, OBJECT_ID = %d TO LS %d TENANT = `%s`"
+	SQL_SWITCH_LS_LEADER          = "ALTER SYSTEM SWITCH REPLICA LEADER LS = %d SERVER = '%s' TENANT = `%s`"
+)
+
+func (t *TenantService) ListLsLocations(tenantId int) (locations []oceanbase.ObLsLocation, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_LS_LOCATIONS).
+		Select("LS_ID, ZONE, SVR_IP, SVR_PORT, ROLE, REPLICA_TYPE").
+		Where("TENANT_ID = ?", tenantId).
+		Order("LS_ID, ZONE").
+		Scan(&locations).Error
+	return
+}
+
+func (t *TenantService) ListLsTabletCounts(tenantId int) (counts []oceanbase.ObLsTabletCount, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_TABLET_TO_LS).
+		Select("LS_ID, COUNT(*) AS TABLET_COUNT").
+		Where("TENANT_ID = ?", tenantId).
+		Group("LS_ID").
+		Scan(&counts).Error
+	return
+}
+
+func (t *TenantService) ListTenantUnitLoads(tenantId int) (units []oceanbase.GvObUnit, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(GV_OB_UNITS).
+		Select("SVR_IP, SVR_PORT, ZONE, UNIT_ID, MAX_CPU, MIN_CPU, MEMORY_SIZE, LOG_DISK_SIZE, LOG_DISK_IN_USE, DATA_DISK_IN_USE, STATUS").
+		Where("TENANT_ID = ?", tenantId).
+		Scan(&units).Error
+	return
+}
+
+func (t *TenantService) GetInProgressBalanceJob(tenantId int) (job *oceanbase.ObBalanceJob, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_BALANCE_JOBS).
+		Select("JOB_ID, CREATE_TIME, JOB_TYPE, BALANCE_STRATEGY, STATUS, COMMENT").
+		Where("TENANT_ID = ?", tenantId).
+		Limit(1).
+		Scan(&job).Error
+	return
+}
+
+func (t *TenantService) GetBalanceJobHistory(tenantId int, jobId int64) (job *oceanbase.ObBalanceJob, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_BALANCE_JOB_HISTORY).
+		Select("JOB_ID, CREATE_TIME, JOB_TYPE, BALANCE_STRATEGY, STATUS, COMMENT").
+		Where("TENANT_ID = ? AND JOB_ID = ?", tenantId, jobId).
+		Scan(&job).Error
+	return
+}
+
+func (t *TenantService) ListTransferPartitionTasks(tenantId int) (tasks []oceanbase.ObTransferPartitionTask, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_TRANSFER_PARTITION_TASKS).
+		Select("TASK_ID, CREATE_TIME, TABLE_ID, OBJECT_ID, DEST_LS, IFNULL(BALANCE_JOB_ID, -1) AS BALANCE_JOB_ID, STATUS, IFNULL(COMMENT, '') AS COMMENT").
+		Where("TENANT_ID = ?", tenantId).
+		Order("TASK_ID").
+		Scan(&tasks).Error
+	return
+}
+
+func (t *TenantService) GetTransferPartitionTask(tenantId int, tableId, objectId int64) (task *oceanbase.ObTransferPartitionTask, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_TRANSFER_PARTITION_TASKS).
+		Select("TASK_ID, CREATE_TIME, TABLE_ID, OBJECT_ID, DEST_LS, IFNULL(BALANCE_JOB_ID, -1) AS BALANCE_JOB_ID, STATUS, IFNULL(COMMENT, '') AS COMMENT").
+		Where("TENANT_ID = ? AND TABLE_ID = ? AND OBJECT_ID = ?", tenantId, tableId, objectId).
+		Scan(&task).Error
+	return
+}
+
+func (t *TenantService) GetTransferPartitionTaskHistory(tenantId int, taskId int64) (task *oceanbase.ObTransferPartitionTask, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_OB_TRANSFER_PARTITION_TASK_HISTORY).
+		Select("TASK_ID, CREATE_TIME, TABLE_ID, OBJECT_ID, DEST_LS, IFNULL(BALANCE_JOB_ID, -1) AS BALANCE_JOB_ID, STATUS, IFNULL(COMMENT, '') AS COMMENT").
+		Where("TENANT_ID = ? AND TASK_ID = ?", tenantId, taskId).
+		Scan(&task).Error
+	return
+}
+
+// GetObjectLsId returns the log stream of the partition, which is the table itself for the non-partitioned table.
+func (t *TenantService) GetObjectLsId(tenantId int, tableId, objectId int64) (lsId int64, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return 0, err
+	}
+	err = db.Table(CDB_OB_TABLE_LOCATIONS).
+		Select("LS_ID").
+		Where("TENANT_ID = ? AND TABLE_ID = ? AND OBJECT_ID = ?", tenantId, tableId, objectId).
+		Limit(1).
+		Scan(&lsId).Error
+	return
+}
+
+func (t *TenantService) EnableTenantRebalance(tenantName string) error {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	if err = db.Exec(fmt.Sprintf(SQL_ENABLE_TENANT_REBALANCE, tenantName)).Error; err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf(SQL_ENABLE_TENANT_TRANSFER, tenantName)).Error
+}
+
+// TriggerPartitionBalance should be called with the connection of the tenant.
+func (t *TenantService) TriggerPartitionBalance(db *gorm.DB) error {
+	return db.Exec(SQL_TRIGGER_PARTITION_BALANCE).Error
+}
+
+func (t *TenantService) TransferPartition(tenantName string, tableId, objectId, lsId int64) error {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf(SQL_TRANSFER_PARTITION, tableId, objectId, lsId, tenantName)).Error
+}
+
+func (t *TenantService) SwitchLsLeader(tenantName string, lsId int64, svrIp string, svrPort int) error {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf(SQL_SWITCH_LS_LEADER, lsId, meta.NewAgentInfo(svrIp, svrPort).String(), tenantName)).Error
+}
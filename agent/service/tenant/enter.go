@@ -25,6 +25,7 @@ const (
 	DBA_OB_UNITS                 = "oceanbase.DBA_OB_UNITS"
 	DBA_OB_RESOURCE_POOLS        = "oceanbase.DBA_OB_RESOURCE_POOLS"
 	DBA_OB_TENANT_JOBS           = "oceanbase.DBA_OB_TENANT_JOBS"
+	DBA_OB_UNIT_JOBS             = "oceanbase.DBA_OB_UNIT_JOBS"
 	DBA_OB_UNIT_CONFIGS          = "oceanbase.DBA_OB_UNIT_CONFIGS"
 	DBA_OB_CLUSTER_EVENT_HISTORY = "oceanbase.DBA_OB_CLUSTER_EVENT_HISTORY"
 	DBA_RECYCLEBIN               = "oceanbase.DBA_RECYCLEBIN"
@@ -32,20 +33,36 @@ const (
 	DBA_OB_DATABASES             = "oceanbase.DBA_OB_DATABASES"
 	DBA_OBJECTS                  = "oceanbase.DBA_OBJECTS"
 
-	CDB_OB_SYS_VARIABLES        = "oceanbase.CDB_OB_SYS_VARIABLES"
-	CDB_OB_ARCHIVELOG           = "oceanbase.CDB_OB_ARCHIVELOG"
-	CDB_OB_BACKUP_DELETE_POLICY = "oceanbase.CDB_OB_BACKUP_DELETE_POLICY"
-	CDB_OB_BACKUP_JOBS          = "oceanbase.CDB_OB_BACKUP_JOBS"
-	CDB_OB_ARCHIVE_DEST         = "oceanbase.CDB_OB_ARCHIVE_DEST"
-	CDB_OB_BACKUP_PARAMETER     = "oceanbase.CDB_OB_BACKUP_PARAMETER"
-	CDB_OB_BACKUP_TASKS         = "oceanbase.CDB_OB_BACKUP_TASKS"
-	CDB_OB_BACKUP_TASK_HISTORY  = "oceanbase.CDB_OB_BACKUP_TASK_HISTORY"
-	CDB_OB_RESTORE_PROGRESS     = "oceanbase.CDB_OB_RESTORE_PROGRESS"
-	CDB_OB_RESTORE_HISTORY      = "oceanbase.CDB_OB_RESTORE_HISTORY"
+	CDB_OB_SYS_VARIABLES          = "oceanbase.CDB_OB_SYS_VARIABLES"
+	CDB_OB_ARCHIVELOG             = "oceanbase.CDB_OB_ARCHIVELOG"
+	CDB_OB_BACKUP_DELETE_POLICY   = "oceanbase.CDB_OB_BACKUP_DELETE_POLICY"
+	CDB_OB_BACKUP_JOBS            = "oceanbase.CDB_OB_BACKUP_JOBS"
+	CDB_OB_ARCHIVE_DEST           = "oceanbase.CDB_OB_ARCHIVE_DEST"
+	CDB_OB_BACKUP_PARAMETER       = "oceanbase.CDB_OB_BACKUP_PARAMETER"
+	CDB_OB_BACKUP_TASKS           = "oceanbase.CDB_OB_BACKUP_TASKS"
+	CDB_OB_BACKUP_TASK_HISTORY    = "oceanbase.CDB_OB_BACKUP_TASK_HISTORY"
+	CDB_OB_BACKUP_SET_FILES       = "oceanbase.CDB_OB_BACKUP_SET_FILES"
+	CDB_OB_ARCHIVELOG_PIECE_FILES = "oceanbase.CDB_OB_ARCHIVELOG_PIECE_FILES"
+	CDB_OB_BACKUP_DELETE_JOBS     = "oceanbase.CDB_OB_BACKUP_DELETE_JOBS"
+	CDB_OB_RESTORE_PROGRESS       = "oceanbase.CDB_OB_RESTORE_PROGRESS"
+	CDB_OB_RESTORE_HISTORY        = "oceanbase.CDB_OB_RESTORE_HISTORY"
+	CDB_OB_TABLE_LOCATIONS        = "oceanbase.CDB_OB_TABLE_LOCATIONS"
+	CDB_OB_TABLET_REPLICAS        = "oceanbase.CDB_OB_TABLET_REPLICAS"
+	CDB_TAB_STATISTICS            = "oceanbase.CDB_TAB_STATISTICS"
+	CDB_OB_LS_LOCATIONS           = "oceanbase.CDB_OB_LS_LOCATIONS"
+	CDB_OB_TABLET_TO_LS           = "oceanbase.CDB_OB_TABLET_TO_LS"
+	CDB_OB_BALANCE_JOBS           = "oceanbase.CDB_OB_BALANCE_JOBS"
+	CDB_OB_BALANCE_JOB_HISTORY    = "oceanbase.CDB_OB_BALANCE_JOB_HISTORY"
+
+	CDB_OB_TRANSFER_PARTITION_TASKS        = "oceanbase.CDB_OB_TRANSFER_PARTITION_TASKS"
+	CDB_OB_TRANSFER_PARTITION_TASK_HISTORY = "oceanbase.CDB_OB_TRANSFER_PARTITION_TASK_HISTORY"
+	CDB_OB_RECOVER_TABLE_JOBS              = "oceanbase.CDB_OB_RECOVER_TABLE_JOBS"
+	CDB_OB_RECOVER_TABLE_JOB_HISTORY       = "oceanbase.CDB_OB_RECOVER_TABLE_JOB_HISTORY"
 
 	GV_OB_PARAMETERS = "oceanbase.GV$OB_PARAMETERS"
 	GV_OB_SERVERS    = "oceanbase.GV$OB_SERVERS"
 	GV_OB_SESSION    = "oceanbase.GV$OB_SESSION"
+	GV_OB_UNITS      = "oceanbase.GV$OB_UNITS"
 
 	MYSQL_TIME_ZONE = "mysql.time_zone"
 	MYSQL_USER      = "mysql.user"
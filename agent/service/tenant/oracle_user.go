@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+// Views and statements for the oracle mode tenant. Gorm quotes identifiers
+// with backticks which are illegal in oracle mode, so raw sql is used here.
+const (
+	ORACLE_DBA_USERS      = "SYS.DBA_USERS"
+	ORACLE_DBA_ROLES      = "SYS.DBA_ROLES"
+	ORACLE_DBA_SYS_PRIVS  = "SYS.DBA_SYS_PRIVS"
+	ORACLE_DBA_ROLE_PRIVS = "SYS.DBA_ROLE_PRIVS"
+	ORACLE_DBA_TAB_PRIVS  = "SYS.DBA_TAB_PRIVS"
+
+	SQL_ORACLE_CREATE_USER     = "CREATE USER %s IDENTIFIED BY %s"
+	SQL_ORACLE_DROP_USER       = "DROP USER %s CASCADE"
+	SQL_ORACLE_ALTER_USER_LOCK = "ALTER USER %s ACCOUNT %s"
+	SQL_ORACLE_ALTER_PASSWORD  = "ALTER USER %s IDENTIFIED BY %s"
+	SQL_ORACLE_CREATE_ROLE     = "CREATE ROLE %s"
+	SQL_ORACLE_DROP_ROLE       = "DROP ROLE %s"
+	SQL_ORACLE_GRANT           = "GRANT %s TO %s"
+	SQL_ORACLE_REVOKE          = "REVOKE %s FROM %s"
+	SQL_ORACLE_GRANT_OBJECT    = "GRANT %s ON %s TO %s"
+	SQL_ORACLE_REVOKE_OBJECT   = "REVOKE %s ON %s FROM %s"
+)
+
+// oracleIdentifier quotes the user name, role name or password with double quotes as a whole,
+// e.g. ab.cd -> "ab.cd". The name should have been normalized by the caller, since
+// a quoted name is case sensitive, and oracle does not allow double quotes in it.
+func oracleIdentifier(name string) string {
+	return fmt.Sprintf("\"%s\"", name)
+}
+
+// oracleObjectName quotes each part of the normalized object name, e.g. SCOTT.EMP -> "SCOTT"."EMP".
+func oracleObjectName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = oracleIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func oracleLiteral(str string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(str, "'", "''"))
+}
+
+func oracleIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, oracleIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (t *TenantService) IsOracleUserExist(db *gorm.DB, userName string) (bool, error) {
+	var count int64
+	err := db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE USERNAME = %s", ORACLE_DBA_USERS, oracleLiteral(userName))).Scan(&count).Error
+	return count > 0, err
+}
+
+func (t *TenantService) CreateOracleUser(db *gorm.DB, userName, password string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_CREATE_USER, oracleIdentifier(userName), oracleIdentifier(password))).Error
+}
+
+func (t *TenantService) DropOracleUser(db *gorm.DB, userName string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_DROP_USER, oracleIdentifier(userName))).Error
+}
+
+func (t *TenantService) LockOracleUser(db *gorm.DB, userName string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_ALTER_USER_LOCK, oracleIdentifier(userName), "LOCK")).Error
+}
+
+func (t *TenantService) UnlockOracleUser(db *gorm.DB, userName string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_ALTER_USER_LOCK, oracleIdentifier(userName), "UNLOCK")).Error
+}
+
+func (t *TenantService) ChangeOracleUserPassword(db *gorm.DB, userName, password string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_ALTER_PASSWORD, oracleIdentifier(userName), oracleIdentifier(password))).Error
+}
+
+func (t *TenantService) ListOracleUsers(db *gorm.DB) ([]oceanbase.OracleDbaUser, error) {
+	users := make([]oceanbase.OracleDbaUser, 0)
+	err := db.Raw(fmt.Sprintf("SELECT USERNAME, ACCOUNT_STATUS, CREATED FROM %s", ORACLE_DBA_USERS)).Scan(&users).Error
+	return users, err
+}
+
+func (t *TenantService) GetOracleUser(db *gorm.DB, userName string) (*oceanbase.OracleDbaUser, error) {
+	users := make([]oceanbase.OracleDbaUser, 0)
+	err := db.Raw(fmt.Sprintf("SELECT USERNAME, ACCOUNT_STATUS, CREATED FROM %s WHERE USERNAME = %s", ORACLE_DBA_USERS, oracleLiteral(userName))).Scan(&users).Error
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return &users[0], nil
+}
+
+func (t *TenantService) IsOracleRoleExist(db *gorm.DB, roleName string) (bool, error) {
+	var count int64
+	err := db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE ROLE = %s", ORACLE_DBA_ROLES, oracleLiteral(roleName))).Scan(&count).Error
+	return count > 0, err
+}
+
+func (t *TenantService) ListOracleRoles(db *gorm.DB) ([]oceanbase.OracleDbaRole, error) {
+	roles := make([]oceanbase.OracleDbaRole, 0)
+	err := db.Raw(fmt.Sprintf("SELECT ROLE FROM %s", ORACLE_DBA_ROLES)).Scan(&roles).Error
+	return roles, err
+}
+
+func (t *TenantService) CreateOracleRole(db *gorm.DB, roleName string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_CREATE_ROLE, oracleIdentifier(roleName))).Error
+}
+
+func (t *TenantService) DropOracleRole(db *gorm.DB, roleName string) error {
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_DROP_ROLE, oracleIdentifier(roleName))).Error
+}
+
+// ListOracleSysPrivileges lists the system privileges, all grantees will be returned if grantee is empty.
+func (t *TenantService) ListOracleSysPrivileges(db *gorm.DB, grantee string) ([]oceanbase.OracleDbaSysPriv, error) {
+	privileges := make([]oceanbase.OracleDbaSysPriv, 0)
+	sql := fmt.Sprintf("SELECT GRANTEE, PRIVILEGE, ADMIN_OPTION FROM %s", ORACLE_DBA_SYS_PRIVS)
+	if grantee != "" {
+		sql += fmt.Sprintf(" WHERE GRANTEE = %s", oracleLiteral(grantee))
+	}
+	err := db.Raw(sql).Scan(&privileges).Error
+	return privileges, err
+}
+
+// ListOracleRolePrivileges lists the granted roles, all grantees will be returned if grantee is empty.
+func (t *TenantService) ListOracleRolePrivileges(db *gorm.DB, grantee string) ([]oceanbase.OracleDbaRolePriv, error) {
+	privileges := make([]oceanbase.OracleDbaRolePriv, 0)
+	sql := fmt.Sprintf("SELECT GRANTEE, GRANTED_ROLE, ADMIN_OPTION FROM %s", ORACLE_DBA_ROLE_PRIVS)
+	if grantee != "" {
+		sql += fmt.Sprintf(" WHERE GRANTEE = %s", oracleLiteral(grantee))
+	}
+	err := db.Raw(sql).Scan(&privileges).Error
+	return privileges, err
+}
+
+// ListOracleObjectPrivileges lists the object privileges, all grantees will be returned if grantee is empty.
+func (t *TenantService) ListOracleObjectPrivileges(db *gorm.DB, grantee string) ([]oceanbase.OracleDbaTabPriv, error) {
+	privileges := make([]oceanbase.OracleDbaTabPriv, 0)
+	sql := fmt.Sprintf("SELECT GRANTEE, OWNER, TABLE_NAME, PRIVILEGE FROM %s", ORACLE_DBA_TAB_PRIVS)
+	if grantee != "" {
+		sql += fmt.Sprintf(" WHERE GRANTEE = %s", oracleLiteral(grantee))
+	}
+	err := db.Raw(sql).Scan(&privileges).Error
+	return privileges, err
+}
+
+// GrantOracleSysPrivileges grants system privileges or roles to the grantee.
+func (t *TenantService) GrantOracleSysPrivileges(db *gorm.DB, grantee string, privileges []string) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_GRANT, strings.Join(convertPrivileges(privileges), ", "), oracleIdentifier(grantee))).Error
+}
+
+// RevokeOracleSysPrivileges revokes system privileges or roles from the grantee.
+func (t *TenantService) RevokeOracleSysPrivileges(db *gorm.DB, grantee string, privileges []string) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_REVOKE, strings.Join(convertPrivileges(privileges), ", "), oracleIdentifier(grantee))).Error
+}
+
+func (t *TenantService) GrantOracleRoles(db *gorm.DB, grantee string, roles []string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_GRANT, oracleIdentifiers(roles), oracleIdentifier(grantee))).Error
+}
+
+func (t *TenantService) RevokeOracleRoles(db *gorm.DB, grantee string, roles []string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_REVOKE, oracleIdentifiers(roles), oracleIdentifier(grantee))).Error
+}
+
+func (t *TenantService) GrantOracleObjectPrivileges(db *gorm.DB, grantee string, object string, privileges []string) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_GRANT_OBJECT, strings.Join(privileges, ", "), oracleObjectName(object), oracleIdentifier(grantee))).Error
+}
+
+func (t *TenantService) RevokeOracleObjectPrivileges(db *gorm.DB, grantee string, object string, privileges []string) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(SQL_ORACLE_REVOKE_OBJECT, strings.Join(privileges, ", "), oracleObjectName(object), oracleIdentifier(grantee))).Error
+}
@@ -60,6 +60,16 @@ func (t *TenantService) GetTenantJobStatus(jobId int) (status string, err error)
 	return
 }
 
+// CountInProgressUnitJobs returns the number of the unit jobs of the type in progress.
+func (t *TenantService) CountInProgressUnitJobs(jobType string) (count int64, err error) {
+	db, err := oceanbase.GetInstance()
+	if err != nil {
+		return 0, err
+	}
+	err = db.Table(DBA_OB_UNIT_JOBS).Where("JOB_TYPE = ? AND JOB_STATUS = 'INPROGRESS'", jobType).Count(&count).Error
+	return
+}
+
 func (t *TenantService) GetTargetTenantJob(jobType string, tenantId int, sqlText string) (id int, err error) {
 	db, err := oceanbase.GetInstance()
 	if err != nil {
@@ -80,7 +90,7 @@ func (t *TenantService) GetInProgressTenantJobBo(jobType string, tenantId int) (
 	}
 	var job *model.DbaObTenantJob
 	if err = db.Table(DBA_OB_TENANT_JOBS).
-		Select("JOB_ID").
+		Select("JOB_ID, JOB_TYPE, JOB_STATUS, TENANT_ID, EXTRA_INFO").
 		Where("JOB_TYPE = ? AND JOB_STATUS = 'INPROGRESS' AND TENANT_ID = (?)", jobType, tenantId).
 		Scan(&job).Error; err != nil {
 		return nil, err
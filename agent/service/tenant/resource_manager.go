@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	DBA_RSRC_PLANS           = "DBA_RSRC_PLANS"
+	DBA_RSRC_PLAN_DIRECTIVES = "DBA_RSRC_PLAN_DIRECTIVES"
+	DBA_RSRC_CONSUMER_GROUPS = "DBA_RSRC_CONSUMER_GROUPS"
+	DBA_RSRC_GROUP_MAPPINGS  = "DBA_RSRC_GROUP_MAPPINGS"
+	GV_OB_GROUP_IO_STAT      = "GV$OB_GROUP_IO_STAT"
+
+	VARIABLE_RESOURCE_MANAGER_PLAN = "resource_manager_plan"
+)
+
+const (
+	SQL_CREATE_RESOURCE_PLAN   = "DBMS_RESOURCE_MANAGER.CREATE_PLAN(PLAN => %s, COMMENT => %s)"
+	SQL_DELETE_RESOURCE_PLAN   = "DBMS_RESOURCE_MANAGER.DELETE_PLAN(PLAN => %s)"
+	SQL_CREATE_CONSUMER_GROUP  = "DBMS_RESOURCE_MANAGER.CREATE_CONSUMER_GROUP(CONSUMER_GROUP => %s, COMMENT => %s)"
+	SQL_DELETE_CONSUMER_GROUP  = "DBMS_RESOURCE_MANAGER.DELETE_CONSUMER_GROUP(CONSUMER_GROUP => %s)"
+	SQL_CREATE_PLAN_DIRECTIVE  = "DBMS_RESOURCE_MANAGER.CREATE_PLAN_DIRECTIVE(PLAN => %s, GROUP_OR_SUBPLAN => %s, COMMENT => %s%s)"
+	SQL_UPDATE_PLAN_DIRECTIVE  = "DBMS_RESOURCE_MANAGER.UPDATE_PLAN_DIRECTIVE(PLAN => %s, GROUP_OR_SUBPLAN => %s%s)"
+	SQL_DELETE_PLAN_DIRECTIVE  = "DBMS_RESOURCE_MANAGER.DELETE_PLAN_DIRECTIVE(PLAN => %s, GROUP_OR_SUBPLAN => %s)"
+	SQL_SET_CONSUMER_GROUP_MAP = "DBMS_RESOURCE_MANAGER.SET_CONSUMER_GROUP_MAPPING(ATTRIBUTE => %s, VALUE => %s, CONSUMER_GROUP => %s)"
+
+	SQL_SET_RESOURCE_MANAGER_PLAN        = "SET GLOBAL resource_manager_plan = %s"
+	SQL_ORACLE_SET_RESOURCE_MANAGER_PLAN = "ALTER SYSTEM SET RESOURCE_MANAGER_PLAN = %s"
+)
+
+// ResourceManagerConn is the connection to the tenant whose resource manager is managed.
+// The procedures of DBMS_RESOURCE_MANAGER are called and the views are queried
+// in the syntax of the tenant mode, gorm is not used to build the queries
+// because it quotes identifiers with backticks which are illegal in oracle mode.
+type ResourceManagerConn struct {
+	*gorm.DB
+	Oracle bool
+}
+
+func (c *ResourceManagerConn) literal(str string) string {
+	if c.Oracle {
+		return oracleLiteral(str)
+	}
+	return fmt.Sprintf("\"%s\"", transfer(str))
+}
+
+func (c *ResourceManagerConn) view(name string) string {
+	if c.Oracle {
+		return "SYS." + name
+	}
+	return "oceanbase." + name
+}
+
+// call calls the procedure, which is a statement in mysql mode and a pl block in oracle mode.
+func (c *ResourceManagerConn) call(procedure string) error {
+	if c.Oracle {
+		return c.Exec(fmt.Sprintf("BEGIN %s; END;", procedure)).Error
+	}
+	return c.Exec("CALL " + procedure).Error
+}
+
+func (c *ResourceManagerConn) selectFrom(view string, where string) string {
+	sql := fmt.Sprintf("SELECT * FROM %s", c.view(view))
+	if where != "" {
+		sql += " WHERE " + where
+	}
+	return sql
+}
+
+func (t *TenantService) CreateResourcePlan(conn *ResourceManagerConn, planName string, comment string) error {
+	return conn.call(fmt.Sprintf(SQL_CREATE_RESOURCE_PLAN, conn.literal(planName), conn.literal(comment)))
+}
+
+func (t *TenantService) DeleteResourcePlan(conn *ResourceManagerConn, planName string) error {
+	return conn.call(fmt.Sprintf(SQL_DELETE_RESOURCE_PLAN, conn.literal(planName)))
+}
+
+func (t *TenantService) GetResourcePlan(conn *ResourceManagerConn, planName string) (plan *oceanbase.DbaRsrcPlan, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_PLANS, "PLAN = "+conn.literal(planName))).Scan(&plan).Error
+	return
+}
+
+func (t *TenantService) ListResourcePlans(conn *ResourceManagerConn) (plans []oceanbase.DbaRsrcPlan, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_PLANS, "")).Scan(&plans).Error
+	return
+}
+
+func (t *TenantService) CreateConsumerGroup(conn *ResourceManagerConn, groupName string, comment string) error {
+	return conn.call(fmt.Sprintf(SQL_CREATE_CONSUMER_GROUP, conn.literal(groupName), conn.literal(comment)))
+}
+
+func (t *TenantService) DeleteConsumerGroup(conn *ResourceManagerConn, groupName string) error {
+	return conn.call(fmt.Sprintf(SQL_DELETE_CONSUMER_GROUP, conn.literal(groupName)))
+}
+
+func (t *TenantService) GetConsumerGroup(conn *ResourceManagerConn, groupName string) (group *oceanbase.DbaRsrcConsumerGroup, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_CONSUMER_GROUPS, "CONSUMER_GROUP = "+conn.literal(groupName))).Scan(&group).Error
+	return
+}
+
+func (t *TenantService) ListConsumerGroups(conn *ResourceManagerConn) (groups []oceanbase.DbaRsrcConsumerGroup, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_CONSUMER_GROUPS, "")).Scan(&groups).Error
+	return
+}
+
+func buildPlanDirectiveOptions(prefix string, mgmtP1, utilizationLimit, minIops, maxIops, weightIops *int) string {
+	options := make([]string, 0)
+	if mgmtP1 != nil {
+		options = append(options, fmt.Sprintf("%sMGMT_P1 => %d", prefix, *mgmtP1))
+	}
+	if utilizationLimit != nil {
+		options = append(options, fmt.Sprintf("%sUTILIZATION_LIMIT => %d", prefix, *utilizationLimit))
+	}
+	if minIops != nil {
+		options = append(options, fmt.Sprintf("%sMIN_IOPS => %d", prefix, *minIops))
+	}
+	if maxIops != nil {
+		options = append(options, fmt.Sprintf("%sMAX_IOPS => %d", prefix, *maxIops))
+	}
+	if weightIops != nil {
+		options = append(options, fmt.Sprintf("%sWEIGHT_IOPS => %d", prefix, *weightIops))
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(options, ", ")
+}
+
+func (t *TenantService) CreatePlanDirective(conn *ResourceManagerConn, planName string, p *param.CreatePlanDirectiveParam) error {
+	options := buildPlanDirectiveOptions("", p.MgmtP1, p.UtilizationLimit, p.MinIops, p.MaxIops, p.WeightIops)
+	return conn.call(fmt.Sprintf(SQL_CREATE_PLAN_DIRECTIVE, conn.literal(planName), conn.literal(p.GroupName), conn.literal(p.Comment), options))
+}
+
+func (t *TenantService) UpdatePlanDirective(conn *ResourceManagerConn, planName string, groupName string, p *param.ModifyPlanDirectiveParam) error {
+	options := buildPlanDirectiveOptions("NEW_", p.MgmtP1, p.UtilizationLimit, p.MinIops, p.MaxIops, p.WeightIops)
+	if p.Comment != nil {
+		options += fmt.Sprintf(", NEW_COMMENT => %s", conn.literal(*p.Comment))
+	}
+	if options == "" {
+		return nil
+	}
+	return conn.call(fmt.Sprintf(SQL_UPDATE_PLAN_DIRECTIVE, conn.literal(planName), conn.literal(groupName), options))
+}
+
+func (t *TenantService) DeletePlanDirective(conn *ResourceManagerConn, planName string, groupName string) error {
+	return conn.call(fmt.Sprintf(SQL_DELETE_PLAN_DIRECTIVE, conn.literal(planName), conn.literal(groupName)))
+}
+
+func (t *TenantService) GetPlanDirective(conn *ResourceManagerConn, planName string, groupName string) (directive *oceanbase.DbaRsrcPlanDirective, err error) {
+	where := fmt.Sprintf("PLAN = %s AND GROUP_OR_SUBPLAN = %s", conn.literal(planName), conn.literal(groupName))
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_PLAN_DIRECTIVES, where)).Scan(&directive).Error
+	return
+}
+
+func (t *TenantService) ListPlanDirectives(conn *ResourceManagerConn) (directives []oceanbase.DbaRsrcPlanDirective, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_PLAN_DIRECTIVES, "")).Scan(&directives).Error
+	return
+}
+
+func (t *TenantService) ListPlanDirectivesOfPlan(conn *ResourceManagerConn, planName string) (directives []oceanbase.DbaRsrcPlanDirective, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_PLAN_DIRECTIVES, "PLAN = "+conn.literal(planName))).Scan(&directives).Error
+	return
+}
+
+// SetConsumerGroupMapping maps the user or column to the consumer group,
+// the mapping will be removed if the consumer group is empty.
+func (t *TenantService) SetConsumerGroupMapping(conn *ResourceManagerConn, attribute string, value string, consumerGroup string) error {
+	group := "NULL"
+	if consumerGroup != "" {
+		group = conn.literal(consumerGroup)
+	}
+	return conn.call(fmt.Sprintf(SQL_SET_CONSUMER_GROUP_MAP, conn.literal(attribute), conn.literal(value), group))
+}
+
+func (t *TenantService) ListConsumerGroupMappings(conn *ResourceManagerConn) (mappings []oceanbase.DbaRsrcGroupMapping, err error) {
+	err = conn.Raw(conn.selectFrom(DBA_RSRC_GROUP_MAPPINGS, "")).Scan(&mappings).Error
+	return
+}
+
+func (t *TenantService) GetActiveResourcePlan(conn *ResourceManagerConn) (plan string, err error) {
+	var variable oceanbase.ObSysVariableWithValue
+	err = conn.Raw(fmt.Sprintf("SHOW GLOBAL VARIABLES LIKE '%s'", VARIABLE_RESOURCE_MANAGER_PLAN)).Scan(&variable).Error
+	return variable.Value, err
+}
+
+func (t *TenantService) SetActiveResourcePlan(conn *ResourceManagerConn, planName string) error {
+	if conn.Oracle {
+		return conn.Exec(fmt.Sprintf(SQL_ORACLE_SET_RESOURCE_MANAGER_PLAN, conn.literal(planName))).Error
+	}
+	return conn.Exec(fmt.Sprintf(SQL_SET_RESOURCE_MANAGER_PLAN, conn.literal(planName))).Error
+}
+
+// ListConsumerGroupIoStats selects all the columns, MODE is a reserved word in oracle mode.
+func (t *TenantService) ListConsumerGroupIoStats(conn *ResourceManagerConn) (stats []oceanbase.GvObGroupIoStat, err error) {
+	err = conn.Raw(conn.selectFrom(GV_OB_GROUP_IO_STAT, "")).Scan(&stats).Error
+	return
+}
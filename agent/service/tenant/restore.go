@@ -59,6 +59,16 @@ func (s *TenantService) GetLastRestoreTask(tenantName string) (*bo.CdbObRestoreH
 	return res.ToBO(), nil
 }
 
+// ListFinishedRestoreHistory returns the latest restore jobs finished with the status.
+func (s *TenantService) ListFinishedRestoreHistory(status string, limit int) (res []oceanbase.CdbObRestoreHistory, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_RESTORE_HISTORY).Where("TENANT_ID = 1 AND STATUS = ?", status).Order("START_TIMESTAMP desc").Limit(limit).Scan(&res).Error
+	return
+}
+
 func (s *TenantService) CancelRestore(tenantName string) (err error) {
 	oceanbaseDb, err := oceanbasedb.GetInstance()
 	if err != nil {
@@ -74,20 +84,7 @@ func (s *TenantService) Restore(c *param.RestoreParam, locality, poolList string
 		return
 	}
 
-	var sql string
-	if c.Decryption != nil && len(*c.Decryption) > 0 {
-		length := len(*c.Decryption)
-		for i := 0; i < length; i++ {
-			(*c.Decryption)[i] = strings.ReplaceAll((*c.Decryption)[i], "\"", "\\\"")
-			(*c.Decryption)[i] = fmt.Sprintf("'%s'", (*c.Decryption)[i])
-		}
-		sql = fmt.Sprintf("SET DECRYPTION IDENTIFIED BY %s;", strings.Join(*c.Decryption, ","))
-	}
-
-	if c.KmsEncryptInfo != nil {
-		sql = fmt.Sprintf("%s SET @kms_encrypt_info =\"%s\";", sql, *c.KmsEncryptInfo)
-	}
-
+	sql := buildDecryptionSql(c.Decryption, c.KmsEncryptInfo)
 	restoreSql := fmt.Sprintf("ALTER SYSTEM RESTORE %s FROM \"%s, %s\"", c.TenantName, c.DataBackupUri, *c.ArchiveLogUri)
 	if c.Timestamp != nil {
 		restoreSql = fmt.Sprintf("%s UNTIL TIME= \"%s\"", restoreSql, c.Timestamp.Format("2006-01-02 15:04:05.00"))
@@ -112,6 +109,140 @@ func (s *TenantService) Restore(c *param.RestoreParam, locality, poolList string
 	return oceanbaseDb.Exec(sql).Error
 }
 
+// buildDecryptionSql returns the statements to set the backup passwords and the kms info before restoring.
+func buildDecryptionSql(decryption *[]string, kmsEncryptInfo *string) (sql string) {
+	if decryption != nil && len(*decryption) > 0 {
+		passwords := make([]string, 0, len(*decryption))
+		for _, password := range *decryption {
+			passwords = append(passwords, fmt.Sprintf("'%s'", strings.ReplaceAll(password, "\"", "\\\"")))
+		}
+		sql = fmt.Sprintf("SET DECRYPTION IDENTIFIED BY %s;", strings.Join(passwords, ","))
+	}
+
+	if kmsEncryptInfo != nil {
+		sql = fmt.Sprintf("%s SET @kms_encrypt_info =\"%s\";", sql, *kmsEncryptInfo)
+	}
+	return
+}
+
+func (s *TenantService) RecoverTable(c *param.RecoverTableParam, poolList string) (err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+
+	recoverList := make([]string, 0, len(c.Tables)+len(c.Databases))
+	for _, table := range c.Tables {
+		recoverList = append(recoverList, quoteRecoverTableName(table))
+	}
+	for _, database := range c.Databases {
+		recoverList = append(recoverList, quoteRecoverTableName(database)+".*")
+	}
+	recoverSql := fmt.Sprintf("ALTER SYSTEM RECOVER TABLE %s TO TENANT `%s`", strings.Join(recoverList, ","), c.TargetTenantName)
+
+	remapList := make([]string, 0)
+	for source, target := range c.RemapTables {
+		remapList = append(remapList, fmt.Sprintf("%s:%s", quoteRecoverTableName(source), quoteRecoverTableName(target)))
+	}
+	for source, target := range c.RemapDatabases {
+		remapList = append(remapList, fmt.Sprintf("%s:%s", quoteRecoverTableName(source), quoteRecoverTableName(target)))
+	}
+	if len(remapList) > 0 {
+		recoverSql = fmt.Sprintf("%s REMAP TABLE %s", recoverSql, strings.Join(remapList, ","))
+	}
+
+	recoverSql = fmt.Sprintf("%s FROM \"%s, %s\"", recoverSql, c.DataBackupUri, *c.ArchiveLogUri)
+	if c.Timestamp != nil {
+		recoverSql = fmt.Sprintf("%s UNTIL TIME= \"%s\"", recoverSql, c.Timestamp.Format("2006-01-02 15:04:05.00"))
+	}
+	if c.SCN != nil {
+		recoverSql = fmt.Sprintf("%s UNTIL SCN=%d", recoverSql, *c.SCN)
+	}
+
+	recoverOption := fmt.Sprintf("pool_list=%s", poolList)
+	if c.PrimaryZone != nil {
+		recoverOption = fmt.Sprintf("%s&primary_zone=%s", recoverOption, *c.PrimaryZone)
+	}
+	if c.Concurrency != nil {
+		recoverOption = fmt.Sprintf("%s&concurrency=%d", recoverOption, *c.Concurrency)
+	}
+	recoverSql = fmt.Sprintf("%s WITH '%s';", recoverSql, recoverOption)
+
+	sql := fmt.Sprintf("%s %s", buildDecryptionSql(c.Decryption, c.KmsEncryptInfo), recoverSql)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) CancelRecoverTable(targetTenantName string) (err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM CANCEL RECOVER TABLE `%s`;", targetTenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+// GetRunningRecoverTableJob returns the table restore job initiated by sys tenant into the target tenant.
+func (s *TenantService) GetRunningRecoverTableJob(targetTenantName string) (*oceanbase.CdbObRecoverTableJob, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	res := &oceanbase.CdbObRecoverTableJob{}
+	err = oceanbaseDb.Table(CDB_OB_RECOVER_TABLE_JOBS).Where("TARGET_TENANT_NAME = ? AND TENANT_ID = 1", targetTenantName).Scan(res).Error
+	if err != nil {
+		return nil, err
+	}
+	if res.TargetTenantName == "" {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// GetLastRecoverTableJobHistory returns the latest finished table restore job into the target tenant.
+func (s *TenantService) GetLastRecoverTableJobHistory(targetTenantName string) (*oceanbase.CdbObRecoverTableJob, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	res := &oceanbase.CdbObRecoverTableJob{}
+	err = oceanbaseDb.Table(CDB_OB_RECOVER_TABLE_JOB_HISTORY).Where("TARGET_TENANT_NAME = ? AND TENANT_ID = 1", targetTenantName).Order("JOB_ID desc").Limit(1).Scan(res).Error
+	if err != nil {
+		return nil, err
+	}
+	if res.TargetTenantName == "" {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func (s *TenantService) GetRecoverTableJobHistory(jobID int64) (*oceanbase.CdbObRecoverTableJob, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	res := &oceanbase.CdbObRecoverTableJob{}
+	err = oceanbaseDb.Table(CDB_OB_RECOVER_TABLE_JOB_HISTORY).Where("JOB_ID = ? AND TENANT_ID = 1", jobID).Scan(res).Error
+	if err != nil {
+		return nil, err
+	}
+	if res.TargetTenantName == "" {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func (s *TenantService) IsTableExist(tenantId int, databaseName, tableName string) (bool, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	err = oceanbaseDb.Table(CDB_OB_TABLE_LOCATIONS).
+		Where("TENANT_ID = ? AND DATABASE_NAME = ? AND TABLE_NAME = ? AND TABLE_TYPE = ?", tenantId, databaseName, tableName, TABLE_TYPE_USER_TABLE).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (s *TenantService) GetTenantLevelDagIDByTenantName(name string) (id *int64, err error) {
 	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
 	if err != nil {
@@ -120,3 +251,47 @@ func (s *TenantService) GetTenantLevelDagIDByTenantName(name string) (id *int64,
 	err = oceanbaseDb.Model(&oceanbase.PartialMaintenance{}).Select("dag_id").Where("lock_name = ? and lock_type = ?", name, task.TENANT_MAINTENANCE).Scan(&id).Error
 	return
 }
+
+func (s *TenantService) CreateRestoreDrillReport(report *oceanbase.RestoreDrillReport) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Create(report).Error
+}
+
+func (s *TenantService) UpdateRestoreDrillReport(id int64, values map[string]interface{}) error {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Model(&oceanbase.RestoreDrillReport{}).Where("id = ?", id).Updates(values).Error
+}
+
+func (s *TenantService) GetRestoreDrillReport(id int64) (report *oceanbase.RestoreDrillReport, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.RestoreDrillReport{}).Where("id = ?", id).Scan(&report).Error
+	return
+}
+
+func (s *TenantService) ListRestoreDrillReports(limit int) (reports []oceanbase.RestoreDrillReport, err error) {
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.RestoreDrillReport{}).Order("id desc").Limit(limit).Scan(&reports).Error
+	return
+}
+
+// quoteRecoverTableName quotes every part of the '[database.]table' name with backticks,
+// the backticks inside the name are escaped by doubling them.
+func quoteRecoverTableName(name string) string {
+	parts := strings.Split(name, ".")
+	for i := range parts {
+		parts[i] = mysqlIdentifier(parts[i])
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	oceanbasedb "github.com/oceanbase/obshell/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+)
+
+const (
+	TABLE_TYPE_USER_TABLE = "USER TABLE"
+	TABLE_TYPE_INDEX      = "INDEX"
+
+	SQL_SHOW_CREATE_TABLE    = "SHOW CREATE TABLE %s.%s"
+	SQL_ORACLE_GET_TABLE_DDL = "SELECT DBMS_METADATA.GET_DDL('TABLE', %s, %s) FROM DUAL"
+)
+
+const tableLocationColumns = "l.DATABASE_NAME, l.TABLE_NAME, l.TABLE_ID, l.TABLE_TYPE, " +
+	"IFNULL(l.PARTITION_NAME, '') AS PARTITION_NAME, IFNULL(l.SUBPARTITION_NAME, '') AS SUBPARTITION_NAME, " +
+	"IFNULL(l.INDEX_NAME, '') AS INDEX_NAME, IFNULL(l.DATA_TABLE_ID, 0) AS DATA_TABLE_ID, l.OBJECT_ID, " +
+	"l.TABLET_ID, l.LS_ID, l.ZONE, l.SVR_IP, l.SVR_PORT, l.ROLE, l.REPLICA_TYPE, " +
+	"IFNULL(r.DATA_SIZE, 0) AS DATA_SIZE, IFNULL(r.REQUIRED_SIZE, 0) AS REQUIRED_SIZE"
+
+const tabletReplicaJoin = "LEFT JOIN " + CDB_OB_TABLET_REPLICAS + " r ON l.TENANT_ID = r.TENANT_ID AND l.TABLET_ID = r.TABLET_ID AND l.SVR_IP = r.SVR_IP AND l.SVR_PORT = r.SVR_PORT"
+
+func tableLocationQuery(db *gorm.DB, tenantId int) *gorm.DB {
+	return db.Table(CDB_OB_TABLE_LOCATIONS+" l").
+		Select(tableLocationColumns).
+		Joins(tabletReplicaJoin).
+		Where("l.TENANT_ID = ?", tenantId).
+		Order("l.TABLE_ID, l.TABLET_ID, l.ZONE")
+}
+
+// ListTableLocations returns the replicas of the user tables in the database,
+// all the tables will be returned if tableName is empty.
+func (t *TenantService) ListTableLocations(tenantId int, databaseName, tableName string) (locations []oceanbase.ObTableLocation, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := tableLocationQuery(db, tenantId).Where("l.TABLE_TYPE = ? AND l.DATABASE_NAME = ?", TABLE_TYPE_USER_TABLE, databaseName)
+	if tableName != "" {
+		query = query.Where("l.TABLE_NAME = ?", tableName)
+	}
+	err = query.Scan(&locations).Error
+	return
+}
+
+func (t *TenantService) ListIndexLocations(tenantId int, dataTableId int64) (locations []oceanbase.ObTableLocation, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = tableLocationQuery(db, tenantId).Where("l.TABLE_TYPE = ? AND l.DATA_TABLE_ID = ?", TABLE_TYPE_INDEX, dataTableId).Scan(&locations).Error
+	return
+}
+
+// filterTabletLocations filters the replicas of the user tables and indexes
+// by the server and the database if they are specified.
+func filterTabletLocations(query *gorm.DB, svrIp string, svrPort int, databaseName string) *gorm.DB {
+	query = query.Where("l.TABLE_TYPE IN (?)", []string{TABLE_TYPE_USER_TABLE, TABLE_TYPE_INDEX})
+	if svrIp != "" {
+		query = query.Where("l.SVR_IP = ?", svrIp)
+	}
+	if svrPort != 0 {
+		query = query.Where("l.SVR_PORT = ?", svrPort)
+	}
+	if databaseName != "" {
+		query = query.Where("l.DATABASE_NAME = ?", databaseName)
+	}
+	return query
+}
+
+// ListTabletLocations returns the replicas of the user tables and indexes,
+// filtered by the server and the database if they are specified.
+// All the replicas will be returned if limit is not positive.
+func (t *TenantService) ListTabletLocations(tenantId int, svrIp string, svrPort int, databaseName string, limit, offset int) (locations []oceanbase.ObTableLocation, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := filterTabletLocations(tableLocationQuery(db, tenantId), svrIp, svrPort, databaseName).Order("l.SVR_IP, l.SVR_PORT")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	err = query.Scan(&locations).Error
+	return
+}
+
+// ListServerTabletStats returns the count and the size of the replicas on each server,
+// filtered the same as ListTabletLocations.
+func (t *TenantService) ListServerTabletStats(tenantId int, svrIp string, svrPort int, databaseName string) (stats []oceanbase.ObServerTabletStat, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := db.Table(CDB_OB_TABLE_LOCATIONS+" l").
+		Select("l.ZONE, l.SVR_IP, l.SVR_PORT, COUNT(*) AS TABLET_COUNT, "+
+			"SUM(CASE WHEN l.ROLE = 'LEADER' THEN 1 ELSE 0 END) AS LEADER_COUNT, IFNULL(SUM(r.DATA_SIZE), 0) AS DATA_SIZE").
+		Joins(tabletReplicaJoin).
+		Where("l.TENANT_ID = ?", tenantId)
+	err = filterTabletLocations(query, svrIp, svrPort, databaseName).Group("l.ZONE, l.SVR_IP, l.SVR_PORT").Scan(&stats).Error
+	return
+}
+
+// ListTableStatistics returns the row count collected by the optimizer statistics.
+func (t *TenantService) ListTableStatistics(tenantId int, databaseName string) (statistics []oceanbase.ObTableStatistic, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(CDB_TAB_STATISTICS).
+		Select("OWNER AS DATABASE_NAME, TABLE_NAME, IFNULL(NUM_ROWS, 0) AS NUM_ROWS").
+		Where("CON_ID = ? AND OWNER = ? AND OBJECT_TYPE = 'TABLE'", tenantId, databaseName).
+		Scan(&statistics).Error
+	return
+}
+
+func (t *TenantService) GetTableDdl(db *gorm.DB, databaseName, tableName string) (string, error) {
+	var name, ddl string
+	err := db.Raw(fmt.Sprintf(SQL_SHOW_CREATE_TABLE, mysqlIdentifier(databaseName), mysqlIdentifier(tableName))).Row().Scan(&name, &ddl)
+	return ddl, err
+}
+
+func (t *TenantService) GetOracleTableDdl(db *gorm.DB, schemaName, tableName string) (string, error) {
+	var ddl string
+	err := db.Raw(fmt.Sprintf(SQL_ORACLE_GET_TABLE_DDL, oracleLiteral(tableName), oracleLiteral(schemaName))).Row().Scan(&ddl)
+	return ddl, err
+}
+
+// mysqlIdentifier quotes the name with backticks, the backticks inside the name are escaped by doubling them.
+func mysqlIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
@@ -18,6 +18,7 @@ package unit
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/oceanbase/obshell/agent/repository/db/oceanbase"
 	oceanbaseModel "github.com/oceanbase/obshell/agent/repository/model/oceanbase"
@@ -27,11 +28,14 @@ import (
 type UnitService struct{}
 
 const (
-	DBA_OB_UNIT_CONFIGS = "oceanbase.DBA_OB_UNIT_CONFIGS"
+	DBA_OB_UNIT_CONFIGS   = "oceanbase.DBA_OB_UNIT_CONFIGS"
+	DBA_OB_UNITS          = "oceanbase.DBA_OB_UNITS"
+	DBA_OB_RESOURCE_POOLS = "oceanbase.DBA_OB_RESOURCE_POOLS"
 )
 
 const (
 	SQL_CREATE_UNIT_CONFIG = "CREATE RESOURCE UNIT `%s`  MEMORY_SIZE '%s', MAX_CPU %f"
+	SQL_ALTER_UNIT_CONFIG  = "ALTER RESOURCE UNIT `%s` "
 	DROP_UNIT_CONFIG       = "DROP RESOURCE UNIT `%s`"
 )
 
@@ -56,6 +60,37 @@ func (u *UnitService) CreateUnit(param param.CreateResourceUnitConfigParams) err
 	return db.Exec(sql).Error
 }
 
+func (u *UnitService) AlterUnit(name string, param param.ModifyResourceUnitConfigParams) error {
+	db, err := oceanbase.GetInstance()
+	if err != nil {
+		return err
+	}
+	options := make([]string, 0)
+	if param.MaxCpu != nil {
+		options = append(options, fmt.Sprintf("MAX_CPU %f", *param.MaxCpu))
+	}
+	if param.MinCpu != nil {
+		options = append(options, fmt.Sprintf("MIN_CPU %f", *param.MinCpu))
+	}
+	if param.MemorySize != nil {
+		options = append(options, fmt.Sprintf("MEMORY_SIZE '%s'", *param.MemorySize))
+	}
+	if param.LogDiskSize != nil {
+		options = append(options, fmt.Sprintf("LOG_DISK_SIZE '%s'", *param.LogDiskSize))
+	}
+	if param.MaxIops != nil {
+		options = append(options, fmt.Sprintf("MAX_IOPS %d", *param.MaxIops))
+	}
+	if param.MinIops != nil {
+		options = append(options, fmt.Sprintf("MIN_IOPS %d", *param.MinIops))
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	sql := fmt.Sprintf(SQL_ALTER_UNIT_CONFIG, name) + strings.Join(options, ", ")
+	return db.Exec(sql).Error
+}
+
 func (u *UnitService) IsUnitConfigExist(unit_name string) (bool, error) {
 	db, err := oceanbase.GetInstance()
 	if err != nil {
@@ -110,3 +145,22 @@ func (u *UnitService) GetUnitConfigNameById(id int) (name string, err error) {
 	err = db.Table(DBA_OB_UNIT_CONFIGS).Select("NAME").Where("UNIT_CONFIG_ID = ?", id).Scan(&name).Error
 	return
 }
+
+func (u *UnitService) GetResourcePoolsByUnitConfigId(id int) (pools []oceanbaseModel.DbaObResourcePool, err error) {
+	db, err := oceanbase.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(DBA_OB_RESOURCE_POOLS).Where("UNIT_CONFIG_ID = ?", id).Scan(&pools).Error
+	return
+}
+
+func (u *UnitService) GetUnitsByUnitConfigId(id int) (units []oceanbaseModel.DbaObUnit, err error) {
+	db, err := oceanbase.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	poolQuery := db.Table(DBA_OB_RESOURCE_POOLS).Select("RESOURCE_POOL_ID").Where("UNIT_CONFIG_ID = ?", id)
+	err = db.Table(DBA_OB_UNITS).Where("RESOURCE_POOL_ID IN (?)", poolQuery).Scan(&units).Error
+	return
+}
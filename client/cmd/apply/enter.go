@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/global"
+	ocsagentlog "github.com/oceanbase/obshell/agent/log"
+	"github.com/oceanbase/obshell/client/cmd/cluster"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+)
+
+const (
+	FLAG_FILE    = "file"
+	FLAG_FILE_SH = "f"
+	FLAG_DRY_RUN = "dry_run"
+)
+
+type applyFlags struct {
+	file        string
+	dryRun      bool
+	skipConfirm bool
+	verbose     bool
+}
+
+func NewApplyCmd() *cobra.Command {
+	opts := &applyFlags{}
+	applyCmd := command.NewCommand(&cobra.Command{
+		Use:   clientconst.CMD_APPLY,
+		Short: "Converge the cluster to the desired state described by a yaml file.",
+		Long: "Compare the zones, servers, unit configs and tenants described by the yaml file with the live cluster, " +
+			"show the plan and then run the needed tasks in order. Nothing will be removed from the cluster.",
+		PersistentPreRunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			defer stdio.StopLoading()
+			global.InitGlobalVariable()
+			return cluster.CheckAndStartDaemon()
+		}),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			ocsagentlog.SetDBLoggerLevel(ocsagentlog.Silent)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return apply(opts)
+		}),
+		Example: `  obshell apply -f cluster.yaml --dry_run
+  obshell apply -f cluster.yaml`,
+	})
+
+	applyCmd.Flags().SortFlags = false
+	applyCmd.VarsPs(&opts.file, []string{FLAG_FILE_SH, FLAG_FILE}, "", "The yaml file describing the desired state of the cluster.", true)
+	applyCmd.VarsPs(&opts.dryRun, []string{FLAG_DRY_RUN}, false, "Only show the plan without applying it.", false)
+	applyCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	applyCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return applyCmd.Command
+}
+
+func apply(opts *applyFlags) error {
+	spec, err := loadClusterSpec(opts.file)
+	if err != nil {
+		return err
+	}
+	state, err := getLiveState()
+	if err != nil {
+		return err
+	}
+	plan, err := buildApplyPlan(spec, state)
+	if err != nil {
+		return err
+	}
+
+	printPlan(plan)
+	if len(plan.steps) == 0 {
+		stdio.Info("The cluster is already in the desired state.")
+		return nil
+	}
+	if opts.dryRun {
+		return nil
+	}
+
+	pass, err := stdio.Confirmf("Please confirm if you need to apply the %d step(s) above.", len(plan.steps))
+	if err != nil {
+		return errors.Wrap(err, "ask for apply confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+	for i, step := range plan.steps {
+		stdio.Infof("[%d/%d] %s %s", i+1, len(plan.steps), step.action, step.target)
+		if err := step.run(); err != nil {
+			return errors.Wrapf(err, "%s %s failed", step.action, step.target)
+		}
+	}
+	stdio.Successf("Apply %s successfully.", opts.file)
+	return nil
+}
+
+func printPlan(plan *applyPlan) {
+	if len(plan.steps) > 0 {
+		data := make([][]string, 0, len(plan.steps))
+		for i, step := range plan.steps {
+			data = append(data, []string{strconv.Itoa(i + 1), step.action, step.target, step.detail})
+		}
+		stdio.PrintTableWithTitle("Apply Plan", []string{"#", "ACTION", "TARGET", "DETAIL"}, data)
+	}
+	for _, warning := range plan.warnings {
+		stdio.Warn(fmt.Sprintf("%s.", warning))
+	}
+}
@@ -0,0 +1,558 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	ACTION_JOIN            = "join"
+	ACTION_CONFIG          = "config"
+	ACTION_INIT            = "init"
+	ACTION_SCALE_OUT       = "scale-out"
+	ACTION_CREATE_UNIT     = "create-unit"
+	ACTION_MODIFY_UNIT     = "modify-unit"
+	ACTION_CREATE_TENANT   = "create-tenant"
+	ACTION_ADD_REPLICAS    = "add-replicas"
+	ACTION_MODIFY_REPLICAS = "modify-replicas"
+	ACTION_SET_PRIMARYZONE = "set-primary-zone"
+	ACTION_SET_WHITELIST   = "set-whitelist"
+	ACTION_SET_PARAMETERS  = "set-parameters"
+	ACTION_SET_VARIABLES   = "set-variables"
+)
+
+// applyStep is a single operation to converge the cluster to the desired state.
+type applyStep struct {
+	action string
+	target string
+	detail string
+	run    func() error
+}
+
+// applyPlan is the ordered steps to apply and the differences which will not be reconciled.
+type applyPlan struct {
+	steps    []*applyStep
+	warnings []string
+}
+
+func (p *applyPlan) addStep(action, target, detail string, run func() error) {
+	p.steps = append(p.steps, &applyStep{action: action, target: target, detail: detail, run: run})
+}
+
+func (p *applyPlan) addWarning(format string, a ...any) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, a...))
+}
+
+// buildApplyPlan compares the desired state with the live state,
+// the topology is converged first, then the unit configs and the tenants.
+func buildApplyPlan(spec *ClusterSpec, state *liveState) (*applyPlan, error) {
+	plan := &applyPlan{}
+	for _, zone := range spec.Zones {
+		for _, server := range zone.Servers {
+			if liveZone, ok := state.agents[server.agentInfo.String()]; ok && liveZone != zone.Name {
+				return nil, errors.Occurf(errors.ErrCliApplyConflict, "server %s is in zone %s rather than %s", server.agentInfo.String(), liveZone, zone.Name)
+			}
+		}
+	}
+
+	var err error
+	if state.isInitialized() {
+		err = planScaleOut(spec, state, plan)
+	} else {
+		err = planDeploy(spec, state, plan)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := planUnitConfigs(spec, state, plan); err != nil {
+		return nil, err
+	}
+	if err := planTenants(spec, state, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planDeploy joins all the servers to the local agent, then sets the configs and initializes the cluster.
+func planDeploy(spec *ClusterSpec, state *liveState, plan *applyPlan) error {
+	identity := state.myAgent.GetIdentity()
+	if identity != meta.SINGLE && identity != meta.MASTER {
+		return errors.Occurf(errors.ErrCliApplyConflict, "the cluster is not initialized, please apply on the master agent rather than %s", identity)
+	}
+	if spec.Cluster.RootPassword == "" {
+		return errors.Occur(errors.ErrCliUsageError, "cluster.root_password is required to initialize the cluster")
+	}
+	myAgent := state.myAgent.AgentInfo
+	mySpec, _ := spec.findServer(&myAgent)
+	if mySpec == nil {
+		return errors.Occurf(errors.ErrCliApplyConflict, "local agent %s is not specified in zones", myAgent.String())
+	}
+
+	if identity == meta.SINGLE {
+		zone := spec.zoneOf(&myAgent)
+		plan.addStep(ACTION_JOIN, myAgent.String(), fmt.Sprintf("zone %s, as master", zone), func() error {
+			return callApiAndWaitDag(http.POST, constant.URI_AGENT_API_PREFIX+constant.URI_JOIN, &param.JoinApiParam{
+				AgentInfo: myAgent,
+				ZoneName:  zone,
+			})
+		})
+	}
+	for _, zone := range spec.Zones {
+		for _, server := range zone.Servers {
+			if server.agentInfo.Equal(&myAgent) {
+				continue
+			}
+			if _, ok := state.agents[server.agentInfo.String()]; ok {
+				continue
+			}
+			target := *server.agentInfo
+			agentPassword := server.AgentPassword
+			joinParam := &param.JoinApiParam{
+				AgentInfo:      myAgent,
+				ZoneName:       zone.Name,
+				MasterPassword: mySpec.AgentPassword,
+			}
+			plan.addStep(ACTION_JOIN, target.String(), fmt.Sprintf("zone %s, master %s", zone.Name, myAgent.String()), func() error {
+				meta.SetOceanbasePwd(agentPassword)
+				dag, err := api.CallApiViaTCP(&target, constant.URI_AGENT_API_PREFIX+constant.URI_JOIN, joinParam)
+				if err != nil {
+					return err
+				}
+				return api.NewDagHandlerWithAgent(dag, &target).PrintDagStage()
+			})
+		}
+	}
+
+	if globalConfigs := filterDeniedConfigs(spec.Cluster.ObConfigs); len(globalConfigs) > 0 {
+		plan.addStep(ACTION_CONFIG, "global", formatConfigs(globalConfigs), func() error {
+			return callApiAndWaitDag(http.POST, constant.URI_API_V1+constant.URI_OBSERVER_GROUP+constant.URI_CONFIG, &param.ObServerConfigParams{
+				ObServerConfig: globalConfigs,
+				Scope:          param.Scope{Type: ob.SCOPE_GLOBAL},
+			})
+		})
+	}
+	for _, zone := range spec.Zones {
+		for _, server := range zone.Servers {
+			configs := filterDeniedConfigs(server.ObConfigs)
+			if len(configs) == 0 {
+				continue
+			}
+			target := server.agentInfo.String()
+			plan.addStep(ACTION_CONFIG, target, formatConfigs(configs), func() error {
+				return callApiAndWaitDag(http.POST, constant.URI_API_V1+constant.URI_OBSERVER_GROUP+constant.URI_CONFIG, &param.ObServerConfigParams{
+					ObServerConfig: configs,
+					Scope:          param.Scope{Type: ob.SCOPE_SERVER, Target: []string{target}},
+				})
+			})
+		}
+	}
+
+	clusterName := spec.Cluster.Name
+	clusterId := spec.Cluster.Id
+	if clusterId == 0 {
+		clusterId = int(time.Now().Unix())
+	}
+	rootPwd := spec.Cluster.RootPassword
+	plan.addStep(ACTION_CONFIG, "obcluster", fmt.Sprintf("name %s, id %d", clusterName, clusterId), func() error {
+		return callApiAndWaitDag(http.POST, constant.URI_API_V1+constant.URI_OBCLUSTER_GROUP+constant.URI_CONFIG, &param.ObClusterConfigParams{
+			ClusterId:   &clusterId,
+			ClusterName: &clusterName,
+			RootPwd:     &rootPwd,
+		})
+	})
+	plan.addStep(ACTION_INIT, "obcluster", "", func() error {
+		return callApiAndWaitDag(http.POST, constant.URI_OB_API_PREFIX+constant.URI_INIT, &param.ObInitParam{})
+	})
+
+	for agent, zone := range state.agents {
+		if _, ok := spec.findServer(parseAgent(agent)); !ok {
+			plan.addWarning("server %s in zone %s is not specified but has joined the cluster, it will be initialized as well", agent, zone)
+		}
+	}
+	return nil
+}
+
+// planScaleOut scales out all the servers which are not in the cluster in one batch.
+func planScaleOut(spec *ClusterSpec, state *liveState, plan *applyPlan) error {
+	if state.clusterName != spec.Cluster.Name {
+		return errors.Occurf(errors.ErrCliApplyConflict, "cluster name is %s rather than %s", state.clusterName, spec.Cluster.Name)
+	}
+	if spec.Cluster.Id != 0 && state.clusterId != spec.Cluster.Id {
+		return errors.Occurf(errors.ErrCliApplyConflict, "cluster id is %d rather than %d", state.clusterId, spec.Cluster.Id)
+	}
+
+	batchParam := &param.ClusterScaleOutParam{
+		ObConfigs: filterDeniedConfigs(spec.Cluster.ObConfigs),
+	}
+	servers := make([]string, 0)
+	for _, zone := range spec.Zones {
+		for _, server := range zone.Servers {
+			if _, ok := state.agents[server.agentInfo.String()]; ok {
+				continue
+			}
+			batchParam.Agents = append(batchParam.Agents, param.ScaleOutAgentParam{
+				AgentInfo:           *server.agentInfo,
+				Zone:                zone.Name,
+				ObConfigs:           filterDeniedConfigs(server.ObConfigs),
+				TargetAgentPassword: server.AgentPassword,
+			})
+			servers = append(servers, fmt.Sprintf("%s(%s)", server.agentInfo.String(), zone.Name))
+		}
+	}
+	if len(batchParam.Agents) > 0 {
+		plan.addStep(ACTION_SCALE_OUT, strings.Join(servers, ", "), fmt.Sprintf("%d server(s)", len(servers)), func() error {
+			return callApiAndWaitDag(http.POST, constant.URI_OB_API_PREFIX+constant.URI_SCALE_OUT, batchParam)
+		})
+	}
+
+	for agent, zone := range state.agents {
+		if _, ok := spec.findServer(parseAgent(agent)); !ok {
+			plan.addWarning("server %s in zone %s is not specified, it will be kept", agent, zone)
+		}
+	}
+	return nil
+}
+
+func planUnitConfigs(spec *ClusterSpec, state *liveState, plan *applyPlan) error {
+	for _, unitConfig := range spec.UnitConfigs {
+		unitConfig := unitConfig
+		live, ok := state.unitConfigs[unitConfig.Name]
+		if !ok {
+			plan.addStep(ACTION_CREATE_UNIT, unitConfig.Name, fmt.Sprintf("memory_size %s, max_cpu %v", unitConfig.MemorySize, unitConfig.MaxCpu), func() error {
+				return api.CallApiWithMethod(http.POST, constant.URI_UNIT_GROUP_PREFIX, &param.CreateResourceUnitConfigParams{
+					Name:        &unitConfig.Name,
+					MemorySize:  &unitConfig.MemorySize,
+					MaxCpu:      &unitConfig.MaxCpu,
+					MinCpu:      unitConfig.MinCpu,
+					MaxIops:     unitConfig.MaxIops,
+					MinIops:     unitConfig.MinIops,
+					LogDiskSize: unitConfig.LogDiskSize,
+				}, nil)
+			})
+			continue
+		}
+
+		modifyParam := &param.ModifyResourceUnitConfigParams{}
+		changes := make([]string, 0)
+		memorySize, ok := parse.CapacityParser(unitConfig.MemorySize)
+		if !ok {
+			return errors.Occurf(errors.ErrCliUsageError, "invalid memory_size '%s' of unit config %s", unitConfig.MemorySize, unitConfig.Name)
+		}
+		if int64(memorySize) != live.MemorySize {
+			modifyParam.MemorySize = &unitConfig.MemorySize
+			changes = append(changes, fmt.Sprintf("memory_size %s -> %s", parse.FormatCapacity(live.MemorySize), unitConfig.MemorySize))
+		}
+		if unitConfig.MaxCpu != live.MaxCpu {
+			modifyParam.MaxCpu = &unitConfig.MaxCpu
+			changes = append(changes, fmt.Sprintf("max_cpu %v -> %v", live.MaxCpu, unitConfig.MaxCpu))
+		}
+		if unitConfig.MinCpu != nil && *unitConfig.MinCpu != live.MinCpu {
+			modifyParam.MinCpu = unitConfig.MinCpu
+			changes = append(changes, fmt.Sprintf("min_cpu %v -> %v", live.MinCpu, *unitConfig.MinCpu))
+		}
+		if unitConfig.LogDiskSize != nil {
+			logDiskSize, ok := parse.CapacityParser(*unitConfig.LogDiskSize)
+			if !ok {
+				return errors.Occurf(errors.ErrCliUsageError, "invalid log_disk_size '%s' of unit config %s", *unitConfig.LogDiskSize, unitConfig.Name)
+			}
+			if int64(logDiskSize) != live.LogDiskSize {
+				modifyParam.LogDiskSize = unitConfig.LogDiskSize
+				changes = append(changes, fmt.Sprintf("log_disk_size %s -> %s", parse.FormatCapacity(live.LogDiskSize), *unitConfig.LogDiskSize))
+			}
+		}
+		if unitConfig.MaxIops != nil && uint(*unitConfig.MaxIops) != live.MaxIops {
+			modifyParam.MaxIops = unitConfig.MaxIops
+			changes = append(changes, fmt.Sprintf("max_iops %d -> %d", live.MaxIops, *unitConfig.MaxIops))
+		}
+		if unitConfig.MinIops != nil && uint(*unitConfig.MinIops) != live.MinIops {
+			modifyParam.MinIops = unitConfig.MinIops
+			changes = append(changes, fmt.Sprintf("min_iops %d -> %d", live.MinIops, *unitConfig.MinIops))
+		}
+		if len(changes) > 0 {
+			plan.addStep(ACTION_MODIFY_UNIT, unitConfig.Name, strings.Join(changes, ", "), func() error {
+				return api.CallApiWithMethod(http.PATCH, constant.URI_UNIT_GROUP_PREFIX+"/"+unitConfig.Name, modifyParam, nil)
+			})
+		}
+	}
+	return nil
+}
+
+func planTenants(spec *ClusterSpec, state *liveState, plan *applyPlan) error {
+	unitConfigs := make(map[string]bool)
+	for name := range state.unitConfigs {
+		unitConfigs[name] = true
+	}
+	for _, unitConfig := range spec.UnitConfigs {
+		unitConfigs[unitConfig.Name] = true
+	}
+
+	specTenants := make(map[string]bool)
+	for _, tenant := range spec.Tenants {
+		specTenants[tenant.Name] = true
+		for _, pool := range tenant.ResourcePools {
+			if !unitConfigs[pool.UnitConfig] {
+				return errors.Occurf(errors.ErrCliApplyConflict, "unit config %s of tenant %s does not exist", pool.UnitConfig, tenant.Name)
+			}
+		}
+		if _, ok := state.tenants[tenant.Name]; !ok {
+			planCreateTenant(tenant, plan)
+			continue
+		}
+		if err := planModifyTenant(tenant, plan); err != nil {
+			return err
+		}
+	}
+
+	for name := range state.tenants {
+		if name != constant.TENANT_SYS && !specTenants[name] {
+			plan.addWarning("tenant %s is not specified, it will be kept", name)
+		}
+	}
+	return nil
+}
+
+func planCreateTenant(tenant TenantSpec, plan *applyPlan) {
+	createParam := &param.CreateTenantParam{
+		Name:         &tenant.Name,
+		ZoneList:     buildZoneParams(tenant.ResourcePools),
+		Mode:         tenant.Mode,
+		PrimaryZone:  tenant.PrimaryZone,
+		Whitelist:    tenant.Whitelist,
+		RootPassword: tenant.RootPassword,
+		Charset:      tenant.Charset,
+		Collation:    tenant.Collation,
+		Variables:    tenant.Variables,
+		Parameters:   tenant.Parameters,
+	}
+	plan.addStep(ACTION_CREATE_TENANT, tenant.Name, formatPools(tenant.ResourcePools), func() error {
+		return callApiAndWaitDag(http.POST, constant.URI_TENANT_API_PREFIX, createParam)
+	})
+}
+
+func planModifyTenant(tenant TenantSpec, plan *applyPlan) error {
+	info, err := getTenantInfo(tenant.Name)
+	if err != nil {
+		return err
+	}
+	if tenant.Mode != "" && !strings.EqualFold(tenant.Mode, info.Mode) {
+		return errors.Occurf(errors.ErrCliApplyConflict, "tenant %s is in %s mode rather than %s", tenant.Name, info.Mode, tenant.Mode)
+	}
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenant.Name
+
+	livePools := make(map[string]*bo.ResourcePoolWithUnit)
+	for _, pool := range info.Pools {
+		livePools[pool.ZoneList] = pool
+	}
+	addPools := make([]ResourcePoolSpec, 0)
+	modifyZones := make([]param.ModifyReplicaZoneParam, 0)
+	changes := make([]string, 0)
+	for _, pool := range tenant.ResourcePools {
+		livePool, ok := livePools[pool.Zone]
+		if !ok {
+			addPools = append(addPools, pool)
+			continue
+		}
+		delete(livePools, pool.Zone)
+		zoneParam := param.ModifyReplicaZoneParam{Name: pool.Zone}
+		if livePool.Unit == nil || livePool.Unit.Name != pool.UnitConfig {
+			unitConfigName := pool.UnitConfig
+			zoneParam.UnitConfigName = &unitConfigName
+			changes = append(changes, fmt.Sprintf("%s: unit_config %s", pool.Zone, pool.UnitConfig))
+		}
+		if livePool.UnitNum != pool.UnitNum {
+			unitNum := pool.UnitNum
+			zoneParam.UnitNum = &unitNum
+			changes = append(changes, fmt.Sprintf("%s: unit_num %d -> %d", pool.Zone, livePool.UnitNum, pool.UnitNum))
+		}
+		if zoneParam.UnitConfigName != nil || zoneParam.UnitNum != nil {
+			modifyZones = append(modifyZones, zoneParam)
+		}
+	}
+	if len(addPools) > 0 {
+		addParam := &param.ScaleOutTenantReplicasParam{ZoneList: buildZoneParams(addPools)}
+		plan.addStep(ACTION_ADD_REPLICAS, tenant.Name, formatPools(addPools), func() error {
+			return callApiAndWaitDag(http.POST, uri+constant.URI_REPLICAS, addParam)
+		})
+	}
+	if len(modifyZones) > 0 {
+		modifyParam := &param.ModifyReplicasParam{ZoneList: modifyZones}
+		plan.addStep(ACTION_MODIFY_REPLICAS, tenant.Name, strings.Join(changes, ", "), func() error {
+			return callApiAndWaitDag(http.PATCH, uri+constant.URI_REPLICAS, modifyParam)
+		})
+	}
+	for zone := range livePools {
+		plan.addWarning("replica of tenant %s in zone %s is not specified, it will be kept", tenant.Name, zone)
+	}
+
+	if tenant.PrimaryZone != "" && tenant.PrimaryZone != info.PrimaryZone {
+		primaryZone := tenant.PrimaryZone
+		plan.addStep(ACTION_SET_PRIMARYZONE, tenant.Name, fmt.Sprintf("%s -> %s", info.PrimaryZone, primaryZone), func() error {
+			return callApiAndWaitDag(http.PUT, uri+constant.URI_PRIMARYZONE, &param.ModifyTenantPrimaryZoneParam{PrimaryZone: &primaryZone})
+		})
+	}
+	if tenant.Whitelist != nil && *tenant.Whitelist != info.Whitelist {
+		whitelist := *tenant.Whitelist
+		plan.addStep(ACTION_SET_WHITELIST, tenant.Name, fmt.Sprintf("%s -> %s", info.Whitelist, whitelist), func() error {
+			return api.CallApiWithMethod(http.PUT, uri+constant.URI_WHITELIST, &param.ModifyTenantWhitelistParam{Whitelist: &whitelist}, nil)
+		})
+	}
+
+	if len(tenant.Parameters) > 0 {
+		liveParameters, err := getTenantParameters(tenant.Name)
+		if err != nil {
+			return err
+		}
+		if diff := diffValues(tenant.Parameters, liveParameters); len(diff) > 0 {
+			plan.addStep(ACTION_SET_PARAMETERS, tenant.Name, formatValues(diff), func() error {
+				return api.CallApiWithMethod(http.PUT, uri+constant.URI_PARAMETERS, &param.SetTenantParametersParam{Parameters: diff}, nil)
+			})
+		}
+	}
+	if len(tenant.Variables) > 0 {
+		liveVariables, err := getTenantVariables(tenant.Name)
+		if err != nil {
+			return err
+		}
+		if diff := diffValues(tenant.Variables, liveVariables); len(diff) > 0 {
+			tenantPassword := tenant.RootPassword
+			plan.addStep(ACTION_SET_VARIABLES, tenant.Name, formatValues(diff), func() error {
+				return api.CallApiWithMethod(http.PUT, uri+constant.URI_VARIABLES, &param.SetTenantVariablesParam{Variables: diff, TenantPassword: tenantPassword}, nil)
+			})
+		}
+	}
+	return nil
+}
+
+// callApiAndWaitDag calls the api and waits for the dag if there is one, some apis return no dag when nothing changes.
+func callApiAndWaitDag(method string, uri string, param interface{}) error {
+	var dag task.DagDetailDTO
+	if err := api.CallApiWithMethod(method, uri, param, &dag); err != nil {
+		return err
+	}
+	if dag.GenericDTO == nil {
+		return nil
+	}
+	return api.NewDagHandler(&dag).PrintDagStage()
+}
+
+func (spec *ClusterSpec) findServer(agent meta.AgentInfoInterface) (*ServerSpec, bool) {
+	for i := range spec.Zones {
+		for j := range spec.Zones[i].Servers {
+			if spec.Zones[i].Servers[j].agentInfo.Equal(agent) {
+				return &spec.Zones[i].Servers[j], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (spec *ClusterSpec) zoneOf(agent meta.AgentInfoInterface) string {
+	for _, zone := range spec.Zones {
+		for _, server := range zone.Servers {
+			if server.agentInfo.Equal(agent) {
+				return zone.Name
+			}
+		}
+	}
+	return ""
+}
+
+func parseAgent(agent string) meta.AgentInfoInterface {
+	agentInfo, _ := meta.ConvertAddressToAgentInfo(agent)
+	return agentInfo
+}
+
+func filterDeniedConfigs(configs map[string]string) map[string]string {
+	res := make(map[string]string, len(configs))
+	for k, v := range configs {
+		res[k] = v
+	}
+	for _, key := range ob.DeniedConfig {
+		delete(res, key)
+	}
+	return res
+}
+
+func buildZoneParams(pools []ResourcePoolSpec) []param.ZoneParam {
+	zoneList := make([]param.ZoneParam, 0, len(pools))
+	for _, pool := range pools {
+		zoneList = append(zoneList, param.ZoneParam{
+			Name: pool.Zone,
+			PoolParam: param.PoolParam{
+				UnitConfigName: pool.UnitConfig,
+				UnitNum:        pool.UnitNum,
+			},
+		})
+	}
+	return zoneList
+}
+
+// diffValues returns the desired values which differ from the live values,
+// the values are compared case-insensitively and the capacities and booleans are normalized.
+func diffValues(desired map[string]interface{}, live map[string]string) map[string]interface{} {
+	diff := make(map[string]interface{})
+	for k, v := range desired {
+		if liveValue, ok := live[k]; !ok || !parse.ConfigValueEqual(fmt.Sprint(v), liveValue) {
+			diff[k] = v
+		}
+	}
+	return diff
+}
+
+func formatPools(pools []ResourcePoolSpec) string {
+	res := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		res = append(res, fmt.Sprintf("%s: %s*%d", pool.Zone, pool.UnitConfig, pool.UnitNum))
+	}
+	return strings.Join(res, ", ")
+}
+
+func formatConfigs(configs map[string]string) string {
+	values := make(map[string]interface{}, len(configs))
+	for k, v := range configs {
+		values[k] = v
+	}
+	return formatValues(values)
+}
+
+func formatValues(values map[string]interface{}) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := make([]string, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, fmt.Sprintf("%s=%v", k, values[k]))
+	}
+	return strings.Join(res, ", ")
+}
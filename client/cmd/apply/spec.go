@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/meta"
+)
+
+// ClusterSpec is the desired state of the cluster described by the yaml file.
+type ClusterSpec struct {
+	Cluster     ClusterBasicSpec `yaml:"cluster"`
+	Zones       []ZoneSpec       `yaml:"zones"`
+	UnitConfigs []UnitConfigSpec `yaml:"unit_configs"`
+	Tenants     []TenantSpec     `yaml:"tenants"`
+}
+
+type ClusterBasicSpec struct {
+	Name         string            `yaml:"name"`
+	Id           int               `yaml:"id"` // Only used when the cluster is initialized, a timestamp will be used if unspecified.
+	RootPassword string            `yaml:"root_password"`
+	ObConfigs    map[string]string `yaml:"ob_configs"` // Shared by all the servers when the servers are deployed.
+}
+
+type ZoneSpec struct {
+	Name    string       `yaml:"name"`
+	Servers []ServerSpec `yaml:"servers"`
+}
+
+type ServerSpec struct {
+	Server        string            `yaml:"server"` // The agent of the server, the port will be 2886 if unspecified.
+	AgentPassword string            `yaml:"agent_password"`
+	ObConfigs     map[string]string `yaml:"ob_configs"` // Override the shared configs.
+
+	agentInfo *meta.AgentInfo
+}
+
+type UnitConfigSpec struct {
+	Name        string   `yaml:"name"`
+	MemorySize  string   `yaml:"memory_size"`
+	MaxCpu      float64  `yaml:"max_cpu"`
+	MinCpu      *float64 `yaml:"min_cpu"`
+	LogDiskSize *string  `yaml:"log_disk_size"`
+	MaxIops     *int     `yaml:"max_iops"`
+	MinIops     *int     `yaml:"min_iops"`
+}
+
+type TenantSpec struct {
+	Name          string                 `yaml:"name"`
+	Mode          string                 `yaml:"mode"`
+	RootPassword  string                 `yaml:"root_password"`
+	PrimaryZone   string                 `yaml:"primary_zone"`
+	Whitelist     *string                `yaml:"whitelist"`
+	Charset       string                 `yaml:"charset"`
+	Collation     string                 `yaml:"collation"`
+	ResourcePools []ResourcePoolSpec     `yaml:"resource_pools"`
+	Parameters    map[string]interface{} `yaml:"parameters"`
+	Variables     map[string]interface{} `yaml:"variables"`
+}
+
+// ResourcePoolSpec is the resource pool of the tenant in the zone.
+type ResourcePoolSpec struct {
+	Zone       string `yaml:"zone"`
+	UnitConfig string `yaml:"unit_config"`
+	UnitNum    int    `yaml:"unit_num"`
+}
+
+func loadClusterSpec(file string) (*ClusterSpec, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file %s failed", file)
+	}
+	var spec ClusterSpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, errors.Wrapf(err, "parse file %s failed", file)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (spec *ClusterSpec) validate() error {
+	if spec.Cluster.Name == "" {
+		return errors.Occur(errors.ErrCliUsageError, "cluster.name is required")
+	}
+	if len(spec.Zones) == 0 {
+		return errors.Occur(errors.ErrCliUsageError, "at least one zone is required")
+	}
+
+	zones := make(map[string]bool)
+	servers := make(map[string]bool)
+	for i := range spec.Zones {
+		zone := &spec.Zones[i]
+		if zone.Name == "" {
+			return errors.Occur(errors.ErrCliUsageError, "zone name is required")
+		}
+		if zones[zone.Name] {
+			return errors.Occurf(errors.ErrCliUsageError, "duplicate zone %s", zone.Name)
+		}
+		zones[zone.Name] = true
+		if len(zone.Servers) == 0 {
+			return errors.Occurf(errors.ErrCliUsageError, "no server is specified in zone %s", zone.Name)
+		}
+		for j := range zone.Servers {
+			server := &zone.Servers[j]
+			agentInfo, err := meta.ConvertAddressToAgentInfo(server.Server)
+			if err != nil {
+				return err
+			}
+			if servers[agentInfo.String()] {
+				return errors.Occurf(errors.ErrCliUsageError, "duplicate server %s", agentInfo.String())
+			}
+			servers[agentInfo.String()] = true
+			server.agentInfo = agentInfo
+		}
+	}
+
+	unitConfigs := make(map[string]bool)
+	for _, unitConfig := range spec.UnitConfigs {
+		if unitConfig.Name == "" {
+			return errors.Occur(errors.ErrCliUsageError, "unit config name is required")
+		}
+		if unitConfigs[unitConfig.Name] {
+			return errors.Occurf(errors.ErrCliUsageError, "duplicate unit config %s", unitConfig.Name)
+		}
+		unitConfigs[unitConfig.Name] = true
+		if _, ok := parse.CapacityParser(unitConfig.MemorySize); !ok {
+			return errors.Occurf(errors.ErrCliUsageError, "invalid memory_size '%s' of unit config %s", unitConfig.MemorySize, unitConfig.Name)
+		}
+		if unitConfig.LogDiskSize != nil {
+			if _, ok := parse.CapacityParser(*unitConfig.LogDiskSize); !ok {
+				return errors.Occurf(errors.ErrCliUsageError, "invalid log_disk_size '%s' of unit config %s", *unitConfig.LogDiskSize, unitConfig.Name)
+			}
+		}
+		if unitConfig.MaxCpu <= 0 {
+			return errors.Occurf(errors.ErrCliUsageError, "max_cpu of unit config %s should be greater than 0", unitConfig.Name)
+		}
+	}
+
+	tenants := make(map[string]bool)
+	for _, tenant := range spec.Tenants {
+		if tenant.Name == "" {
+			return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+		}
+		if tenants[tenant.Name] {
+			return errors.Occurf(errors.ErrCliUsageError, "duplicate tenant %s", tenant.Name)
+		}
+		tenants[tenant.Name] = true
+		if len(tenant.ResourcePools) == 0 {
+			return errors.Occurf(errors.ErrCliUsageError, "no resource pool is specified for tenant %s", tenant.Name)
+		}
+		poolZones := make(map[string]bool)
+		for _, pool := range tenant.ResourcePools {
+			if !zones[pool.Zone] {
+				return errors.Occurf(errors.ErrCliUsageError, "zone %s of tenant %s is not specified in zones", pool.Zone, tenant.Name)
+			}
+			if poolZones[pool.Zone] {
+				return errors.Occurf(errors.ErrCliUsageError, "duplicate resource pool in zone %s of tenant %s", pool.Zone, tenant.Name)
+			}
+			poolZones[pool.Zone] = true
+			if pool.UnitConfig == "" || pool.UnitNum <= 0 {
+				return errors.Occurf(errors.ErrCliUsageError, "unit_config and a positive unit_num are required for the resource pool in zone %s of tenant %s", pool.Zone, tenant.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"strings"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+)
+
+// liveState is the current state of the cluster which the desired state is compared with.
+type liveState struct {
+	myAgent     meta.AgentInfoWithIdentity
+	clusterName string
+	clusterId   int
+	agents      map[string]string // agent -> zone, the agents which have joined the cluster.
+	unitConfigs map[string]oceanbase.DbaObUnitConfig
+	tenants     map[string]oceanbase.DbaObTenant
+}
+
+func (s *liveState) isInitialized() bool {
+	return s.myAgent.IsClusterAgent()
+}
+
+func getLiveState() (*liveState, error) {
+	stdio.StartLoading("Get the current state of the cluster")
+	defer stdio.StopLoading()
+
+	status, err := api.GetMyAgentStatus()
+	if err != nil {
+		return nil, err
+	}
+	state := &liveState{
+		myAgent:     status.Agent,
+		agents:      make(map[string]string),
+		unitConfigs: make(map[string]oceanbase.DbaObUnitConfig),
+		tenants:     make(map[string]oceanbase.DbaObTenant),
+	}
+	if status.UnderMaintenance {
+		return nil, errors.Occur(errors.ErrAgentUnderMaintenance, status.Agent.String())
+	}
+
+	switch status.Agent.GetIdentity() {
+	case meta.SINGLE:
+		return state, nil
+	case meta.MASTER, meta.FOLLOWER, meta.CLUSTER_AGENT:
+	default:
+		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, status.Agent.String(), status.Agent.GetIdentity(),
+			strings.Join([]string{string(meta.SINGLE), string(meta.MASTER), string(meta.FOLLOWER), string(meta.CLUSTER_AGENT)}, " or "))
+	}
+
+	obInfo, err := api.GetObInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, agent := range obInfo.Agents {
+		state.agents[agent.AgentInfo.String()] = agent.GetZone()
+	}
+	if !state.isInitialized() {
+		return state, nil
+	}
+
+	var clusterInfo bo.ClusterInfo
+	if err := api.CallApiWithMethod(http.GET, constant.URI_OBCLUSTER_API_PREFIX+constant.URI_INFO, nil, &clusterInfo); err != nil {
+		return nil, err
+	}
+	state.clusterName = clusterInfo.ClusterName
+	state.clusterId = clusterInfo.ClusterId
+
+	var unitConfigs []oceanbase.DbaObUnitConfig
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_UNITS_GROUP, nil, &unitConfigs); err != nil {
+		return nil, err
+	}
+	for _, unitConfig := range unitConfigs {
+		state.unitConfigs[unitConfig.Name] = unitConfig
+	}
+
+	var tenants []oceanbase.DbaObTenant
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_TENANTS_GROUP+constant.URI_OVERVIEW, nil, &tenants); err != nil {
+		return nil, err
+	}
+	for _, tenant := range tenants {
+		state.tenants[tenant.TenantName] = tenant
+	}
+	return state, nil
+}
+
+func getTenantInfo(name string) (*bo.TenantInfo, error) {
+	var info bo.TenantInfo
+	if err := api.CallApiWithMethod(http.GET, constant.URI_TENANT_API_PREFIX+"/"+name, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func getTenantParameters(name string) (map[string]string, error) {
+	var parameters []oceanbase.GvObParameter
+	if err := api.CallApiWithMethod(http.GET, constant.URI_TENANT_API_PREFIX+"/"+name+constant.URI_PARAMETERS, nil, &parameters); err != nil {
+		return nil, err
+	}
+	res := make(map[string]string, len(parameters))
+	for _, parameter := range parameters {
+		res[parameter.Name] = parameter.Value
+	}
+	return res, nil
+}
+
+func getTenantVariables(name string) (map[string]string, error) {
+	var variables []oceanbase.CdbObSysVariable
+	if err := api.CallApiWithMethod(http.GET, constant.URI_TENANT_API_PREFIX+"/"+name+constant.URI_VARIABLES, nil, &variables); err != nil {
+		return nil, err
+	}
+	res := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		res[variable.Name] = variable.Value
+	}
+	return res, nil
+}
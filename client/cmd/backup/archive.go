@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_ARCHIVE   = "archive"
+	CMD_LAG       = "lag"
+	CMD_REMEDIATE = "remediate"
+	CMD_WATCHER   = "watcher"
+
+	FLAG_ENABLE         = "enable"
+	FLAG_LAG_THRESHOLD  = "lag_threshold"
+	FLAG_AUTO_REMEDIATE = "auto_remediate"
+)
+
+type ArchiveWatcherFlags struct {
+	Enable        bool
+	LagThreshold  string
+	AutoRemediate bool
+	verbose       bool
+}
+
+func newArchiveCmd() *cobra.Command {
+	archiveCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ARCHIVE,
+		Short: "Monitor and remediate the archive log.",
+	})
+	archiveCmd.AddCommand(newArchiveLagCmd())
+	archiveCmd.AddCommand(newArchiveRemediateCmd())
+	archiveCmd.AddCommand(newArchiveWatcherCmd())
+	return archiveCmd.Command
+}
+
+func newArchiveLagCmd() *cobra.Command {
+	var tenantName string
+	var verbose bool
+	lagCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_LAG,
+		Short:   "Show the archive log status and lag for the entire cluster or a specific tenant.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return archiveLag(tenantName)
+		}),
+		Example: `  obshell backup archive lag
+  obshell backup archive lag -t tenant1`,
+	})
+
+	lagCmd.Flags().SortFlags = false
+	lagCmd.VarsPs(&tenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant to show archive lag.", false)
+	lagCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return lagCmd.Command
+}
+
+func archiveLag(tenantName string) error {
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_ARCHIVE + constant.URI_LAG
+	if tenantName != "" {
+		uri = constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_BACKUP + constant.URI_ARCHIVE + constant.URI_LAG
+	}
+	var overview param.ArchiveLagOverview
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &overview); err != nil {
+		return err
+	}
+	printer.PrintArchiveLagOverview(&overview)
+	return nil
+}
+
+func newArchiveRemediateCmd() *cobra.Command {
+	var skipConfirm, verbose bool
+	remediateCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_REMEDIATE,
+		Short:   "Re-open the archive log and re-enable the archive dest of a tenant.",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSkipConfirmMode(skipConfirm)
+			stdio.SetSilenceMode(false)
+			return archiveRemediate(args[0])
+		}),
+		Example: `  obshell backup archive remediate tenant1`,
+	})
+
+	remediateCmd.Flags().SortFlags = false
+	remediateCmd.VarsPs(&skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	remediateCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return remediateCmd.Command
+}
+
+func archiveRemediate(tenantName string) error {
+	msg := fmt.Sprintf("The archive log of %s will be closed and opened again if it is interrupted, please confirm", tenantName)
+	res, err := stdio.Confirm(msg)
+	if err != nil {
+		return errors.Wrap(err, "ask for remediation confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_BACKUP + constant.URI_ARCHIVE + constant.URI_REMEDIATE
+	dag, err := api.CallApiAndPrintStage(uri, nil)
+	if err != nil {
+		return err
+	}
+	log.Info("Remediate archive log finished, DAG ID: ", dag.DagID)
+	return nil
+}
+
+func newArchiveWatcherCmd() *cobra.Command {
+	opts := &ArchiveWatcherFlags{}
+	watcherCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_WATCHER,
+		Short:   "Show or modify the archive lag watcher config.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return archiveWatcher(cmd, opts)
+		}),
+		Example: `  obshell backup archive watcher
+  obshell backup archive watcher --lag_threshold 10m --auto_remediate=true`,
+	})
+
+	watcherCmd.Flags().SortFlags = false
+	watcherCmd.VarsPs(&opts.Enable, []string{FLAG_ENABLE}, true, "Whether to watch the archive log.", false)
+	watcherCmd.VarsPs(&opts.LagThreshold, []string{FLAG_LAG_THRESHOLD}, "", "The archive lag to raise an alarm, such as 30m.", false)
+	watcherCmd.VarsPs(&opts.AutoRemediate, []string{FLAG_AUTO_REMEDIATE}, false, "Whether to remediate the alarming archive log automatically.", false)
+	watcherCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return watcherCmd.Command
+}
+
+func archiveWatcher(cmd *cobra.Command, opts *ArchiveWatcherFlags) error {
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_ARCHIVE + constant.URI_WATCHER
+	p := &param.ArchiveWatcherConfigParam{}
+	if cmd.Flags().Changed(FLAG_ENABLE) {
+		p.Enabled = &opts.Enable
+	}
+	if cmd.Flags().Changed(FLAG_LAG_THRESHOLD) {
+		p.LagThreshold = &opts.LagThreshold
+	}
+	if cmd.Flags().Changed(FLAG_AUTO_REMEDIATE) {
+		p.AutoRemediate = &opts.AutoRemediate
+	}
+
+	var cfg param.ArchiveWatcherConfig
+	var err error
+	if p.Enabled == nil && p.LagThreshold == nil && p.AutoRemediate == nil {
+		err = api.CallApiWithMethod(http.GET, uri, nil, &cfg)
+	} else {
+		err = api.CallApiWithMethod(http.PATCH, uri, p, &cfg)
+	}
+	if err != nil {
+		return err
+	}
+	stdio.Printf("Archive lag watcher enabled: %v, lag threshold: %s, auto remediate: %v", cfg.Enabled, cfg.LagThreshold, cfg.AutoRemediate)
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	FLAG_BACKUP_SET_IDS    = "backup_set_ids"
+	FLAG_ARCHIVE_BEFORE    = "archive_before"
+	FLAG_ARCHIVE_BEFORE_SH = "a"
+	FLAG_PREVIEW           = "preview"
+)
+
+type BackupCleanFlags struct {
+	TenantName    string
+	BackupSetIDs  string
+	ArchiveBefore string
+	preview       bool
+	skipConfirm   bool
+	verbose       bool
+}
+
+func newCleanCmd() *cobra.Command {
+	opts := &BackupCleanFlags{}
+	cleanCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CLEAN,
+		Short:   "Delete backup sets and obsolete archive pieces of a specific tenant.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetSilenceMode(false)
+			return backupClean(opts)
+		}),
+		Example: cleanCmdExample(),
+	})
+
+	cleanCmd.Flags().SortFlags = false
+	cleanCmd.VarsPs(&opts.TenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant to clean backup.", true)
+	cleanCmd.VarsPs(&opts.BackupSetIDs, []string{FLAG_BACKUP_SET_IDS, FLAG_BACKUP_SET_ID_SH}, "", "The ids of the backup sets to delete, separated by ','.", false)
+	cleanCmd.VarsPs(&opts.ArchiveBefore, []string{FLAG_ARCHIVE_BEFORE, FLAG_ARCHIVE_BEFORE_SH}, "", "Delete the archive pieces checkpointed before this time, in RFC3339 format. Pieces still needed by retained backup sets are kept.", false)
+	cleanCmd.VarsPs(&opts.preview, []string{FLAG_PREVIEW}, false, "Only display what would be removed", false)
+	cleanCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	cleanCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return cleanCmd.Command
+}
+
+func backupClean(opts *BackupCleanFlags) error {
+	cleanParam, err := opts.toBackupCleanParam()
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/%s%s%s", constant.URI_TENANT_API_PREFIX, opts.TenantName, constant.URI_BACKUP, constant.URI_CLEAN)
+	var preview param.BackupCleanPreview
+	if err = api.CallApiWithMethod(http.POST, uri+constant.URI_PREVIEW, cleanParam, &preview); err != nil {
+		return err
+	}
+	printer.PrintBackupCatalog([]param.TenantBackupCatalog{{
+		TenantName:    preview.TenantName,
+		BackupSets:    preview.BackupSets,
+		ArchivePieces: preview.ArchivePieces,
+	}})
+	if opts.preview {
+		return nil
+	}
+	if len(preview.BackupSets) == 0 && len(preview.ArchivePieces) == 0 {
+		stdio.Info("Nothing to clean.")
+		return nil
+	}
+
+	res, err := stdio.Confirm("Please confirm if you need to delete the backup sets and archive pieces above")
+	if err != nil {
+		return errors.Wrap(err, "ask for clean confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	dag, err := api.CallApiAndPrintStage(uri, cleanParam)
+	if err != nil {
+		return err
+	}
+	log.Info(dag)
+	return nil
+}
+
+func (f *BackupCleanFlags) toBackupCleanParam() (*param.BackupCleanParam, error) {
+	p := &param.BackupCleanParam{}
+	if f.BackupSetIDs != "" {
+		for _, item := range strings.Split(f.BackupSetIDs, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+			if err != nil {
+				return nil, errors.Occurf(errors.ErrCliUsageError, "invalid backup set id '%s'", item)
+			}
+			p.BackupSetIDs = append(p.BackupSetIDs, id)
+		}
+	}
+	if f.ArchiveBefore != "" {
+		before, err := time.Parse(time.RFC3339, f.ArchiveBefore)
+		if err != nil {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "invalid time '%s', should be in RFC3339 format", f.ArchiveBefore)
+		}
+		p.ArchiveBefore = &before
+	}
+	return p, p.Check()
+}
+
+func cleanCmdExample() string {
+	return `  Preview the backup sets to be deleted:
+    obshell backup clean -t tenant1 -b 1,2 --preview
+
+  Delete backup set 1 and the archive pieces checkpointed before 2026-01-01:
+    obshell backup clean -t tenant1 -b 1 -a 2026-01-01T00:00:00+08:00
+`
+}
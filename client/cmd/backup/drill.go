@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_RUN      = "run"
+	CMD_SCHEDULE = "schedule"
+
+	FLAG_SCRATCH_TENANT    = "scratch_tenant"
+	FLAG_SCRATCH_TENANT_SH = "s"
+	FLAG_TABLES            = "tables"
+	FLAG_VERIFY_SQL        = "verify_sql"
+	FLAG_REPORT_ID         = "id"
+	FLAG_LIMIT             = "limit"
+	FLAG_LIMIT_SH          = "l"
+	FLAG_INTERVAL          = "interval"
+)
+
+type RestoreDrillFlags struct {
+	DataBackupUri string
+	ArchiveLogUri string
+	ScratchTenant string
+	Decryption    string
+	RootPassword  string
+	Tables        string
+	VerifySql     string
+	skipConfirm   bool
+	verbose       bool
+
+	replica.ZoneParamsFlags
+}
+
+func newDrillCmd() *cobra.Command {
+	drillCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DRILL,
+		Short: "Run restore drills and display the drill reports.",
+	})
+	drillCmd.AddCommand(newDrillRunCmd())
+	drillCmd.AddCommand(newDrillShowCmd())
+	drillCmd.AddCommand(newDrillScheduleCmd())
+	return drillCmd.Command
+}
+
+func newDrillRunCmd() *cobra.Command {
+	opts := &RestoreDrillFlags{}
+	runCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_RUN,
+		Short:   "Restore the latest restorable point into a scratch tenant, verify it and drop it.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetSilenceMode(false)
+			return restoreDrill(cmd, opts)
+		}),
+		Example: drillRunCmdExample(),
+	})
+
+	runCmd.Flags().SortFlags = false
+	runCmd.VarsPs(&opts.DataBackupUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored.", true)
+	runCmd.VarsPs(&opts.ArchiveLogUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored.", false)
+	runCmd.VarsPs(&opts.Zones, []string{tenant.FLAG_ZONE, tenant.FLAG_ZONE_SH}, "", "The zones of the scratch tenant.", false)
+	runCmd.VarsPs(&opts.UnitConfigName, []string{tenant.FLAG_UNIT, tenant.FLAG_UNIT_SH}, "", "The unit config name of the scratch tenant.", false)
+	runCmd.VarsPs(&opts.ScratchTenant, []string{FLAG_SCRATCH_TENANT, FLAG_SCRATCH_TENANT_SH}, "", "The name of the scratch tenant, generated if not specified.", false)
+	runCmd.VarsPs(&opts.Decryption, []string{tenant.FLAG_DECRYPTION, tenant.FLAG_DECRYPTION_SH}, "", "The decryption password for all backups, the stored backup keys are used if not set.", false)
+	runCmd.VarsPs(&opts.RootPassword, []string{tenant.FLAG_ROOT_PASSWORD}, "", "The root password of the backed up tenant, used to run the verifications.", false)
+	runCmd.VarsPs(&opts.Tables, []string{FLAG_TABLES}, "", "The tables in 'database.table' format to count rows, separated by ','.", false)
+	runCmd.VarsPs(&opts.VerifySql, []string{FLAG_VERIFY_SQL}, "", "The verification SQLs separated by ';', the first column of the first row is recorded.", false)
+	runCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	runCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return runCmd.Command
+}
+
+func restoreDrill(cmd *cobra.Command, opts *RestoreDrillFlags) error {
+	drillParam, err := opts.toRestoreDrillParam(cmd)
+	if err != nil {
+		return err
+	}
+	if err = tenant.ConfirmRestore(); err != nil {
+		return err
+	}
+
+	dag, err := api.CallApiAndPrintStage(constant.URI_API_V1+constant.URI_RESTORE+constant.URI_DRILL, drillParam)
+	if err != nil {
+		return err
+	}
+	log.Info("Restore drill finished, DAG ID: ", dag.DagID)
+	return nil
+}
+
+func (f *RestoreDrillFlags) toRestoreDrillParam(cmd *cobra.Command) (*param.RestoreDrillParam, error) {
+	f.UnitNum = constant.RESTORE_UNIT_NUM_DEFAULT
+	zoneList, err := replica.BuildZoneParams(cmd, &f.ZoneParamsFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &param.RestoreDrillParam{
+		RestoreWindowsParam: param.RestoreWindowsParam{
+			DataBackupUri: f.DataBackupUri,
+		},
+		ZoneList: zoneList,
+	}
+	if f.ArchiveLogUri != "" {
+		p.ArchiveLogUri = &f.ArchiveLogUri
+	}
+	if f.ScratchTenant != "" {
+		p.ScratchTenantName = &f.ScratchTenant
+	}
+	if f.Decryption != "" {
+		pwds := strings.Split(strings.TrimSpace(f.Decryption), ",")
+		p.Decryption = &pwds
+	}
+	if cmd.Flags().Changed(tenant.FLAG_ROOT_PASSWORD) {
+		p.RootPassword = &f.RootPassword
+	}
+	if f.Tables != "" {
+		for _, table := range strings.Split(f.Tables, ",") {
+			p.Tables = append(p.Tables, strings.TrimSpace(table))
+		}
+	}
+	for _, sql := range strings.Split(f.VerifySql, ";") {
+		if sql = strings.TrimSpace(sql); sql != "" {
+			p.Verifications = append(p.Verifications, param.RestoreDrillVerification{
+				Name: fmt.Sprintf("sql#%d", len(p.Verifications)+1),
+				Sql:  sql,
+			})
+		}
+	}
+	return p, nil
+}
+
+type RestoreDrillShowFlags struct {
+	id      int64
+	limit   int
+	verbose bool
+}
+
+func newDrillShowCmd() *cobra.Command {
+	opts := &RestoreDrillShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Display the restore drill report history.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return showRestoreDrill(opts)
+		}),
+		Example: drillShowCmdExample(),
+	})
+
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.id, []string{FLAG_REPORT_ID}, int64(0), "The id of the report to display with its verifications", false)
+	showCmd.VarsPs(&opts.limit, []string{FLAG_LIMIT, FLAG_LIMIT_SH}, constant.RESTORE_DRILL_REPORTS_DEFAULT, "The max number of reports to display", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func showRestoreDrill(opts *RestoreDrillShowFlags) error {
+	uri := constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_DRILL + constant.URI_REPORTS
+	if opts.id != 0 {
+		var report bo.RestoreDrillReport
+		if err := api.CallApiWithMethod(http.GET, fmt.Sprintf("%s/%d", uri, opts.id), nil, &report); err != nil {
+			return err
+		}
+		printer.PrintRestoreDrillReport(&report)
+		return nil
+	}
+
+	var reports []bo.RestoreDrillReport
+	if err := api.CallApiWithMethod(http.GET, fmt.Sprintf("%s?limit=%d", uri, opts.limit), nil, &reports); err != nil {
+		return err
+	}
+	printer.PrintRestoreDrillReports(reports)
+	return nil
+}
+
+type RestoreDrillScheduleFlags struct {
+	Enable   bool
+	Interval string
+	RestoreDrillFlags
+}
+
+func newDrillScheduleCmd() *cobra.Command {
+	opts := &RestoreDrillScheduleFlags{}
+	scheduleCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SCHEDULE,
+		Short:   "Show or modify the schedule to run the restore drill periodically.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return drillSchedule(cmd, opts)
+		}),
+		Example: drillScheduleCmdExample(),
+	})
+
+	scheduleCmd.Flags().SortFlags = false
+	scheduleCmd.VarsPs(&opts.Enable, []string{FLAG_ENABLE}, true, "Whether to run the restore drill periodically.", false)
+	scheduleCmd.VarsPs(&opts.Interval, []string{FLAG_INTERVAL}, "", "The interval between two drills, such as 1d, at least 1h.", false)
+	scheduleCmd.VarsPs(&opts.DataBackupUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored, the drill is replaced if set.", false)
+	scheduleCmd.VarsPs(&opts.ArchiveLogUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored.", false)
+	scheduleCmd.VarsPs(&opts.Zones, []string{tenant.FLAG_ZONE, tenant.FLAG_ZONE_SH}, "", "The zones of the scratch tenant.", false)
+	scheduleCmd.VarsPs(&opts.UnitConfigName, []string{tenant.FLAG_UNIT, tenant.FLAG_UNIT_SH}, "", "The unit config name of the scratch tenant.", false)
+	scheduleCmd.VarsPs(&opts.ScratchTenant, []string{FLAG_SCRATCH_TENANT, FLAG_SCRATCH_TENANT_SH}, "", "The name of the scratch tenant, generated for each drill if not specified.", false)
+	scheduleCmd.VarsPs(&opts.Decryption, []string{tenant.FLAG_DECRYPTION, tenant.FLAG_DECRYPTION_SH}, "", "The decryption password for all backups, the stored backup keys are used if not set.", false)
+	scheduleCmd.VarsPs(&opts.RootPassword, []string{tenant.FLAG_ROOT_PASSWORD}, "", "The root password of the backed up tenant, used to run the verifications.", false)
+	scheduleCmd.VarsPs(&opts.Tables, []string{FLAG_TABLES}, "", "The tables in 'database.table' format to count rows, separated by ','.", false)
+	scheduleCmd.VarsPs(&opts.VerifySql, []string{FLAG_VERIFY_SQL}, "", "The verification SQLs separated by ';', the first column of the first row is recorded.", false)
+	scheduleCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return scheduleCmd.Command
+}
+
+func drillSchedule(cmd *cobra.Command, opts *RestoreDrillScheduleFlags) (err error) {
+	uri := constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_DRILL + constant.URI_SCHEDULE
+	p := &param.RestoreDrillScheduleParam{}
+	if cmd.Flags().Changed(FLAG_ENABLE) {
+		p.Enabled = &opts.Enable
+	}
+	if cmd.Flags().Changed(FLAG_INTERVAL) {
+		p.Interval = &opts.Interval
+	}
+	if opts.DataBackupUri != "" {
+		if p.Drill, err = opts.toRestoreDrillParam(cmd); err != nil {
+			return err
+		}
+	}
+
+	var schedule param.RestoreDrillSchedule
+	if p.Enabled == nil && p.Interval == nil && p.Drill == nil {
+		err = api.CallApiWithMethod(http.GET, uri, nil, &schedule)
+	} else {
+		err = api.CallApiWithMethod(http.PATCH, uri, p, &schedule)
+	}
+	if err != nil {
+		return err
+	}
+	stdio.Printf("Restore drill schedule enabled: %v, interval: %s", schedule.Enabled, schedule.Interval)
+	if schedule.Drill != nil {
+		stdio.Printf("Data backup uri: %s", schedule.Drill.DataBackupUri)
+		if schedule.Drill.ArchiveLogUri != nil {
+			stdio.Printf("Archive log uri: %s", *schedule.Drill.ArchiveLogUri)
+		}
+	}
+	return nil
+}
+
+func drillRunCmdExample() string {
+	return `  Run a restore drill and count the rows of two tables:
+    obshell backup drill run -d 'file:///data/backup/data' -a 'file:///data/backup/clog' -z zone1 -u unit1 --root_password '***' --tables db1.t1,db1.t2
+`
+}
+
+func drillShowCmdExample() string {
+	return `  Show the latest restore drill reports:
+    obshell backup drill show
+
+  Show a specific report with its verifications:
+    obshell backup drill show --id 3
+`
+}
+
+func drillScheduleCmdExample() string {
+	return `  Show the restore drill schedule:
+    obshell backup drill schedule
+
+  Run a restore drill every day:
+    obshell backup drill schedule --interval 1d -d 'file:///data/backup/data' -a 'file:///data/backup/clog' -z zone1 -u unit1 --root_password '***' --tables db1.t1
+
+  Stop the scheduled restore drill:
+    obshell backup drill schedule --enable=false
+`
+}
@@ -29,6 +29,11 @@ import (
 const (
 	CMD_SHOW       = "show"
 	CMD_SET_CONFIG = "set-config"
+	CMD_LIST       = "list"
+	CMD_VALIDATE   = "validate"
+	CMD_CLEAN      = "clean"
+	CMD_DRILL      = "drill"
+	CMD_USAGE      = "usage"
 )
 
 func NewBackupCmd() *cobra.Command {
@@ -43,5 +48,12 @@ func NewBackupCmd() *cobra.Command {
 	})
 	taskCmd.AddCommand(newShowCmd())
 	taskCmd.AddCommand(NewSetConfigCmd())
+	taskCmd.AddCommand(newListCmd())
+	taskCmd.AddCommand(newValidateCmd())
+	taskCmd.AddCommand(newCleanCmd())
+	taskCmd.AddCommand(newDrillCmd())
+	taskCmd.AddCommand(newUsageCmd())
+	taskCmd.AddCommand(newArchiveCmd())
+	taskCmd.AddCommand(newKeyCmd())
 	return taskCmd.Command
 }
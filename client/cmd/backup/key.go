@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_KEY    = "key"
+	CMD_ROTATE = "rotate"
+
+	FLAG_PASSWORD    = "password"
+	FLAG_PASSWORD_SH = "p"
+)
+
+func newKeyCmd() *cobra.Command {
+	keyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_KEY,
+		Short: "Manage the backup encryption keys stored by obshell.",
+	})
+	keyCmd.AddCommand(newKeyListCmd())
+	keyCmd.AddCommand(newKeyRotateCmd())
+	return keyCmd.Command
+}
+
+func newKeyListCmd() *cobra.Command {
+	var verbose bool
+	listCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_LIST,
+		Short:   "List the backup encryption key versions of a tenant and the backup sets encrypted with them.",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return listBackupKeys(args[0])
+		}),
+		Example: `  obshell backup key list tenant1`,
+	})
+
+	listCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	listCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return listCmd.Command
+}
+
+func listBackupKeys(tenantName string) error {
+	var keys []bo.BackupEncryptionKey
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_BACKUP + constant.URI_KEYS
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &keys); err != nil {
+		return err
+	}
+	printer.PrintBackupKeys(keys)
+	return nil
+}
+
+func newKeyRotateCmd() *cobra.Command {
+	var password string
+	var skipConfirm, verbose bool
+	rotateCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_ROTATE,
+		Short:   "Activate a new backup encryption key version for a tenant, the later backups are encrypted with it.",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSkipConfirmMode(skipConfirm)
+			stdio.SetSilenceMode(false)
+			p := &param.BackupKeyParam{}
+			if cmd.Flags().Changed(FLAG_PASSWORD) {
+				p.Password = &password
+			}
+			return rotateBackupKey(args[0], p)
+		}),
+		Example: `  obshell backup key rotate tenant1
+  obshell backup key rotate tenant1 -p '******'`,
+	})
+
+	rotateCmd.Flags().SortFlags = false
+	rotateCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	rotateCmd.VarsPs(&password, []string{FLAG_PASSWORD, FLAG_PASSWORD_SH}, "", "The new backup encryption password, a random one is generated if not set.", false)
+	rotateCmd.VarsPs(&skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	rotateCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return rotateCmd.Command
+}
+
+func rotateBackupKey(tenantName string, p *param.BackupKeyParam) error {
+	msg := fmt.Sprintf("The later backups of %s will be encrypted with a new key version, please confirm", tenantName)
+	res, err := stdio.Confirm(msg)
+	if err != nil {
+		return errors.Wrap(err, "ask for rotation confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	var key bo.BackupEncryptionKey
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_BACKUP + constant.URI_KEYS
+	if err = api.CallApiWithMethod(http.POST, uri, p, &key); err != nil {
+		return err
+	}
+	stdio.Successf("Backup key of %s is rotated to version %d", tenantName, key.Version)
+	return nil
+}
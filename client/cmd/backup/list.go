@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+type BackupListFlags struct {
+	TenantName string
+	verbose    bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &BackupListFlags{}
+	listCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_LIST,
+		Short:   "List the backup sets and archive pieces for the entire cluster or a specific tenant.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupList(opts)
+		}),
+		Example: listCmdExample(),
+	})
+
+	listCmd.Flags().SortFlags = false
+	listCmd.VarsPs(&opts.TenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant to list backup sets.", false)
+	listCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return listCmd.Command
+}
+
+func backupList(opts *BackupListFlags) error {
+	if opts.TenantName != "" {
+		catalog, err := api.GetTenantBackupCatalog(opts.TenantName)
+		if err != nil {
+			return err
+		}
+		printer.PrintBackupCatalog([]param.TenantBackupCatalog{*catalog})
+		return nil
+	}
+
+	catalog, err := api.GetClusterBackupCatalog()
+	if err != nil {
+		return err
+	}
+	printer.PrintBackupCatalog(catalog.Tenants)
+	return nil
+}
+
+func listCmdExample() string {
+	return `  List the backup sets and archive pieces for the entire cluster:
+    obshell backup list
+
+  List the backup sets and archive pieces for a specific tenant:
+    obshell backup list -t tenant1
+`
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	FLAG_CAPACITY = "capacity"
+)
+
+type BackupUsageFlags struct {
+	TenantName string
+	Capacity   string
+	verbose    bool
+}
+
+func newUsageCmd() *cobra.Command {
+	opts := &BackupUsageFlags{}
+	usageCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_USAGE,
+		Short:   "Show the backup storage usage, backup throughput and days until the destination is full.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupUsage(opts)
+		}),
+		Example: usageCmdExample(),
+	})
+
+	usageCmd.Flags().SortFlags = false
+	usageCmd.VarsPs(&opts.TenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant to show backup usage.", false)
+	usageCmd.VarsPs(&opts.Capacity, []string{FLAG_CAPACITY}, "", "The capacity of the backup destination, such as 10T. Required to estimate days until full for object storage.", false)
+	usageCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return usageCmd.Command
+}
+
+func backupUsage(opts *BackupUsageFlags) error {
+	if opts.TenantName != "" {
+		usage, err := api.GetTenantBackupUsage(opts.TenantName, opts.Capacity)
+		if err != nil {
+			return err
+		}
+		printer.PrintBackupUsage([]param.TenantBackupUsage{*usage})
+		return nil
+	}
+
+	usage, err := api.GetClusterBackupUsage(opts.Capacity)
+	if err != nil {
+		return err
+	}
+	printer.PrintBackupUsage(usage.Tenants)
+	return nil
+}
+
+func usageCmdExample() string {
+	return `  Show the backup storage usage for the entire cluster:
+    obshell backup usage
+
+  Show the backup storage usage for a specific tenant with a 10T destination:
+    obshell backup usage -t tenant1 --capacity 10T
+`
+}
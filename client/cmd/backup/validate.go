@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	FLAG_BACKUP_SET_ID    = "backup_set_id"
+	FLAG_BACKUP_SET_ID_SH = "b"
+)
+
+type BackupValidateFlags struct {
+	TenantName  string
+	BackupSetID int64
+	verbose     bool
+}
+
+func newValidateCmd() *cobra.Command {
+	opts := &BackupValidateFlags{}
+	validateCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_VALIDATE,
+		Short:   "Validate that the backup sets and archive pieces are restorable.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupValidate(opts)
+		}),
+		Example: validateCmdExample(),
+	})
+
+	validateCmd.Flags().SortFlags = false
+	validateCmd.VarsPs(&opts.TenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant to validate backup sets.", false)
+	validateCmd.VarsPs(&opts.BackupSetID, []string{FLAG_BACKUP_SET_ID, FLAG_BACKUP_SET_ID_SH}, int64(0), "The id of the backup set to validate, all successful backup sets are validated if not specified", false)
+	validateCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return validateCmd.Command
+}
+
+func backupValidate(opts *BackupValidateFlags) error {
+	validateParam := &param.BackupValidateParam{}
+	if opts.BackupSetID != 0 {
+		if opts.TenantName == "" {
+			return errors.Occurf(errors.ErrCliUsageError, "--%s must be specified with --%s", tenant.FLAG_TENANT_NAME, FLAG_BACKUP_SET_ID)
+		}
+		validateParam.BackupSetID = &opts.BackupSetID
+	}
+
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_VALIDATE
+	if opts.TenantName != "" {
+		uri = fmt.Sprintf("%s/%s%s%s", constant.URI_TENANT_API_PREFIX, opts.TenantName, constant.URI_BACKUP, constant.URI_VALIDATE)
+	}
+	dag, err := api.CallApiAndPrintStage(uri, validateParam)
+	if err != nil {
+		return err
+	}
+	log.Info(dag)
+	return nil
+}
+
+func validateCmdExample() string {
+	return `  Validate all backup sets of the entire cluster:
+    obshell backup validate
+
+  Validate a specific backup set of a tenant:
+    obshell backup validate -t tenant1 -b 3
+`
+}
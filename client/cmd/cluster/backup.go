@@ -79,7 +79,7 @@ func newBackupCmd() *cobra.Command {
 	backupCmd.VarsPs(&opts.Mode, []string{FLAG_BACKUP_MODE, FLAG_BACKUP_MODE_SH}, "", fmt.Sprintf("The backup mode: '%s' for incremental backup or '%s' for a full backup. Defaults: '%s'.", constant.BACKUP_MODE_INCREMENTAL, constant.BACKUP_MODE_FULL, constant.BACKUP_MODE_FULL), false)
 	backupCmd.VarsPs(&opts.LogArchiveConcurrency, []string{FLAG_LOG_ARCHIVE_CONCURRENCY, FLAG_LOG_ARCHIVE_CONCURRENCY_SH}, "", "Configure the total number of working threads for log archiving.", false)
 	backupCmd.VarsPs(&opts.Binding, []string{FLAG_BINDING, FLAG_BINDING_SH}, "", fmt.Sprintf("Set the archiving and business priority mode. Supports '%s' and '%s' modes. Defaults: '%s'.", constant.BINDING_MODE_OPTIONAL, constant.BINDING_MODE_MANDATORY, constant.BINDING_MODE_OPTIONAL), false)
-	backupCmd.VarsPs(&opts.Encryption, []string{FLAG_ENCRYPTION, FLAG_ENCRYPTION_SH}, "", "The password for encrypting the backup set.", false)
+	backupCmd.VarsPs(&opts.Encryption, []string{FLAG_ENCRYPTION, FLAG_ENCRYPTION_SH}, "", "The password for encrypting the backup set, stored as the new backup key if it differs from the active one.", false)
 	backupCmd.VarsPs(&opts.HaLowThreadScore, []string{FLAG_HA_LOW_THREAD_SCORE, FLAG_HA_LOW_THREAD_SCORE_SH}, "", "Specifies the number of current working threads for low-priority threads.", false)
 	backupCmd.VarsPs(&opts.PieceSwitchInterval, []string{FLAG_PIECE_SWITCH_INTERVAL, FLAG_PIECE_SWITCH_INTERVAL_SH}, "", "Configure the piece switch interval. Range: [1d, 7d].", false)
 	backupCmd.VarsPs(&opts.ArchiveLagTarget, []string{FLAG_ARCHIVE_LAG_TARGET, FLAG_ARCHIVE_LAG_TARGET_SH}, "", "Sets the target lag time for log archiving processes", false)
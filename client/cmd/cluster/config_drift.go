@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+var configDriftApiPrefix = constant.URI_API_V1 + constant.URI_OBSERVER_GROUP + constant.URI_CONFIG + constant.URI_DRIFT
+
+type configDriftFlags struct {
+	refresh bool
+	verbose bool
+}
+
+func newConfigDriftCmd() *cobra.Command {
+	opts := &configDriftFlags{}
+	driftCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DRIFT,
+		Short: "Display the observer configs persisted by obshell which differ from the live parameters.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return showConfigDrift(opts)
+		}),
+		Example: `  obshell cluster parameter drift
+  obshell cluster parameter drift --refresh`,
+	})
+	driftCmd.Flags().SortFlags = false
+	driftCmd.VarsPs(&opts.refresh, []string{FLAG_REFRESH}, false, "Check the drift right now rather than display the latest periodic check.", false)
+	driftCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return driftCmd.Command
+}
+
+func showConfigDrift(opts *configDriftFlags) error {
+	var report bo.ConfigDriftReport
+	if err := api.CallApiWithMethod(http.GET, fmt.Sprintf("%s?refresh=%t", configDriftApiPrefix, opts.refresh), nil, &report); err != nil {
+		return err
+	}
+	if len(report.Drifts) == 0 {
+		stdio.Infof("No config drift is found at %s.", report.CheckTime.Local().Format(time.DateTime))
+		return nil
+	}
+	printer.PrintConfigDrifts(fmt.Sprintf("Config Drift (checked at %s)", report.CheckTime.Local().Format(time.DateTime)), report.Drifts)
+	return nil
+}
+
+type reconcileConfigDriftFlags struct {
+	parameterConfirmFlags
+	direction string
+	names     string
+	servers   string
+}
+
+func newReconcileConfigDriftCmd() *cobra.Command {
+	opts := &reconcileConfigDriftFlags{}
+	reconcileCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_RECONCILE,
+		Short: "Reconcile the drift between the persisted observer configs and the live parameters.",
+		Long: "Reconcile the drift between the observer configs persisted by obshell and the live parameters.\n" +
+			"persisted_to_live: set the live parameters to the persisted values.\n" +
+			"live_to_persisted: persist the live values as the server configs, which are used to start or take over the observers.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return reconcileConfigDrift(opts)
+		}),
+		Example: `  obshell cluster parameter reconcile -d live_to_persisted
+  obshell cluster parameter reconcile -d persisted_to_live -n memory_limit -s 192.168.1.1:2882`,
+	})
+	reconcileCmd.Flags().SortFlags = false
+	reconcileCmd.VarsPs(&opts.direction, []string{FLAG_DIRECTION, FLAG_DIRECTION_SH}, "", "The direction to reconcile, 'persisted_to_live' or 'live_to_persisted'.", true)
+	reconcileCmd.VarsPs(&opts.names, []string{FLAG_NAME, FLAG_NAME_SH}, "", "The configs to reconcile, separated by commas. All if unspecified.", false)
+	reconcileCmd.VarsPs(&opts.servers, []string{FLAG_SERVER, FLAG_SERVER_SH}, "", "The observers (ip:rpc_port) to reconcile, separated by commas. All if unspecified.", false)
+	setParameterConfirmFlags(reconcileCmd, &opts.parameterConfirmFlags)
+	return reconcileCmd.Command
+}
+
+func reconcileConfigDrift(opts *reconcileConfigDriftFlags) error {
+	direction := strings.ToUpper(opts.direction)
+	if direction != constant.CONFIG_DRIFT_PERSISTED_TO_LIVE && direction != constant.CONFIG_DRIFT_LIVE_TO_PERSISTED {
+		return errors.Occurf(errors.ErrCliUsageError, "invalid direction '%s', should be 'persisted_to_live' or 'live_to_persisted'", opts.direction)
+	}
+	reconcileParam := &param.ReconcileConfigDriftParam{
+		Direction: direction,
+		Names:     splitCommaList(opts.names),
+		Servers:   splitCommaList(opts.servers),
+	}
+
+	var report bo.ConfigDriftReport
+	if err := api.CallApiWithMethod(http.GET, configDriftApiPrefix+"?refresh=true", nil, &report); err != nil {
+		return err
+	}
+	if len(report.Drifts) == 0 {
+		stdio.Info("No config drift is found.")
+		return nil
+	}
+	printer.PrintConfigDrifts("Config Drift", report.Drifts)
+	if err := confirmParameterOperation(fmt.Sprintf("Please confirm if you need to reconcile the matched config drift as %s.", strings.ToLower(direction))); err != nil {
+		return err
+	}
+
+	var reconciliation ob.ConfigDriftReconciliation
+	if err := api.CallApiWithMethod(http.POST, configDriftApiPrefix+constant.URI_RECONCILE, reconcileParam, &reconciliation); err != nil {
+		return err
+	}
+	if len(reconciliation.Reconciled) == 0 {
+		stdio.Info("No config drift matches the filters.")
+		return nil
+	}
+	if reconciliation.Dag != nil {
+		if err := api.NewDagHandler(reconciliation.Dag).PrintDagStage(); err != nil {
+			return err
+		}
+	}
+	stdio.Successf("Reconciled %d config drift(s) as %s.", len(reconciliation.Reconciled), strings.ToLower(direction))
+	return nil
+}
+
+func splitCommaList(s string) []string {
+	res := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -93,10 +93,18 @@ const (
 
 	// CMD_SCALE_OUT represents the "scale-out" command.
 	CMD_SCALE_OUT = "scale-out"
+	// Flags for the "scale-out" command.
+	FLAG_FILE    = "file"
+	FLAG_FILE_SH = "f"
 
 	// CMD_SCALE_IN represents the "scale-in" command.
 	CMD_SCALE_IN = "scale-in"
 
+	// CMD_REPLACE_SERVER represents the "replace-server" command used to replace a failed server with current node.
+	CMD_REPLACE_SERVER = "replace-server"
+	// Flags for the "replace-server" command.
+	FLAG_OLD_SERVER = "old_server"
+
 	// CMD_UPGRADE represents the "upgrade" command for upgrading the cluster.
 	CMD_UPGRADE = "upgrade"
 	// Flags for the "upgrade" command.
@@ -108,6 +116,46 @@ const (
 	FLAG_MODE_SH        = "m"
 	FLAG_UPGRADE_DIR    = "tmp_directory"
 	FLAG_UPGRADE_DIR_SH = "t"
+	// Flags for rolling upgrade with per-zone approval.
+	FLAG_ZONE_APPROVAL           = "zone_approval"
+	FLAG_APPROVAL_TIMEOUT        = "approval_timeout"
+	FLAG_APPROVAL_TIMEOUT_ACTION = "approval_timeout_action"
+	// Subcommands of the "upgrade" command used to handle zone approval gates and roll back a failed upgrade.
+	CMD_APPROVE     = "approve"
+	CMD_ABORT       = "abort"
+	CMD_GATES       = "gates"
+	CMD_ROLLBACK    = "rollback"
+	FLAG_COMMENT    = "comment"
+	FLAG_COMMENT_SH = "c"
+
+	// CMD_MAINTENANCE represents the "maintenance" command used to put a zone into or out of maintenance.
+	CMD_MAINTENANCE = "maintenance"
+	// Subcommands of the "maintenance" command.
+	CMD_ENTER = "enter"
+	CMD_EXIT  = "exit"
+
+	// CMD_PARAMETER represents the "parameter" command used to handle the parameter change history and snapshots.
+	CMD_PARAMETER = "parameter"
+	// Subcommands of the "parameter" command.
+	CMD_HISTORY   = "history"
+	CMD_SNAPSHOT  = "snapshot"
+	CMD_CREATE    = "create"
+	CMD_LIST      = "list"
+	CMD_DELETE    = "delete"
+	CMD_DIFF      = "diff"
+	CMD_DRIFT     = "drift"
+	CMD_RECONCILE = "reconcile"
+	// Flags for the "parameter" command.
+	FLAG_NAME          = "name"
+	FLAG_NAME_SH       = "n"
+	FLAG_TENANT        = "tenant"
+	FLAG_TENANT_SH     = "t"
+	FLAG_TARGET_TENANT = "target_tenant"
+	FLAG_LIMIT         = "limit"
+	FLAG_LIMIT_SH      = "l"
+	FLAG_REFRESH       = "refresh"
+	FLAG_DIRECTION     = "direction"
+	FLAG_DIRECTION_SH  = "d"
 
 	// CMD_SHOW represents the "show" command used to display information about the cluster status.
 	CMD_SHOW = "show"
@@ -154,7 +202,7 @@ func NewClusterCmd() *cobra.Command {
 			case CMD_START:
 				AsyncCheckAndStartDaemon()
 				fmt.Println("Starting the OceanBase cluster, please wait...")
-			case CMD_STOP, CMD_SHOW, CMD_SCALE_OUT, CMD_UPGRADE, CMD_SCALE_IN:
+			case CMD_STOP, CMD_SHOW, CMD_SCALE_OUT, CMD_UPGRADE, CMD_SCALE_IN, CMD_REPLACE_SERVER, CMD_ENTER, CMD_EXIT:
 				return CheckAndStartDaemon(true)
 			default:
 				return CheckAndStartDaemon()
@@ -169,8 +217,11 @@ func NewClusterCmd() *cobra.Command {
 	clusterCmd.AddCommand(newUpgradeCmd())
 	clusterCmd.AddCommand(NewScaleOutCmd())
 	clusterCmd.AddCommand(NewScaleInCmd())
+	clusterCmd.AddCommand(newReplaceServerCmd())
 	clusterCmd.AddCommand(newShowCmd())
 	clusterCmd.AddCommand(newStopCmd())
 	clusterCmd.AddCommand(newBackupCmd())
+	clusterCmd.AddCommand(newMaintenanceCmd())
+	clusterCmd.AddCommand(newParameterCmd())
 	return clusterCmd.Command
 }
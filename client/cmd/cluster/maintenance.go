@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+)
+
+type maintenanceFlags struct {
+	zone        string
+	verbose     bool
+	skipConfirm bool
+}
+
+func newMaintenanceCmd() *cobra.Command {
+	maintenanceCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MAINTENANCE,
+		Short: "Put a zone into or out of maintenance.",
+	})
+	maintenanceCmd.AddCommand(newMaintenanceEnterCmd())
+	maintenanceCmd.AddCommand(newMaintenanceExitCmd())
+	return maintenanceCmd.Command
+}
+
+func newMaintenanceEnterCmd() *cobra.Command {
+	opts := &maintenanceFlags{}
+	enterCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ENTER,
+		Short: "Stop the zone to drain its leaders and stop all observers in it.",
+		Long:  "Check that every tenant keeps the majority without the zone, stop the zone to drain its leaders, wait for the leaders to leave and the log streams in sync and then stop all observers in the zone.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return zoneMaintenance(http.POST, opts.zone, fmt.Sprintf("Please confirm if you need to put '%s' into maintenance, all observers in it will be stopped.", opts.zone))
+		}),
+		Example: `  obshell cluster maintenance enter -z zone1`,
+	})
+	setMaintenanceFlags(enterCmd, opts)
+	return enterCmd.Command
+}
+
+func newMaintenanceExitCmd() *cobra.Command {
+	opts := &maintenanceFlags{}
+	exitCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_EXIT,
+		Short: "Start all observers in the zone and restore leaders to the primary zone.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return zoneMaintenance(http.DELETE, opts.zone, fmt.Sprintf("Please confirm if you need to bring '%s' back from maintenance.", opts.zone))
+		}),
+		Example: `  obshell cluster maintenance exit -z zone1`,
+	})
+	setMaintenanceFlags(exitCmd, opts)
+	return exitCmd.Command
+}
+
+func setMaintenanceFlags(cmd *command.Command, opts *maintenanceFlags) {
+	cmd.Flags().SortFlags = false
+	cmd.VarsPs(&opts.zone, []string{FLAG_ZONE, FLAG_ZONE_SH}, "", "The zone to handle.", true)
+	cmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	cmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+}
+
+func zoneMaintenance(method string, zone string, message string) error {
+	if zone == "" {
+		return errors.Occur(errors.ErrObZoneNameEmpty)
+	}
+	pass, err := stdio.Confirm(message)
+	if err != nil {
+		return errors.Wrap(err, "ask for maintenance confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	var dag task.DagDetailDTO
+	uri := constant.URI_ZONE_API_PREFIX + "/" + zone + constant.URI_MAINTENANCE
+	if err := api.CallApiWithMethod(method, uri, nil, &dag); err != nil {
+		return err
+	}
+	return api.NewDagHandler(&dag).PrintDagStage()
+}
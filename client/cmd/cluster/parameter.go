@@ -0,0 +1,355 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const parameterHistoryDefaultLimit = 20
+
+var parameterApiPrefix = constant.URI_OBCLUSTER_API_PREFIX + constant.URI_PARAMETERS
+
+func newParameterCmd() *cobra.Command {
+	parameterCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PARAMETER,
+		Short: "Trace, diff and roll back the parameter and variable changes, and reconcile the config drift.",
+	})
+	parameterCmd.AddCommand(newParameterHistoryCmd())
+	parameterCmd.AddCommand(newParameterRollbackCmd())
+	parameterCmd.AddCommand(newParameterSnapshotCmd())
+	parameterCmd.AddCommand(newParameterDiffCmd())
+	parameterCmd.AddCommand(newConfigDriftCmd())
+	parameterCmd.AddCommand(newReconcileConfigDriftCmd())
+	return parameterCmd.Command
+}
+
+type parameterHistoryFlags struct {
+	name    string
+	tenant  string
+	limit   int
+	verbose bool
+}
+
+func newParameterHistoryCmd() *cobra.Command {
+	opts := &parameterHistoryFlags{}
+	historyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_HISTORY,
+		Short: "Display the parameter and variable changes made by obshell.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return showParameterHistory(opts)
+		}),
+		Example: `  obshell cluster parameter history
+  obshell cluster parameter history -n memstore_limit_percentage -t t1`,
+	})
+	historyCmd.Flags().SortFlags = false
+	historyCmd.VarsPs(&opts.name, []string{FLAG_NAME, FLAG_NAME_SH}, "", "The parameter or variable name to filter.", false)
+	historyCmd.VarsPs(&opts.tenant, []string{FLAG_TENANT, FLAG_TENANT_SH}, "", "The tenant name to filter.", false)
+	historyCmd.VarsPs(&opts.limit, []string{FLAG_LIMIT, FLAG_LIMIT_SH}, parameterHistoryDefaultLimit, "The max number of changes to display.", false)
+	historyCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return historyCmd.Command
+}
+
+func showParameterHistory(opts *parameterHistoryFlags) error {
+	query := url.Values{}
+	if opts.name != "" {
+		query.Set("name", opts.name)
+	}
+	if opts.tenant != "" {
+		query.Set("tenant", opts.tenant)
+	}
+	query.Set("limit", strconv.Itoa(opts.limit))
+
+	var changes []bo.ParameterChange
+	uri := parameterApiPrefix + constant.URI_HISTORY + "?" + query.Encode()
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &changes); err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		stdio.Info("No parameter change has been recorded.")
+		return nil
+	}
+	printer.PrintParameterChanges(changes)
+	return nil
+}
+
+type parameterConfirmFlags struct {
+	verbose     bool
+	skipConfirm bool
+}
+
+func setParameterConfirmFlags(cmd *command.Command, opts *parameterConfirmFlags) {
+	cmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	cmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+}
+
+func confirmParameterOperation(message string) error {
+	pass, err := stdio.Confirm(message)
+	if err != nil {
+		return errors.Wrap(err, "ask for parameter confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+	return nil
+}
+
+func newParameterRollbackCmd() *cobra.Command {
+	opts := &parameterConfirmFlags{}
+	rollbackCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ROLLBACK,
+		Short: "Set the parameter or variable back to the old value of the change.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return rollbackParameterChange(args[0])
+		}),
+		Example: `  obshell cluster parameter rollback 12`,
+	})
+	rollbackCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<change-id>"}
+	setParameterConfirmFlags(rollbackCmd, opts)
+	return rollbackCmd.Command
+}
+
+func rollbackParameterChange(id string) error {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "id", err.Error())
+	}
+	if err := confirmParameterOperation(fmt.Sprintf("Please confirm if you need to roll back the parameter change %s.", id)); err != nil {
+		return err
+	}
+
+	var change bo.ParameterChange
+	uri := parameterApiPrefix + constant.URI_HISTORY + "/" + id + constant.URI_ROLLBACK
+	if err := api.CallApiWithMethod(http.POST, uri, nil, &change); err != nil {
+		return err
+	}
+	stdio.Successf("Rolled back %s '%s' from '%s' to '%s'.", change.Category, change.Name, change.OldValue, change.NewValue)
+	return nil
+}
+
+func newParameterSnapshotCmd() *cobra.Command {
+	snapshotCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SNAPSHOT,
+		Short: "Save, compare and restore the snapshot of all the parameters and variables.",
+	})
+	snapshotCmd.AddCommand(newParameterSnapshotCreateCmd())
+	snapshotCmd.AddCommand(newParameterSnapshotListCmd())
+	snapshotCmd.AddCommand(newParameterSnapshotDeleteCmd())
+	snapshotCmd.AddCommand(newParameterSnapshotDiffCmd())
+	snapshotCmd.AddCommand(newParameterSnapshotRollbackCmd())
+	return snapshotCmd.Command
+}
+
+func newParameterSnapshotCreateCmd() *cobra.Command {
+	var verbose bool
+	var comment string
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Save the current parameters and variables as a snapshot.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			p := param.CreateParameterSnapshotParam{Name: args[0], Comment: comment}
+			if err := api.CallApiWithMethod(http.POST, parameterApiPrefix+constant.URI_SNAPSHOTS, p, nil); err != nil {
+				return err
+			}
+			stdio.Successf("Parameter snapshot '%s' is created.", args[0])
+			return nil
+		}),
+		Example: `  obshell cluster parameter snapshot create before_tuning -c "before tuning memstore"`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<snapshot-name>"}
+	createCmd.VarsPs(&comment, []string{FLAG_COMMENT, FLAG_COMMENT_SH}, "", "The comment of the snapshot.", false)
+	createCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return createCmd.Command
+}
+
+func newParameterSnapshotListCmd() *cobra.Command {
+	var verbose bool
+	listCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_LIST,
+		Short: "List the parameter snapshots.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			var snapshots []bo.ParameterSnapshot
+			if err := api.CallApiWithMethod(http.GET, parameterApiPrefix+constant.URI_SNAPSHOTS, nil, &snapshots); err != nil {
+				return err
+			}
+			if len(snapshots) == 0 {
+				stdio.Info("No parameter snapshot.")
+				return nil
+			}
+			printer.PrintParameterSnapshots(snapshots)
+			return nil
+		}),
+		Example: `  obshell cluster parameter snapshot list`,
+	})
+	listCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return listCmd.Command
+}
+
+func newParameterSnapshotDeleteCmd() *cobra.Command {
+	opts := &parameterConfirmFlags{}
+	deleteCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DELETE,
+		Short: "Delete the parameter snapshot.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			if err := confirmParameterOperation(fmt.Sprintf("Please confirm if you need to delete the parameter snapshot '%s'.", args[0])); err != nil {
+				return err
+			}
+			if err := api.CallApiWithMethod(http.DELETE, parameterApiPrefix+constant.URI_SNAPSHOTS+"/"+args[0], nil, nil); err != nil {
+				return err
+			}
+			stdio.Successf("Parameter snapshot '%s' is deleted.", args[0])
+			return nil
+		}),
+		Example: `  obshell cluster parameter snapshot delete before_tuning`,
+	})
+	deleteCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<snapshot-name>"}
+	setParameterConfirmFlags(deleteCmd, opts)
+	return deleteCmd.Command
+}
+
+func newParameterSnapshotDiffCmd() *cobra.Command {
+	var verbose bool
+	diffCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DIFF,
+		Short: "Compare the snapshot with the current parameters and variables.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			var diffs []bo.ParameterDiff
+			uri := parameterApiPrefix + constant.URI_SNAPSHOTS + "/" + args[0] + constant.URI_DIFF
+			if err := api.CallApiWithMethod(http.GET, uri, nil, &diffs); err != nil {
+				return err
+			}
+			if len(diffs) == 0 {
+				stdio.Infof("Nothing has changed since the snapshot '%s'.", args[0])
+				return nil
+			}
+			printer.PrintParameterDiffs(fmt.Sprintf("Snapshot '%s' (base) vs Current (target)", args[0]), diffs)
+			return nil
+		}),
+		Example: `  obshell cluster parameter snapshot diff before_tuning`,
+	})
+	diffCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<snapshot-name>"}
+	diffCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return diffCmd.Command
+}
+
+func newParameterSnapshotRollbackCmd() *cobra.Command {
+	opts := &parameterConfirmFlags{}
+	rollbackCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ROLLBACK,
+		Short: "Set the parameters and variables which differ from the snapshot back to the snapshot.",
+		Long:  "Set the parameters and variables which differ from the snapshot back to the snapshot. The readonly parameters, the charset and collation variables, and the servers or tenants which no longer exist are skipped.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			if err := confirmParameterOperation(fmt.Sprintf("Please confirm if you need to roll back the parameters and variables to the snapshot '%s'.", args[0])); err != nil {
+				return err
+			}
+			var changes []bo.ParameterChange
+			uri := parameterApiPrefix + constant.URI_SNAPSHOTS + "/" + args[0] + constant.URI_ROLLBACK
+			if err := api.CallApiWithMethod(http.POST, uri, nil, &changes); err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				stdio.Infof("Nothing has changed since the snapshot '%s'.", args[0])
+				return nil
+			}
+			printer.PrintParameterChanges(changes)
+			stdio.Successf("Rolled back %d items to the snapshot '%s'.", len(changes), args[0])
+			return nil
+		}),
+		Example: `  obshell cluster parameter snapshot rollback before_tuning`,
+	})
+	rollbackCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<snapshot-name>"}
+	setParameterConfirmFlags(rollbackCmd, opts)
+	return rollbackCmd.Command
+}
+
+type parameterDiffFlags struct {
+	tenant       string
+	targetTenant string
+	verbose      bool
+}
+
+func newParameterDiffCmd() *cobra.Command {
+	opts := &parameterDiffFlags{}
+	diffCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DIFF,
+		Short: "Compare the tenant parameters and variables between two tenants.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return diffTenantParameters(opts)
+		}),
+		Example: `  obshell cluster parameter diff -t t1 --target_tenant t2`,
+	})
+	diffCmd.Flags().SortFlags = false
+	diffCmd.VarsPs(&opts.tenant, []string{FLAG_TENANT, FLAG_TENANT_SH}, "", "The base tenant.", true)
+	diffCmd.VarsPs(&opts.targetTenant, []string{FLAG_TARGET_TENANT}, "", "The target tenant.", true)
+	diffCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return diffCmd.Command
+}
+
+func diffTenantParameters(opts *parameterDiffFlags) error {
+	query := url.Values{}
+	query.Set("tenant", opts.tenant)
+	query.Set("target_tenant", opts.targetTenant)
+
+	var diffs []bo.ParameterDiff
+	if err := api.CallApiWithMethod(http.GET, parameterApiPrefix+constant.URI_DIFF+"?"+query.Encode(), nil, &diffs); err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		stdio.Infof("The tenant parameters and variables of '%s' and '%s' are the same.", opts.tenant, opts.targetTenant)
+		return nil
+	}
+	printer.PrintParameterDiffs(fmt.Sprintf("Tenant '%s' (base) vs Tenant '%s' (target)", opts.tenant, opts.targetTenant), diffs)
+	return nil
+}
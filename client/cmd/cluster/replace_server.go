@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	ocsagentlog "github.com/oceanbase/obshell/agent/log"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type ClusterReplaceServerFlags struct {
+	agent       string // the address of any agent in the target cluster
+	oldServer   string // the agent of the failed server
+	password    string
+	skipConfirm bool
+	verbose     bool
+	ObserverConfigFlags
+}
+
+func newReplaceServerCmd() *cobra.Command {
+	opts := &ClusterReplaceServerFlags{}
+	replaceCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_REPLACE_SERVER,
+		Short: "Replace a failed observer with current node in the same zone.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			ocsagentlog.SetDBLoggerLevel(ocsagentlog.Silent)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return clusterReplaceServer(cmd, opts)
+		}),
+		Example: `  obshell cluster replace-server -s 192.168.1.1:2886 --old_server 192.168.1.2:2886 --rp ****`,
+	})
+
+	replaceCmd.Flags().SortFlags = false
+	replaceCmd.VarsPs(&opts.agent, []string{FLAG_SERVER_SH, FLAG_SERVER}, "", "Any server in the cluster. If the port is unspecified, it will be 2886.", true)
+	replaceCmd.VarsPs(&opts.oldServer, []string{FLAG_OLD_SERVER}, "", "The failed server to be replaced. If the port is unspecified, it will be 2886.", true)
+
+	replaceCmd.VarsPs(&opts.mysqlPort, []string{FLAG_MYSQL_PORT_SH, FLAG_MYSQL_PORT}, 0, "The SQL service port for the current node.", false)
+	replaceCmd.VarsPs(&opts.rpcPort, []string{FLAG_RPC_PORT_SH, FLAG_RPC_PORT}, 0, "The remote access port for intra-cluster communication.", false)
+	replaceCmd.VarsPs(&opts.dataDir, []string{FLAG_DATA_DIR_SH, FLAG_DATA_DIR}, "", "The directory for storing the observer's data.", false)
+	replaceCmd.VarsPs(&opts.redoDir, []string{FLAG_REDO_DIR_SH, FLAG_REDO_DIR}, "", "The directory for storing the observer's clogs.", false)
+	replaceCmd.VarsPs(&opts.logLevel, []string{FLAG_LOG_LEVEL_SH, FLAG_LOG_LEVEL}, "", "The log print level for the observer.", false)
+	replaceCmd.VarsPs(&opts.optStr, []string{FLAG_OPT_STR_SH, FLAG_OPT_STR}, "", "Additional parameters for the observer, use the format key=value for each configuration, separated by commas.", false)
+	replaceCmd.VarsPs(&opts.password, []string{FLAG_PASSWORD, FLAG_PASSWORD_ALIAS}, "", "Password for OceanBase root@sys user.", false)
+	replaceCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	replaceCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+
+	return replaceCmd.Command
+}
+
+func clusterReplaceServer(cmd *cobra.Command, flags *ClusterReplaceServerFlags) error {
+	if err := parseObserverConfigFlags(cmd, &flags.ObserverConfigFlags); err != nil {
+		return err
+	}
+	targetAgentInfo, err := meta.ConvertAddressToAgentInfo(flags.agent)
+	if err != nil {
+		return err
+	}
+	oldAgentInfo, err := meta.ConvertAddressToAgentInfo(flags.oldServer)
+	if err != nil {
+		return err
+	}
+
+	stdio.StartLoading("Get my agent info")
+	myAgent, err := api.GetMyAgentInfo()
+	if err != nil {
+		stdio.LoadFailedWithoutMsg()
+		return err
+	}
+	stdio.StopLoading()
+	if !myAgent.IsSingleAgent() {
+		return errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, myAgent.String(), myAgent.GetIdentity(), meta.SINGLE)
+	}
+
+	pass, err := stdio.Confirm(fmt.Sprintf("Please confirm if you need to replace %s with current node %s via %s, %s will be deleted from the cluster after the replicas are rebuilt.",
+		oldAgentInfo.String(), myAgent.AgentInfo.String(), flags.agent, oldAgentInfo.String()))
+	if err != nil {
+		return errors.Wrap(err, "ask for replace-server confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+	meta.SetOceanbasePwd(flags.password)
+	replaceReq := &param.ClusterReplaceServerParam{
+		OldAgent:  *oldAgentInfo,
+		NewAgent:  myAgent.AgentInfo,
+		ObConfigs: flags.parsedConfig,
+	}
+	dag, err := callScaleOutApi(targetAgentInfo, constant.URI_OB_API_PREFIX+constant.URI_REPLACE, replaceReq)
+	if err != nil {
+		return err
+	}
+	log.Infof("Replace server with dag: %+v", dag)
+	return nil
+}
@@ -18,9 +18,12 @@ package cluster
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
 	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/engine/task"
@@ -55,9 +58,23 @@ type ClusterScaleOutFlags struct {
 	password    string
 	skipConfirm bool
 	verbose     bool
+	file        string // the yaml file of the servers to be scaled out in batch
 	ObserverConfigFlags
 }
 
+// batchScaleOutFile is the content of the yaml file for batch scale-out.
+type batchScaleOutFile struct {
+	ObConfigs map[string]string    `yaml:"ob_configs"` // Shared by all the servers.
+	Servers   []batchScaleOutAgent `yaml:"servers"`
+}
+
+type batchScaleOutAgent struct {
+	Server    string            `yaml:"server"` // The agent of the server, the port will be 2886 if unspecified.
+	Zone      string            `yaml:"zone"`
+	Password  string            `yaml:"agent_password"`
+	ObConfigs map[string]string `yaml:"ob_configs"` // Override the shared configs.
+}
+
 func NewScaleOutCmd() *cobra.Command {
 	opts := &ClusterScaleOutFlags{}
 	scaleOutCmd := command.NewCommand(&cobra.Command{
@@ -75,7 +92,8 @@ func NewScaleOutCmd() *cobra.Command {
 	scaleOutCmd.Flags().SortFlags = false
 	// Setup of required flags for 'scale-out' command.
 	scaleOutCmd.VarsPs(&opts.agent, []string{FLAG_SERVER_SH, FLAG_SERVER}, "", "Any server in the cluster. If the port is unspecified, it will be 2886.", true)
-	scaleOutCmd.VarsPs(&opts.zone, []string{FLAG_ZONE_SH, FLAG_ZONE}, "", "The zone in which you are located", true)
+	scaleOutCmd.VarsPs(&opts.zone, []string{FLAG_ZONE_SH, FLAG_ZONE}, "", "The zone in which you are located. Required unless --file is specified.", false)
+	scaleOutCmd.VarsPs(&opts.file, []string{FLAG_FILE_SH, FLAG_FILE}, "", "The yaml file listing the servers to be scaled out in one task, each with its own zone and configs.", false)
 
 	// Configuration of optional flags for more detailed setup.
 	scaleOutCmd.VarsPs(&opts.mysqlPort, []string{FLAG_MYSQL_PORT_SH, FLAG_MYSQL_PORT}, 0, "The SQL service port for the current node.", false)
@@ -92,6 +110,15 @@ func NewScaleOutCmd() *cobra.Command {
 }
 
 func clusterScaleOut(cmd *cobra.Command, flags *ClusterScaleOutFlags) (err error) {
+	if flags.file != "" {
+		if flags.zone != "" {
+			return errors.Occur(errors.ErrCliUsageError, "zone should be specified for each server in the file when --file is specified")
+		}
+		return clusterBatchScaleOut(cmd, flags)
+	}
+	if flags.zone == "" {
+		return errors.Occur(errors.ErrCliUsageError, "zone is required")
+	}
 	if err := parseObserverConfigFlags(cmd, &flags.ObserverConfigFlags); err != nil {
 		return err
 	}
@@ -113,7 +140,7 @@ func clusterScaleOut(cmd *cobra.Command, flags *ClusterScaleOutFlags) (err error
 	if err != nil {
 		return err
 	}
-	dag, err := callScaleOutApi(targetAgentInfo, scaleOutReq)
+	dag, err := callScaleOutApi(targetAgentInfo, constant.URI_OB_API_PREFIX+constant.URI_SCALE_OUT, scaleOutReq)
 	if err != nil {
 		return err
 	}
@@ -122,8 +149,92 @@ func clusterScaleOut(cmd *cobra.Command, flags *ClusterScaleOutFlags) (err error
 	return
 }
 
-func callScaleOutApi(agent meta.AgentInfoInterface, param interface{}) (*task.DagDetailDTO, error) {
-	dag, err := api.CallApiViaTCP(agent, constant.URI_OB_API_PREFIX+constant.URI_SCALE_OUT, param)
+func clusterBatchScaleOut(cmd *cobra.Command, flags *ClusterScaleOutFlags) error {
+	if err := parseObserverConfigFlags(cmd, &flags.ObserverConfigFlags); err != nil {
+		return err
+	}
+	targetAgentInfo, err := meta.ConvertAddressToAgentInfo(flags.agent)
+	if err != nil {
+		return err
+	}
+	batchReq, err := buildBatchScaleOutParam(flags)
+	if err != nil {
+		return err
+	}
+
+	servers := make([]string, 0, len(batchReq.Agents))
+	for _, agent := range batchReq.Agents {
+		servers = append(servers, fmt.Sprintf("%s(%s)", agent.AgentInfo.String(), agent.Zone))
+	}
+	pass, err := stdio.Confirm(fmt.Sprintf("Please confirm if you need to scale out %s into the cluster via %s.", strings.Join(servers, ", "), flags.agent))
+	if err != nil {
+		return errors.Wrap(err, "ask for scale-out confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+	meta.SetOceanbasePwd(flags.password)
+	dag, err := callScaleOutApi(targetAgentInfo, constant.URI_OB_API_PREFIX+constant.URI_SCALE_OUT, batchReq)
+	if err != nil {
+		return err
+	}
+	log.Infof("Batch scale out with dag: %+v", dag)
+	return nil
+}
+
+// buildBatchScaleOutParam builds the batch scale-out param from the file,
+// the configs set by flags are shared by all the servers.
+func buildBatchScaleOutParam(flags *ClusterScaleOutFlags) (*param.ClusterScaleOutParam, error) {
+	content, err := os.ReadFile(flags.file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file %s failed", flags.file)
+	}
+	var file batchScaleOutFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, errors.Wrapf(err, "parse file %s failed", flags.file)
+	}
+	if len(file.Servers) == 0 {
+		return nil, errors.Occurf(errors.ErrCliUsageError, "no server is specified in %s", flags.file)
+	}
+
+	sharedConfigs := make(map[string]string)
+	for k, v := range file.ObConfigs {
+		sharedConfigs[k] = v
+	}
+	for k, v := range flags.parsedConfig {
+		if val, ok := sharedConfigs[k]; ok && val != v {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "Duplicate observer config: %s", k)
+		}
+		sharedConfigs[k] = v
+	}
+
+	batchReq := &param.ClusterScaleOutParam{
+		ObConfigs: sharedConfigs,
+		Agents:    make([]param.ScaleOutAgentParam, 0, len(file.Servers)),
+	}
+	for _, server := range file.Servers {
+		agentInfo, err := meta.ConvertAddressToAgentInfo(server.Server)
+		if err != nil {
+			return nil, err
+		}
+		if server.Zone == "" {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "zone of %s is not specified", server.Server)
+		}
+		if server.ObConfigs == nil {
+			server.ObConfigs = make(map[string]string)
+		}
+		batchReq.Agents = append(batchReq.Agents, param.ScaleOutAgentParam{
+			AgentInfo:           *agentInfo,
+			Zone:                server.Zone,
+			ObConfigs:           server.ObConfigs,
+			TargetAgentPassword: server.Password,
+		})
+	}
+	return batchReq, nil
+}
+
+func callScaleOutApi(agent meta.AgentInfoInterface, uri string, param interface{}) (*task.DagDetailDTO, error) {
+	dag, err := api.CallApiViaTCP(agent, uri, param)
 	if err != nil {
 		return nil, err
 	}
@@ -160,5 +271,6 @@ func buildScaleOutParam(flags *ClusterScaleOutFlags) (*param.ScaleOutParam, erro
 }
 
 func scaleOutCmdExample() string {
-	return `  obshell cluster scale-out -s 192.168.1.1:2886 -z zone1 --rp ****`
+	return `  obshell cluster scale-out -s 192.168.1.1:2886 -z zone1 --rp ****
+  obshell cluster scale-out -s 192.168.1.1:2886 -f servers.yaml --rp ****`
 }
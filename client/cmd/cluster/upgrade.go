@@ -41,7 +41,10 @@ import (
 	"github.com/oceanbase/obshell/utils"
 )
 
-var upgradeFlagUsage = fmt.Sprintf("Cluster upgrade mode: '%s' or '%s'", ob.PARAM_ROLLING_UPGRADE, ob.PARAM_STOP_SERVICE_UPGRADE)
+var (
+	upgradeFlagUsage           = fmt.Sprintf("Cluster upgrade mode: '%s' or '%s'", ob.PARAM_ROLLING_UPGRADE, ob.PARAM_STOP_SERVICE_UPGRADE)
+	approvalTimeoutActionUsage = fmt.Sprintf("Action on approval timeout: '%s' or '%s'", constant.UPGRADE_GATE_TIMEOUT_ACTION_APPROVE, constant.UPGRADE_GATE_TIMEOUT_ACTION_ABORT)
+)
 
 type clusterUpgradeFlags struct {
 	pkgDir      string
@@ -50,6 +53,10 @@ type clusterUpgradeFlags struct {
 	upgradeDir  string
 	skipConfirm bool
 	verbose     bool
+
+	zoneApproval          bool
+	approvalTimeout       string
+	approvalTimeoutAction string
 }
 
 func newUpgradeCmd() *cobra.Command {
@@ -73,9 +80,16 @@ func newUpgradeCmd() *cobra.Command {
 	upgradeCmd.VarsPs(&opts.version, []string{FLAG_VERSION, FLAG_VERSION_SH}, "", "Target build version for the OceanBase upgrade", false)
 	upgradeCmd.VarsPs(&opts.mode, []string{FLAG_MODE, FLAG_MODE_SH}, ob.PARAM_ROLLING_UPGRADE, upgradeFlagUsage, false)
 	upgradeCmd.VarsPs(&opts.upgradeDir, []string{FLAG_UPGRADE_DIR, FLAG_UPGRADE_DIR_SH}, "", "Temporary directory used by upgrade tasks", false)
+	upgradeCmd.VarsPs(&opts.zoneApproval, []string{FLAG_ZONE_APPROVAL}, false, "Pause the rolling upgrade after each zone until it is approved or aborted", false)
+	upgradeCmd.VarsPs(&opts.approvalTimeout, []string{FLAG_APPROVAL_TIMEOUT}, "", "How long a zone waits for approval, such as '2h'. Wait forever if not set.", false)
+	upgradeCmd.VarsPs(&opts.approvalTimeoutAction, []string{FLAG_APPROVAL_TIMEOUT_ACTION}, constant.UPGRADE_GATE_TIMEOUT_ACTION_ABORT, approvalTimeoutActionUsage, false)
 	upgradeCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
 	upgradeCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
 
+	upgradeCmd.AddCommand(newUpgradeApproveCmd())
+	upgradeCmd.AddCommand(newUpgradeAbortCmd())
+	upgradeCmd.AddCommand(newUpgradeGatesCmd())
+	upgradeCmd.AddCommand(newUpgradeRollbackCmd())
 	return upgradeCmd.Command
 }
 
@@ -164,6 +178,9 @@ func checkFlagsForUpgrade(opts *clusterUpgradeFlags) (err error) {
 	default:
 		return errors.Occur(errors.ErrObUpgradeModeNotSupported, opts.mode)
 	}
+	if opts.zoneApproval && mode != ob.PARAM_ROLLING_UPGRADE {
+		return errors.Occur(errors.ErrObUpgradeApprovalNotSupported)
+	}
 	return nil
 }
 
@@ -187,6 +204,10 @@ func obUpgrade(params *param.ObUpgradeParam) (err error) {
 	if err != nil {
 		return err
 	}
+	if params.ZoneApproval {
+		stdio.Infof("The upgrade pauses after each zone, run '%s %s %s %s' to continue or '%s %s %s %s' to stop it.",
+			CLUSTER_CMD, CMD_UPGRADE, CMD_APPROVE, dag.GenericID, CLUSTER_CMD, CMD_UPGRADE, CMD_ABORT, dag.GenericID)
+	}
 	dagHandler := api.NewDagHandler(dag)
 	dagHandler.SetRetryTimes(600)
 	dagHandler.SetForUpgrade()
@@ -239,7 +260,12 @@ func getUpgradeParams(opts *clusterUpgradeFlags, pkgs map[string]*rpm.Package) (
 			Release:    fmt.Sprintf("%s%s", items[1], constant.DIST),
 			UpgradeDir: opts.upgradeDir,
 		},
-		Mode: opts.mode,
+		Mode:         opts.mode,
+		ZoneApproval: opts.zoneApproval,
+	}
+	if opts.zoneApproval {
+		params.ApprovalTimeout = opts.approvalTimeout
+		params.ApprovalTimeoutAction = opts.approvalTimeoutAction
 	}
 	log.Infof("upgrade params: %#+v", params)
 	return params, nil
@@ -327,5 +353,7 @@ func getAllOBRpmsInDir(pkgDir string) (rpmPkgs map[string]*rpm.Package, err erro
 
 func upgradeCmdExample() string {
 	return `  obshell cluster upgrade -d /home/oceanbase/upgrade/  
-  obshell cluster upgrade -d /home/oceanbase/upgrade/ -V 4.2.1.0-20231224224959 -m stopService`
+  obshell cluster upgrade -d /home/oceanbase/upgrade/ -V 4.2.1.0-20231224224959 -m stopService
+  obshell cluster upgrade -d /home/oceanbase/upgrade/ --zone_approval --approval_timeout 2h
+  obshell cluster upgrade rollback 21100000000000000001`
 }
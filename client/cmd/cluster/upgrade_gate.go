@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+type upgradeGateFlags struct {
+	zone    string
+	comment string
+	verbose bool
+}
+
+func newUpgradeApproveCmd() *cobra.Command {
+	opts := &upgradeGateFlags{}
+	approveCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_APPROVE,
+		Short: "Approve the zone waiting for approval and continue the rolling upgrade.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return closeUpgradeGate(constant.URI_APPROVE, args[0], opts)
+		}),
+		Example: `  obshell cluster upgrade approve 21100000000000000001
+  obshell cluster upgrade approve 21100000000000000001 -z zone1 -c 'soak passed'`,
+	})
+	setUpgradeGateFlags(approveCmd, opts)
+	return approveCmd.Command
+}
+
+func newUpgradeAbortCmd() *cobra.Command {
+	opts := &upgradeGateFlags{}
+	abortCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ABORT,
+		Short: "Abort the rolling upgrade at the zone waiting for approval.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return closeUpgradeGate(constant.URI_ABORT, args[0], opts)
+		}),
+		Example: `  obshell cluster upgrade abort 21100000000000000001 -c 'error rate increased'`,
+	})
+	setUpgradeGateFlags(abortCmd, opts)
+	return abortCmd.Command
+}
+
+func setUpgradeGateFlags(cmd *command.Command, opts *upgradeGateFlags) {
+	cmd.Flags().SortFlags = false
+	cmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<dag-id>"}
+	cmd.VarsPs(&opts.zone, []string{FLAG_ZONE, FLAG_ZONE_SH}, "", "The zone waiting for approval. Any waiting zone if not set.", false)
+	cmd.VarsPs(&opts.comment, []string{FLAG_COMMENT, FLAG_COMMENT_SH}, "", "The comment recorded with the decision.", false)
+	cmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+}
+
+func closeUpgradeGate(action string, dagID string, opts *upgradeGateFlags) error {
+	var gate bo.UpgradeZoneGate
+	params := param.UpgradeGateParam{
+		DagID:   dagID,
+		Zone:    opts.zone,
+		Comment: opts.comment,
+	}
+	uri := constant.URI_OB_API_PREFIX + constant.URI_UPGRADE + action
+	if err := api.CallApiWithMethod(http.POST, uri, params, &gate); err != nil {
+		return err
+	}
+	if gate.Status == constant.UPGRADE_GATE_STATUS_APPROVED {
+		stdio.Successf("Zone %s of upgrade %s is approved, the upgrade continues.", gate.Zone, dagID)
+	} else {
+		stdio.Successf("Zone %s of upgrade %s is aborted, the upgrade stops.", gate.Zone, dagID)
+	}
+	return nil
+}
+
+func newUpgradeGatesCmd() *cobra.Command {
+	var verbose bool
+	gatesCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_GATES,
+		Short: "List the zone approval gates of a rolling upgrade.",
+		Args:  cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return listUpgradeGates(args[0])
+		}),
+		Example: `  obshell cluster upgrade gates 21100000000000000001`,
+	})
+	gatesCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<dag-id>"}
+	gatesCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return gatesCmd.Command
+}
+
+func listUpgradeGates(dagID string) error {
+	var gates []bo.UpgradeZoneGate
+	uri := constant.URI_OB_API_PREFIX + constant.URI_UPGRADE + constant.URI_GATES
+	if err := api.CallApiWithMethod(http.GET, uri, param.UpgradeGateParam{DagID: dagID}, &gates); err != nil {
+		return err
+	}
+	if len(gates) == 0 {
+		stdio.Infof("No zone of upgrade %s has reached its approval gate yet.", dagID)
+		return nil
+	}
+	printer.PrintUpgradeZoneGates(gates)
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+type upgradeRollbackFlags struct {
+	skipConfirm bool
+	verbose     bool
+}
+
+func newUpgradeRollbackCmd() *cobra.Command {
+	opts := &upgradeRollbackFlags{}
+	rollbackCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ROLLBACK,
+		Short: "Roll back the zones switched by a failed rolling upgrade to the previous observer.",
+		Long: "Roll back the zones switched by the failed upgrade process of a rolling upgrade. " +
+			"The observer backed up before the upgrade process is restored zone by zone and the parameters are restored. " +
+			"The failed upgrade task can not be retried after rollback.",
+		Args: cobra.ExactArgs(1),
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetSilenceMode(false)
+			return rollbackUpgrade(args[0])
+		}),
+		Example: `  obshell cluster upgrade rollback 21100000000000000001`,
+	})
+	rollbackCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<dag-id>"}
+	rollbackCmd.Flags().SortFlags = false
+	rollbackCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	rollbackCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return rollbackCmd.Command
+}
+
+func rollbackUpgrade(dagID string) error {
+	uri := constant.URI_OB_API_PREFIX + constant.URI_UPGRADE + constant.URI_ROLLBACK
+	rollbackParam := param.UpgradeRollbackParam{DagID: dagID}
+	var point param.UpgradeRollbackPoint
+	if err := api.CallApiWithMethod(http.GET, uri, rollbackParam, &point); err != nil {
+		return err
+	}
+	printer.PrintUpgradeRollbackPoint(&point)
+
+	pass, err := stdio.Confirmf("Please confirm if you need to roll back upgrade process %d of %s", point.RouteIndex, dagID)
+	if err != nil {
+		return errors.Wrap(err, "ask for rollback confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	var dag task.DagDetailDTO
+	if err = api.CallApiWithMethod(http.POST, uri, rollbackParam, &dag); err != nil {
+		return err
+	}
+	dagHandler := api.NewDagHandler(&dag)
+	dagHandler.SetRetryTimes(600)
+	if err = dagHandler.PrintDagStage(); err != nil {
+		return err
+	}
+	log.Info("upgrade rollback dag: ", dag)
+
+	if dagHandler.Dag != nil && dagHandler.Dag.AdditionalData != nil {
+		if data, ok := (*dagHandler.Dag.AdditionalData)[ob.ADDL_KEY_ROLLBACK_POINT]; ok {
+			if b, err := json.Marshal(data); err == nil && json.Unmarshal(b, &point) == nil {
+				printer.PrintUpgradeRollbackPoint(&point)
+			}
+		}
+	}
+	return nil
+}
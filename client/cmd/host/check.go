@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	ocsagentlog "github.com/oceanbase/obshell/agent/log"
+	"github.com/oceanbase/obshell/agent/meta"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+type checkFlags struct {
+	servers string
+	all     bool
+	profile string
+	dataDir string
+	redoDir string
+	verbose bool
+}
+
+func newCheckCmd() *cobra.Command {
+	opts := &checkFlags{}
+	checkCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CHECK,
+		Short: "Check whether the hosts meet the requirements of OceanBase.",
+		Long: "Evaluate the CPU, memory, ulimits, kernel parameters, clock synchronization and disks of the hosts " +
+			"against the requirement profile, and report PASS, WARN or FAIL with the fix for each item. " +
+			"The profile matching the local observer binary is used unless specified. " +
+			"Check the hosts to be joined or scaled out by --server before deploying.",
+		Args: cobra.NoArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			ocsagentlog.SetDBLoggerLevel(ocsagentlog.Silent)
+			stdio.SetVerboseMode(opts.verbose)
+			return hostCheck(opts)
+		}),
+		Example: `  obshell host check
+  obshell host check -a
+  obshell host check -s 192.168.1.1,192.168.1.2:2886 --profile 4.3 -d /data/1 -r /data/log1`,
+	})
+
+	checkCmd.Flags().SortFlags = false
+	checkCmd.VarsPs(&opts.servers, []string{FLAG_SERVER_SH, FLAG_SERVER}, "", "The servers to be checked, separated by commas. If the port is unspecified, it will be 2886.", false)
+	checkCmd.VarsPs(&opts.all, []string{FLAG_ALL_SH, FLAG_ALL}, false, "Check all the servers in the cluster.", false)
+	checkCmd.VarsPs(&opts.profile, []string{FLAG_PROFILE}, "", "The requirement profile, such as 4.2 or 4.3.", false)
+	checkCmd.VarsPs(&opts.dataDir, []string{FLAG_DATA_DIR_SH, FLAG_DATA_DIR}, "", "The directory for storing the observer's data.", false)
+	checkCmd.VarsPs(&opts.redoDir, []string{FLAG_REDO_DIR_SH, FLAG_REDO_DIR}, "", "The directory for storing the observer's clogs.", false)
+	checkCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return checkCmd.Command
+}
+
+func hostCheck(opts *checkFlags) error {
+	if opts.all && opts.servers != "" {
+		return errors.Occurf(errors.ErrCliUsageError, "--%s and --%s cannot be specified at the same time", FLAG_ALL, FLAG_SERVER)
+	}
+	checkParam := param.HostCheckParam{
+		Profile: opts.profile,
+		DataDir: opts.dataDir,
+		RedoDir: opts.redoDir,
+	}
+	for _, server := range strings.Split(opts.servers, ",") {
+		if server = strings.TrimSpace(server); server == "" {
+			continue
+		}
+		agentInfo, err := meta.ConvertAddressToAgentInfo(server)
+		if err != nil {
+			return err
+		}
+		checkParam.Servers = append(checkParam.Servers, *agentInfo)
+	}
+
+	var reports []bo.HostCheckReport
+	if opts.all || len(checkParam.Servers) != 0 {
+		if err := api.CallApiWithMethod(http.POST, constant.URI_OB_API_PREFIX+constant.URI_HOST_CHECK, checkParam, &reports); err != nil {
+			return err
+		}
+	} else {
+		var report bo.HostCheckReport
+		if err := api.CallApiWithMethod(http.POST, constant.URI_AGENT_API_PREFIX+constant.URI_HOST_CHECK, checkParam, &report); err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	failed := make([]string, 0)
+	for i := range reports {
+		printer.PrintHostCheckReport(&reports[i])
+		if reports[i].Result == constant.HOST_CHECK_RESULT_FAIL {
+			failed = append(failed, reports[i].Agent)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Occur(errors.ErrCliHostCheckFailed, strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/global"
+	"github.com/oceanbase/obshell/client/cmd/cluster"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+)
+
+const (
+	// obshell host check
+	CMD_CHECK = "check"
+
+	FLAG_SERVER      = "server"
+	FLAG_SERVER_SH   = "s"
+	FLAG_ALL         = "all"
+	FLAG_ALL_SH      = "a"
+	FLAG_PROFILE     = "profile"
+	FLAG_DATA_DIR    = "data_dir"
+	FLAG_DATA_DIR_SH = "d"
+	FLAG_REDO_DIR    = "redo_dir"
+	FLAG_REDO_DIR_SH = "r"
+)
+
+func NewHostCmd() *cobra.Command {
+	hostCmd := command.NewCommand(&cobra.Command{
+		Use:   clientconst.CMD_HOST,
+		Short: "Check the hosts of obshell.",
+		Args:  cobra.NoArgs,
+		PersistentPreRunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			defer stdio.StopLoading()
+			global.InitGlobalVariable()
+			return cluster.CheckAndStartDaemon()
+		}),
+	})
+	hostCmd.AddCommand(newCheckCmd())
+	return hostCmd.Command
+}
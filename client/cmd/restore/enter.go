@@ -43,5 +43,9 @@ func NewRestoreCmd() *cobra.Command {
 	})
 	taskCmd.AddCommand(newShowCmd())
 	taskCmd.AddCommand(newCancelCmd())
+	taskCmd.AddCommand(newTableCmd())
+	taskCmd.AddCommand(newPlanCmd())
+	taskCmd.AddCommand(newWindowsCmd())
+	taskCmd.AddCommand(newPointCmd())
 	return taskCmd.Command
 }
@@ -0,0 +1,306 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restore
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_PLAN = "plan"
+)
+
+type RestorePlanFlags struct {
+	DataBackupBaseUri string
+	ArchiveLogBaseUri string
+
+	verbose bool
+}
+
+func newPlanCmd() *cobra.Command {
+	opts := &RestorePlanFlags{}
+	planCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PLAN,
+		Short: "Interactively plan and start a restore from the backups of any cluster.",
+		Long: "Discover the tenant backups and their restore windows under the base uri, which may be written by a cluster managed by another obshell, " +
+			"then choose the backup, the restore point, the zone and unit mapping and the decryption passwords step by step, " +
+			"and restore the tenant into the current cluster.",
+		Args: cobra.NoArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return restorePlan(opts)
+		}),
+		Example: planCmdExample(),
+	})
+
+	planCmd.Flags().SortFlags = false
+	planCmd.VarsPs(&opts.DataBackupBaseUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The base path of the backups, prompted if not set.", false)
+	planCmd.VarsPs(&opts.ArchiveLogBaseUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The base path of the archive logs, the same as the backups if not set.", false)
+	planCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return planCmd.Command
+}
+
+func restorePlan(opts *RestorePlanFlags) (err error) {
+	if opts.DataBackupBaseUri == "" {
+		if opts.DataBackupBaseUri, err = stdio.Input("The base path of the backups", ""); err != nil {
+			return err
+		}
+		if opts.DataBackupBaseUri == "" {
+			return errors.Occur(errors.ErrCliUsageError, "the base path of the backups is required")
+		}
+	}
+	if opts.ArchiveLogBaseUri == "" {
+		opts.ArchiveLogBaseUri = opts.DataBackupBaseUri
+	}
+
+	sources, err := listRestoreSources(opts)
+	if err != nil {
+		return err
+	}
+	printer.PrintRestoreSources(sources)
+
+	source, err := selectRestoreSource(sources)
+	if err != nil {
+		return err
+	}
+	restoreParam, err := buildPlannedRestoreParam(opts, source)
+	if err != nil {
+		return err
+	}
+
+	printRestorePlan(restoreParam, source)
+	if err = tenant.ConfirmRestore(); err != nil {
+		return err
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + constant.URI_RESTORE
+	dag, err := api.CallApiAndPrintStage(uri, restoreParam)
+	if err != nil {
+		return err
+	}
+	log.Info("Restore tenant successfully, DAG ID: ", dag.DagID)
+	return nil
+}
+
+func listRestoreSources(opts *RestorePlanFlags) (sources []param.RestoreSource, err error) {
+	sourceParam := param.RestoreSourceParam{
+		DataBackupBaseUri: opts.DataBackupBaseUri,
+		ArchiveLogBaseUri: &opts.ArchiveLogBaseUri,
+	}
+	stdio.StartLoading("Discover the tenant backups")
+	defer stdio.StopLoading()
+	uri := constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_SOURCES
+	if err = api.CallApiWithMethod(http.POST, uri, sourceParam, &sources); err != nil {
+		return nil, err
+	}
+	stdio.LoadSuccessf("Found %d tenant backups", len(sources))
+	return sources, nil
+}
+
+func selectRestoreSource(sources []param.RestoreSource) (*param.RestoreSource, error) {
+	if len(sources) == 1 {
+		return &sources[0], nil
+	}
+	for {
+		input, err := stdio.Input(fmt.Sprintf("Select the backup to restore [1-%d]", len(sources)), "")
+		if err != nil {
+			return nil, err
+		}
+		if no, err := strconv.Atoi(input); err == nil && no >= 1 && no <= len(sources) {
+			return &sources[no-1], nil
+		}
+		stdio.Warnf("Invalid backup number '%s'", input)
+	}
+}
+
+func buildPlannedRestoreParam(opts *RestorePlanFlags, source *param.RestoreSource) (*param.RestoreParam, error) {
+	dataBackupUri, err := sourceURI(opts.DataBackupBaseUri, source.DataSubpath)
+	if err != nil {
+		return nil, err
+	}
+	archiveLogUri, err := sourceURI(opts.ArchiveLogBaseUri, source.ArchiveLogSubpath)
+	if err != nil {
+		return nil, err
+	}
+	restoreParam := &param.RestoreParam{
+		RestoreWindowsParam: param.RestoreWindowsParam{
+			DataBackupUri: dataBackupUri,
+			ArchiveLogUri: &archiveLogUri,
+		},
+	}
+
+	for restoreParam.TenantName == "" {
+		if restoreParam.TenantName, err = stdio.Input("The name of the restored tenant", ""); err != nil {
+			return nil, err
+		}
+	}
+	if err = inputRestorePoint(restoreParam); err != nil {
+		return nil, err
+	}
+	if restoreParam.ZoneList, err = inputZoneList(); err != nil {
+		return nil, err
+	}
+
+	primaryZone, err := stdio.Input("The primary zone of the restored tenant", constant.PRIMARY_ZONE_RANDOM)
+	if err != nil {
+		return nil, err
+	}
+	restoreParam.PrimaryZone = &primaryZone
+
+	decryption, err := stdio.InputPassword("The decryption passwords of the backups, separated by ','(enter means the stored backup keys): ")
+	if err != nil {
+		return nil, err
+	}
+	stdio.Print("") // just for a new line
+	if pwds := splitList(decryption); len(pwds) > 0 {
+		restoreParam.Decryption = &pwds
+	}
+	return restoreParam, nil
+}
+
+// sourceURI returns the uri with secret of the tenant backup under the base uri.
+func sourceURI(baseUri, subpath string) (string, error) {
+	storage, err := system.GetStorageInterfaceByURI(baseUri)
+	if err != nil {
+		return "", errors.Occur(errors.ErrCliUsageError, err.Error())
+	}
+	if subpath != "" {
+		storage = storage.NewWithObjectKey(subpath)
+	}
+	return storage.GenerateURI(), nil
+}
+
+func inputRestorePoint(restoreParam *param.RestoreParam) error {
+	for {
+		input, err := stdio.Input("The time in RFC3339 format or the SCN to restore to (enter means the latest)", "")
+		if err != nil {
+			return err
+		}
+		if input == "" {
+			return nil
+		}
+		if scn, err := strconv.ParseInt(input, 10, 64); err == nil && scn > 0 {
+			restoreParam.SCN = &scn
+			return nil
+		}
+		if timestamp, err := time.Parse(time.RFC3339, input); err == nil {
+			restoreParam.Timestamp = &timestamp
+			return nil
+		}
+		stdio.Warnf("Invalid restore point '%s'", input)
+	}
+}
+
+// inputZoneList maps each zone of the current cluster to the unit config and unit num of the restored tenant.
+func inputZoneList() ([]param.ZoneParam, error) {
+	obInfo, err := api.GetObInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ob info")
+	}
+	zones := make([]string, 0, len(obInfo.Config.ZoneConfig))
+	for zone := range obInfo.Config.ZoneConfig {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	zoneList := make([]param.ZoneParam, 0)
+	for _, zone := range zones {
+		unitConfigName, err := stdio.Input(fmt.Sprintf("The unit config of zone '%s' (enter means skip the zone)", zone), "")
+		if err != nil {
+			return nil, err
+		}
+		if unitConfigName == "" {
+			continue
+		}
+		unitNum := 0
+		for unitNum <= 0 {
+			input, err := stdio.Input(fmt.Sprintf("The unit num of zone '%s'", zone), fmt.Sprint(constant.RESTORE_UNIT_NUM_DEFAULT))
+			if err != nil {
+				return nil, err
+			}
+			if unitNum, err = strconv.Atoi(input); err != nil || unitNum <= 0 {
+				stdio.Warnf("Invalid unit num '%s'", input)
+				unitNum = 0
+			}
+		}
+		zoneList = append(zoneList, param.ZoneParam{
+			Name: zone,
+			PoolParam: param.PoolParam{
+				UnitConfigName: unitConfigName,
+				UnitNum:        unitNum,
+			},
+		})
+	}
+	if len(zoneList) == 0 {
+		return nil, errors.Occur(errors.ErrObTenantZoneListEmpty)
+	}
+	return zoneList, nil
+}
+
+func printRestorePlan(restoreParam *param.RestoreParam, source *param.RestoreSource) {
+	restorePoint := "latest"
+	if restoreParam.Timestamp != nil {
+		restorePoint = restoreParam.Timestamp.Format(time.RFC3339)
+	} else if restoreParam.SCN != nil {
+		restorePoint = fmt.Sprintf("SCN %d", *restoreParam.SCN)
+	}
+	zones := make([]string, 0, len(restoreParam.ZoneList))
+	for _, zone := range restoreParam.ZoneList {
+		zones = append(zones, fmt.Sprintf("%s: %s * %d", zone.Name, zone.UnitConfigName, zone.UnitNum))
+	}
+	decryption := "stored backup keys"
+	if restoreParam.Decryption != nil {
+		decryption = fmt.Sprintf("%d passwords", len(*restoreParam.Decryption))
+	}
+	data := [][]string{
+		{"TENANT_NAME", restoreParam.TenantName},
+		{"DATA_BACKUP_URI", source.DataBackupUri},
+		{"ARCHIVE_LOG_URI", source.ArchiveLogUri},
+		{"RESTORE_POINT", restorePoint},
+		{"ZONE_LIST", strings.Join(zones, "\n")},
+		{"PRIMARY_ZONE", *restoreParam.PrimaryZone},
+		{"DECRYPTION", decryption},
+	}
+	stdio.PrintTable(nil, data)
+}
+
+func planCmdExample() string {
+	return `  obshell restore plan
+  obshell restore plan -d 'file:///data/nfs/backup'
+  obshell restore plan -d 'oss://bucket/backup?host=xxx&access_id=xxx&access_key=xxx'`
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restore
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_POINT = "point"
+)
+
+type RestorePointFlags struct {
+	DataBackupUri string
+	ArchiveLogUri string
+	Timestamp     string
+	Timezone      string
+	SCN           int64
+
+	verbose bool
+}
+
+func newPointCmd() *cobra.Command {
+	opts := &RestorePointFlags{}
+	pointCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_POINT,
+		Short: "Find the closest restorable point to a time and estimate the restore.",
+		Long: "Find the closest restorable SCN to the wall-clock time in any timezone or the SCN, " +
+			"and display the backup sets and archive pieces it needs with the estimated restore size and duration.",
+		Args: cobra.NoArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return showRestorePoint(opts)
+		}),
+		Example: pointCmdExample(),
+	})
+
+	pointCmd.Flags().SortFlags = false
+	pointCmd.VarsPs(&opts.DataBackupUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored.", true)
+	pointCmd.VarsPs(&opts.ArchiveLogUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored, the same as the backups if not set.", false)
+	pointCmd.VarsPs(&opts.Timestamp, []string{tenant.FLAG_TIMESTAMP, tenant.FLAG_TIMESTAMP_SH}, "", "The time to restore to, such as '2024-05-01 12:00:00' or '2024-05-01T12:00:00+08:00'.", false)
+	pointCmd.VarsPs(&opts.Timezone, []string{tenant.FLAG_TIMEZONE}, "", "The timezone of the time without offset, such as 'Asia/Shanghai' or '+08:00'. Default is the local timezone.", false)
+	pointCmd.VarsPs(&opts.SCN, []string{tenant.FLAG_SCN, tenant.FLAG_SCN_SH}, int64(0), "The SCN to restore to", false)
+	pointCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return pointCmd.Command
+}
+
+func showRestorePoint(opts *RestorePointFlags) error {
+	if (opts.Timestamp == "") == (opts.SCN == 0) {
+		return errors.Occur(errors.ErrCliUsageError, "exactly one of --timestamp and --scn is required")
+	}
+	pointParam := param.RestorePointParam{
+		RestoreWindowsParam: param.RestoreWindowsParam{
+			DataBackupUri: opts.DataBackupUri,
+			ArchiveLogUri: &opts.ArchiveLogUri,
+		},
+	}
+	if opts.Timestamp != "" {
+		if opts.Timezone == "" {
+			// The time is in the local timezone of the client, which may differ from the agent.
+			t, err := parse.HumanTimeParse(opts.Timestamp, time.Local)
+			if err != nil {
+				return err
+			}
+			opts.Timestamp = t.Format(time.RFC3339Nano)
+		}
+		pointParam.Time = &opts.Timestamp
+		pointParam.Timezone = &opts.Timezone
+	} else {
+		pointParam.SCN = &opts.SCN
+	}
+
+	stdio.StartLoading("Find the restore point")
+	var point param.RestorePoint
+	uri := constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_POINT
+	if err := api.CallApiWithMethod(http.POST, uri, pointParam, &point); err != nil {
+		stdio.StopLoading()
+		return err
+	}
+	stdio.StopLoading()
+
+	printer.PrintRestorePoint(&point)
+	return nil
+}
+
+func pointCmdExample() string {
+	return `  obshell restore point -d file:///data/backup/data -a file:///data/backup/clog -T '2024-05-01 12:00:00' --timezone Asia/Shanghai
+  obshell restore point -d file:///data/backup/data -S 1714536000000000000
+`
+}
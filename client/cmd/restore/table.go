@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_TABLE = "table"
+
+	FLAG_DATABASES      = "databases"
+	FLAG_TABLES         = "tables"
+	FLAG_REMAP_DATABASE = "remap_database"
+	FLAG_REMAP_TABLE    = "remap_table"
+)
+
+type RecoverTableFlags struct {
+	TenantName string
+
+	DataBackupUri  string
+	ArchiveLogUri  string
+	Timestamp      string
+	SCN            int64
+	Databases      string
+	Tables         string
+	RemapDatabases string
+	RemapTables    string
+	PrimaryZone    string
+	Concurrency    string
+	Decryption     string
+	KmsEncryptInfo string
+
+	verbose     bool
+	skipConfirm bool
+
+	replica.ZoneParamsFlags
+}
+
+func newTableCmd() *cobra.Command {
+	opts := &RecoverTableFlags{}
+	tableCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_TABLE,
+		Short:   "Restore databases and tables from backup into an existing tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetSilenceMode(false)
+
+			opts.TenantName = args[0]
+			return recoverTable(cmd, opts)
+		}),
+		Example: tableCmdExample(),
+	})
+
+	tableCmd.Flags().SortFlags = false
+	tableCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	tableCmd.VarsPs(&opts.DataBackupUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored.", true)
+
+	tableCmd.VarsPs(&opts.Databases, []string{FLAG_DATABASES}, "", "The databases to restore with all their tables, separated by ','.", false)
+	tableCmd.VarsPs(&opts.Tables, []string{FLAG_TABLES}, "", "The tables in 'database.table' format to restore, separated by ','.", false)
+	tableCmd.VarsPs(&opts.RemapDatabases, []string{FLAG_REMAP_DATABASE}, "", "The database remapping in 'source:target' format, separated by ','.", false)
+	tableCmd.VarsPs(&opts.RemapTables, []string{FLAG_REMAP_TABLE}, "", "The table remapping in 'database.table:[database.]table' format, separated by ','.", false)
+	tableCmd.VarsPs(&opts.Timestamp, []string{tenant.FLAG_TIMESTAMP, tenant.FLAG_TIMESTAMP_SH}, "", "The timestamp to restore to.", false)
+	tableCmd.VarsPs(&opts.SCN, []string{tenant.FLAG_SCN, tenant.FLAG_SCN_SH}, int64(0), "The SCN to restore to", false)
+	tableCmd.VarsPs(&opts.ArchiveLogUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored.", false)
+
+	tableCmd.VarsPs(&opts.Zones, []string{tenant.FLAG_ZONE, tenant.FLAG_ZONE_SH}, "", "The zones of the auxiliary tenant.", false)
+	tableCmd.VarsPs(&opts.UnitConfigName, []string{tenant.FLAG_UNIT, tenant.FLAG_UNIT_SH}, "", "The unit config name of the auxiliary tenant.", false)
+	tableCmd.VarsPs(&opts.PrimaryZone, []string{tenant.FLAG_PRIMARY_ZONE, tenant.FLAG_PRIMARY_ZONE_SH}, "", "The primary zone of the auxiliary tenant.", false)
+	tableCmd.VarsPs(&opts.Concurrency, []string{tenant.FLAG_CONCURRENCY, tenant.FLAG_CONCURRENCY_SH}, "", "The number of threads to use for the restore operation.", false)
+	tableCmd.VarsPs(&opts.Decryption, []string{tenant.FLAG_DECRYPTION, tenant.FLAG_DECRYPTION_SH}, "", "The decryption password for all backups, the stored backup keys are used if not set.", false)
+	tableCmd.VarsPs(&opts.KmsEncryptInfo, []string{tenant.FLAG_KMS_ENCRYPT_INFO, tenant.FLAG_KMS_ENCRYPT_INFO_SH}, "", "The KMS encryption information.", false)
+
+	tableCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	tableCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return tableCmd.Command
+}
+
+func recoverTable(cmd *cobra.Command, opts *RecoverTableFlags) error {
+	recoverParam, err := opts.toRecoverTableParam(cmd)
+	if err != nil {
+		return err
+	}
+
+	res, err := stdio.Confirmf("Please confirm if you need to restore the tables into tenant '%s'", opts.TenantName)
+	if err != nil {
+		return errors.Wrap(err, "ask for restore confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	uri := fmt.Sprintf("%s/%s%s%s", constant.URI_TENANT_API_PREFIX, opts.TenantName, constant.URI_RESTORE, constant.URI_TABLES)
+	dag, err := api.CallApiAndPrintStage(uri, recoverParam)
+	if err != nil {
+		return err
+	}
+	log.Info("Restore tables successfully, DAG ID: ", dag.DagID)
+	return nil
+}
+
+func splitList(value string) []string {
+	res := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func parseRemap(flag, value string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, item := range splitList(value) {
+		source, target, found := strings.Cut(item, ":")
+		if !found || source == "" || target == "" {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "invalid --%s '%s', should be in 'source:target' format", flag, item)
+		}
+		res[source] = target
+	}
+	return res, nil
+}
+
+func (f *RecoverTableFlags) toRecoverTableParam(cmd *cobra.Command) (*param.RecoverTableParam, error) {
+	f.UnitNum = constant.RESTORE_UNIT_NUM_DEFAULT
+	zoneList, err := replica.BuildZoneParams(cmd, &f.ZoneParamsFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	recoverParam := &param.RecoverTableParam{
+		RestoreWindowsParam: param.RestoreWindowsParam{
+			DataBackupUri: f.DataBackupUri,
+		},
+		Databases: splitList(f.Databases),
+		Tables:    splitList(f.Tables),
+		ZoneList:  zoneList,
+	}
+	stdio.Verbosef("Zone list is %v", recoverParam.ZoneList)
+
+	if recoverParam.RemapDatabases, err = parseRemap(FLAG_REMAP_DATABASE, f.RemapDatabases); err != nil {
+		return nil, err
+	}
+	if recoverParam.RemapTables, err = parseRemap(FLAG_REMAP_TABLE, f.RemapTables); err != nil {
+		return nil, err
+	}
+
+	if f.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, f.Timestamp)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid timestamp")
+		}
+		recoverParam.Timestamp = &timestamp
+	}
+	if f.SCN != 0 {
+		recoverParam.SCN = &f.SCN
+	}
+	if f.ArchiveLogUri != "" {
+		recoverParam.ArchiveLogUri = &f.ArchiveLogUri
+	}
+	if f.PrimaryZone != "" {
+		recoverParam.PrimaryZone = &f.PrimaryZone
+	}
+	if f.Concurrency != "" {
+		concurrency, err := strconv.Atoi(f.Concurrency)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid concurrency")
+		}
+		recoverParam.Concurrency = &concurrency
+	}
+	if f.Decryption != "" {
+		pwds := strings.Split(strings.TrimSpace(f.Decryption), ",")
+		recoverParam.Decryption = &pwds
+	}
+	if f.KmsEncryptInfo != "" {
+		recoverParam.KmsEncryptInfo = &f.KmsEncryptInfo
+	}
+	return recoverParam, nil
+}
+
+func tableCmdExample() string {
+	return `  # Restore a dropped table under a new name at a specific time.
+	obshell restore table tenant1 -d '/path/to/backup/data' -a '/path/to/backup/clog' -z zone1 -u unit1 --tables db1.t1 --remap_table db1.t1:t1_restored -T "2024-01-01T00:00:00.000+08:00"
+
+  # Restore a whole database into another database at a specific SCN.
+	obshell restore table tenant1 -d '/path/to/backup/data' -z zone1 -u unit1 --databases db1 --remap_database db1:db1_restored -S 1704038400000000000`
+}
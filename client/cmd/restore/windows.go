@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/client/cmd/tenant"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/client/utils/printer"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	CMD_WINDOWS = "windows"
+)
+
+type RestoreWindowsFlags struct {
+	DataBackupUri string
+	ArchiveLogUri string
+
+	verbose bool
+}
+
+func newWindowsCmd() *cobra.Command {
+	opts := &RestoreWindowsFlags{}
+	windowsCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_WINDOWS,
+		Short: "Display the merged restore windows of the backups in local time.",
+		Args:  cobra.NoArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return showRestoreWindows(opts)
+		}),
+		Example: windowsCmdExample(),
+	})
+
+	windowsCmd.Flags().SortFlags = false
+	windowsCmd.VarsPs(&opts.DataBackupUri, []string{tenant.FLAG_DATA_BACKUP_URI, tenant.FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored.", true)
+	windowsCmd.VarsPs(&opts.ArchiveLogUri, []string{tenant.FLAG_ARCHIVE_LOG_URI, tenant.FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored, the same as the backups if not set.", false)
+	windowsCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return windowsCmd.Command
+}
+
+func showRestoreWindows(opts *RestoreWindowsFlags) error {
+	windowsParam := param.RestoreWindowsParam{
+		DataBackupUri: opts.DataBackupUri,
+		ArchiveLogUri: &opts.ArchiveLogUri,
+	}
+	stdio.StartLoading("Get the restore windows")
+	var windows system.RestoreWindows
+	uri := constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_WINDOWS
+	if err := api.CallApiWithMethod(http.GET, uri, windowsParam, &windows); err != nil {
+		stdio.StopLoading()
+		return err
+	}
+	stdio.StopLoading()
+
+	printer.PrintRestoreWindows(windows.Windows)
+	return nil
+}
+
+func windowsCmdExample() string {
+	return `  obshell restore windows -d file:///data/backup/data -a file:///data/backup/clog
+`
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/executor/ob"
 	"github.com/oceanbase/obshell/client/command"
 	clientconst "github.com/oceanbase/obshell/client/constant"
 	cmdlib "github.com/oceanbase/obshell/client/lib/cmd"
@@ -89,6 +90,12 @@ func taskShow(flags *TaskShowFlags) (err error) {
 	}
 
 	for _, dag := range dags {
+		if strings.HasPrefix(dag.Name, ob.DAG_OB_ROLLING_UPGRADE) {
+			// Get the detail to know whether the upgrade is paused.
+			if detail, err := api.GetDagDetail(dag.GenericID); err == nil {
+				dag = detail
+			}
+		}
 		printer.PrintDagStruct(dag, false)
 		stdio.Print("")
 	}
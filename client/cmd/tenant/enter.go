@@ -23,6 +23,8 @@ import (
 	"github.com/oceanbase/obshell/client/cmd/cluster"
 	"github.com/oceanbase/obshell/client/cmd/tenant/parameter"
 	"github.com/oceanbase/obshell/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/client/cmd/tenant/resourcemanager"
+	"github.com/oceanbase/obshell/client/cmd/tenant/table"
 	"github.com/oceanbase/obshell/client/cmd/tenant/variable"
 	"github.com/oceanbase/obshell/client/command"
 	clientconst "github.com/oceanbase/obshell/client/constant"
@@ -65,6 +67,7 @@ const (
 	FLAG_UNIT_CONFIG_NAME_SH     = "u"
 	FLAG_TIMESTAMP               = "timestamp"
 	FLAG_TIMESTAMP_SH            = "T"
+	FLAG_TIMEZONE                = "timezone"
 	FLAG_SCN                     = "scn"
 	FLAG_SCN_SH                  = "S"
 	FLAG_HA_HIGH_THREAD_SCORE    = "ha_high_thread_score"
@@ -140,6 +143,8 @@ func NewTenantCmd() *cobra.Command {
 	tenantCmd.AddCommand(replica.NewReplicaCmd())
 	tenantCmd.AddCommand(variable.NewVariableCmd())
 	tenantCmd.AddCommand(parameter.NewParameterCmd())
+	tenantCmd.AddCommand(resourcemanager.NewResourceManagerCmd())
+	tenantCmd.AddCommand(table.NewTableCmd())
 	tenantCmd.AddCommand(newRenameCmd())
 	tenantCmd.AddCommand(newBackupCmd())
 	tenantCmd.AddCommand(newRestoreCmd())
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type directiveFlags struct {
+	planFlags
+	mgmtP1           int
+	utilizationLimit int
+	minIops          int
+	maxIops          int
+	weightIops       int
+}
+
+// optionalInt returns nil when the flag is not set by user.
+func optionalInt(cmd *cobra.Command, flag string, value int) *int {
+	if cmd.Flags().Changed(flag) {
+		return &value
+	}
+	return nil
+}
+
+func setDirectiveFlags(directiveCmd *command.Command, opts *directiveFlags) {
+	directiveCmd.VarsPs(&opts.mgmtP1, []string{FLAG_MGMT_P1}, 0, "CPU weight of the consumer group in percentage", false)
+	directiveCmd.VarsPs(&opts.utilizationLimit, []string{FLAG_UTILIZATION_LIMIT}, 0, "CPU upper limit of the consumer group in percentage", false)
+	directiveCmd.VarsPs(&opts.minIops, []string{FLAG_MIN_IOPS}, 0, "IO lower limit of the consumer group in percentage", false)
+	directiveCmd.VarsPs(&opts.maxIops, []string{FLAG_MAX_IOPS}, 0, "IO upper limit of the consumer group in percentage", false)
+	directiveCmd.VarsPs(&opts.weightIops, []string{FLAG_WEIGHT_IOPS}, 0, "IO weight of the consumer group in percentage", false)
+	directiveCmd.VarsPs(&opts.comment, []string{FLAG_COMMENT}, "", "Comment of the directive", false)
+	directiveCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	directiveCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+}
+
+func newDirectiveCmd() *cobra.Command {
+	directiveCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DIRECTIVE,
+		Short: "Manage the cpu and io directives of consumer groups in a resource plan.",
+	})
+	directiveCmd.AddCommand(newDirectiveCreateCmd())
+	directiveCmd.AddCommand(newDirectiveModifyCmd())
+	directiveCmd.AddCommand(newDirectiveDropCmd())
+	return directiveCmd.Command
+}
+
+func directiveUri(tenantName, planName string) string {
+	return resourceManagerUri(tenantName) + constant.URI_PLANS + "/" + planName + constant.URI_DIRECTIVES
+}
+
+func newDirectiveCreateCmd() *cobra.Command {
+	var opts directiveFlags
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create the directive of a consumer group in a resource plan.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name, plan name and consumer group name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.CreatePlanDirectiveParam{
+				TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)},
+				GroupName:               args[2],
+				Comment:                 opts.comment,
+				MgmtP1:                  optionalInt(cmd, FLAG_MGMT_P1, opts.mgmtP1),
+				UtilizationLimit:        optionalInt(cmd, FLAG_UTILIZATION_LIMIT, opts.utilizationLimit),
+				MinIops:                 optionalInt(cmd, FLAG_MIN_IOPS, opts.minIops),
+				MaxIops:                 optionalInt(cmd, FLAG_MAX_IOPS, opts.maxIops),
+				WeightIops:              optionalInt(cmd, FLAG_WEIGHT_IOPS, opts.weightIops),
+			}
+			stdio.StartLoadingf("create directive for %s in %s", args[2], args[1])
+			if err := api.CallApiWithMethod(http.POST, directiveUri(args[0], args[1]), params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("create directive for %s in %s", args[2], args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager directive create t1 daytime batch_group --mgmt_p1 20 --utilization_limit 40 --max_iops 30`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name> <group-name>"}
+	setDirectiveFlags(createCmd, &opts)
+	return createCmd.Command
+}
+
+func newDirectiveModifyCmd() *cobra.Command {
+	var opts directiveFlags
+	modifyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MODIFY,
+		Short: "Modify the directive of a consumer group in a resource plan.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name, plan name and consumer group name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.ModifyPlanDirectiveParam{
+				TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)},
+				MgmtP1:                  optionalInt(cmd, FLAG_MGMT_P1, opts.mgmtP1),
+				UtilizationLimit:        optionalInt(cmd, FLAG_UTILIZATION_LIMIT, opts.utilizationLimit),
+				MinIops:                 optionalInt(cmd, FLAG_MIN_IOPS, opts.minIops),
+				MaxIops:                 optionalInt(cmd, FLAG_MAX_IOPS, opts.maxIops),
+				WeightIops:              optionalInt(cmd, FLAG_WEIGHT_IOPS, opts.weightIops),
+			}
+			if cmd.Flags().Changed(FLAG_COMMENT) {
+				params.Comment = &opts.comment
+			}
+			stdio.StartLoadingf("modify directive for %s in %s", args[2], args[1])
+			if err := api.CallApiWithMethod(http.PATCH, directiveUri(args[0], args[1])+"/"+args[2], params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("modify directive for %s in %s", args[2], args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager directive modify t1 daytime batch_group --utilization_limit 60`,
+	})
+	modifyCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name> <group-name>"}
+	setDirectiveFlags(modifyCmd, &opts)
+	return modifyCmd.Command
+}
+
+func newDirectiveDropCmd() *cobra.Command {
+	var opts planFlags
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DROP,
+		Short: "Drop the directive of a consumer group in a resource plan.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name, plan name and consumer group name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)}
+			stdio.StartLoadingf("drop directive for %s in %s", args[2], args[1])
+			if err := api.CallApiWithMethod(http.DELETE, directiveUri(args[0], args[1])+"/"+args[2], params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("drop directive for %s in %s", args[2], args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager directive drop t1 daytime batch_group`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name> <group-name>"}
+	dropCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return dropCmd.Command
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/client/command"
+)
+
+const (
+	CMD_RESOURCE_MANAGER = "resource-manager"
+	CMD_RM               = "rm"
+
+	// obshell tenant resource-manager plan
+	CMD_PLAN = "plan"
+
+	// obshell tenant resource-manager group
+	CMD_GROUP = "group"
+
+	// obshell tenant resource-manager directive
+	CMD_DIRECTIVE = "directive"
+
+	// obshell tenant resource-manager mapping
+	CMD_MAPPING = "mapping"
+
+	// obshell tenant resource-manager usage
+	CMD_USAGE = "usage"
+
+	CMD_CREATE     = "create"
+	CMD_DROP       = "drop"
+	CMD_SHOW       = "show"
+	CMD_MODIFY     = "modify"
+	CMD_SET        = "set"
+	CMD_REMOVE     = "remove"
+	CMD_ACTIVATE   = "activate"
+	CMD_DEACTIVATE = "deactivate"
+
+	FLAG_ROOT_PASSWORD     = "root_password"
+	FLAG_COMMENT           = "comment"
+	FLAG_MGMT_P1           = "mgmt_p1"
+	FLAG_UTILIZATION_LIMIT = "utilization_limit"
+	FLAG_MIN_IOPS          = "min_iops"
+	FLAG_MAX_IOPS          = "max_iops"
+	FLAG_WEIGHT_IOPS       = "weight_iops"
+	FLAG_ATTRIBUTE         = "attribute"
+	FLAG_ATTRIBUTE_SH      = "a"
+	FLAG_VALUE             = "value"
+	FLAG_CONSUMER_GROUP    = "consumer_group"
+	FLAG_CONSUMER_GROUP_SH = "g"
+)
+
+func NewResourceManagerCmd() *cobra.Command {
+	resourceManagerCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_RESOURCE_MANAGER,
+		Short:   "Manage the resource plans, consumer groups and mappings of the tenant.",
+		Aliases: []string{CMD_RM},
+	})
+	resourceManagerCmd.AddCommand(newPlanCmd())
+	resourceManagerCmd.AddCommand(newGroupCmd())
+	resourceManagerCmd.AddCommand(newDirectiveCmd())
+	resourceManagerCmd.AddCommand(newMappingCmd())
+	resourceManagerCmd.AddCommand(newUsageCmd())
+	return resourceManagerCmd.Command
+}
+
+func resourceManagerUri(tenantName string) string {
+	return constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_RESOURCE_MANAGER
+}
+
+// rootPassword returns nil when the flag is not set, so that the agent
+// uses the tenant root password it has persisted.
+func rootPassword(cmd *cobra.Command, password string) *string {
+	if cmd.Flags().Changed(FLAG_ROOT_PASSWORD) {
+		return &password
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+func newGroupCmd() *cobra.Command {
+	groupCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_GROUP,
+		Short: "Manage the consumer groups of the tenant.",
+	})
+	groupCmd.AddCommand(newGroupCreateCmd())
+	groupCmd.AddCommand(newGroupDropCmd())
+	groupCmd.AddCommand(newGroupShowCmd())
+	return groupCmd.Command
+}
+
+func newGroupCreateCmd() *cobra.Command {
+	var opts planFlags
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create a consumer group.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name and consumer group name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.CreateConsumerGroupParam{
+				TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)},
+				GroupName:               args[1],
+				Comment:                 opts.comment,
+			}
+			stdio.StartLoadingf("create consumer group %s", args[1])
+			if err := api.CallApiWithMethod(http.POST, resourceManagerUri(args[0])+constant.URI_CONSUMER_GROUPS, params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("create consumer group %s", args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager group create t1 batch_group --comment "batch jobs"`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <group-name>"}
+	createCmd.VarsPs(&opts.comment, []string{FLAG_COMMENT}, "", "Comment of the consumer group", false)
+	createCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return createCmd.Command
+}
+
+func newGroupDropCmd() *cobra.Command {
+	var opts planFlags
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DROP,
+		Short: "Drop a consumer group.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name and consumer group name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)}
+			stdio.StartLoadingf("drop consumer group %s", args[1])
+			if err := api.CallApiWithMethod(http.DELETE, resourceManagerUri(args[0])+constant.URI_CONSUMER_GROUPS+"/"+args[1], params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("drop consumer group %s", args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager group drop t1 batch_group`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <group-name>"}
+	dropCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return dropCmd.Command
+}
+
+func newGroupShowCmd() *cobra.Command {
+	var opts planFlags
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the consumer groups.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			groups := make([]bo.ConsumerGroup, 0)
+			params := param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)}
+			if err := api.CallApiWithMethod(http.GET, resourceManagerUri(args[0])+constant.URI_CONSUMER_GROUPS, params, &groups); err != nil {
+				return err
+			}
+			if len(groups) == 0 {
+				stdio.Infof("No consumer group in tenant %s.", args[0])
+				return nil
+			}
+			data := make([][]string, 0)
+			for _, group := range groups {
+				data = append(data, []string{fmt.Sprint(group.ConsumerGroupId), group.ConsumerGroup, group.Comments})
+			}
+			stdio.PrintTable([]string{"ID", "Consumer Group", "Comments"}, data)
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager group show t1`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
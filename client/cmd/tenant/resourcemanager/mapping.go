@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type mappingFlags struct {
+	planFlags
+	attribute     string
+	value         string
+	consumerGroup string
+}
+
+func newMappingCmd() *cobra.Command {
+	mappingCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MAPPING,
+		Short: "Manage the user and column mappings of consumer groups.",
+	})
+	mappingCmd.AddCommand(newMappingSetCmd())
+	mappingCmd.AddCommand(newMappingRemoveCmd())
+	mappingCmd.AddCommand(newMappingShowCmd())
+	return mappingCmd.Command
+}
+
+func newMappingSetCmd() *cobra.Command {
+	var opts mappingFlags
+	setCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SET,
+		Short: "Map a user or column to a consumer group.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return setMapping(args[0], opts.attribute, opts.value, opts.consumerGroup, rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager mapping set t1 -a USER --value batch_user -g batch_group
+  obshell tenant resource-manager mapping set t1 -a COLUMN --value "test.t1.c1 = 1" -g batch_group`,
+	})
+	setCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	setCmd.VarsPs(&opts.attribute, []string{FLAG_ATTRIBUTE, FLAG_ATTRIBUTE_SH}, "USER", "Mapping attribute, 'USER' or 'COLUMN'", false)
+	setCmd.VarsPs(&opts.value, []string{FLAG_VALUE}, "", "User name, or column condition such as 'db.tbl.col = 1'", true)
+	setCmd.VarsPs(&opts.consumerGroup, []string{FLAG_CONSUMER_GROUP, FLAG_CONSUMER_GROUP_SH}, "", "Consumer group name", true)
+	setCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	setCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return setCmd.Command
+}
+
+func newMappingRemoveCmd() *cobra.Command {
+	var opts mappingFlags
+	removeCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_REMOVE,
+		Short: "Remove the consumer group mapping of a user or column.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return setMapping(args[0], opts.attribute, opts.value, "", rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager mapping remove t1 -a USER --value batch_user`,
+	})
+	removeCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	removeCmd.VarsPs(&opts.attribute, []string{FLAG_ATTRIBUTE, FLAG_ATTRIBUTE_SH}, "USER", "Mapping attribute, 'USER' or 'COLUMN'", false)
+	removeCmd.VarsPs(&opts.value, []string{FLAG_VALUE}, "", "User name, or column condition such as 'db.tbl.col = 1'", true)
+	removeCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	removeCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return removeCmd.Command
+}
+
+func setMapping(tenantName, attribute, value, consumerGroup string, password *string) error {
+	params := param.ConsumerGroupMappingParam{
+		TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: password},
+		Attribute:               attribute,
+		Value:                   value,
+		ConsumerGroup:           consumerGroup,
+	}
+	if consumerGroup == "" {
+		stdio.StartLoadingf("remove consumer group mapping of %s %s", attribute, value)
+	} else {
+		stdio.StartLoadingf("map %s %s to %s", attribute, value, consumerGroup)
+	}
+	if err := api.CallApiWithMethod(http.PUT, resourceManagerUri(tenantName)+constant.URI_MAPPINGS, params, nil); err != nil {
+		return err
+	}
+	if consumerGroup == "" {
+		stdio.LoadSuccessf("remove consumer group mapping of %s %s", attribute, value)
+	} else {
+		stdio.LoadSuccessf("map %s %s to %s", attribute, value, consumerGroup)
+	}
+	return nil
+}
+
+func newMappingShowCmd() *cobra.Command {
+	var opts planFlags
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the consumer group mappings.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			mappings := make([]bo.ConsumerGroupMapping, 0)
+			params := param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)}
+			if err := api.CallApiWithMethod(http.GET, resourceManagerUri(args[0])+constant.URI_MAPPINGS, params, &mappings); err != nil {
+				return err
+			}
+			if len(mappings) == 0 {
+				stdio.Infof("No consumer group mapping in tenant %s.", args[0])
+				return nil
+			}
+			printMappings(mappings)
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager mapping show t1`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func printMappings(mappings []bo.ConsumerGroupMapping) {
+	data := make([][]string, 0)
+	for _, mapping := range mappings {
+		data = append(data, []string{mapping.Attribute, mapping.Value, mapping.ConsumerGroup, mapping.Status})
+	}
+	stdio.PrintTable([]string{"Attribute", "Value", "Consumer Group", "Status"}, data)
+}
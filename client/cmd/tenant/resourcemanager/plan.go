@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type planFlags struct {
+	comment  string
+	password string
+	verbose  bool
+}
+
+func newPlanCmd() *cobra.Command {
+	planCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PLAN,
+		Short: "Manage the resource plans of the tenant.",
+	})
+	planCmd.AddCommand(newPlanCreateCmd())
+	planCmd.AddCommand(newPlanDropCmd())
+	planCmd.AddCommand(newPlanShowCmd())
+	planCmd.AddCommand(newPlanActivateCmd())
+	planCmd.AddCommand(newPlanDeactivateCmd())
+	return planCmd.Command
+}
+
+func newPlanCreateCmd() *cobra.Command {
+	var opts planFlags
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create a resource plan.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name and plan name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.CreateResourcePlanParam{
+				TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)},
+				PlanName:                args[1],
+				Comment:                 opts.comment,
+			}
+			stdio.StartLoadingf("create resource plan %s", args[1])
+			if err := api.CallApiWithMethod(http.POST, resourceManagerUri(args[0])+constant.URI_PLANS, params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("create resource plan %s", args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager plan create t1 daytime --comment "OLTP first"`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name>"}
+	createCmd.VarsPs(&opts.comment, []string{FLAG_COMMENT}, "", "Comment of the resource plan", false)
+	createCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return createCmd.Command
+}
+
+func newPlanDropCmd() *cobra.Command {
+	var opts planFlags
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DROP,
+		Short: "Drop a resource plan and its directives.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name and plan name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			params := param.TenantRootPasswordParam{RootPassword: rootPassword(cmd, opts.password)}
+			stdio.StartLoadingf("drop resource plan %s", args[1])
+			if err := api.CallApiWithMethod(http.DELETE, resourceManagerUri(args[0])+constant.URI_PLANS+"/"+args[1], params, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("drop resource plan %s", args[1])
+			return nil
+		}),
+		Example: `  obshell tenant resource-manager plan drop t1 daytime`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name>"}
+	dropCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return dropCmd.Command
+}
+
+func newPlanShowCmd() *cobra.Command {
+	var opts planFlags
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the resource plans and their directives.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showPlans(args[0], rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager plan show t1`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func showPlans(tenantName string, password *string) error {
+	plans := make([]bo.ResourcePlan, 0)
+	params := param.TenantRootPasswordParam{RootPassword: password}
+	if err := api.CallApiWithMethod(http.GET, resourceManagerUri(tenantName)+constant.URI_PLANS, params, &plans); err != nil {
+		return err
+	}
+	if len(plans) == 0 {
+		stdio.Infof("No resource plan in tenant %s.", tenantName)
+		return nil
+	}
+	data := make([][]string, 0)
+	for _, plan := range plans {
+		groups := make([]string, 0, len(plan.Directives))
+		for _, directive := range plan.Directives {
+			groups = append(groups, directive.GroupOrSubplan)
+		}
+		data = append(data, []string{plan.Plan, fmt.Sprint(plan.IsActive), strings.Join(groups, ","), plan.Comments})
+	}
+	stdio.PrintTable([]string{"Plan", "Active", "Consumer Groups", "Comments"}, data)
+	for _, plan := range plans {
+		if len(plan.Directives) == 0 {
+			continue
+		}
+		printDirectives(plan.Plan, plan.Directives)
+	}
+	return nil
+}
+
+func printDirectives(planName string, directives []bo.ResourcePlanDirective) {
+	data := make([][]string, 0)
+	for _, directive := range directives {
+		data = append(data, []string{
+			directive.GroupOrSubplan,
+			fmt.Sprint(directive.MgmtP1),
+			fmt.Sprint(directive.UtilizationLimit),
+			fmt.Sprint(directive.MinIops),
+			fmt.Sprint(directive.MaxIops),
+			fmt.Sprint(directive.WeightIops),
+		})
+	}
+	stdio.PrintTableWithTitle(fmt.Sprintf("Directives of %s", planName), []string{"Consumer Group", "MGMT_P1", "Utilization Limit", "Min IOPS", "Max IOPS", "Weight IOPS"}, data)
+}
+
+func newPlanActivateCmd() *cobra.Command {
+	var opts planFlags
+	activateCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_ACTIVATE,
+		Short: "Activate a resource plan.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name and plan name are required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return activatePlan(args[0], args[1], rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager plan activate t1 daytime`,
+	})
+	activateCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name> <plan-name>"}
+	activateCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	activateCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return activateCmd.Command
+}
+
+func newPlanDeactivateCmd() *cobra.Command {
+	var opts planFlags
+	deactivateCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DEACTIVATE,
+		Short: "Deactivate the resource plan in use.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return activatePlan(args[0], "", rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager plan deactivate t1`,
+	})
+	deactivateCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	deactivateCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	deactivateCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return deactivateCmd.Command
+}
+
+func activatePlan(tenantName string, planName string, password *string) error {
+	params := param.ActivateResourcePlanParam{
+		TenantRootPasswordParam: param.TenantRootPasswordParam{RootPassword: password},
+		PlanName:                planName,
+	}
+	msg := fmt.Sprintf("activate resource plan %s", planName)
+	if planName == "" {
+		msg = "deactivate resource plan"
+	}
+	stdio.StartLoading(msg)
+	if err := api.CallApiWithMethod(http.PUT, resourceManagerUri(tenantName)+constant.URI_ACTIVE_PLAN, params, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccess(msg)
+	return nil
+}
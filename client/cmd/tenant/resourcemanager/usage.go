@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourcemanager
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+func newUsageCmd() *cobra.Command {
+	var opts planFlags
+	usageCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_USAGE,
+		Short: "Show the effective resource plan and the io usage of consumer groups.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showUsage(args[0], rootPassword(cmd, opts.password))
+		}),
+		Example: `  obshell tenant resource-manager usage t1`,
+	})
+	usageCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	usageCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password", false)
+	usageCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return usageCmd.Command
+}
+
+func showUsage(tenantName string, password *string) error {
+	var usage bo.ResourceManagerUsage
+	params := param.TenantRootPasswordParam{RootPassword: password}
+	if err := api.CallApiWithMethod(http.GET, resourceManagerUri(tenantName)+constant.URI_USAGE, params, &usage); err != nil {
+		return err
+	}
+	if usage.ActivePlan == "" {
+		stdio.Infof("No resource plan is active in tenant %s.", tenantName)
+	} else {
+		stdio.Infof("Active resource plan: %s", usage.ActivePlan)
+		if len(usage.Directives) != 0 {
+			printDirectives(usage.ActivePlan, usage.Directives)
+		}
+	}
+	if len(usage.Mappings) != 0 {
+		printMappings(usage.Mappings)
+	}
+	if len(usage.GroupIoStats) != 0 {
+		data := make([][]string, 0)
+		for _, stat := range usage.GroupIoStats {
+			data = append(data, []string{
+				fmt.Sprintf("%s:%d", stat.SvrIp, stat.SvrPort),
+				stat.GroupName,
+				stat.Mode,
+				fmt.Sprint(stat.MinIops),
+				fmt.Sprint(stat.MaxIops),
+				fmt.Sprint(stat.RealIops),
+			})
+		}
+		stdio.PrintTableWithTitle("Consumer Group IO", []string{"Server", "Consumer Group", "Mode", "Min IOPS", "Max IOPS", "Real IOPS"}, data)
+	}
+	return nil
+}
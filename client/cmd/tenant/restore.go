@@ -19,13 +19,13 @@ package tenant
 import (
 	"strconv"
 	"strings"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/parse"
 	"github.com/oceanbase/obshell/client/cmd/tenant/replica"
 	"github.com/oceanbase/obshell/client/command"
 	clientconst "github.com/oceanbase/obshell/client/constant"
@@ -42,6 +42,7 @@ type TenantRestoreFlags struct {
 	ArchiveLogUri string
 
 	Timestamp           string `json:"timestamp" time_format:"2006-01-02T15:04:05.000Z07:00"`
+	Timezone            string
 	SCN                 int64
 	PrimaryZone         string
 	Concurrency         string
@@ -83,12 +84,13 @@ func newRestoreCmd() *cobra.Command {
 	restoreCmd.VarsPs(&opts.ReplicaType, []string{FLAG_REPLICA_TYPE}, "", "The replica type of the tenant.", false)
 	restoreCmd.VarsPs(&opts.PrimaryZone, []string{FLAG_PRIMARY_ZONE, FLAG_PRIMARY_ZONE_SH}, "", "The primary zone of the tenant to be restored.", false)
 
-	restoreCmd.VarsPs(&opts.Timestamp, []string{FLAG_TIMESTAMP, FLAG_TIMESTAMP_SH}, "", "The timestamp to restore to.", false)
+	restoreCmd.VarsPs(&opts.Timestamp, []string{FLAG_TIMESTAMP, FLAG_TIMESTAMP_SH}, "", "The timestamp to restore to, such as '2024-05-01 12:00:00' or '2024-05-01T12:00:00+08:00'.", false)
+	restoreCmd.VarsPs(&opts.Timezone, []string{FLAG_TIMEZONE}, "", "The timezone of the timestamp without offset, such as 'Asia/Shanghai' or '+08:00'. Default is the local timezone.", false)
 	restoreCmd.VarsPs(&opts.SCN, []string{FLAG_SCN, FLAG_SCN_SH}, int64(0), "The SCN to restore to.", false)
 	restoreCmd.VarsPs(&opts.ArchiveLogUri, []string{FLAG_ARCHIVE_LOG_URI, FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored.", false)
 	restoreCmd.VarsPs(&opts.HaHighThreadScore, []string{FLAG_HA_HIGH_THREAD_SCORE, FLAG_HA_HIGH_THREAD_SCORE_SH}, "", "The high thread score for HA. Range: [0, 100]", false)
 	restoreCmd.VarsPs(&opts.Concurrency, []string{FLAG_CONCURRENCY, FLAG_CONCURRENCY_SH}, "", "The number of threads to use for the restore operation.", false)
-	restoreCmd.VarsPs(&opts.Decryption, []string{FLAG_DECRYPTION, FLAG_DECRYPTION_SH}, "", "The decryption password for all backups.", false)
+	restoreCmd.VarsPs(&opts.Decryption, []string{FLAG_DECRYPTION, FLAG_DECRYPTION_SH}, "", "The decryption password for all backups, the stored backup keys are used if not set.", false)
 	restoreCmd.VarsPs(&opts.KmsEncryptInfo, []string{FLAG_KMS_ENCRYPT_INFO, FLAG_KMS_ENCRYPT_INFO_SH}, "", "The KMS encryption information.", false)
 
 	restoreCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
@@ -144,9 +146,13 @@ func (f *TenantRestoreFlags) toRestoreParam(cmd *cobra.Command) (*param.RestoreP
 	stdio.Verbosef("Zone list is %v", restoreParam.ZoneList)
 
 	if f.Timestamp != "" {
-		timestamp, err := time.Parse(time.RFC3339, f.Timestamp)
+		loc, err := parse.LoadLocation(f.Timezone)
 		if err != nil {
-			return nil, errors.Wrap(err, "Invalid timestamp")
+			return nil, err
+		}
+		timestamp, err := parse.HumanTimeParse(f.Timestamp, loc)
+		if err != nil {
+			return nil, err
 		}
 		restoreParam.Timestamp = &timestamp
 	}
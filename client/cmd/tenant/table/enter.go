@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package table
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/client/command"
+)
+
+const (
+	CMD_TABLE = "table"
+
+	// obshell tenant table show
+	CMD_SHOW           = "show"
+	FLAG_DATABASE      = "database"
+	FLAG_DATABASE_SH   = "d"
+	FLAG_TABLE         = "table"
+	FLAG_TABLE_SH      = "t"
+	FLAG_DDL           = "ddl"
+	FLAG_ROOT_PASSWORD = "root_password"
+)
+
+func NewTableCmd() *cobra.Command {
+	tableCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_TABLE,
+		Short: "Display the tables, indexes and partitions of the tenant.",
+	})
+	tableCmd.AddCommand(newShowCmd())
+	return tableCmd.Command
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type showFlags struct {
+	database string
+	table    string
+	ddl      bool
+	password string
+	verbose  bool
+}
+
+func newShowCmd() *cobra.Command {
+	var opts showFlags
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the tables of a database, or the partitions and indexes of a table.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "tenant name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			var password *string
+			if cmd.Flags().Changed(FLAG_ROOT_PASSWORD) {
+				password = &opts.password
+			}
+			if opts.table == "" {
+				if opts.ddl {
+					return errors.Occur(errors.ErrCliUsageError, "table is required to show ddl")
+				}
+				return showTables(args[0], opts.database)
+			}
+			return showTable(args[0], opts.database, opts.table, opts.ddl, password)
+		}),
+		Example: `  obshell tenant table show t1 -d test
+  obshell tenant table show t1 -d test -t orders
+  obshell tenant table show t1 -d test -t orders --ddl`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&opts.database, []string{FLAG_DATABASE, FLAG_DATABASE_SH}, "", "The database of the tables", true)
+	showCmd.VarsPs(&opts.table, []string{FLAG_TABLE, FLAG_TABLE_SH}, "", "The table to show the partitions and indexes of", false)
+	showCmd.VarsPs(&opts.ddl, []string{FLAG_DDL}, false, "Show the create statement of the table", false)
+	showCmd.VarsPs(&opts.password, []string{FLAG_ROOT_PASSWORD}, "", "Tenant root password, only used to show ddl", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func tableUri(tenantName, databaseName string) string {
+	return constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_DATABASES + "/" + databaseName + constant.URI_TABLES
+}
+
+func showTables(tenantName, databaseName string) error {
+	tables := make([]bo.ObTable, 0)
+	if err := api.CallApiWithMethod(http.GET, tableUri(tenantName, databaseName), nil, &tables); err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		stdio.Infof("No table in database %s of tenant %s.", databaseName, tenantName)
+		return nil
+	}
+	data := make([][]string, 0)
+	for _, table := range tables {
+		data = append(data, []string{
+			table.TableName,
+			fmt.Sprint(table.TableId),
+			fmt.Sprint(table.RowCount),
+			parse.FormatCapacity(table.DataSize),
+			fmt.Sprint(table.PartitionCount),
+			table.PrimaryZone,
+		})
+	}
+	stdio.PrintTable([]string{"Table", "Table ID", "Rows", "Data Size", "Partitions", "Primary Zone"}, data)
+	return nil
+}
+
+func partitionName(partition bo.TablePartition) string {
+	if partition.SubpartitionName != "" {
+		return partition.PartitionName + "." + partition.SubpartitionName
+	}
+	if partition.PartitionName != "" {
+		return partition.PartitionName
+	}
+	return "-"
+}
+
+func printPartitions(title string, partitions []bo.TablePartition) {
+	data := make([][]string, 0)
+	for _, partition := range partitions {
+		replicas := make([]string, 0)
+		for _, replica := range partition.Replicas {
+			replicas = append(replicas, fmt.Sprintf("%s(%s:%d)", replica.Zone, replica.SvrIp, replica.SvrPort))
+		}
+		data = append(data, []string{
+			partitionName(partition),
+			fmt.Sprint(partition.TabletId),
+			fmt.Sprint(partition.LsId),
+			partition.Leader,
+			strings.Join(replicas, ", "),
+		})
+	}
+	stdio.PrintTableWithTitle(title, []string{"Partition", "Tablet ID", "LS ID", "Leader", "Replicas"}, data)
+}
+
+func showTable(tenantName, databaseName, tableName string, ddl bool, password *string) error {
+	var table bo.ObTableDetail
+	uri := tableUri(tenantName, databaseName) + "/" + tableName
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &table); err != nil {
+		return err
+	}
+	stdio.PrintTable([]string{"Table", "Table ID", "Rows", "Data Size", "Partitions", "Primary Zone"}, [][]string{{
+		table.TableName,
+		fmt.Sprint(table.TableId),
+		fmt.Sprint(table.RowCount),
+		parse.FormatCapacity(table.DataSize),
+		fmt.Sprint(table.PartitionCount),
+		table.PrimaryZone,
+	}})
+	printPartitions("Partitions", table.Partitions)
+
+	if len(table.Indexes) != 0 {
+		data := make([][]string, 0)
+		for _, index := range table.Indexes {
+			data = append(data, []string{
+				index.IndexName,
+				fmt.Sprint(index.IndexTableId),
+				parse.FormatCapacity(index.DataSize),
+				fmt.Sprint(index.PartitionCount),
+			})
+		}
+		stdio.PrintTableWithTitle("Indexes", []string{"Index", "Index Table ID", "Data Size", "Partitions"}, data)
+	}
+
+	if ddl {
+		var tableDdl bo.TableDdl
+		params := param.TenantRootPasswordParam{RootPassword: password}
+		if err := api.CallApiWithMethod(http.GET, uri+constant.URI_DDL, params, &tableDdl); err != nil {
+			return err
+		}
+		stdio.Info(tableDdl.Ddl)
+	}
+	return nil
+}
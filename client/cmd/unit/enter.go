@@ -40,6 +40,9 @@ const (
 	// obshell unit drop
 	CMD_DROP = "drop"
 
+	// obshell unit modify
+	CMD_MODIFY = "modify"
+
 	// obshell unit show
 	CMD_SHOW = "show"
 )
@@ -55,6 +58,7 @@ func NewUnitCommand() *cobra.Command {
 	})
 	unitCommand.AddCommand(newCreateCmd())
 	unitCommand.AddCommand(newDropCmd())
+	unitCommand.AddCommand(newModifyCmd())
 	unitCommand.AddCommand(newShowCmd())
 	return unitCommand.Command
 }
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/http"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/command"
+	clientconst "github.com/oceanbase/obshell/client/constant"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/client/utils/api"
+	"github.com/oceanbase/obshell/param"
+)
+
+type unitConfigModifyFlags struct {
+	MemorySize  string
+	MaxCpu      float64
+	MinCpu      float64
+	LogDiskSize string
+	MinIops     int
+	MaxIops     int
+	SkipConfirm bool
+	Verbose     bool
+}
+
+var modifyCheckHeader = []string{"Server", "Zone", "Unit Count", "Cpu Required/Free", "Memory Required/Free", "Log Disk Required/Free", "Passed"}
+
+func newModifyCmd() *cobra.Command {
+	opts := unitConfigModifyFlags{}
+	modifyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MODIFY,
+		Short: "Modify a resource unit config in place.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			// get unit config name
+			if len(args) <= 0 {
+				return errors.Occur(errors.ErrCliUsageError, "unit config name is required")
+			}
+			stdio.SetSkipConfirmMode(opts.SkipConfirm)
+			stdio.SetVerboseMode(opts.Verbose)
+			return unitConfigModify(cmd, args[0], &opts)
+		}),
+		Example: `  obshell unit modify s1 -m 8G -c 4`,
+	})
+
+	modifyCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<unit-config-name>"}
+	modifyCmd.Flags().SortFlags = false
+	modifyCmd.VarsPs(&opts.MemorySize, []string{FLAG_MEMORY_SIZE, FLAG_MEMORY_SIZE_SH}, "", "Unit Config memory size.", false)
+	modifyCmd.VarsPs(&opts.MaxCpu, []string{FLAG_MAX_CPU, FLAG_MAX_CPU_SH}, float64(0), "Unit Config max cpu.", false)
+	modifyCmd.VarsPs(&opts.MinCpu, []string{FLAG_MIN_CPU}, float64(0), "Unit Config min cpu.", false)
+	modifyCmd.VarsPs(&opts.LogDiskSize, []string{FLAG_LOG_DISK_SIZE}, "", "Unit Config log disk size.", false)
+	modifyCmd.VarsPs(&opts.MinIops, []string{FLAG_MIN_IOPS}, 0, "Unit Config min iops.", false)
+	modifyCmd.VarsPs(&opts.MaxIops, []string{FLAG_MAX_IOPS}, 0, "Unit Config max iops.", false)
+	modifyCmd.VarsPs(&opts.SkipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation of modify unit config operation", false)
+	modifyCmd.VarsPs(&opts.Verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Show verbose output.", false)
+
+	return modifyCmd.Command
+}
+
+func unitConfigModify(cmd *cobra.Command, name string, opts *unitConfigModifyFlags) error {
+	params := buildModifyUnitConfigParams(cmd, opts)
+	if params == nil {
+		return errors.Occur(errors.ErrCliUsageError, "nothing to modify, please specify at least one of the unit config flags")
+	}
+
+	var impact bo.UnitConfigModifyImpact
+	stdio.StartLoadingf("check resource for unit config %s", name)
+	if err := api.CallApiWithMethod(http.POST, constant.URI_UNIT_GROUP_PREFIX+"/"+name+constant.URI_CHECK, params, &impact); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("check resource for unit config %s", name)
+	printUnitConfigModifyImpact(&impact)
+	if !impact.Passed {
+		return errors.Occur(errors.ErrCliUsageError, "resource is not enough on some servers, please check the output above")
+	}
+
+	pass, err := stdio.Confirmf("Please confirm if you need to modify unit config %s", name)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	stdio.StartLoadingf("modify unit config %s", name)
+	if err := api.CallApiWithMethod(http.PATCH, constant.URI_UNIT_GROUP_PREFIX+"/"+name, params, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("modify unit config %s", name)
+	return nil
+}
+
+func buildModifyUnitConfigParams(cmd *cobra.Command, opts *unitConfigModifyFlags) *param.ModifyResourceUnitConfigParams {
+	params := param.ModifyResourceUnitConfigParams{}
+	changed := false
+	if cmd.Flags().Changed(FLAG_MEMORY_SIZE) {
+		params.MemorySize = &opts.MemorySize
+		changed = true
+	}
+	if cmd.Flags().Changed(FLAG_MAX_CPU) {
+		params.MaxCpu = &opts.MaxCpu
+		changed = true
+	}
+	if cmd.Flags().Changed(FLAG_MIN_CPU) {
+		params.MinCpu = &opts.MinCpu
+		changed = true
+	}
+	if cmd.Flags().Changed(FLAG_LOG_DISK_SIZE) {
+		params.LogDiskSize = &opts.LogDiskSize
+		changed = true
+	}
+	if cmd.Flags().Changed(FLAG_MIN_IOPS) {
+		params.MinIops = &opts.MinIops
+		changed = true
+	}
+	if cmd.Flags().Changed(FLAG_MAX_IOPS) {
+		params.MaxIops = &opts.MaxIops
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &params
+}
+
+func printUnitConfigModifyImpact(impact *bo.UnitConfigModifyImpact) {
+	if len(impact.AffectedTenants) != 0 {
+		stdio.Printf("Affected tenants: %s", strings.Join(impact.AffectedTenants, ", "))
+	}
+	pools := make([]string, 0, len(impact.AffectedPools))
+	for _, pool := range impact.AffectedPools {
+		pools = append(pools, pool.PoolName)
+	}
+	if len(pools) != 0 {
+		stdio.Printf("Affected resource pools: %s", strings.Join(pools, ", "))
+	}
+	if len(impact.Servers) == 0 {
+		return
+	}
+	data := make([][]string, 0)
+	for _, server := range impact.Servers {
+		data = append(data, []string{
+			fmt.Sprintf("%s:%d", server.SvrIp, server.SvrPort),
+			server.Zone,
+			fmt.Sprint(server.UnitCount),
+			fmt.Sprintf("%v/%v", server.CpuRequired, server.CpuFree),
+			fmt.Sprintf("%s/%s", transferSignedCapacity(server.MemoryRequired), transferCapacity(server.MemoryFree)),
+			fmt.Sprintf("%s/%s", transferSignedCapacity(server.LogDiskRequired), transferCapacity(server.LogDiskFree)),
+			fmt.Sprint(server.Passed),
+		})
+	}
+	stdio.PrintTable(modifyCheckHeader, data)
+}
+
+// transferSignedCapacity formats the capacity delta, a negative value means the resource will be released.
+func transferSignedCapacity(capacity int64) string {
+	if capacity < 0 {
+		return "-" + transferCapacity(-capacity)
+	}
+	return transferCapacity(capacity)
+}
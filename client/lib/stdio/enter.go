@@ -94,6 +94,10 @@ func InputPassword(msg string) (string, error) {
 	return std.InputPassword(msg)
 }
 
+func Input(msg string, defaultValue string) (string, error) {
+	return std.Input(msg, defaultValue)
+}
+
 func StartLoading(msg string) {
 	std.StartLoading(msg)
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/term"
@@ -270,6 +271,40 @@ func (io *IO) InputPassword(msg string) (string, error) {
 	return string(input), nil
 }
 
+// Input reads a line from the terminal, defaultValue is returned if the line is empty.
+func (io *IO) Input(msg string, defaultValue string) (string, error) {
+	if io.IsBusy() {
+		return "", errors.New("stdio is busy")
+	}
+	if !io.inputIsTTY {
+		return "", errors.New("input is not a terminal")
+	}
+
+	if defaultValue != "" {
+		msg = fmt.Sprintf("%s [%s]", msg, defaultValue)
+	}
+	io.print(NORM, msg+": ", "")
+	// Read byte by byte so that nothing after the line is buffered away.
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := io.inputStream.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if input := strings.TrimSpace(string(line)); input != "" {
+		return input, nil
+	}
+	return defaultValue, nil
+}
+
 func (io *IO) NewSubIO() *IO {
 	return &IO{
 		rootIO: io,
@@ -17,6 +17,8 @@
 package api
 
 import (
+	"net/url"
+
 	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/lib/http"
 	"github.com/oceanbase/obshell/agent/lib/path"
@@ -43,3 +45,50 @@ func GetTenantBackupOverview(name string) (res *param.TenantBackupOverview, err
 	}
 	return
 }
+
+func GetClusterBackupCatalog() (res *param.BackupCatalog, err error) {
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_CATALOG
+	stdio.Verbosef("Calling API %s", uri)
+	err = http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func GetTenantBackupCatalog(name string) (res *param.TenantBackupCatalog, err error) {
+	uri := constant.URI_TENANT_API_PREFIX + "/" + name + constant.URI_BACKUP + constant.URI_CATALOG
+	stdio.Verbosef("Calling API %s", uri)
+	err = http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func GetClusterBackupUsage(capacity string) (res *param.BackupUsage, err error) {
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_USAGE + backupUsageQuery(capacity)
+	stdio.Verbosef("Calling API %s", uri)
+	err = http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func GetTenantBackupUsage(name string, capacity string) (res *param.TenantBackupUsage, err error) {
+	uri := constant.URI_TENANT_API_PREFIX + "/" + name + constant.URI_BACKUP + constant.URI_USAGE + backupUsageQuery(capacity)
+	stdio.Verbosef("Calling API %s", uri)
+	err = http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func backupUsageQuery(capacity string) string {
+	if capacity == "" {
+		return ""
+	}
+	return "?capacity=" + url.QueryEscape(capacity)
+}
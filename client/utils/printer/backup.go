@@ -18,7 +18,12 @@ package printer
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/oceanbase/obshell/agent/constant"
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
 	"github.com/oceanbase/obshell/client/lib/stdio"
 	"github.com/oceanbase/obshell/param"
 )
@@ -51,6 +56,43 @@ const (
 	DATA_TURN_ID             = "DATA_TURN_ID"
 	RESULT                   = "RESULT"
 	PATH                     = "PATH"
+
+	TENANT_NAME     = "TENANT_NAME"
+	BACKUP_TYPE     = "BACKUP_TYPE"
+	FILE_STATUS     = "FILE_STATUS"
+	DEST_ID         = "DEST_ID"
+	ROUND_ID        = "ROUND_ID"
+	PIECE_ID        = "PIECE_ID"
+	CHECKPOINT_SCN  = "CHECKPOINT_SCN"
+	PREV_BACKUP_SET = "PREV_BACKUP_SET"
+
+	DEST_TYPE          = "DEST_TYPE"
+	STORAGE_TYPE       = "STORAGE_TYPE"
+	SOURCE             = "SOURCE"
+	USED               = "USED"
+	OBJECT_COUNT       = "OBJECT_COUNT"
+	CATALOG_SIZE       = "CATALOG_SIZE"
+	STORAGE_USED       = "STORAGE_USED"
+	TOTAL              = "TOTAL"
+	AVAILABLE          = "AVAILABLE"
+	DAILY_GROWTH       = "DAILY_GROWTH"
+	DAYS_UNTIL_FULL    = "DAYS_UNTIL_FULL"
+	DURATION           = "DURATION"
+	OUTPUT_RATE        = "OUTPUT_RATE"
+	CHECKPOINT_TIME    = "CHECKPOINT_TIME"
+	USAGE_DEST_DATA    = "DATA"
+	USAGE_DEST_ARCHIVE = "ARCHIVE"
+
+	LAG         = "LAG"
+	ALARMING    = "ALARMING"
+	REASON      = "REASON"
+	REMEDIATION = "REMEDIATION_DAG"
+
+	KEY_VERSION    = "KEY_VERSION"
+	ACTIVE         = "ACTIVE"
+	CREATE_TIME    = "CREATE_TIME"
+	RETIRED_TIME   = "RETIRED_TIME"
+	BACKUP_SET_IDS = "BACKUP_SET_IDS"
 )
 
 func PrintDetailedClusterBackupOverview(overview *param.BackupOverview) {
@@ -153,3 +195,185 @@ func PrintTenantBackupOverview(overview *param.TenantBackupOverview) {
 	}
 	stdio.PrintTable(nil, data)
 }
+
+func PrintBackupCatalog(catalogs []param.TenantBackupCatalog) {
+	setHeaders := []string{TENANT_NAME, BACKUP_SET_ID, BACKUP_TYPE, PREV_BACKUP_SET, START_TIMESTAMP, END_TIMESTAMP, STATUS, FILE_STATUS, START_SCN, END_SCN, OUTPUT_BYTES, ENCRYPTION_MODE, PATH}
+	setData := [][]string{}
+	pieceHeaders := []string{TENANT_NAME, DEST_ID, ROUND_ID, PIECE_ID, STATUS, FILE_STATUS, START_SCN, CHECKPOINT_SCN, OUTPUT_BYTES, PATH}
+	pieceData := [][]string{}
+	for _, catalog := range catalogs {
+		for _, set := range catalog.BackupSets {
+			prev := "-"
+			if set.PrevIncBackupSetID != 0 {
+				prev = fmt.Sprint(set.PrevIncBackupSetID)
+			} else if set.PrevFullBackupSetID != 0 {
+				prev = fmt.Sprint(set.PrevFullBackupSetID)
+			}
+			setData = append(setData, []string{
+				catalog.TenantName,
+				fmt.Sprint(set.BackupSetID),
+				set.BackupType,
+				prev,
+				fmt.Sprint(set.StartTimestamp),
+				fmt.Sprint(set.EndTimestamp),
+				set.Status,
+				set.FileStatus,
+				fmt.Sprint(set.StartReplayScn),
+				fmt.Sprint(set.MinRestoreScn),
+				parse.FormatCapacity(set.OutputBytes),
+				set.EncryptionMode,
+				set.Path,
+			})
+		}
+		for _, piece := range catalog.ArchivePieces {
+			pieceData = append(pieceData, []string{
+				catalog.TenantName,
+				fmt.Sprint(piece.DestID),
+				fmt.Sprint(piece.RoundID),
+				fmt.Sprint(piece.PieceID),
+				piece.Status,
+				piece.FileStatus,
+				fmt.Sprint(piece.StartScn),
+				fmt.Sprint(piece.CheckpointScn),
+				parse.FormatCapacity(piece.OutputBytes),
+				piece.Path,
+			})
+		}
+	}
+	stdio.PrintTableWithTitle("Backup Sets", setHeaders, setData)
+	stdio.PrintTableWithTitle("Archive Pieces", pieceHeaders, pieceData)
+}
+
+func PrintBackupUsage(usages []param.TenantBackupUsage) {
+	destHeaders := []string{TENANT_NAME, DEST_TYPE, STORAGE_TYPE, PATH, SOURCE, USED, OBJECT_COUNT, CATALOG_SIZE, STORAGE_USED, TOTAL, AVAILABLE, DAILY_GROWTH, DAYS_UNTIL_FULL}
+	destData := [][]string{}
+	taskHeaders := []string{TENANT_NAME, TASK_ID, JOB_ID, BACKUP_SET_ID, STATUS, START_TIMESTAMP, DURATION, OUTPUT_BYTES, OUTPUT_RATE}
+	taskData := [][]string{}
+	pieceHeaders := []string{TENANT_NAME, ROUND_ID, PIECE_ID, STATUS, START_TIMESTAMP, CHECKPOINT_TIME, OUTPUT_BYTES, OUTPUT_RATE}
+	pieceData := [][]string{}
+	errs := []string{}
+	for _, usage := range usages {
+		for _, dest := range []struct {
+			destType string
+			usage    *param.BackupDestUsage
+		}{{USAGE_DEST_DATA, usage.DataDest}, {USAGE_DEST_ARCHIVE, usage.ArchiveDest}} {
+			if dest.usage == nil {
+				continue
+			}
+			destData = append(destData, []string{
+				usage.TenantName,
+				dest.destType,
+				dest.usage.StorageType,
+				dest.usage.Path,
+				dest.usage.Source,
+				parse.FormatCapacity(dest.usage.UsedBytes),
+				formatObjectCount(dest.usage),
+				parse.FormatCapacity(dest.usage.CatalogBytes),
+				parse.FormatCapacity(dest.usage.StorageUsedBytes),
+				formatOptionalCapacity(dest.usage.TotalBytes),
+				formatOptionalCapacity(dest.usage.AvailableBytes),
+				parse.FormatCapacity(dest.usage.DailyGrowthBytes),
+				formatDaysUntilFull(dest.usage.DaysUntilFull),
+			})
+			if dest.usage.Error != "" {
+				errs = append(errs, fmt.Sprintf("%s %s destination is reported from the catalog: %s", usage.TenantName, strings.ToLower(dest.destType), dest.usage.Error))
+			}
+		}
+		for _, task := range usage.BackupTasks {
+			taskData = append(taskData, []string{
+				usage.TenantName,
+				fmt.Sprint(task.TaskID),
+				fmt.Sprint(task.JobID),
+				fmt.Sprint(task.BackupSetID),
+				task.Status,
+				fmt.Sprint(task.StartTimestamp),
+				fmt.Sprint(time.Duration(task.DurationSeconds) * time.Second),
+				parse.FormatCapacity(task.OutputBytes),
+				parse.FormatCapacity(task.OutputRateBytes) + "/s",
+			})
+		}
+		for _, piece := range usage.ArchivePieces {
+			pieceData = append(pieceData, []string{
+				usage.TenantName,
+				fmt.Sprint(piece.RoundID),
+				fmt.Sprint(piece.PieceID),
+				piece.Status,
+				fmt.Sprint(piece.StartTimestamp),
+				fmt.Sprint(piece.CheckpointTime),
+				parse.FormatCapacity(piece.OutputBytes),
+				parse.FormatCapacity(piece.OutputRateBytes) + "/s",
+			})
+		}
+	}
+	stdio.PrintTableWithTitle("Backup Destinations", destHeaders, destData)
+	for _, err := range errs {
+		stdio.Warn(err)
+	}
+	stdio.PrintTableWithTitle("Backup Tasks", taskHeaders, taskData)
+	stdio.PrintTableWithTitle("Archive Pieces", pieceHeaders, pieceData)
+}
+
+func formatObjectCount(usage *param.BackupDestUsage) string {
+	if usage.Source != constant.BACKUP_USAGE_SOURCE_STORAGE {
+		return "-"
+	}
+	return fmt.Sprint(usage.ObjectCount)
+}
+
+func formatOptionalCapacity(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return parse.FormatCapacity(bytes)
+}
+
+func formatDaysUntilFull(days *float64) string {
+	if days == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", *days)
+}
+
+func PrintArchiveLagOverview(overview *param.ArchiveLagOverview) {
+	stdio.Printf("Archive lag watcher enabled: %v, lag threshold: %s, auto remediate: %v",
+		overview.Config.Enabled, overview.Config.LagThreshold, overview.Config.AutoRemediate)
+	headers := []string{TENANT_NAME, STATUS, ROUND_ID, CHECKPOINT_TIME, LAG, ALARMING, REASON, REMEDIATION}
+	data := [][]string{}
+	for _, status := range overview.Tenants {
+		data = append(data, []string{
+			status.TenantName,
+			status.Status,
+			fmt.Sprint(status.RoundID),
+			fmt.Sprint(status.CheckpointTime),
+			fmt.Sprint(time.Duration(status.LagSeconds) * time.Second),
+			fmt.Sprint(status.Alarming),
+			status.Reason,
+			status.RemediationDagID,
+		})
+	}
+	stdio.PrintTableWithTitle("Archive Log Lag", headers, data)
+}
+
+func PrintBackupKeys(keys []bo.BackupEncryptionKey) {
+	headers := []string{TENANT_NAME, KEY_VERSION, ACTIVE, CREATE_TIME, RETIRED_TIME, BACKUP_SET_IDS}
+	data := [][]string{}
+	for _, key := range keys {
+		retiredTime := "-"
+		if key.RetiredTime != nil {
+			retiredTime = key.RetiredTime.Format(time.DateTime)
+		}
+		backupSetIDs := make([]string, 0, len(key.BackupSetIDs))
+		for _, id := range key.BackupSetIDs {
+			backupSetIDs = append(backupSetIDs, fmt.Sprint(id))
+		}
+		data = append(data, []string{
+			key.TenantName,
+			fmt.Sprint(key.Version),
+			fmt.Sprint(key.Active),
+			key.CreateTime.Format(time.DateTime),
+			retiredTime,
+			strings.Join(backupSetIDs, ","),
+		})
+	}
+	stdio.PrintTableWithTitle("Backup Encryption Keys", headers, data)
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printer
+
+import (
+	"fmt"
+
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+)
+
+const (
+	FIX = "FIX"
+)
+
+func PrintHostCheckReport(report *bo.HostCheckReport) {
+	headers := []string{NAME, EXPECTED, ACTUAL, RESULT, FIX}
+	data := [][]string{}
+	for _, item := range report.Items {
+		data = append(data, []string{item.Name, item.Expected, item.Actual, item.Result, item.Fix})
+	}
+	stdio.PrintTableWithTitle(fmt.Sprintf("Host Check of %s (profile %s): %s", report.Agent, report.Profile, report.Result), headers, data)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+)
+
+const (
+	CHANGE_ID    = "ID"
+	CATEGORY     = "CATEGORY"
+	SCOPE        = "SCOPE"
+	NAME         = "NAME"
+	TARGET       = "TARGET"
+	OLD_VALUE    = "OLD_VALUE"
+	NEW_VALUE    = "NEW_VALUE"
+	OPERATOR     = "OPERATOR"
+	ROLLBACK_OF  = "ROLLBACK_OF"
+	ITEM_COUNT   = "ITEM_COUNT"
+	BASE_VALUE   = "BASE_VALUE"
+	TARGET_VALUE = "TARGET_VALUE"
+
+	LEVEL           = "LEVEL"
+	PERSISTED_VALUE = "PERSISTED_VALUE"
+	LIVE_VALUE      = "LIVE_VALUE"
+)
+
+// parameterTarget returns where the change or the diff takes effect, "-" means the whole cluster.
+func parameterTarget(zone, server, tenantName string) string {
+	switch {
+	case tenantName != "":
+		return "tenant:" + tenantName
+	case server != "":
+		return "server:" + server
+	case zone != "":
+		return "zone:" + zone
+	default:
+		return "-"
+	}
+}
+
+func PrintParameterChanges(changes []bo.ParameterChange) {
+	headers := []string{CHANGE_ID, CATEGORY, SCOPE, NAME, TARGET, OLD_VALUE, NEW_VALUE, OPERATOR, ROLLBACK_OF, CREATE_TIME}
+	data := [][]string{}
+	for _, change := range changes {
+		rollbackOf := "-"
+		if change.RollbackOf != 0 {
+			rollbackOf = fmt.Sprint(change.RollbackOf)
+		}
+		oldValue := change.OldValue
+		if change.OldValueUnknown {
+			oldValue = "<unknown>"
+		}
+		data = append(data, []string{
+			fmt.Sprint(change.Id),
+			change.Category,
+			change.Scope,
+			change.Name,
+			parameterTarget(change.Zone, change.Server, change.TenantName),
+			oldValue,
+			change.NewValue,
+			change.Operator,
+			rollbackOf,
+			change.CreateTime.Local().Format(time.DateTime),
+		})
+	}
+	stdio.PrintTableWithTitle("Parameter Changes", headers, data)
+}
+
+func PrintParameterSnapshots(snapshots []bo.ParameterSnapshot) {
+	headers := []string{NAME, ITEM_COUNT, OPERATOR, CREATE_TIME, COMMENT}
+	data := [][]string{}
+	for _, snapshot := range snapshots {
+		data = append(data, []string{
+			snapshot.Name,
+			fmt.Sprint(snapshot.ItemCount),
+			snapshot.Operator,
+			snapshot.CreateTime.Local().Format(time.DateTime),
+			snapshot.Comment,
+		})
+	}
+	stdio.PrintTableWithTitle("Parameter Snapshots", headers, data)
+}
+
+func PrintParameterDiffs(title string, diffs []bo.ParameterDiff) {
+	headers := []string{CATEGORY, SCOPE, NAME, TARGET, BASE_VALUE, TARGET_VALUE}
+	data := [][]string{}
+	for _, diff := range diffs {
+		data = append(data, []string{
+			diff.Category,
+			diff.Scope,
+			diff.Name,
+			parameterTarget("", diff.Server, diff.TenantName),
+			diff.BaseValue,
+			diff.TargetValue,
+		})
+	}
+	stdio.PrintTableWithTitle(title, headers, data)
+}
+
+func PrintConfigDrifts(title string, drifts []bo.ConfigDrift) {
+	headers := []string{NAME, SERVER, COL_ZONE, LEVEL, PERSISTED_VALUE, LIVE_VALUE}
+	data := [][]string{}
+	for _, drift := range drifts {
+		data = append(data, []string{
+			drift.Name,
+			drift.Server,
+			drift.Zone,
+			drift.Level,
+			drift.PersistedValue,
+			drift.LiveValue,
+		})
+	}
+	stdio.PrintTableWithTitle(title, headers, data)
+}
@@ -18,7 +18,12 @@ package printer
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/oceanbase/obshell/agent/lib/parse"
+	"github.com/oceanbase/obshell/agent/lib/system"
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
 	"github.com/oceanbase/obshell/client/lib/stdio"
 	"github.com/oceanbase/obshell/param"
 )
@@ -92,6 +97,36 @@ const (
 	LS_COUNT               = "LS_COUNT"
 	FINISH_LS_COUNT        = "FINISH_LS_COUNT"
 	FINISH_TIMESTAMP       = "FINISH_TIMESTAMP"
+
+	REPORT_ID       = "REPORT_ID"
+	DAG_ID          = "DAG_ID"
+	SCRATCH_TENANT  = "SCRATCH_TENANT"
+	DATA_BACKUP_URI = "DATA_BACKUP_URI"
+	ARCHIVE_LOG_URI = "ARCHIVE_LOG_URI"
+	RESTORE_TIME    = "RESTORE_TIME"
+	START_TIME      = "START_TIME"
+	END_TIME        = "END_TIME"
+	VERIFICATION    = "VERIFICATION"
+	SQL             = "SQL"
+	EXPECTED        = "EXPECTED"
+	ACTUAL          = "ACTUAL"
+	PASSED          = "PASSED"
+	ERROR           = "ERROR"
+
+	SOURCE_NO       = "NO"
+	CLUSTER_ID      = "CLUSTER_ID"
+	RESTORE_WINDOWS = "RESTORE_WINDOWS"
+
+	WINDOW_NO          = "NO"
+	TIME_SPAN          = "TIME_SPAN"
+	REQUESTED_TIME     = "REQUESTED_TIME"
+	REQUESTED_SCN      = "REQUESTED_SCN"
+	ADJUSTED           = "ADJUSTED"
+	MIN_RESTORE_TIME   = "MIN_RESTORE_TIME"
+	BYTES              = "BYTES"
+	ESTIMATED_SIZE     = "ESTIMATED_SIZE"
+	ESTIMATED_DURATION = "ESTIMATED_DURATION"
+	THROUGHPUT         = "THROUGHPUT"
 )
 
 func PrintDetailedTenantRestoreOverview(overview *param.RestoreOverview) {
@@ -148,3 +183,169 @@ func PrintTenantRestoreOverview(overview *param.RestoreOverview) {
 	}
 	stdio.PrintTable(nil, data)
 }
+
+func formatDrillTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
+
+func PrintRestoreDrillReports(reports []bo.RestoreDrillReport) {
+	headers := []string{REPORT_ID, DAG_ID, SCRATCH_TENANT, STATUS, RESTORE_TIME, START_TIME, END_TIME, PASSED}
+	data := [][]string{}
+	for _, report := range reports {
+		passed := 0
+		for _, verification := range report.Verifications {
+			if verification.Passed {
+				passed++
+			}
+		}
+		data = append(data, []string{
+			fmt.Sprint(report.Id),
+			fmt.Sprint(report.DagID),
+			report.ScratchTenant,
+			report.Status,
+			formatDrillTime(report.RestoreTime),
+			formatDrillTime(&report.StartTime),
+			formatDrillTime(report.EndTime),
+			fmt.Sprintf("%d/%d", passed, len(report.Verifications)),
+		})
+	}
+	stdio.PrintTableWithTitle("Restore Drill Reports", headers, data)
+}
+
+func PrintRestoreDrillReport(report *bo.RestoreDrillReport) {
+	data := [][]string{
+		{REPORT_ID, fmt.Sprint(report.Id)},
+		{DAG_ID, fmt.Sprint(report.DagID)},
+		{SCRATCH_TENANT, report.ScratchTenant},
+		{DATA_BACKUP_URI, report.DataBackupUri},
+		{ARCHIVE_LOG_URI, report.ArchiveLogUri},
+		{RESTORE_TIME, formatDrillTime(report.RestoreTime)},
+		{STATUS, report.Status},
+		{START_TIME, formatDrillTime(&report.StartTime)},
+		{END_TIME, formatDrillTime(report.EndTime)},
+	}
+	stdio.PrintTable(nil, data)
+
+	headers := []string{VERIFICATION, SQL, EXPECTED, ACTUAL, PASSED, ERROR}
+	verifications := [][]string{}
+	for _, verification := range report.Verifications {
+		verifications = append(verifications, []string{
+			verification.Name,
+			verification.Sql,
+			verification.Expected,
+			verification.Actual,
+			fmt.Sprint(verification.Passed),
+			verification.Error,
+		})
+	}
+	stdio.PrintTableWithTitle("Verifications", headers, verifications)
+}
+
+func PrintRestoreSources(sources []param.RestoreSource) {
+	headers := []string{SOURCE_NO, CLUSTER_ID, TENANT_ID, DATA_BACKUP_URI, ARCHIVE_LOG_URI, RESTORE_WINDOWS}
+	data := [][]string{}
+	errs := []string{}
+	for i, source := range sources {
+		windows := make([]string, 0, len(source.Windows))
+		for _, window := range source.Windows {
+			windows = append(windows, fmt.Sprintf("%s ~ %s", window.StartTime.Format(time.RFC3339), window.EndTime.Format(time.RFC3339)))
+		}
+		data = append(data, []string{
+			fmt.Sprint(i + 1),
+			formatSourceID(source.ClusterID),
+			formatSourceID(source.TenantID),
+			source.DataBackupUri,
+			source.ArchiveLogUri,
+			strings.Join(windows, "\n"),
+		})
+		if source.Error != "" {
+			errs = append(errs, fmt.Sprintf("restore windows of source %d are unavailable: %s", i+1, source.Error))
+		}
+	}
+	stdio.PrintTableWithTitle("Restore Sources", headers, data)
+	for _, err := range errs {
+		stdio.Warn(err)
+	}
+}
+
+// formatSourceID returns '-' for the id of the backup dest of a tenant itself, which is unknown.
+func formatSourceID(id int) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprint(id)
+}
+
+// PrintRestoreWindows prints the merged restore windows in local time.
+func PrintRestoreWindows(windows []system.RestoreWindow) {
+	headers := []string{WINDOW_NO, START_TIME, END_TIME, TIME_SPAN}
+	data := [][]string{}
+	for i, window := range windows {
+		data = append(data, []string{
+			fmt.Sprint(i + 1),
+			formatLocalTime(window.StartTime),
+			formatLocalTime(window.EndTime),
+			window.EndTime.Sub(window.StartTime).Round(time.Second).String(),
+		})
+	}
+	stdio.PrintTableWithTitle(fmt.Sprintf("Restore Windows (%s)", time.Now().Format("MST -07:00")), headers, data)
+}
+
+func PrintRestorePoint(point *param.RestorePoint) {
+	data := [][]string{
+		{REQUESTED_TIME, formatLocalTime(point.RequestedTime)},
+		{REQUESTED_SCN, fmt.Sprint(point.RequestedSCN)},
+		{RESTORE_TIME, formatLocalTime(point.RestoreTime)},
+		{RESTORE_SCN, fmt.Sprint(point.RestoreSCN)},
+		{ADJUSTED, fmt.Sprint(point.Adjusted)},
+		{ESTIMATED_SIZE, formatRestoreBytes(point.EstimatedBytes)},
+		{ESTIMATED_DURATION, (time.Duration(point.EstimatedDuration) * time.Second).String()},
+		{THROUGHPUT, fmt.Sprintf("%s/s (%s)", parse.FormatCapacity(point.Throughput), point.ThroughputSource)},
+	}
+	stdio.PrintTable(nil, data)
+
+	setHeaders := []string{BACKUP_SET_ID, BACKUP_TYPE, MIN_RESTORE_TIME, BYTES}
+	sets := [][]string{}
+	for _, set := range point.BackupSets {
+		sets = append(sets, []string{
+			fmt.Sprint(set.BackupSetID),
+			set.BackupType,
+			formatLocalTime(set.MinRestoreTime),
+			formatRestoreBytes(set.Bytes),
+		})
+	}
+	stdio.PrintTableWithTitle("Backup Sets", setHeaders, sets)
+
+	pieceHeaders := []string{DEST_ID, ROUND_ID, PIECE_ID, START_TIME, CHECKPOINT_TIME, BYTES}
+	pieces := [][]string{}
+	for _, piece := range point.ArchivePieces {
+		pieces = append(pieces, []string{
+			fmt.Sprint(piece.DestID),
+			fmt.Sprint(piece.RoundID),
+			fmt.Sprint(piece.PieceID),
+			formatLocalTime(piece.StartTime),
+			formatLocalTime(piece.CheckpointTime),
+			formatRestoreBytes(piece.Bytes),
+		})
+	}
+	stdio.PrintTableWithTitle("Archive Pieces", pieceHeaders, pieces)
+
+	if point.Adjusted {
+		stdio.Warnf("The requested time is out of the restore windows, the closest restorable point %s is used", formatLocalTime(point.RestoreTime))
+	}
+}
+
+func formatLocalTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05.000000")
+}
+
+// formatRestoreBytes returns '-' for the size which is unknown.
+func formatRestoreBytes(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return parse.FormatCapacity(bytes)
+}
@@ -17,13 +17,17 @@
 package printer
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
 	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/engine/task"
+	"github.com/oceanbase/obshell/agent/executor/ob"
 )
 
 func PrintDagStruct(dag *task.DagDetailDTO, detail bool) {
@@ -51,6 +55,9 @@ func convertDag2MapSlice(dag *task.DagDetailDTO, detail bool) (data yaml.MapSlic
 		yaml.MapItem{Key: "start_time", Value: dag.StartTime},
 		yaml.MapItem{Key: "end_time", Value: dag.EndTime},
 	)
+	if paused := pausedUpgradeGates(dag); len(paused) > 0 {
+		data = append(data, yaml.MapItem{Key: "paused", Value: paused})
+	}
 	if detail {
 		data = append(data, yaml.MapItem{
 			Key: "nodes", Value: convertNodes2MapSlice(dag.Nodes),
@@ -102,6 +109,31 @@ func convertSubTasks2MapSlice(subTasks []*task.TaskDetailDTO) (data yaml.MapSlic
 	return
 }
 
+// pausedUpgradeGates returns the zones of the rolling upgrade waiting for approval.
+func pausedUpgradeGates(dag *task.DagDetailDTO) (res []string) {
+	if dag.AdditionalData == nil {
+		return
+	}
+	gates, ok := (*dag.AdditionalData)[ob.ADDL_KEY_UPGRADE_GATES].(map[string]any)
+	if !ok {
+		return
+	}
+	for key, value := range gates {
+		gate, ok := value.(map[string]any)
+		if !ok || gate["status"] != constant.UPGRADE_GATE_STATUS_WAITING {
+			continue
+		}
+		zone := key[strings.Index(key, "/")+1:]
+		if deadline, ok := gate["deadline"].(string); ok {
+			res = append(res, fmt.Sprintf("zone %s is waiting for approval, %s automatically at %s", zone, strings.ToLower(fmt.Sprint(gate["timeout_action"])), deadline))
+		} else {
+			res = append(res, fmt.Sprintf("zone %s is waiting for approval", zone))
+		}
+	}
+	sort.Strings(res)
+	return
+}
+
 func postprocessDagStructText(text string) string {
 	return strings.ReplaceAll(text, "\"", "")
 }
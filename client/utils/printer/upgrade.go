@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/client/lib/stdio"
+	"github.com/oceanbase/obshell/param"
+)
+
+const (
+	ROUTE_INDEX    = "ROUTE_INDEX"
+	DEADLINE       = "DEADLINE"
+	TIMEOUT_ACTION = "TIMEOUT_ACTION"
+	UPDATE_TIME    = "UPDATE_TIME"
+
+	BINARY_SWITCHED = "BINARY_SWITCHED"
+	SERVER          = "SERVER"
+	BUILD_VERSION   = "BUILD_VERSION"
+)
+
+func PrintUpgradeZoneGates(gates []bo.UpgradeZoneGate) {
+	headers := []string{ROUTE_INDEX, COL_ZONE, STATUS, DEADLINE, TIMEOUT_ACTION, UPDATE_TIME, COMMENT}
+	data := [][]string{}
+	for _, gate := range gates {
+		deadline := "-"
+		if gate.Deadline != nil {
+			deadline = gate.Deadline.Local().Format(time.DateTime)
+		}
+		data = append(data, []string{
+			fmt.Sprint(gate.RouteIndex),
+			gate.Zone,
+			gate.Status,
+			deadline,
+			gate.TimeoutAction,
+			gate.UpdateTime.Local().Format(time.DateTime),
+			gate.Comment,
+		})
+	}
+	stdio.PrintTableWithTitle("Upgrade Zone Gates", headers, data)
+}
+
+func PrintUpgradeRollbackPoint(point *param.UpgradeRollbackPoint) {
+	toVersion := point.ToVersion
+	if toVersion == "" {
+		toVersion = "the version before upgrade"
+	}
+	stdio.Printf("Upgrade process %d of %s failed at '%s'.", point.RouteIndex, point.UpgradeDagID, point.FailedTask)
+	stdio.Printf("Roll back from %s to %s, restore parameters: %t.", point.FromVersion, toVersion, point.RestoreParameters)
+	if len(point.Zones) == 0 {
+		return
+	}
+	headers := []string{COL_ZONE, BINARY_SWITCHED, SERVER, BUILD_VERSION}
+	data := [][]string{}
+	for _, zone := range point.Zones {
+		if len(zone.ServerVersions) == 0 {
+			data = append(data, []string{zone.Zone, fmt.Sprint(zone.BinarySwitched), "-", "-"})
+			continue
+		}
+		servers := make([]string, 0, len(zone.ServerVersions))
+		for server := range zone.ServerVersions {
+			servers = append(servers, server)
+		}
+		sort.Strings(servers)
+		for _, server := range servers {
+			data = append(data, []string{zone.Zone, fmt.Sprint(zone.BinarySwitched), server, zone.ServerVersions[server]})
+		}
+	}
+	stdio.PrintTableWithTitle("Upgrade Rollback Zones", headers, data)
+}
@@ -28,8 +28,10 @@ import (
 	"github.com/oceanbase/obshell/agent/cmd/server"
 	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/client/cmd/agent"
+	"github.com/oceanbase/obshell/client/cmd/apply"
 	"github.com/oceanbase/obshell/client/cmd/backup"
 	"github.com/oceanbase/obshell/client/cmd/cluster"
+	"github.com/oceanbase/obshell/client/cmd/host"
 	"github.com/oceanbase/obshell/client/cmd/pool"
 	"github.com/oceanbase/obshell/client/cmd/recyclebin"
 	"github.com/oceanbase/obshell/client/cmd/restore"
@@ -58,6 +60,8 @@ func main() {
 	cmds.AddCommand(recyclebin.NewRecyclebinCmd())
 	cmds.AddCommand(backup.NewBackupCmd())
 	cmds.AddCommand(restore.NewRestoreCmd())
+	cmds.AddCommand(apply.NewApplyCmd())
+	cmds.AddCommand(host.NewHostCmd())
 
 	var showDetailedVersion bool
 	cmds.Flags().BoolVarP(&showDetailedVersion, agentcmd.CMD_VERSION, agentcmd.CMD_V, false, "Display version for obshell and exit")
@@ -53,3 +53,10 @@ type AddTokenParam struct {
 	AgentInfo meta.AgentInfo `json:"agentInfo" binding:"required"`
 	Token     string         `json:"token" binding:"required"`
 }
+
+type HostCheckParam struct {
+	Profile string           `json:"profile"`
+	DataDir string           `json:"data_dir"`
+	RedoDir string           `json:"redo_dir"`
+	Servers []meta.AgentInfo `json:"servers"` // Only used by the cluster level check, the agents in the cluster will be checked if not specified.
+}
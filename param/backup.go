@@ -18,6 +18,7 @@ package param
 
 import (
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -174,14 +175,14 @@ func (p *BackupConfigParam) Check() (backupConf *BackupConf, err error) {
 
 	backupConf = p.newBackupConf()
 	if backupConf.ArchiveDest != nil && backupConf.ArchiveDest.BaseURI != "" {
-		backupConf.ArchiveDest.StorageType, err = system.GetResourceType(backupConf.ArchiveDest.BaseURI)
+		backupConf.ArchiveDest.StorageType, err = checkStorageURI(backupConf.ArchiveDest.BaseURI)
 		if err != nil {
 			return nil, err
 		}
 		log.Infof("archive storage type is %s", backupConf.ArchiveDest.StorageType)
 	}
 	if backupConf.DataDest != nil && backupConf.DataDest.BaseURI != "" {
-		backupConf.DataDest.StorageType, err = system.GetResourceType(backupConf.DataDest.BaseURI)
+		backupConf.DataDest.StorageType, err = checkStorageURI(backupConf.DataDest.BaseURI)
 		if err != nil {
 			return nil, err
 		}
@@ -197,6 +198,16 @@ func (p *BackupConfigParam) Check() (backupConf *BackupConf, err error) {
 	return backupConf, nil
 }
 
+// checkStorageURI parses the uri with the storage of its protocol to check the
+// required params, and returns the storage type.
+func checkStorageURI(uri string) (string, error) {
+	storage, err := system.GetStorageInterfaceByURI(uri)
+	if err != nil {
+		return "", err
+	}
+	return storage.GetResourceType(), nil
+}
+
 func (p *BackupConfigParam) checkDeletePolicy() error {
 	if p.DeletePolicy != nil {
 		if p.DeletePolicy.Policy == "" {
@@ -275,6 +286,21 @@ func (p *BackupParam) Check() error {
 	}
 }
 
+// BackupKeyParam rotates the backup encryption password of a tenant, a random one is generated if not specified.
+type BackupKeyParam struct {
+	Password *string `json:"password"`
+}
+
+func (p *BackupKeyParam) Check() error {
+	if p.Password == nil {
+		return nil
+	}
+	if *p.Password == "" || strings.ContainsAny(*p.Password, "'\"\\") {
+		return errors.Occur(errors.ErrObBackupKeyPasswordInvalid)
+	}
+	return nil
+}
+
 type BackupStatusParam struct {
 	Status *string `json:"status"`
 }
@@ -329,3 +355,136 @@ type BackupOverview struct {
 type TenantBackupOverview struct {
 	Status oceanbase.CdbObBackupTask `json:"status"`
 }
+
+type BackupValidateParam struct {
+	BackupSetID *int64 `json:"backup_set_id"`
+}
+
+type TenantBackupCatalog struct {
+	TenantID      int                                  `json:"tenant_id"`
+	TenantName    string                               `json:"tenant_name"`
+	BackupSets    []oceanbase.CdbObBackupSetFile       `json:"backup_sets"`
+	ArchivePieces []oceanbase.CdbObArchivelogPieceFile `json:"archive_pieces"`
+}
+
+type BackupCatalog struct {
+	Tenants []TenantBackupCatalog `json:"tenants"`
+}
+
+type BackupCleanParam struct {
+	BackupSetIDs  []int64    `json:"backup_set_ids"`
+	ArchiveBefore *time.Time `json:"archive_before" time_format:"2006-01-02T15:04:05.000Z07:00"`
+}
+
+func (p *BackupCleanParam) Check() error {
+	if len(p.BackupSetIDs) == 0 && (p.ArchiveBefore == nil || *p.ArchiveBefore == constant.ZERO_TIME) {
+		return errors.Occur(errors.ErrObBackupCleanTargetEmpty)
+	}
+	return nil
+}
+
+type BackupCleanPreview struct {
+	TenantName     string                               `json:"tenant_name"`
+	RecoveryWindow string                               `json:"recovery_window"`
+	BackupSets     []oceanbase.CdbObBackupSetFile       `json:"backup_sets"`
+	ArchivePieces  []oceanbase.CdbObArchivelogPieceFile `json:"archive_pieces"`
+}
+
+type BackupDestUsage struct {
+	Path        string `json:"path"`
+	StorageType string `json:"storage_type"`
+	// Storage identifies the file system or the bucket the destination is on.
+	Storage string `json:"storage"`
+	// Source is "storage" when the destination was walked directly, or "catalog"
+	// when the usage was summed from the backup catalog views. Object storage is
+	// always reported from the catalog since its credentials are hidden in the dest views.
+	Source string `json:"source"`
+	system.StorageUsage
+	CatalogBytes     int64 `json:"catalog_bytes"`
+	DailyGrowthBytes int64 `json:"daily_growth_bytes"`
+	// StorageUsedBytes and StorageDailyGrowthBytes are summed over all the destinations
+	// on the same storage, the availability and days until full are estimated with them.
+	StorageUsedBytes        int64    `json:"storage_used_bytes"`
+	StorageDailyGrowthBytes int64    `json:"storage_daily_growth_bytes"`
+	DaysUntilFull           *float64 `json:"days_until_full,omitempty"`
+	Error                   string   `json:"error,omitempty"`
+}
+
+type BackupTaskThroughput struct {
+	TaskID          int64     `json:"task_id"`
+	JobID           int64     `json:"job_id"`
+	BackupSetID     int64     `json:"backup_set_id"`
+	Status          string    `json:"status"`
+	StartTimestamp  time.Time `json:"start_timestamp"`
+	EndTimestamp    time.Time `json:"end_timestamp"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	OutputBytes     int64     `json:"output_bytes"`
+	OutputRateBytes int64     `json:"output_rate_bytes"`
+}
+
+type ArchivePieceThroughput struct {
+	RoundID         int64     `json:"round_id"`
+	PieceID         int64     `json:"piece_id"`
+	Status          string    `json:"status"`
+	StartTimestamp  time.Time `json:"start_timestamp"`
+	CheckpointTime  time.Time `json:"checkpoint_time"`
+	OutputBytes     int64     `json:"output_bytes"`
+	OutputRateBytes int64     `json:"output_rate_bytes"`
+}
+
+type TenantBackupUsage struct {
+	TenantID      int                      `json:"tenant_id"`
+	TenantName    string                   `json:"tenant_name"`
+	DataDest      *BackupDestUsage         `json:"data_dest,omitempty"`
+	ArchiveDest   *BackupDestUsage         `json:"archive_dest,omitempty"`
+	BackupTasks   []BackupTaskThroughput   `json:"backup_tasks"`
+	ArchivePieces []ArchivePieceThroughput `json:"archive_pieces"`
+}
+
+type BackupUsage struct {
+	Tenants []TenantBackupUsage `json:"tenants"`
+}
+
+type ArchiveWatcherConfig struct {
+	Enabled       bool   `json:"enabled"`
+	LagThreshold  string `json:"lag_threshold"`
+	AutoRemediate bool   `json:"auto_remediate"`
+}
+
+type ArchiveWatcherConfigParam struct {
+	Enabled       *bool   `json:"enabled"`
+	LagThreshold  *string `json:"lag_threshold"`
+	AutoRemediate *bool   `json:"auto_remediate"`
+}
+
+func (p *ArchiveWatcherConfigParam) Check() error {
+	if p.LagThreshold == nil {
+		return nil
+	}
+	*p.LagThreshold = strings.ToLower(strings.TrimSpace(*p.LagThreshold))
+	duration, err := system.ParseTime(*p.LagThreshold)
+	if err != nil || duration <= 0 {
+		return errors.Occur(errors.ErrObArchiveWatcherThresholdInvalid, *p.LagThreshold)
+	}
+	return nil
+}
+
+type ArchiveLagStatus struct {
+	TenantID       int       `json:"tenant_id"`
+	TenantName     string    `json:"tenant_name"`
+	Status         string    `json:"status"`
+	RoundID        int64     `json:"round_id"`
+	CheckpointTime time.Time `json:"checkpoint_time"`
+	LagSeconds     int64     `json:"lag_seconds"`
+	Alarming       bool      `json:"alarming"`
+	Reason         string    `json:"reason,omitempty"`
+	Comment        string    `json:"comment,omitempty"`
+	// RemediationDagID is the id of the last remediation dag started by the watcher.
+	RemediationDagID string `json:"remediation_dag_id,omitempty"`
+}
+
+type ArchiveLagOverview struct {
+	Config    ArchiveWatcherConfig `json:"config"`
+	CheckTime time.Time            `json:"check_time"`
+	Tenants   []ArchiveLagStatus   `json:"tenants"`
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type TransferPartitionParam struct {
+	DatabaseName     *string `json:"database_name" binding:"required"`
+	TableName        *string `json:"table_name" binding:"required"`
+	PartitionName    *string `json:"partition_name"`    // Required for the partitioned table.
+	SubpartitionName *string `json:"subpartition_name"` // Required for the subpartitioned table.
+	DestLsId         *int64  `json:"dest_ls_id" binding:"required"`
+}
+
+type SwitchLeaderParam struct {
+	// The zone to switch the leaders to, should be in the first priority of the primary zone.
+	// The leaders will be switched to any zone of the first priority if not specified.
+	Zone *string `json:"zone"`
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/system"
+)
+
+type ClockSkewWatcherConfig struct {
+	Enabled bool `json:"enabled"`
+	// WarnThreshold is the clock offset to raise a warning alarm.
+	WarnThreshold string `json:"warn_threshold"`
+	// BlockThreshold is the clock offset to raise a critical alarm and block the risky operations, such as scale-out and upgrade.
+	BlockThreshold string `json:"block_threshold"`
+}
+
+type ClockSkewWatcherConfigParam struct {
+	Enabled        *bool   `json:"enabled"`
+	WarnThreshold  *string `json:"warn_threshold"`
+	BlockThreshold *string `json:"block_threshold"`
+}
+
+func (p *ClockSkewWatcherConfigParam) Check() error {
+	for _, threshold := range []*string{p.WarnThreshold, p.BlockThreshold} {
+		if threshold == nil {
+			continue
+		}
+		*threshold = strings.ToLower(strings.TrimSpace(*threshold))
+		duration, err := system.ParseTime(*threshold)
+		if err != nil || duration <= 0 {
+			return errors.Occur(errors.ErrObClusterClockSkewThresholdInvalid, *threshold, "expected a positive duration such as 50ms")
+		}
+	}
+	return nil
+}
+
+// ClockSkewSample is the clock offset of an agent measured by the maintainer agent,
+// the offset is positive if the clock of the agent is ahead of the maintainer.
+type ClockSkewSample struct {
+	SampleTime time.Time `json:"sample_time"`
+	OffsetMs   float64   `json:"offset_ms"`
+	RttMs      float64   `json:"rtt_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+type ClockSkewStatus struct {
+	Agent string `json:"agent"`
+	Zone  string `json:"zone"`
+	ClockSkewSample
+	Level  string `json:"level"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type ClockSkewOverview struct {
+	Config     ClockSkewWatcherConfig `json:"config"`
+	Maintainer string                 `json:"maintainer"`
+	CheckTime  time.Time              `json:"check_time"`
+	// MaxSkewMs is the max clock difference between any two agents.
+	MaxSkewMs float64           `json:"max_skew_ms"`
+	Agents    []ClockSkewStatus `json:"agents"`
+}
+
+type ClockSkewHistory struct {
+	Agent   string            `json:"agent"`
+	Samples []ClockSkewSample `json:"samples"`
+}
@@ -19,6 +19,7 @@ package param
 import (
 	"strings"
 
+	"github.com/oceanbase/obshell/agent/errors"
 	"github.com/oceanbase/obshell/agent/meta"
 )
 
@@ -45,15 +46,55 @@ type ObServerConfigParams struct {
 	Scope          Scope             `json:"scope" binding:"required"`
 }
 
+// ReconcileConfigDriftParam reconciles the drift between the persisted configs and the live parameters.
+type ReconcileConfigDriftParam struct {
+	Direction string   `json:"direction" binding:"required"` // PERSISTED_TO_LIVE or LIVE_TO_PERSISTED.
+	Names     []string `json:"names"`                        // Only reconcile the given configs, all if empty.
+	Servers   []string `json:"servers"`                      // Only reconcile on the given observers(ip:rpc_port), all if empty.
+}
+
 type ScaleOutParam struct {
 	AgentInfo meta.AgentInfo    `json:"agentInfo" binding:"required"`
 	ObConfigs map[string]string `json:"obConfigs" binding:"required"`
 	Zone      string            `json:"zone" binding:"required"`
 }
 
+// ClusterScaleOutParam scales out the agent, and the agents in Agents if any, in one dag.
+// The agent and zone may be empty if the agents to scale out are all in Agents.
 type ClusterScaleOutParam struct {
-	ScaleOutParam
-	TargetAgentPassword string `json:"targetAgentPassword"`
+	AgentInfo           meta.AgentInfo       `json:"agentInfo"`
+	ObConfigs           map[string]string    `json:"obConfigs"` // Shared by all the agents.
+	Zone                string               `json:"zone"`
+	TargetAgentPassword string               `json:"targetAgentPassword"`
+	Agents              []ScaleOutAgentParam `json:"agents"`
+}
+
+// ScaleOutAgentParam describes an agent to be scaled out together with others.
+type ScaleOutAgentParam struct {
+	AgentInfo           meta.AgentInfo    `json:"agentInfo" binding:"required"`
+	Zone                string            `json:"zone" binding:"required"`
+	ObConfigs           map[string]string `json:"obConfigs"` // Overrides the shared configs for this agent.
+	TargetAgentPassword string            `json:"targetAgentPassword"`
+}
+
+// ScaleOutAgents returns all the agents to scale out.
+func (p *ClusterScaleOutParam) ScaleOutAgents() ([]ScaleOutAgentParam, error) {
+	agents := make([]ScaleOutAgentParam, 0, len(p.Agents)+1)
+	if p.AgentInfo.Ip != "" {
+		if p.Zone == "" {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "zone", "zone is required")
+		}
+		agents = append(agents, ScaleOutAgentParam{
+			AgentInfo:           p.AgentInfo,
+			Zone:                p.Zone,
+			TargetAgentPassword: p.TargetAgentPassword,
+		})
+	}
+	agents = append(agents, p.Agents...)
+	if len(agents) == 0 {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "agents", "at least one agent is required")
+	}
+	return agents, nil
 }
 
 type LocalScaleOutParam struct {
@@ -74,6 +115,14 @@ type ClusterScaleInParam struct {
 	ForceKill bool           `json:"force_kill"` // default to false
 }
 
+// ClusterReplaceServerParam describes the failed agent to be replaced by a new agent in the same zone.
+type ClusterReplaceServerParam struct {
+	OldAgent            meta.AgentInfo    `json:"oldAgent" binding:"required"`
+	NewAgent            meta.AgentInfo    `json:"newAgent" binding:"required"`
+	ObConfigs           map[string]string `json:"obConfigs"`
+	TargetAgentPassword string            `json:"targetAgentPassword"`
+}
+
 type ObInitParam struct {
 	ImportScript      bool   `json:"import_script"`
 	CreateProxyroUser bool   `json:"create_proxyro_user"`
@@ -110,6 +159,39 @@ type UpgradeCheckParam struct {
 type ObUpgradeParam struct {
 	UpgradeCheckParam
 	Mode string `json:"mode" binding:"required"`
+
+	// ZoneApproval pauses the rolling upgrade after each zone is upgraded
+	// until it is approved or aborted.
+	ZoneApproval          bool   `json:"zoneApproval"`
+	ApprovalTimeout       string `json:"approvalTimeout"`       // Such as '2h', waits forever if empty.
+	ApprovalTimeoutAction string `json:"approvalTimeoutAction"` // 'APPROVE' or 'ABORT', default is 'ABORT'.
+}
+
+type UpgradeGateParam struct {
+	DagID   string `json:"dagId" binding:"required"` // Generic id of the upgrade dag.
+	Zone    string `json:"zone"`                     // The zone waiting for approval, any if empty.
+	Comment string `json:"comment"`
+}
+
+type UpgradeRollbackParam struct {
+	DagID string `json:"dagId" binding:"required"` // Generic id of the failed upgrade dag.
+}
+
+// UpgradeRollbackPoint describes the point which a failed rolling upgrade is rolled back to.
+type UpgradeRollbackPoint struct {
+	UpgradeDagID      string                `json:"upgrade_dag_id"`
+	RouteIndex        int                   `json:"route_index"`
+	FromVersion       string                `json:"from_version"` // Target build version of the failed upgrade process.
+	ToVersion         string                `json:"to_version"`   // Build version before the failed upgrade process, empty if unknown.
+	FailedTask        string                `json:"failed_task"`
+	Zones             []UpgradeRollbackZone `json:"zones"` // In the order of rollback.
+	RestoreParameters bool                  `json:"restore_parameters"`
+}
+
+type UpgradeRollbackZone struct {
+	Zone           string            `json:"zone"`
+	BinarySwitched bool              `json:"binary_switched"`
+	ServerVersions map[string]string `json:"server_versions,omitempty"` // Build version of the servers after rollback.
 }
 
 type Scope struct {
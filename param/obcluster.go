@@ -69,6 +69,11 @@ type SetSingleObclusterParameterParam struct {
 	AllUserTenant bool     `json:"all_user_tenant"` // Whether to set all tenants, if true, the Tenants field will be ignored.
 }
 
+type CreateParameterSnapshotParam struct {
+	Name    string `json:"name" binding:"required"`
+	Comment string `json:"comment"`
+}
+
 type SetParameterParam struct {
 	Name   string
 	Value  string
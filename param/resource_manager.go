@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateResourcePlanParam struct {
+	TenantRootPasswordParam
+	PlanName string `json:"plan_name" binding:"required"`
+	Comment  string `json:"comment"`
+}
+
+type CreateConsumerGroupParam struct {
+	TenantRootPasswordParam
+	GroupName string `json:"group_name" binding:"required"`
+	Comment   string `json:"comment"`
+}
+
+type CreatePlanDirectiveParam struct {
+	TenantRootPasswordParam
+	GroupName        string `json:"group_name" binding:"required"`
+	Comment          string `json:"comment"`
+	MgmtP1           *int   `json:"mgmt_p1"`           // cpu weight in percentage, [0, 100]
+	UtilizationLimit *int   `json:"utilization_limit"` // cpu upper limit in percentage, [1, 100]
+	MinIops          *int   `json:"min_iops"`          // io lower limit in percentage, [0, 100]
+	MaxIops          *int   `json:"max_iops"`          // io upper limit in percentage, [0, 100]
+	WeightIops       *int   `json:"weight_iops"`       // io weight in percentage, [0, 100]
+}
+
+type ModifyPlanDirectiveParam struct {
+	TenantRootPasswordParam
+	Comment          *string `json:"comment"`
+	MgmtP1           *int    `json:"mgmt_p1"`
+	UtilizationLimit *int    `json:"utilization_limit"`
+	MinIops          *int    `json:"min_iops"`
+	MaxIops          *int    `json:"max_iops"`
+	WeightIops       *int    `json:"weight_iops"`
+}
+
+type ConsumerGroupMappingParam struct {
+	TenantRootPasswordParam
+	Attribute     string `json:"attribute" binding:"required"` // "USER" or "COLUMN", "USER" is mapped as "ORACLE_USER" in oracle mode tenant
+	Value         string `json:"value" binding:"required"`     // user name, or column condition such as "db.tbl.col = 1"
+	ConsumerGroup string `json:"consumer_group"`               // empty means removing the mapping
+}
+
+type ActivateResourcePlanParam struct {
+	TenantRootPasswordParam
+	PlanName string `json:"plan_name"` // empty means disabling the resource manager
+}
@@ -17,11 +17,13 @@
 package param
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/oceanbase/obshell/agent/constant"
 	"github.com/oceanbase/obshell/agent/errors"
+	"github.com/oceanbase/obshell/agent/lib/system"
 	"github.com/oceanbase/obshell/agent/repository/model/bo"
 )
 
@@ -94,3 +96,323 @@ type RestoreOverview struct {
 	Comment              string `json:"comment"`
 	FinishTimestamp      string `json:"finish_timestamp"`
 }
+
+type RestoreDrillVerification struct {
+	Name     string  `json:"name" binding:"required"`
+	Sql      string  `json:"sql" binding:"required"`
+	Expected *string `json:"expected"`
+}
+
+type RestoreDrillParam struct {
+	RestoreWindowsParam
+
+	ScratchTenantName *string     `json:"scratch_tenant_name"`
+	ZoneList          []ZoneParam `json:"zone_list" binding:"required"` // Scratch tenant zone list, the unit num is always 1.
+	Decryption        *[]string   `json:"decryption"`
+	KmsEncryptInfo    *string     `json:"kms_encrypt_info"`
+
+	// RootPassword is the root password of the backed up tenant,
+	// used to connect to the scratch tenant for verification.
+	RootPassword  *string                    `json:"root_password"`
+	Tables        []string                   `json:"tables"` // Tables in 'database.table' format whose rows will be counted.
+	Verifications []RestoreDrillVerification `json:"verifications"`
+}
+
+func (p *RestoreDrillParam) Format() {
+	if p.ArchiveLogUri == nil || *p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = &p.DataBackupUri
+	}
+	if p.ScratchTenantName == nil || *p.ScratchTenantName == "" {
+		name := fmt.Sprintf("%s%d", constant.RESTORE_DRILL_TENANT_PREFIX, time.Now().Unix())
+		p.ScratchTenantName = &name
+	}
+	for i := range p.ZoneList {
+		p.ZoneList[i].UnitNum = constant.RESTORE_UNIT_NUM_DEFAULT
+	}
+}
+
+func (p *RestoreDrillParam) Check() error {
+	p.Format()
+	for _, table := range p.Tables {
+		if strings.Count(table, ".") != 1 || !isValidRecoverTableName(table, 2) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "tables", fmt.Sprintf("'%s' should be in 'database.table' format", table))
+		}
+	}
+	return nil
+}
+
+// ToVerifications returns all the checks of the drill, row counts of the tables first.
+func (p *RestoreDrillParam) ToVerifications() []RestoreDrillVerification {
+	verifications := make([]RestoreDrillVerification, 0, len(p.Tables)+len(p.Verifications))
+	for _, table := range p.Tables {
+		verifications = append(verifications, RestoreDrillVerification{
+			Name: fmt.Sprintf("row count of %s", table),
+			Sql:  fmt.Sprintf("SELECT COUNT(*) FROM %s", table),
+		})
+	}
+	return append(verifications, p.Verifications...)
+}
+
+func (p *RestoreDrillParam) ToRestoreParam(timestamp *time.Time) *RestoreParam {
+	return &RestoreParam{
+		RestoreWindowsParam: p.RestoreWindowsParam,
+		TenantName:          *p.ScratchTenantName,
+		Timestamp:           timestamp,
+		ZoneList:            p.ZoneList,
+		Decryption:          p.Decryption,
+		KmsEncryptInfo:      p.KmsEncryptInfo,
+	}
+}
+
+// RestoreDrillSchedule runs the drill periodically on the maintainer agent.
+type RestoreDrillSchedule struct {
+	Enabled  bool               `json:"enabled"`
+	Interval string             `json:"interval"`
+	Drill    *RestoreDrillParam `json:"drill,omitempty"`
+}
+
+type RestoreDrillScheduleParam struct {
+	Enabled  *bool              `json:"enabled"`
+	Interval *string            `json:"interval"` // Such as 1d, at least 1h.
+	Drill    *RestoreDrillParam `json:"drill"`
+}
+
+func (p *RestoreDrillScheduleParam) Check() error {
+	if p.Interval != nil {
+		*p.Interval = strings.ToLower(strings.TrimSpace(*p.Interval))
+		if _, err := system.ParseTimeWithRange(*p.Interval, constant.RESTORE_DRILL_SCHEDULE_INTERVAL_MIN, 365*system.Day); err != nil {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "interval", err.Error())
+		}
+	}
+	if p.Drill != nil {
+		if len(p.Drill.ZoneList) == 0 {
+			return errors.Occur(errors.ErrObTenantZoneListEmpty)
+		}
+		if p.Drill.ScratchTenantName != nil && *p.Drill.ScratchTenantName == constant.TENANT_SYS {
+			return errors.Occur(errors.ErrObTenantSysOperationNotAllowed)
+		}
+		// Check a copy, the scratch tenant name is generated for each drill if not specified.
+		drill := *p.Drill
+		if err := drill.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSourceParam points at the base uri of the backups and archive logs,
+// which may be written by a cluster managed by another obshell.
+type RestoreSourceParam struct {
+	DataBackupBaseUri string  `json:"data_backup_base_uri" binding:"required"`
+	ArchiveLogBaseUri *string `json:"archive_log_base_uri"`
+}
+
+func (p *RestoreSourceParam) Format() {
+	if p.ArchiveLogBaseUri == nil || *p.ArchiveLogBaseUri == "" {
+		p.ArchiveLogBaseUri = &p.DataBackupBaseUri
+	}
+}
+
+// RestoreSource is a tenant backup found under the base uri.
+// The subpaths are relative to the base uri, and both are empty
+// if the base uri is the backup dest of the tenant itself.
+type RestoreSource struct {
+	ClusterID         int                    `json:"cluster_id"`
+	TenantID          int                    `json:"tenant_id"`
+	DataSubpath       string                 `json:"data_subpath"`
+	ArchiveLogSubpath string                 `json:"archive_log_subpath"`
+	DataBackupUri     string                 `json:"data_backup_uri"` // Without secret.
+	ArchiveLogUri     string                 `json:"archive_log_uri"` // Without secret.
+	Windows           []system.RestoreWindow `json:"windows"`
+	Error             string                 `json:"error,omitempty"` // Why the restore windows are unavailable.
+}
+
+// RestorePointParam asks for the closest restorable point to a wall-clock time or a scn.
+type RestorePointParam struct {
+	RestoreWindowsParam
+
+	Time     *string `json:"time"`     // Wall-clock time such as '2024-05-01 12:00:00'.
+	Timezone *string `json:"timezone"` // IANA name or UTC offset for the time without offset, default is the timezone of the agent.
+	SCN      *int64  `json:"scn"`
+}
+
+func (p *RestorePointParam) Format() {
+	if p.ArchiveLogUri == nil || *p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = &p.DataBackupUri
+	}
+	if p.SCN != nil && *p.SCN == 0 {
+		p.SCN = nil
+	}
+	if p.Time != nil && *p.Time == "" {
+		p.Time = nil
+	}
+}
+
+func (p *RestorePointParam) Check() error {
+	if p.Time == nil && p.SCN == nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "time", "either time or scn is required")
+	}
+	if p.Time != nil && p.SCN != nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "scn", "time and scn cannot be specified at the same time")
+	}
+	return nil
+}
+
+// RestorePoint is the closest restorable point and what restoring to it takes.
+type RestorePoint struct {
+	RequestedSCN  int64     `json:"requested_scn"`
+	RequestedTime time.Time `json:"requested_time"`
+	RestoreSCN    int64     `json:"restore_scn"`
+	RestoreTime   time.Time `json:"restore_time"`
+	Adjusted      bool      `json:"adjusted"` // Whether the requested point is out of the restore windows.
+
+	BackupSets    []RestorePointBackupSet `json:"backup_sets"` // The full backup set and the incremental ones on it, in order.
+	ArchivePieces []RestorePointPiece     `json:"archive_pieces"`
+
+	EstimatedBytes    int64  `json:"estimated_bytes"`
+	EstimatedDuration int64  `json:"estimated_duration"` // In seconds.
+	Throughput        int64  `json:"throughput"`         // Bytes per second used for the estimation.
+	ThroughputSource  string `json:"throughput_source"`  // 'history' or 'default'.
+
+	Windows []system.RestoreWindow `json:"windows"`
+}
+
+type RestorePointBackupSet struct {
+	BackupSetID    int       `json:"backup_set_id"`
+	BackupType     string    `json:"backup_type"`
+	MinRestoreSCN  int64     `json:"min_restore_scn"`
+	MinRestoreTime time.Time `json:"min_restore_time"`
+	Bytes          int64     `json:"bytes"` // Zero if unknown.
+}
+
+type RestorePointPiece struct {
+	DestID         int       `json:"dest_id"`
+	RoundID        int       `json:"round_id"`
+	PieceID        int       `json:"piece_id"`
+	StartSCN       int64     `json:"start_scn"`
+	CheckpointSCN  int64     `json:"checkpoint_scn"`
+	StartTime      time.Time `json:"start_time"`
+	CheckpointTime time.Time `json:"checkpoint_time"`
+	Bytes          int64     `json:"bytes"` // Zero if unknown.
+}
+
+// RecoverTableParam restores databases and tables from the backup into an existing tenant.
+type RecoverTableParam struct {
+	RestoreWindowsParam
+
+	TargetTenantName string `json:"-"`
+
+	Timestamp *time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000Z07:00"`
+	SCN       *int64     `json:"scn"`
+
+	Databases      []string          `json:"databases"`       // Databases to restore with all their tables.
+	Tables         []string          `json:"tables"`          // Tables in 'database.table' format.
+	RemapDatabases map[string]string `json:"remap_databases"` // Source database to target database.
+	RemapTables    map[string]string `json:"remap_tables"`    // Source 'database.table' to target '[database.]table'.
+
+	ZoneList       []ZoneParam `json:"zone_list" binding:"required"` // Auxiliary tenant zone list with unit config.
+	PrimaryZone    *string     `json:"primary_zone"`
+	Concurrency    *int        `json:"concurrency"`
+	Decryption     *[]string   `json:"decryption"`
+	KmsEncryptInfo *string     `json:"kms_encrypt_info"`
+}
+
+func (p *RecoverTableParam) Format() {
+	if p.ArchiveLogUri == nil || *p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = &p.DataBackupUri
+	}
+	if p.SCN != nil && *p.SCN == 0 {
+		p.SCN = nil
+	}
+	if p.Timestamp != nil && *p.Timestamp == constant.ZERO_TIME {
+		p.Timestamp = nil
+	}
+	if p.PrimaryZone == nil || *p.PrimaryZone == "" ||
+		strings.ToUpper(*p.PrimaryZone) == constant.PRIMARY_ZONE_RANDOM {
+		primaryZone := constant.PRIMARY_ZONE_RANDOM
+		p.PrimaryZone = &primaryZone
+	}
+	for i := range p.ZoneList {
+		if p.ZoneList[i].UnitNum == 0 {
+			p.ZoneList[i].UnitNum = constant.RESTORE_UNIT_NUM_DEFAULT
+		}
+	}
+}
+
+func (p *RecoverTableParam) Check() error {
+	p.Format()
+	if p.Timestamp != nil && p.SCN != nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "timestamp or scn", "cannot be set at the same time")
+	}
+	if p.Timestamp == nil && p.SCN == nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "timestamp or scn", "one of them must be set")
+	}
+	if len(p.Databases) == 0 && len(p.Tables) == 0 {
+		return errors.Occur(errors.ErrObRestoreTableTargetEmpty)
+	}
+
+	databases := make(map[string]bool)
+	for _, database := range p.Databases {
+		if !isValidRecoverTableName(database, 1) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "databases", fmt.Sprintf("'%s' is not a valid database name", database))
+		}
+		databases[database] = true
+	}
+	tables := make(map[string]bool)
+	for _, table := range p.Tables {
+		if strings.Count(table, ".") != 1 || !isValidRecoverTableName(table, 2) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "tables", fmt.Sprintf("'%s' should be in 'database.table' format", table))
+		}
+		tables[table] = true
+	}
+
+	for source, target := range p.RemapDatabases {
+		if !databases[source] {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_databases", fmt.Sprintf("database '%s' is not going to be restored", source))
+		}
+		if !isValidRecoverTableName(target, 1) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_databases", fmt.Sprintf("'%s' is not a valid database name", target))
+		}
+	}
+	for source, target := range p.RemapTables {
+		if !tables[source] {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_tables", fmt.Sprintf("table '%s' is not going to be restored", source))
+		}
+		if !isValidRecoverTableName(target, 2) {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_tables", fmt.Sprintf("'%s' should be in '[database.]table' format", target))
+		}
+	}
+	return nil
+}
+
+// isValidRecoverTableName checks the name has at most maxParts non-empty parts separated by dots,
+// and none of the parts contains a backtick, so that every part could be quoted safely.
+func isValidRecoverTableName(name string, maxParts int) bool {
+	parts := strings.Split(name, ".")
+	if len(parts) > maxParts {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || strings.Contains(part, "`") {
+			return false
+		}
+	}
+	return true
+}
+
+// TargetTables returns the 'database.table' names the tables will have in the target tenant.
+func (p *RecoverTableParam) TargetTables() []string {
+	res := make([]string, 0, len(p.Tables))
+	for _, table := range p.Tables {
+		target, ok := p.RemapTables[table]
+		if !ok {
+			res = append(res, table)
+			continue
+		}
+		if !strings.Contains(target, ".") {
+			target = strings.Split(table, ".")[0] + "." + target
+		}
+		res = append(res, target)
+	}
+	return res
+}
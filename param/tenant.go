@@ -121,6 +121,7 @@ type CreateUserParam struct {
 	GlobalPrivileges []string           `json:"global_privileges"`
 	DbPrivileges     []DbPrivilegeParam `json:"db_privileges"`
 	HostName         string             `json:"host_name"`
+	Roles            []string           `json:"roles"` // Only for ORACLE tenant
 }
 
 type DbPrivilegeParam struct {
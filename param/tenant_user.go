@@ -30,3 +30,25 @@ type ChangeUserPasswordParam struct {
 	TenantRootPasswordParam
 	NewPassword string `json:"new_password"`
 }
+
+type ModifyGrantedRolesParam struct {
+	TenantRootPasswordParam
+	Roles []string `json:"roles"`
+}
+
+type ObjectPrivilegeParam struct {
+	Object     string   `json:"object" binding:"required"` // object name with owner, such as "SCOTT.EMP", quote a part to keep its case, such as "SCOTT.\"emp\""
+	Privileges []string `json:"privileges" binding:"required"`
+}
+
+type ModifyObjectPrivilegeParam struct {
+	TenantRootPasswordParam
+	ObjectPrivileges []ObjectPrivilegeParam `json:"object_privileges"`
+}
+
+type CreateRoleParam struct {
+	TenantRootPasswordParam
+	RoleName         string   `json:"role_name" binding:"required"`
+	GlobalPrivileges []string `json:"global_privileges"`
+	Roles            []string `json:"roles"`
+}
@@ -30,3 +30,12 @@ type ClusterUnitConfigLimit struct {
 	MinMemory float64 `json:"min_memory,omitempty"`
 	MinCpu    int     `json:"min_cpu,omitempty"`
 }
+
+type ModifyResourceUnitConfigParams struct {
+	MemorySize  *string  `json:"memory_size"`   // memory size, greater than or equal to '1G'
+	MaxCpu      *float64 `json:"max_cpu"`       // max cpu cores, greater than 0
+	MinCpu      *float64 `json:"min_cpu"`       // min cpu cores, smaller than or equal 'max_cpu_cores'
+	MaxIops     *int     `json:"max_iops"`      // max iops, greater than or equal to 1024
+	MinIops     *int     `json:"min_iops"`      // min iops, smaller than or equal to 'max_iops'
+	LogDiskSize *string  `json:"log_disk_size"` // log disk size, greater than or equal to '2G'
+}